@@ -22,6 +22,7 @@ func newMeshCmd(config *action.Configuration, in io.Reader, out io.Writer) *cobr
 		Args:  cobra.NoArgs,
 	}
 	cmd.AddCommand(newMeshList(out))
+	cmd.AddCommand(newMeshRolloutCmd(out))
 
 	if !settings.IsManaged() {
 		cmd.AddCommand(newMeshUpgradeCmd(config, out))
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/debugger"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+const meshRolloutDescription = `
+This command will display the status of the staged rollout of MeshConfig changes
+for the mesh in the OSM namespace. The status is retrieved from the debug server
+of an osm-controller pod, which requires the debug server to be enabled with
+the MeshConfig field spec.observability.enableDebugServer. The state of the
+rollout is also persisted in the status of the MeshConfig.
+
+A MeshConfig change is only rolled back automatically when the canary proxies
+reject it (NACK). Changes accepted by the proxies that degrade the traffic,
+such as an increased error rate, must be monitored separately and reverted by
+restoring the previous MeshConfig.
+`
+
+// configRolloutAPIPath is the debug server path serving the status of the staged rollout of MeshConfig changes
+const configRolloutAPIPath = debugger.APIPrefix + "/config-rollout"
+
+type meshRolloutCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	localPort uint16
+}
+
+func newMeshRolloutCmd(out io.Writer) *cobra.Command {
+	rolloutCmd := &meshRolloutCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "display the status of the MeshConfig rollout",
+		Long:  meshRolloutDescription,
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+			rolloutCmd.config = config
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			rolloutCmd.clientSet = clientset
+			return rolloutCmd.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.Uint16VarP(&rolloutCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (r *meshRolloutCmd) run() error {
+	controllerPods := k8s.GetOSMControllerPods(r.clientSet, settings.Namespace())
+	if len(controllerPods.Items) == 0 {
+		return annotateErrorMessageWithOsmNamespace("No osm-controller pods found")
+	}
+
+	// The rollout state is tracked by every osm-controller pod, checking the first pod should suffice
+	status, err := getRolloutStatusForControllerPod(controllerPods.Items[0].Name, settings.Namespace(), r.config, r.clientSet, r.localPort)
+	if err != nil {
+		return err
+	}
+
+	w := newTabWriter(r.out)
+	fmt.Fprint(w, getPrettyPrintedRolloutStatus(status))
+	_ = w.Flush()
+
+	return nil
+}
+
+// getPrettyPrintedRolloutStatus returns a pretty printed status of the MeshConfig rollout
+func getPrettyPrintedRolloutStatus(status debugger.ConfigRolloutInfo) string {
+	s := fmt.Sprintf("PHASE:\t%s\n", status.Phase)
+	if status.Message != "" {
+		s += fmt.Sprintf("MESSAGE:\t%s\n", status.Message)
+	}
+	if status.LastTransitionTime != nil {
+		s += fmt.Sprintf("LAST TRANSITION:\t%s\n", status.LastTransitionTime.Format(time.RFC3339))
+	}
+	s += fmt.Sprintf("STABLE GENERATION:\t%d\n", status.StableGeneration)
+	if status.CanaryGeneration == 0 {
+		return s
+	}
+
+	s += fmt.Sprintf("CANARY GENERATION:\t%d\n", status.CanaryGeneration)
+	s += fmt.Sprintf("CANARY PERCENTAGE:\t%d%%\n", status.CanaryPercentage)
+	s += fmt.Sprintf("CANARY NAMESPACES:\t%s\n", strings.Join(status.CanaryNamespaces, ","))
+	if status.StartedAt != nil {
+		s += fmt.Sprintf("STARTED:\t%s\n", status.StartedAt.Format(time.RFC3339))
+	}
+	s += fmt.Sprintf("BAKE DURATION:\t%s\n", status.BakeDuration)
+	s += fmt.Sprintf("NACKS:\t%d/%d\n", status.NACKs, status.MaxNACKs)
+
+	return s
+}
+
+// getRolloutStatusForControllerPod returns the status of the MeshConfig rollout
+// from the debug server of a given osm controller pod in a namespace
+func getRolloutStatusForControllerPod(pod string, namespace string, restConfig *rest.Config, clientSet kubernetes.Interface, localPort uint16) (debugger.ConfigRolloutInfo, error) {
+	var status debugger.ConfigRolloutInfo
+
+	dialer, err := k8s.DialerToPod(restConfig, clientSet, pod, namespace)
+	if err != nil {
+		return status, err
+	}
+
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, constants.DebugPort))
+	if err != nil {
+		return status, errors.Errorf("Error setting up port forwarding: %s", err)
+	}
+
+	err = portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		defer pf.Stop()
+		url := fmt.Sprintf("http://localhost:%d%s", localPort, configRolloutAPIPath)
+
+		// #nosec G107: Potential HTTP request made with variable url
+		resp, err := http.Get(url)
+		if err != nil {
+			return errors.Errorf("Error fetching url %s: %s", url, err)
+		}
+		//nolint: errcheck
+		//#nosec G307
+		defer resp.Body.Close()
+
+		if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+			return errors.Errorf("Error rendering HTTP response: %s", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return status, errors.Errorf("Error retrieving the MeshConfig rollout status for pod %s in namespace %s, check that the debug server is enabled: %s", pod, namespace, err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/debugger"
+)
+
+func TestGetPrettyPrintedRolloutStatus(t *testing.T) {
+	transitionTime := time.Date(2021, time.October, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		status   debugger.ConfigRolloutInfo
+		expected string
+	}{
+		{
+			name: "no rollout in progress",
+			status: debugger.ConfigRolloutInfo{
+				Phase:            string(configurator.RolloutPhaseStable),
+				StableGeneration: 2,
+			},
+			expected: "PHASE:\tStable\nSTABLE GENERATION:\t2\n",
+		},
+		{
+			name: "rollout in progress",
+			status: debugger.ConfigRolloutInfo{
+				Phase:              string(configurator.RolloutPhaseProgressing),
+				Message:            "Rolling out MeshConfig generation 3 to canary proxies",
+				LastTransitionTime: &transitionTime,
+				StableGeneration:   2,
+				CanaryGeneration:   3,
+				CanaryPercentage:   10,
+				CanaryNamespaces:   []string{"bookbuyer", "bookstore"},
+				StartedAt:          &transitionTime,
+				BakeDuration:       "5m0s",
+				NACKs:              1,
+				MaxNACKs:           3,
+			},
+			expected: "PHASE:\tProgressing\n" +
+				"MESSAGE:\tRolling out MeshConfig generation 3 to canary proxies\n" +
+				"LAST TRANSITION:\t2021-10-01T10:00:00Z\n" +
+				"STABLE GENERATION:\t2\n" +
+				"CANARY GENERATION:\t3\n" +
+				"CANARY PERCENTAGE:\t10%\n" +
+				"CANARY NAMESPACES:\tbookbuyer,bookstore\n" +
+				"STARTED:\t2021-10-01T10:00:00Z\n" +
+				"BAKE DURATION:\t5m0s\n" +
+				"NACKS:\t1/3\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, getPrettyPrintedRolloutStatus(tc.status))
+		})
+	}
+}
@@ -201,7 +201,10 @@ func (d *uninstallMeshCmd) uninstallCustomResourceDefinitions() error {
 		"meshconfigs.config.openservicemesh.io",
 		"upstreamtrafficsettings.policy.openservicemesh.io",
 		"retries.policy.openservicemesh.io",
+		"externalauthorizations.policy.openservicemesh.io",
+		"sidecarscopes.policy.openservicemesh.io",
 		"multiclusterservices.config.openservicemesh.io",
+		"namespaceconfigs.config.openservicemesh.io",
 		"httproutegroups.specs.smi-spec.io",
 		"tcproutes.specs.smi-spec.io",
 		"trafficsplits.split.smi-spec.io",
@@ -357,6 +360,26 @@ func (d *uninstallMeshCmd) uninstallSecrets() error {
 		}
 	}
 
+	// Secrets persisting the certificates issued to workloads, if enabled
+	issuedCertSecrets, err := d.clientSet.CoreV1().Secrets(d.meshNamespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", constants.OSMIssuedCertificateLabelKey),
+	})
+	if err != nil {
+		fmt.Fprintf(d.out, "Failed to list the OSM issued certificate secrets in namespace %s: %s\n", d.meshNamespace, err.Error())
+		failedDeletions = append(failedDeletions, "issued certificates")
+	} else {
+		for _, secret := range issuedCertSecrets.Items {
+			err := d.clientSet.CoreV1().Secrets(d.meshNamespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{})
+			if err != nil && !k8sApiErrors.IsNotFound(err) {
+				fmt.Fprintf(d.out, "Found but failed to delete the OSM secret %s in namespace %s: %s\n", secret.Name, d.meshNamespace, err.Error())
+				failedDeletions = append(failedDeletions, secret.Name)
+			}
+		}
+		if len(issuedCertSecrets.Items) > 0 {
+			fmt.Fprintf(d.out, "Deleted OSM issued certificate secrets in namespace %s\n", d.meshNamespace)
+		}
+	}
+
 	if len(failedDeletions) != 0 {
 		return errors.Errorf("Found but failed to delete the following OSM secrets in namespace %s: %+v", d.meshNamespace, failedDeletions)
 	}
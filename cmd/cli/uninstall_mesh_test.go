@@ -451,6 +451,14 @@ func TestUninstallClusterWideResources(t *testing.T) {
 						Namespace: testNamespace,
 					},
 				},
+				// OSM issued certificate Secret
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "osm-issued-cert-test",
+						Namespace: testNamespace,
+						Labels:    map[string]string{constants.OSMIssuedCertificateLabelKey: "true"},
+					},
+				},
 				// OSM Secret
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
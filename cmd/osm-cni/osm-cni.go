@@ -0,0 +1,105 @@
+// Package main implements the main entrypoint for osm-cni.
+// osm-cni redirects the traffic of meshed pods to their sidecar when the network of the pods is set up.
+// When invoked by the container runtime, it runs as a CNI plugin. Otherwise, it runs as the installer
+// of the plugin on the node it is scheduled on.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cni"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/signals"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+var log = logger.New("osm-cni/main")
+
+func main() {
+	// The container runtime sets the CNI_COMMAND environment variable when invoking plugins
+	if os.Getenv("CNI_COMMAND") != "" {
+		os.Exit(runPlugin(os.Stdin, os.Stdout))
+	}
+
+	runInstaller()
+}
+
+// runPlugin runs the CNI command given by the container runtime and returns the exit code of the plugin
+func runPlugin(stdin io.Reader, stdout io.Writer) int {
+	args, err := cni.ArgsFromEnv(os.Getenv)
+	if err != nil {
+		fmt.Fprint(stdout, string(cni.ErrorResult("", err)))
+		return 1
+	}
+
+	conf, err := io.ReadAll(stdin)
+	if err != nil {
+		fmt.Fprint(stdout, string(cni.ErrorResult("", err)))
+		return 1
+	}
+
+	result, err := cni.Execute(args, conf, cni.NewPlugin)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error executing CNI command %s for container %s", args.Command, args.ContainerID)
+		// Errors are reported using the version of the network configuration, if it can be parsed
+		var pluginConf cni.PluginConf
+		_ = json.Unmarshal(conf, &pluginConf)
+		fmt.Fprint(stdout, string(cni.ErrorResult(pluginConf.CNIVersion, err)))
+		return 1
+	}
+
+	fmt.Fprint(stdout, string(result))
+	return 0
+}
+
+// runInstaller installs the plugin on the node and uninstalls it on exit
+func runInstaller() {
+	log.Info().Msgf("Starting osm-cni installer %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
+
+	var verbosity string
+	installer := &cni.Installer{}
+
+	flags := pflag.NewFlagSet("osm-cni", pflag.ExitOnError)
+	flags.StringVarP(&verbosity, "verbosity", "v", "info", "Set log verbosity level")
+	flags.StringVar(&installer.CNIBinDir, "cni-bin-dir", "/host/opt/cni/bin", "CNI binary directory of the node, as mounted in the container")
+	flags.StringVar(&installer.CNIConfDir, "cni-conf-dir", "/host/etc/cni/net.d", "CNI network configuration directory of the node, as mounted in the container")
+	flags.StringVar(&installer.HostCNIConfDir, "host-cni-conf-dir", "/etc/cni/net.d", "CNI network configuration directory on the node")
+	flags.StringVar(&installer.OSMNamespace, "osm-namespace", "", "Namespace to which OSM belongs to.")
+	flags.StringVar(&installer.LogLevel, "plugin-log-level", "info", "Log level of the osm-cni plugin")
+
+	if err := flags.Parse(os.Args); err != nil {
+		log.Fatal().Err(err).Msg("Error parsing cmd line arguments")
+	}
+
+	if err := logger.SetLogLevel(verbosity); err != nil {
+		log.Fatal().Err(err).Msg("Error setting log level")
+	}
+
+	if installer.OSMNamespace == "" {
+		log.Fatal().Msg("Please specify the OSM namespace using --osm-namespace")
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating in-cluster kube config")
+	}
+
+	if err := installer.Install(restConfig); err != nil {
+		log.Fatal().Err(err).Msg("Error installing osm-cni plugin")
+	}
+	log.Info().Msg("Installed osm-cni plugin")
+
+	stop := signals.RegisterExitHandlers()
+	<-stop
+
+	if err := installer.Uninstall(); err != nil {
+		log.Fatal().Err(err).Msg("Error uninstalling osm-cni plugin")
+	}
+	log.Info().Msg("Uninstalled osm-cni plugin")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/multicluster"
@@ -21,26 +23,50 @@ import (
 )
 
 const (
-	gatewayBootstrapSecretName = "osm-multicluster-gateway-bootstrap-config" // #nosec G101: Potential hardcoded credentials
-	bootstrapConfigKey         = "bootstrap.yaml"
+	gatewayBootstrapSecretName        = "osm-multicluster-gateway-bootstrap-config" // #nosec G101: Potential hardcoded credentials
+	egressGatewayBootstrapSecretName  = "osm-egress-gateway-bootstrap-config"       // #nosec G101: Potential hardcoded credentials
+	ingressGatewayBootstrapSecretName = "osm-ingress-gateway-bootstrap-config"      // #nosec G101: Potential hardcoded credentials
+	bootstrapConfigKey                = "bootstrap.yaml"
 )
 
 func bootstrapOSMMulticlusterGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace string) error {
-	secret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), gatewayBootstrapSecretName, metav1.GetOptions{})
+	gatewayCN := multicluster.GetMulticlusterGatewaySubjectCommonName(osmServiceAccount, osmNamespace)
+	return bootstrapGateway(kubeClient, certManager, osmNamespace, gatewayBootstrapSecretName, gatewayCN)
+}
+
+// bootstrapOSMEgressGateway configures the bootstrap config of the OSM egress gateway. The egress gateway
+// connects to the controller with its own service account, which is the identity sidecars validate when
+// routing egress traffic through it.
+func bootstrapOSMEgressGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace string) error {
+	gatewayCN := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindEgressGateway, constants.OSMEgressGatewayName, osmNamespace)
+	return bootstrapGateway(kubeClient, certManager, osmNamespace, egressGatewayBootstrapSecretName, gatewayCN)
+}
+
+// bootstrapOSMIngressGateway configures the bootstrap config of the OSM ingress gateway programmed from
+// Gateway API resources. The ingress gateway connects to the controller with its own service account, which
+// is the identity mesh services authorize when the gateway routes traffic to them.
+func bootstrapOSMIngressGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace string) error {
+	gatewayCN := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindIngressGateway, constants.OSMIngressGatewayName, osmNamespace)
+	return bootstrapGateway(kubeClient, certManager, osmNamespace, ingressGatewayBootstrapSecretName, gatewayCN)
+}
+
+// bootstrapGateway issues an xDS certificate with the given common name and writes the bootstrap config
+// using it to the given secret, unless the secret already holds a valid bootstrap config.
+func bootstrapGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, osmNamespace string, secretName string, gatewayCN certificate.CommonName) error {
+	secret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), secretName, metav1.GetOptions{})
 	if err != nil {
-		return errors.Errorf("Error fetching OSM gateway's bootstrap config %s/%s", osmNamespace, gatewayBootstrapSecretName)
+		return errors.Errorf("Error fetching OSM gateway's bootstrap config %s/%s", osmNamespace, secretName)
 	}
 
 	if bootstrapData, ok := secret.Data[bootstrapConfigKey]; !ok {
-		return errors.Errorf("Missing OSM gateway bootstrap config in %s/%s", osmNamespace, gatewayBootstrapSecretName)
+		return errors.Errorf("Missing OSM gateway bootstrap config in %s/%s", osmNamespace, secretName)
 	} else if isValidBootstrapData(bootstrapData) {
 		// If there is a valid bootstrap config, it means we do not need to reconfigure it. It implies
 		// osm-controller restarted after creating the bootstrap config previously.
-		log.Info().Msg("A valid bootstrap config exists for OSM gateway, skipping gateway bootstrapping")
+		log.Info().Msgf("A valid bootstrap config exists in %s/%s, skipping gateway bootstrapping", osmNamespace, secretName)
 		return nil
 	}
 
-	gatewayCN := multicluster.GetMulticlusterGatewaySubjectCommonName(osmServiceAccount, osmNamespace)
 	bootstrapCert, err := certManager.IssueCertificate(gatewayCN, constants.XDSCertificateValidityPeriod)
 	if err != nil {
 		return errors.Errorf("Error issuing bootstrap certificate for OSM gateway: %s", err)
@@ -57,17 +83,17 @@ func bootstrapOSMMulticlusterGateway(kubeClient kubernetes.Interface, certManage
 		PrivateKey:       bootstrapCert.GetPrivateKey(),
 	})
 	if err != nil {
-		return errors.Errorf("Error building OSM gateway's bootstrap config from %s/%s", osmNamespace, gatewayBootstrapSecretName)
+		return errors.Errorf("Error building OSM gateway's bootstrap config from %s/%s", osmNamespace, secretName)
 	}
 
 	bootstrapData, err := utils.ProtoToYAML(bootstrapConfig)
 	if err != nil {
-		return errors.Errorf("Error marshalling updated OSM gateway's bootstrap config from %s/%s", osmNamespace, gatewayBootstrapSecretName)
+		return errors.Errorf("Error marshalling updated OSM gateway's bootstrap config from %s/%s", osmNamespace, secretName)
 	}
 
 	updatedSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      gatewayBootstrapSecretName,
+			Name:      secretName,
 			Namespace: osmNamespace,
 		},
 		Data: map[string][]byte{
@@ -80,8 +106,8 @@ func bootstrapOSMMulticlusterGateway(kubeClient kubernetes.Interface, certManage
 		return err
 	}
 
-	if _, err = kubeClient.CoreV1().Secrets(osmNamespace).Patch(context.Background(), gatewayBootstrapSecretName, types.StrategicMergePatchType, patchJSON, metav1.PatchOptions{}); err != nil {
-		return errors.Errorf("Error patching OSM gateway's bootstrap secret %s/%s: %s", osmNamespace, gatewayBootstrapSecretName, err)
+	if _, err = kubeClient.CoreV1().Secrets(osmNamespace).Patch(context.Background(), secretName, types.StrategicMergePatchType, patchJSON, metav1.PatchOptions{}); err != nil {
+		return errors.Errorf("Error patching OSM gateway's bootstrap secret %s/%s: %s", osmNamespace, secretName, err)
 	}
 
 	return nil
@@ -151,6 +151,62 @@ static_resources:
 	}
 }
 
+func TestBootstrapOSMEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	fakeCertManager := tresor.NewFakeCertManager(mockConfigurator)
+	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(15 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+
+	testNs := "test"
+	fakeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      egressGatewayBootstrapSecretName,
+			Namespace: testNs,
+		},
+		Data: map[string][]byte{
+			bootstrapConfigKey: []byte("placeholder"),
+		},
+	})
+
+	assert.Nil(bootstrapOSMEgressGateway(fakeClient, fakeCertManager, testNs))
+
+	secret, err := fakeClient.CoreV1().Secrets(testNs).Get(context.Background(), egressGatewayBootstrapSecretName, metav1.GetOptions{})
+	assert.Nil(err)
+	assert.True(isValidBootstrapData(secret.Data[bootstrapConfigKey]))
+}
+
+func TestBootstrapOSMIngressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	fakeCertManager := tresor.NewFakeCertManager(mockConfigurator)
+	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(15 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+
+	testNs := "test"
+	fakeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressGatewayBootstrapSecretName,
+			Namespace: testNs,
+		},
+		Data: map[string][]byte{
+			bootstrapConfigKey: []byte("placeholder"),
+		},
+	})
+
+	assert.Nil(bootstrapOSMIngressGateway(fakeClient, fakeCertManager, testNs))
+
+	secret, err := fakeClient.CoreV1().Secrets(testNs).Get(context.Background(), ingressGatewayBootstrapSecretName, metav1.GetOptions{})
+	assert.Nil(err)
+	assert.True(isValidBootstrapData(secret.Data[bootstrapConfigKey]))
+}
+
 func TestIsValidBootstrapData(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
@@ -23,6 +24,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/clientcmd"
+	gatewayClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/gateway/versioned"
 
 	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	policyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
@@ -30,6 +32,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/reconciler"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
 	"github.com/openservicemesh/osm/pkg/config"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -39,15 +42,18 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/ads"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
 	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/httpserver"
 	httpserverconstants "github.com/openservicemesh/osm/pkg/httpserver/constants"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/leader"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
 	"github.com/openservicemesh/osm/pkg/policy"
+	policystatus "github.com/openservicemesh/osm/pkg/policy/status"
 	"github.com/openservicemesh/osm/pkg/providers/kube"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/signals"
@@ -70,7 +76,8 @@ var (
 	osmMeshConfigName          string
 	osmVersion                 string
 
-	certProviderKind string
+	certProviderKind    string
+	persistCertificates bool
 
 	tresorOptions      providers.TresorOptions
 	vaultOptions       providers.VaultOptions
@@ -79,6 +86,8 @@ var (
 	enableReconciler      bool
 	validateTrafficTarget bool
 
+	shutdownDrainWindow time.Duration
+
 	scheme = runtime.NewScheme()
 )
 
@@ -99,6 +108,7 @@ func init() {
 	// Generic certificate manager/provider options
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
 	flags.StringVar(&caBundleSecretName, "ca-bundle-secret-name", "", "Name of the Kubernetes Secret for the OSM CA bundle")
+	flags.BoolVar(&persistCertificates, "persist-certificates", false, "Persist issued certificates in Kubernetes Secrets to reuse them across restarts")
 
 	// Vault certificate manager/provider options
 	flags.StringVar(&vaultOptions.VaultProtocol, "vault-protocol", "http", "Host name of the Hashi Vault")
@@ -116,6 +126,9 @@ func init() {
 	flags.BoolVar(&enableReconciler, "enable-reconciler", false, "Enable reconciler for CDRs, mutating webhook and validating webhook")
 	flags.BoolVar(&validateTrafficTarget, "validate-traffic-target", true, "Enable traffic target validation")
 
+	// Shutdown options
+	flags.DurationVar(&shutdownDrainWindow, "shutdown-drain-window", 30*time.Second, "Window over which the connected proxies are disconnected in batches on shutdown")
+
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = admissionv1.AddToScheme(scheme)
 }
@@ -137,6 +150,7 @@ func main() {
 	}
 	kubeClient := kubernetes.NewForConfigOrDie(kubeConfig)
 	policyClient := policyClientset.NewForConfigOrDie(kubeConfig)
+	configKubeClient := configClientset.NewForConfigOrDie(kubeConfig)
 
 	// Initialize the generic Kubernetes event recorder and associate it with the osm-controller pod resource
 	controllerPod, err := getOSMControllerPod(kubeClient)
@@ -153,7 +167,10 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error validating CLI parameters")
 	}
 
-	stop := signals.RegisterExitHandlers()
+	// The components are stopped once the proxies are drained on exit, so that the proxies still
+	// connected during the drain keep receiving config updates.
+	exit := signals.RegisterExitHandlers()
+	stop := make(chan struct{})
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -164,9 +181,9 @@ func main() {
 
 	// This component will be watching the OSM MeshConfig and will make it available
 	// to the rest of the components.
-	cfg := configurator.NewConfigurator(configClientset.NewForConfigOrDie(kubeConfig), stop, osmNamespace, osmMeshConfigName, msgBroker)
+	cfg := configurator.NewConfigurator(configKubeClient, stop, osmNamespace, osmMeshConfigName, msgBroker)
 
-	k8sClient, err := k8s.NewKubernetesController(kubeClient, policyClient, meshName, stop, msgBroker)
+	k8sClient, err := k8s.NewKubernetesController(kubeClient, policyClient, configKubeClient, meshName, stop, msgBroker)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Kubernetes Controller")
 	}
@@ -176,22 +193,62 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating MeshSpec")
 	}
 
+	var certStore certificate.Store
+	var secretStore *providers.SecretStore
+	if persistCertificates {
+		secretStore = providers.NewSecretStore(kubeClient, osmNamespace, stop)
+		certStore = secretStore
+	}
+
 	certManager, certDebugger, _, err := providers.NewCertificateProvider(kubeClient, kubeConfig, cfg, providers.Kind(certProviderKind), osmNamespace,
-		caBundleSecretName, tresorOptions, vaultOptions, certManagerOptions, msgBroker)
+		caBundleSecretName, tresorOptions, vaultOptions, certManagerOptions, certStore, msgBroker)
 
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
 			"Error fetching certificate manager of kind %s", certProviderKind)
 	}
 
-	if cfg.GetFeatureFlags().EnableMulticlusterMode {
-		log.Info().Msgf("Bootstrapping OSM multicluster gateway")
-		if err := bootstrapOSMMulticlusterGateway(kubeClient, certManager, osmNamespace); err != nil {
-			events.GenericEventRecorder().FatalEvent(err, events.InitializationError,
-				"Error bootstraping OSM multicluster gateway")
-		}
+	// Every replica serves xDS from its own informer caches, while the duties writing shared state
+	// are only run by the elected leader to avoid concurrent writes to the same resources.
+	// Proxies are not sharded across replicas: any replica serves any proxy connecting through the
+	// Service, and a proxy reconnects to another replica when its replica goes away. The replicas issue
+	// consistent certificates because they share the root certificate stored in the CA bundle secret,
+	// and reuse the certificates persisted by the leader when certificates are persisted. Only the certificates
+	// issued by the leader while it leads are persisted, so that a new leader does not write stale changes.
+	// Duties are rerun on every leadership change, so each duty must be safe to restart and must not
+	// rewrite resources that are already up to date.
+	elector := leader.NewElector(kubeClient, osmNamespace, constants.OSMControllerLeaderLeaseName, controllerPod.Name)
+
+	if secretStore != nil {
+		elector.AddDuty("certificate-store", secretStore.Run)
 	}
 
+	elector.AddDuty("gateway-bootstrap", func(_ <-chan struct{}) {
+		if cfg.GetFeatureFlags().EnableMulticlusterMode {
+			log.Info().Msgf("Bootstrapping OSM multicluster gateway")
+			if err := bootstrapOSMMulticlusterGateway(kubeClient, certManager, osmNamespace); err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InitializationError,
+					"Error bootstraping OSM multicluster gateway")
+			}
+		}
+
+		if cfg.GetFeatureFlags().EnableEgressGateway {
+			log.Info().Msgf("Bootstrapping OSM egress gateway")
+			if err := bootstrapOSMEgressGateway(kubeClient, certManager, osmNamespace); err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InitializationError,
+					"Error bootstraping OSM egress gateway")
+			}
+		}
+
+		if cfg.GetFeatureFlags().EnableGatewayAPI {
+			log.Info().Msgf("Bootstrapping OSM ingress gateway")
+			if err := bootstrapOSMIngressGateway(kubeClient, certManager, osmNamespace); err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InitializationError,
+					"Error bootstraping OSM ingress gateway")
+			}
+		}
+	})
+
 	var configClient config.Controller
 
 	if cfg.GetFeatureFlags().EnableMulticlusterMode {
@@ -206,20 +263,32 @@ func main() {
 	endpointsProviders := []endpoint.Provider{kubeProvider}
 	serviceProviders := []service.Provider{kubeProvider}
 
-	if err := ingress.Initialize(kubeClient, k8sClient, stop, cfg, certManager, msgBroker); err != nil {
-		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Ingress client")
-	}
+	elector.AddDuty("ingress-gateway-certificate", func(leaderStop <-chan struct{}) {
+		if err := ingress.Initialize(kubeClient, k8sClient, leaderStop, cfg, certManager, msgBroker); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Ingress client")
+		}
+	})
 
 	policyController, err := policy.NewPolicyController(k8sClient, policyClient, stop, msgBroker)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating controller for policy.openservicemesh.io")
 	}
 
+	// A nil gatewayAPIController is passed in if the Gateway API is not enabled.
+	var gatewayAPIController gatewayapi.Controller
+	if cfg.GetFeatureFlags().EnableGatewayAPI {
+		gatewayAPIController, err = gatewayapi.NewGatewayAPIController(k8sClient, gatewayClientset.NewForConfigOrDie(kubeConfig), stop, msgBroker)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating controller for gateway.networking.k8s.io")
+		}
+	}
+
 	meshCatalog := catalog.NewMeshCatalog(
 		k8sClient,
 		meshSpec,
 		certManager,
 		policyController,
+		gatewayAPIController,
 		stop,
 		cfg,
 		serviceProviders,
@@ -231,6 +300,17 @@ func main() {
 	proxyRegistry := registry.NewProxyRegistry(proxyMapper, msgBroker)
 	go proxyRegistry.ReleaseCertificateHandler(certManager, stop)
 
+	// Only notify the proxies whose configuration depends on the resources changed by an event.
+	// Updates are not targeted in snapshot cache mode, which the ADS server also determines at startup.
+	if !cfg.GetFeatureFlags().EnableSnapshotCacheMode {
+		msgBroker.SetProxyUpdateTargeter(registry.NewDependencyIndex(proxyRegistry, meshCatalog))
+	}
+
+	// Report the status conditions of the policy resources.
+	// A nil configClient is passed in if multi cluster mode is not enabled.
+	statusReporter := policystatus.NewReporter(k8sClient, policyController, configClient, meshSpec, meshCatalog)
+	elector.AddDuty("policy-status", statusReporter.Start)
+
 	adsCert, err := certManager.IssueCertificate(xdsServerCertificateCommonName, constants.XDSCertificateValidityPeriod)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.CertificateIssuanceFailure, "Error issuing XDS certificate to ADS server")
@@ -275,20 +355,33 @@ func main() {
 	go debugConfig.StartDebugServerConfigListener(stop)
 
 	// Start the k8s pod watcher that updates corresponding k8s secrets
-	go k8s.WatchAndUpdateProxyBootstrapSecret(kubeClient, msgBroker, stop)
+	elector.AddDuty("proxy-bootstrap-secret", func(leaderStop <-chan struct{}) {
+		k8s.WatchAndUpdateProxyBootstrapSecret(kubeClient, msgBroker, leaderStop)
+	})
 	// Start the global log level watcher that updates the log level dynamically
 	go k8s.WatchAndUpdateLogLevel(msgBroker, stop)
 
 	if enableReconciler {
 		log.Info().Msgf("OSM reconciler enabled for validating webhook")
-		err = reconciler.NewReconcilerClient(kubeClient, nil, meshName, osmVersion, stop, reconciler.ValidatingWebhookInformerKey)
-		if err != nil {
-			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating reconciler client to reconcile validating webhook")
-		}
+		elector.AddDuty("reconciler", func(leaderStop <-chan struct{}) {
+			err := reconciler.NewReconcilerClient(kubeClient, nil, meshName, osmVersion, leaderStop, reconciler.ValidatingWebhookInformerKey)
+			if err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating reconciler client to reconcile validating webhook")
+			}
+		})
 	}
 
-	<-stop
+	// Start campaigning for leadership once all the singleton duties are added.
+	// Leadership is released as soon as the exit signal is received, so that another replica takes
+	// over the singleton duties while the proxies are drained.
+	go elector.Run(exit)
+
+	<-exit
 	log.Info().Msgf("Stopping osm-controller %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
+
+	// Disconnect the proxies in batches, so that they do not all reconnect to the other instances at once
+	xdsServer.Drain(shutdownDrainWindow)
+	close(stop)
 }
 
 // Start the metric store, register the metrics OSM will expose
@@ -300,6 +393,9 @@ func startMetricsStore() {
 		metricsstore.DefaultMetricsStore.ProxyReconnectCount,
 		metricsstore.DefaultMetricsStore.ProxyConfigUpdateTime,
 		metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount,
+		metricsstore.DefaultMetricsStore.ProxyTargetedEventCount,
+		metricsstore.DefaultMetricsStore.ProxyUpdateFanout,
+		metricsstore.DefaultMetricsStore.ProxyUpdateSkippedCount,
 		metricsstore.DefaultMetricsStore.ProxyResponseSendSuccessCount,
 		metricsstore.DefaultMetricsStore.ProxyResponseSendErrorCount,
 		metricsstore.DefaultMetricsStore.ErrCodeCounter,
@@ -307,7 +403,12 @@ func startMetricsStore() {
 		metricsstore.DefaultMetricsStore.HTTPResponseDuration,
 		metricsstore.DefaultMetricsStore.FeatureFlagEnabled,
 		metricsstore.DefaultMetricsStore.ProxyXDSRequestCount,
+		metricsstore.DefaultMetricsStore.ProxyXDSPushSkippedCount,
 		metricsstore.DefaultMetricsStore.ProxyMaxConnectionsRejected,
+		metricsstore.DefaultMetricsStore.ProxyConnectionsQueued,
+		metricsstore.DefaultMetricsStore.ProxyConnectionsDelayed,
+		metricsstore.DefaultMetricsStore.ProxyConnectionsThrottled,
+		metricsstore.DefaultMetricsStore.ProxyConfigGenerationsQueued,
 	)
 }
 
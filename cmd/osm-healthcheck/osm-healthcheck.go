@@ -1,5 +1,5 @@
 // Package main implements the main entrypoint for osm-healthcheck.
-// osm-healthcheck provides TCPSocket probe support for pods in the mesh.
+// osm-healthcheck provides TCPSocket and gRPC probe support for pods in the mesh.
 package main
 
 import (
@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -65,12 +68,24 @@ func main() {
 	}
 }
 
+const (
+	originalTCPPortHeader     = "Original-Tcp-Port"
+	originalGRPCPortHeader    = "Original-Grpc-Port"
+	originalGRPCServiceHeader = "Original-Grpc-Service"
+)
+
 // healthcheckHandler handles HTTP requests and attempts to open a socket to a container
 // on the TCP port specified in the request's header.
 // If a connection is successfully established, the connection is closed and the response
 // status code will be 200.
+// Requests for gRPC probes are handled by grpcHealthcheckHandler.
 func healthcheckHandler(w http.ResponseWriter, req *http.Request) {
-	port := req.Header.Get("Original-Tcp-Port")
+	if req.Header.Get(originalGRPCPortHeader) != "" {
+		grpcHealthcheckHandler(w, req)
+		return
+	}
+
+	port := req.Header.Get(originalTCPPortHeader)
 	if port == "" {
 		msg := "Header Original-Tcp-Port not found in request"
 		log.Error().Msg(msg)
@@ -96,6 +111,42 @@ func healthcheckHandler(w http.ResponseWriter, req *http.Request) {
 	setHealthcheckResponse(w, http.StatusOK, msg)
 }
 
+// grpcHealthcheckHandler handles HTTP requests and calls the gRPC health service of a container
+// on the port specified in the request's header, for the service specified in the request's header.
+// If the service is serving, the response status code will be 200.
+func grpcHealthcheckHandler(w http.ResponseWriter, req *http.Request) {
+	address := fmt.Sprintf("%s:%s", constants.LocalhostIPAddress, req.Header.Get(originalGRPCPortHeader))
+	service := req.Header.Get(originalGRPCServiceHeader)
+
+	conn, err := grpc.DialContext(req.Context(), address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to create gRPC connection to %s", address)
+		log.Error().Err(err).Msg(msg)
+		setHealthcheckResponse(w, http.StatusInternalServerError, msg)
+		return
+	}
+	defer conn.Close() //nolint: errcheck
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(req.Context(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to check the health of gRPC service %q on %s", service, address)
+		log.Error().Err(err).Msg(msg)
+		setHealthcheckResponse(w, http.StatusNotFound, msg)
+		return
+	}
+
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		msg := fmt.Sprintf("gRPC service %q on %s is %s", service, address, resp.GetStatus())
+		log.Debug().Msg(msg)
+		setHealthcheckResponse(w, http.StatusServiceUnavailable, msg)
+		return
+	}
+
+	msg := fmt.Sprintf("gRPC service %q on %s is %s", service, address, resp.GetStatus())
+	log.Debug().Msg(msg)
+	setHealthcheckResponse(w, http.StatusOK, msg)
+}
+
 func setHealthcheckResponse(w http.ResponseWriter, responseCode int, msg string) {
 	w.WriteHeader(responseCode)
 	if _, err := w.Write([]byte(msg)); err != nil {
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 )
@@ -78,3 +81,77 @@ func TestGetHealthcheckHander(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCHealthcheckHandler(t *testing.T) {
+	testCases := []struct {
+		name               string
+		service            string
+		shouldListen       bool
+		expectedStatusCode int
+	}{
+		{
+			name:               "OK response when the server is serving",
+			service:            "",
+			shouldListen:       true,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "OK response when the service is serving",
+			service:            "serving.Service",
+			shouldListen:       true,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Service unavailable response when the service is not serving",
+			service:            "notserving.Service",
+			shouldListen:       true,
+			expectedStatusCode: 503,
+		},
+		{
+			name:               "Not found response when the service is unknown",
+			service:            "unknown.Service",
+			shouldListen:       true,
+			expectedStatusCode: 404,
+		},
+		{
+			name:               "Not found response when unable to establish connection",
+			shouldListen:       false,
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			listener, err := net.Listen("tcp", fmt.Sprintf("%s:0", constants.LocalhostIPAddress))
+			assert.Nil(err)
+			port := listener.Addr().(*net.TCPAddr).Port
+
+			if test.shouldListen {
+				healthServer := health.NewServer()
+				healthServer.SetServingStatus("serving.Service", grpc_health_v1.HealthCheckResponse_SERVING)
+				healthServer.SetServingStatus("notserving.Service", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+				server := grpc.NewServer()
+				grpc_health_v1.RegisterHealthServer(server, healthServer)
+				go server.Serve(listener) //nolint: errcheck
+				defer server.Stop()
+			} else {
+				assert.Nil(listener.Close())
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/osm-healthcheck", nil)
+			req.Header.Add("Original-Grpc-Port", fmt.Sprint(port))
+			if test.service != "" {
+				req.Header.Add("Original-Grpc-Service", test.service)
+			}
+
+			w := httptest.NewRecorder()
+
+			healthcheckHandler(w, req)
+
+			res := w.Result()
+			assert.Equal(test.expectedStatusCode, res.StatusCode)
+		})
+	}
+}
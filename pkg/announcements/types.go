@@ -68,6 +68,17 @@ const (
 
 	// ---
 
+	// SecretAdded is the type of announcement emitted when we observe an addition of a Kubernetes Secret
+	SecretAdded Kind = "secret-added"
+
+	// SecretDeleted the type of announcement emitted when we observe the deletion of a Kubernetes Secret
+	SecretDeleted Kind = "secret-deleted"
+
+	// SecretUpdated is the type of announcement emitted when we observe an update to a Kubernetes Secret
+	SecretUpdated Kind = "secret-updated"
+
+	// ---
+
 	// TrafficSplitAdded is the type of announcement emitted when we observe an addition of a Kubernetes TrafficSplit
 	TrafficSplitAdded Kind = "trafficsplit-added"
 
@@ -137,6 +148,20 @@ const (
 	// MeshConfigUpdated is the type of announcement emitted when we observe an update to a Kubernetes MeshConfig
 	MeshConfigUpdated Kind = "meshconfig-updated"
 
+	// MeshConfigRolloutUpdated is the type of announcement emitted when the staged rollout of a MeshConfig change starts, is promoted or rolled back
+	MeshConfigRolloutUpdated Kind = "meshconfig-rollout-updated"
+
+	// ---
+
+	// NamespaceConfigAdded is the type of announcement emitted when we observe an addition of a namespaceconfig.config.openservicemesh.io
+	NamespaceConfigAdded Kind = "namespaceconfig-added"
+
+	// NamespaceConfigDeleted is the type of announcement emitted when we observe a deletion of a namespaceconfig.config.openservicemesh.io
+	NamespaceConfigDeleted Kind = "namespaceconfig-deleted"
+
+	// NamespaceConfigUpdated is the type of announcement emitted when we observe an update of a namespaceconfig.config.openservicemesh.io
+	NamespaceConfigUpdated Kind = "namespaceconfig-updated"
+
 	// --- policy.openservicemesh.io API events
 
 	// EgressAdded is the type of announcement emitted when we observe an addition of egresses.policy.openservicemesh.io
@@ -175,6 +200,24 @@ const (
 	// UpstreamTrafficSettingUpdated is the type of announcement emitted when we observe an update of upstreamtrafficsettings.policy.openservicemesh.io
 	UpstreamTrafficSettingUpdated Kind = "upstreamtrafficsetting-updated"
 
+	// ExternalAuthorizationAdded is the type of announcement emitted when we observe an addition of externalauthorizations.policy.openservicemesh.io
+	ExternalAuthorizationAdded Kind = "externalauthorization-added"
+
+	// ExternalAuthorizationDeleted is the type of announcement emitted when we observe a deletion of externalauthorizations.policy.openservicemesh.io
+	ExternalAuthorizationDeleted Kind = "externalauthorization-deleted"
+
+	// ExternalAuthorizationUpdated is the type of announcement emitted when we observe an update of externalauthorizations.policy.openservicemesh.io
+	ExternalAuthorizationUpdated Kind = "externalauthorization-updated"
+
+	// SidecarScopeAdded is the type of announcement emitted when we observe an addition of sidecarscopes.policy.openservicemesh.io
+	SidecarScopeAdded Kind = "sidecarscope-added"
+
+	// SidecarScopeDeleted is the type of announcement emitted when we observe a deletion of sidecarscopes.policy.openservicemesh.io
+	SidecarScopeDeleted Kind = "sidecarscope-deleted"
+
+	// SidecarScopeUpdated is the type of announcement emitted when we observe an update of sidecarscopes.policy.openservicemesh.io
+	SidecarScopeUpdated Kind = "sidecarscope-updated"
+
 	// ---
 
 	// MultiClusterServiceAdded is the type of announcement emitted when we observe an addition of a multiclusterservice.config.openservicemesh.io
@@ -185,6 +228,50 @@ const (
 
 	// MultiClusterServiceUpdated is the type of announcement emitted when we observe an update of a multiclusterservice.config.openservicemesh.io
 	MultiClusterServiceUpdated Kind = "multiclusterservice-updated"
+
+	// ---
+
+	// GatewayClassAdded is the type of announcement emitted when we observe an addition of gatewayclasses.gateway.networking.k8s.io
+	GatewayClassAdded Kind = "gatewayclass-added"
+
+	// GatewayClassDeleted is the type of announcement emitted when we observe a deletion of gatewayclasses.gateway.networking.k8s.io
+	GatewayClassDeleted Kind = "gatewayclass-deleted"
+
+	// GatewayClassUpdated is the type of announcement emitted when we observe an update to gatewayclasses.gateway.networking.k8s.io
+	GatewayClassUpdated Kind = "gatewayclass-updated"
+
+	// ---
+
+	// GatewayAdded is the type of announcement emitted when we observe an addition of gateways.gateway.networking.k8s.io
+	GatewayAdded Kind = "gateway-added"
+
+	// GatewayDeleted is the type of announcement emitted when we observe a deletion of gateways.gateway.networking.k8s.io
+	GatewayDeleted Kind = "gateway-deleted"
+
+	// GatewayUpdated is the type of announcement emitted when we observe an update to gateways.gateway.networking.k8s.io
+	GatewayUpdated Kind = "gateway-updated"
+
+	// ---
+
+	// HTTPRouteAdded is the type of announcement emitted when we observe an addition of httproutes.gateway.networking.k8s.io
+	HTTPRouteAdded Kind = "httproute-added"
+
+	// HTTPRouteDeleted is the type of announcement emitted when we observe a deletion of httproutes.gateway.networking.k8s.io
+	HTTPRouteDeleted Kind = "httproute-deleted"
+
+	// HTTPRouteUpdated is the type of announcement emitted when we observe an update to httproutes.gateway.networking.k8s.io
+	HTTPRouteUpdated Kind = "httproute-updated"
+
+	// ---
+
+	// TLSRouteAdded is the type of announcement emitted when we observe an addition of tlsroutes.gateway.networking.k8s.io
+	TLSRouteAdded Kind = "tlsroute-added"
+
+	// TLSRouteDeleted is the type of announcement emitted when we observe a deletion of tlsroutes.gateway.networking.k8s.io
+	TLSRouteDeleted Kind = "tlsroute-deleted"
+
+	// TLSRouteUpdated is the type of announcement emitted when we observe an update to tlsroutes.gateway.networking.k8s.io
+	TLSRouteUpdated Kind = "tlsroute-updated"
 )
 
 // Announcement is a struct for messages between various components of OSM signaling a need for a change in Envoy proxy configuration
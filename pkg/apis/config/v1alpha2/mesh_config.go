@@ -7,7 +7,6 @@ import (
 
 // MeshConfig is the type used to represent the mesh configuration.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type MeshConfig struct {
 	// Object's type metadata.
@@ -20,6 +19,50 @@ type MeshConfig struct {
 	// Spec is the MeshConfig specification.
 	// +optional
 	Spec MeshConfigSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+
+	// Status is the status of the MeshConfig, maintained by the OSM controller.
+	// +optional
+	Status MeshConfigStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// MeshConfigStatus is the type used to represent the status of a MeshConfig resource.
+type MeshConfigStatus struct {
+	// Rollout is the state of the staged rollout of MeshConfig changes, shared by the OSM controller replicas
+	// and preserved across their restarts.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus is the type used to represent the state of the staged rollout of MeshConfig changes.
+type RolloutStatus struct {
+	// Phase is the phase of the rollout: Stable, Progressing or RolledBack.
+	Phase string `json:"phase"`
+
+	// Message describes the last transition of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the time of the last transition of the rollout.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// StableGeneration is the generation of the MeshConfig applied to the proxies that are not canaries.
+	StableGeneration int64 `json:"stableGeneration"`
+
+	// StableSpec is the spec of the MeshConfig applied to the proxies that are not canaries.
+	// +optional
+	StableSpec *MeshConfigSpec `json:"stableSpec,omitempty"`
+
+	// CanaryGeneration is the generation of the MeshConfig being rolled out or rolled back.
+	// +optional
+	CanaryGeneration int64 `json:"canaryGeneration,omitempty"`
+
+	// StartedAt is the time the rollout started.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// NACKs is the number of NACKs received from the canary proxies, across the OSM controller replicas.
+	NACKs int `json:"nacks"`
 }
 
 // MeshConfigSpec is the spec for OSM's configuration.
@@ -38,6 +81,9 @@ type MeshConfigSpec struct {
 
 	// FeatureFlags defines the feature flags for a mesh instance.
 	FeatureFlags FeatureFlags `json:"featureFlags,omitempty"`
+
+	// Rollout defines the staged rollout of MeshConfig changes to the proxies in a mesh instance.
+	Rollout RolloutSpec `json:"rollout,omitempty"`
 }
 
 // SidecarSpec is the type used to represent the specifications for the proxy sidecar.
@@ -60,6 +106,10 @@ type SidecarSpec struct {
 	// MaxDataPlaneConnections defines the maximum allowed data plane connections from a proxy sidecar to the OSM controller.
 	MaxDataPlaneConnections int `json:"maxDataPlaneConnections,omitempty"`
 
+	// DataPlaneConnectionAdmission defines the admission control of new data plane connections to the OSM controller,
+	// protecting it from reconnect storms such as when the proxies reconnect after the OSM controller restarts.
+	DataPlaneConnectionAdmission DataPlaneConnectionAdmissionSpec `json:"dataPlaneConnectionAdmission,omitempty"`
+
 	// ConfigResyncInterval defines the resync interval for regular proxy broadcast updates.
 	ConfigResyncInterval string `json:"configResyncInterval,omitempty"`
 
@@ -77,8 +127,50 @@ type SidecarSpec struct {
 
 	// ECDHCurves defines a list of ECDH curves that TLS connection supports. If not specified, the curves are [X25519, P-256] for non-FIPS build and P-256 for builds using BoringSSL FIPS.
 	ECDHCurves []string `json:"ecdhCurves,omitempty"`
+
+	// HoldApplicationUntilProxyStarts defines a boolean indicating whether the application containers of a meshed pod
+	// are started only once the sidecar is ready, i.e. after it has applied its initial configuration.
+	// The sidecar is restarted if it is not ready within 120 seconds.
+	HoldApplicationUntilProxyStarts bool `json:"holdApplicationUntilProxyStarts,omitempty"`
+
+	// DrainDuration defines the duration during which the sidecar gracefully drains its inbound listeners when the pod terminates,
+	// while still serving the outbound traffic of the application. Draining is disabled when set to 0s. Defaults to 5s.
+	// The drain is triggered by a preStop hook running a shell command, which requires a sidecar image providing a shell.
+	DrainDuration string `json:"drainDuration,omitempty"`
+
+	// TrafficRedirectionMode defines how the traffic of meshed pods is redirected to their sidecar.
+	// Must be one of: InitContainer, CNI. Defaults to InitContainer.
+	TrafficRedirectionMode string `json:"trafficRedirectionMode,omitempty"`
 }
 
+// DataPlaneConnectionAdmissionSpec is the type used to represent the admission control of new data plane connections.
+// Gateways are prioritized over sidecars and are always admitted.
+type DataPlaneConnectionAdmissionSpec struct {
+	// ConnectionsPerSecond defines the rate at which new data plane connections are admitted. Admission control is disabled when set to 0.
+	ConnectionsPerSecond int `json:"connectionsPerSecond,omitempty"`
+
+	// Burst defines the number of new data plane connections admitted at once before being rate limited. Defaults to ConnectionsPerSecond.
+	Burst int `json:"burst,omitempty"`
+
+	// MaxQueueDuration defines the maximum duration a new data plane connection waits to be admitted, beyond which it is rejected
+	// with a hint to retry after a backoff. Defaults to 10s.
+	MaxQueueDuration string `json:"maxQueueDuration,omitempty"`
+
+	// MaxConcurrentConfigGenerations defines the maximum number of full proxy configurations generated concurrently.
+	// Unbounded when set to 0.
+	MaxConcurrentConfigGenerations int `json:"maxConcurrentConfigGenerations,omitempty"`
+}
+
+const (
+	// TrafficRedirectionModeInitContainer is the traffic redirection mode where the traffic redirection rules
+	// of a meshed pod are programmed by an init container injected in the pod.
+	TrafficRedirectionModeInitContainer = "InitContainer"
+
+	// TrafficRedirectionModeCNI is the traffic redirection mode where the traffic redirection rules of a meshed
+	// pod are programmed by the osm-cni plugin when the network of the pod is set up.
+	TrafficRedirectionModeCNI = "CNI"
+)
+
 // TrafficSpec is the type used to represent OSM's traffic management configuration.
 type TrafficSpec struct {
 	// EnableEgress defines a boolean indicating if mesh-wide Egress is enabled.
@@ -101,7 +193,8 @@ type TrafficSpec struct {
 	EnablePermissiveTrafficPolicyMode bool `json:"enablePermissiveTrafficPolicyMode"`
 
 	// InboundExternalAuthorization defines a ruleset that, if enabled, will configure a remote external authorization endpoint
-	// for all inbound and ingress traffic in the mesh.
+	// for all inbound and ingress traffic in the mesh. ExternalAuthorization policies override this configuration
+	// for the services or namespaces they target.
 	InboundExternalAuthorization ExternalAuthzSpec `json:"inboundExternalAuthorization,omitempty"`
 }
 
@@ -117,6 +210,31 @@ type ObservabilitySpec struct {
 	Tracing TracingSpec `json:"tracing,omitempty"`
 }
 
+// RolloutSpec is the type to represent the staged rollout of MeshConfig changes.
+// When enabled, a change to the MeshConfig is first applied to the canary proxies only, while the remaining
+// proxies keep the previous configuration. The change is promoted to every proxy once the canary proxies
+// have been running it for the bake duration, or rolled back if the canary proxies reject it (NACK).
+// Only configuration rejections are taken into account: a change that is accepted by the proxies but degrades
+// the traffic, such as an increased error rate, is not rolled back automatically and must be reverted by
+// restoring the previous MeshConfig.
+type RolloutSpec struct {
+	// Enable defines a boolean indicating if MeshConfig changes are rolled out in stages.
+	Enable bool `json:"enable"`
+
+	// CanaryPercentage defines the percentage of proxies the MeshConfig change is first applied to.
+	CanaryPercentage int `json:"canaryPercentage,omitempty"`
+
+	// CanaryNamespaces defines the namespaces whose proxies the MeshConfig change is first applied to.
+	CanaryNamespaces []string `json:"canaryNamespaces,omitempty"`
+
+	// BakeDuration defines the duration the canary proxies run the MeshConfig change before it is promoted.
+	BakeDuration string `json:"bakeDuration,omitempty"`
+
+	// MaxNACKs defines the number of configuration rejections (NACKs) from the canary proxies that are tolerated
+	// before the MeshConfig change is rolled back.
+	MaxNACKs int `json:"maxNACKs,omitempty"`
+}
+
 // TracingSpec is the type to represent OSM's tracing configuration.
 type TracingSpec struct {
 	// Enable defines a boolean indicating if the sidecars are enabled for tracing.
@@ -200,6 +318,14 @@ type FeatureFlags struct {
 	// EnableMulticlusterMode defines if Multicluster mode is enabled.
 	EnableMulticlusterMode bool `json:"enableMulticlusterMode"`
 
+	// EnableEgressGateway defines if the OSM egress gateway is enabled, allowing Egress policies
+	// to route external traffic through the egress gateway.
+	EnableEgressGateway bool `json:"enableEgressGateway"`
+
+	// EnableGatewayAPI defines if OSM implements the Kubernetes Gateway API, programming the OSM
+	// ingress gateway from Gateway, HTTPRoute and TLSRoute resources.
+	EnableGatewayAPI bool `json:"enableGatewayAPI"`
+
 	// EnableSnapshotCacheMode defines if XDS server starts with snapshot cache.
 	EnableSnapshotCacheMode bool `json:"enableSnapshotCacheMode"`
 
@@ -216,4 +342,8 @@ type FeatureFlags struct {
 
 	// EnableRetryPolicy defines if retry policy is enabled.
 	EnableRetryPolicy bool `json:"enableRetryPolicy"`
+
+	// EnableDNSProxy defines if the sidecars capture the DNS queries of pods in the mesh, and answer the names
+	// of mesh services and the hosts of Egress policies, allowing hostname based Egress policies for TCP traffic.
+	EnableDNSProxy bool `json:"enableDNSProxy"`
 }
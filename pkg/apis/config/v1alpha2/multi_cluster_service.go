@@ -9,7 +9,6 @@ import (
 // MultiClusterService is the type used to represent the multicluster configuration.
 // MultiClusterService name needs to match the name of the service backing the pods in each cluster.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type MultiClusterService struct {
 	// Object's type metadata.
@@ -21,6 +20,10 @@ type MultiClusterService struct {
 
 	// Spec is the MultiClusterService specification.
 	Spec MultiClusterServiceSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+
+	// Status is the status of the MultiClusterService.
+	// +optional
+	Status MultiClusterServiceStatus `json:"status,omitempty" yaml:"status,omitempty"`
 }
 
 func (mcs MultiClusterService) String() string {
@@ -63,6 +66,16 @@ type PortSpec struct {
 	Protocol string
 }
 
+// MultiClusterServiceStatus is the type used to represent the status of a MultiClusterService resource.
+type MultiClusterServiceStatus struct {
+	// Conditions describe the current state of the MultiClusterService resource.
+	// Known condition types are Accepted, ResolvedRefs and Programmed.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
 // MultiClusterServiceList defines the list of MultiClusterService objects.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type MultiClusterServiceList struct {
@@ -0,0 +1,79 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceConfig is the type used to represent the configuration overrides applied to the workloads in a namespace.
+// A NamespaceConfig overrides a subset of the MeshConfig fields for the workloads in its namespace.
+// When multiple NamespaceConfig resources exist in a namespace, the oldest one is used.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespaceConfig struct {
+	// Object's type metadata.
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+
+	// Object's metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Spec is the NamespaceConfig specification.
+	// +optional
+	Spec NamespaceConfigSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// NamespaceConfigSpec is the spec for the configuration overrides of a namespace.
+// Fields that are not set inherit their value from the MeshConfig.
+type NamespaceConfigSpec struct {
+	// Sidecar defines the overrides of the sidecar configuration.
+	Sidecar NamespaceSidecarSpec `json:"sidecar,omitempty"`
+
+	// Traffic defines the overrides of the traffic configuration.
+	Traffic NamespaceTrafficSpec `json:"traffic,omitempty"`
+
+	// Observability defines the overrides of the observability configuration.
+	Observability NamespaceObservabilitySpec `json:"observability,omitempty"`
+}
+
+// NamespaceSidecarSpec is the type used to represent the overrides of the sidecar configuration.
+type NamespaceSidecarSpec struct {
+	// LogLevel overrides the sidecar's log level.
+	LogLevel *string `json:"logLevel,omitempty"`
+
+	// TLSMinProtocolVersion overrides the minimum TLS protocol version that the sidecar supports.
+	TLSMinProtocolVersion *string `json:"tlsMinProtocolVersion,omitempty"`
+
+	// TLSMaxProtocolVersion overrides the maximum TLS protocol version that the sidecar supports.
+	TLSMaxProtocolVersion *string `json:"tlsMaxProtocolVersion,omitempty"`
+
+	// CipherSuites overrides the list of ciphers that the sidecar supports.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ECDHCurves overrides the list of ECDH curves that the sidecar supports.
+	ECDHCurves []string `json:"ecdhCurves,omitempty"`
+}
+
+// NamespaceTrafficSpec is the type used to represent the overrides of the traffic configuration.
+type NamespaceTrafficSpec struct {
+	// EnableEgress overrides whether egress is enabled for the workloads in the namespace.
+	EnableEgress *bool `json:"enableEgress,omitempty"`
+
+	// EnablePermissiveTrafficPolicyMode overrides whether permissive traffic policy mode is enabled for the workloads in the namespace.
+	EnablePermissiveTrafficPolicyMode *bool `json:"enablePermissiveTrafficPolicyMode,omitempty"`
+}
+
+// NamespaceObservabilitySpec is the type used to represent the overrides of the observability configuration.
+type NamespaceObservabilitySpec struct {
+	// Tracing overrides the tracing configuration.
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+}
+
+// NamespaceConfigList lists the NamespaceConfig objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespaceConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NamespaceConfig `json:"items"`
+}
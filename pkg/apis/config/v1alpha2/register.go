@@ -41,6 +41,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&MeshConfigList{},
 		&MultiClusterService{},
 		&MultiClusterServiceList{},
+		&NamespaceConfig{},
+		&NamespaceConfigList{},
 	)
 
 	metav1.AddToGroupVersion(
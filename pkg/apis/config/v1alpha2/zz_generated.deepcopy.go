@@ -19,6 +19,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -59,6 +60,22 @@ func (in *ClusterSpec) DeepCopy() *ClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneConnectionAdmissionSpec) DeepCopyInto(out *DataPlaneConnectionAdmissionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneConnectionAdmissionSpec.
+func (in *DataPlaneConnectionAdmissionSpec) DeepCopy() *DataPlaneConnectionAdmissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneConnectionAdmissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalAuthzSpec) DeepCopyInto(out *ExternalAuthzSpec) {
 	*out = *in
@@ -119,6 +136,7 @@ func (in *MeshConfig) DeepCopyInto(out *MeshConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -181,6 +199,7 @@ func (in *MeshConfigSpec) DeepCopyInto(out *MeshConfigSpec) {
 	out.Observability = in.Observability
 	in.Certificate.DeepCopyInto(&out.Certificate)
 	out.FeatureFlags = in.FeatureFlags
+	in.Rollout.DeepCopyInto(&out.Rollout)
 	return
 }
 
@@ -194,12 +213,34 @@ func (in *MeshConfigSpec) DeepCopy() *MeshConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshConfigStatus) DeepCopyInto(out *MeshConfigStatus) {
+	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshConfigStatus.
+func (in *MeshConfigStatus) DeepCopy() *MeshConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiClusterService) DeepCopyInto(out *MultiClusterService) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -280,6 +321,196 @@ func (in *MultiClusterServiceSpec) DeepCopy() *MultiClusterServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterServiceStatus) DeepCopyInto(out *MultiClusterServiceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceStatus.
+func (in *MultiClusterServiceStatus) DeepCopy() *MultiClusterServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceConfig) DeepCopyInto(out *NamespaceConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceConfig.
+func (in *NamespaceConfig) DeepCopy() *NamespaceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceConfigList) DeepCopyInto(out *NamespaceConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceConfigList.
+func (in *NamespaceConfigList) DeepCopy() *NamespaceConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceConfigSpec) DeepCopyInto(out *NamespaceConfigSpec) {
+	*out = *in
+	in.Sidecar.DeepCopyInto(&out.Sidecar)
+	in.Traffic.DeepCopyInto(&out.Traffic)
+	in.Observability.DeepCopyInto(&out.Observability)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceConfigSpec.
+func (in *NamespaceConfigSpec) DeepCopy() *NamespaceConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceObservabilitySpec) DeepCopyInto(out *NamespaceObservabilitySpec) {
+	*out = *in
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceObservabilitySpec.
+func (in *NamespaceObservabilitySpec) DeepCopy() *NamespaceObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSidecarSpec) DeepCopyInto(out *NamespaceSidecarSpec) {
+	*out = *in
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSMinProtocolVersion != nil {
+		in, out := &in.TLSMinProtocolVersion, &out.TLSMinProtocolVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSMaxProtocolVersion != nil {
+		in, out := &in.TLSMaxProtocolVersion, &out.TLSMaxProtocolVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ECDHCurves != nil {
+		in, out := &in.ECDHCurves, &out.ECDHCurves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSidecarSpec.
+func (in *NamespaceSidecarSpec) DeepCopy() *NamespaceSidecarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSidecarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceTrafficSpec) DeepCopyInto(out *NamespaceTrafficSpec) {
+	*out = *in
+	if in.EnableEgress != nil {
+		in, out := &in.EnableEgress, &out.EnableEgress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnablePermissiveTrafficPolicyMode != nil {
+		in, out := &in.EnablePermissiveTrafficPolicyMode, &out.EnablePermissiveTrafficPolicyMode
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTrafficSpec.
+func (in *NamespaceTrafficSpec) DeepCopy() *NamespaceTrafficSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceTrafficSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
 	*out = *in
@@ -313,9 +544,57 @@ func (in *PortSpec) DeepCopy() *PortSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
+	*out = *in
+	if in.CanaryNamespaces != nil {
+		in, out := &in.CanaryNamespaces, &out.CanaryNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
+func (in *RolloutSpec) DeepCopy() *RolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.StableSpec != nil {
+		in, out := &in.StableSpec, &out.StableSpec
+		*out = new(MeshConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSpec) DeepCopyInto(out *SidecarSpec) {
 	*out = *in
+	out.DataPlaneConnectionAdmission = in.DataPlaneConnectionAdmission
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.CipherSuites != nil {
 		in, out := &in.CipherSuites, &out.CipherSuites
@@ -0,0 +1,40 @@
+package v1alpha1
+
+// Condition types reported in the status of the policy resources
+const (
+	// PolicyConditionAccepted indicates whether the policy is valid and does not conflict with other policies
+	PolicyConditionAccepted = "Accepted"
+
+	// PolicyConditionResolvedRefs indicates whether the resources referenced by the policy exist
+	PolicyConditionResolvedRefs = "ResolvedRefs"
+
+	// PolicyConditionProgrammed indicates whether the policy is configured on proxies
+	PolicyConditionProgrammed = "Programmed"
+)
+
+// Reasons of the conditions reported in the status of the policy resources
+const (
+	// PolicyReasonAccepted is the reason of the Accepted condition when the policy is accepted
+	PolicyReasonAccepted = "Accepted"
+
+	// PolicyReasonInvalid is the reason of the Accepted condition when the policy fails validation
+	PolicyReasonInvalid = "Invalid"
+
+	// PolicyReasonConflicted is the reason of the Accepted condition when the policy conflicts with another policy
+	PolicyReasonConflicted = "Conflicted"
+
+	// PolicyReasonResolvedRefs is the reason of the ResolvedRefs condition when all the references are resolved
+	PolicyReasonResolvedRefs = "ResolvedRefs"
+
+	// PolicyReasonRefNotFound is the reason of the ResolvedRefs condition when a referenced resource does not exist
+	PolicyReasonRefNotFound = "RefNotFound"
+
+	// PolicyReasonProgrammed is the reason of the Programmed condition when the policy is configured on proxies
+	PolicyReasonProgrammed = "Programmed"
+
+	// PolicyReasonNoProxies is the reason of the Programmed condition when no proxy of a ready pod is configured with the policy
+	PolicyReasonNoProxies = "NoProxies"
+
+	// PolicyReasonNotAccepted is the reason of the Programmed condition when the policy is not accepted
+	PolicyReasonNotAccepted = "NotAccepted"
+)
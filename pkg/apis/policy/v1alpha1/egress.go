@@ -10,7 +10,6 @@ import (
 // external to the service mesh or cluster based on the specified
 // rules in the policy.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Egress struct {
 	// Object's type metadata
@@ -23,6 +22,10 @@ type Egress struct {
 	// Spec is the Egress policy specification
 	// +optional
 	Spec EgressSpec `json:"spec,omitempty"`
+
+	// Status is the status of the Egress policy.
+	// +optional
+	Status EgressStatus `json:"status,omitempty"`
 }
 
 // EgressSpec is the type used to represent the Egress policy specification.
@@ -40,6 +43,10 @@ type EgressSpec struct {
 	// in the TLS handshake is matched against the list of Hosts specified.
 	//
 	// - For non-HTTP(s) based protocols, the Hosts field is ignored.
+	//
+	// For HTTPS traffic, a host may be a wildcard domain of the form '*.example.com',
+	// matching any subdomain of 'example.com'. Wildcard hosts are not supported for
+	// HTTP traffic.
 	// +optional
 	Hosts []string `json:"hosts,omitempty"`
 
@@ -56,6 +63,45 @@ type EgressSpec struct {
 	// Matches defines the list of object references the Egress policy should match on.
 	// +optional
 	Matches []corev1.TypedLocalObjectReference `json:"matches,omitempty"`
+
+	// TLS defines the TLS origination settings for the HTTP ports in the Egress policy.
+	// When specified, plaintext HTTP traffic sent by the application to the Hosts is
+	// upgraded to TLS by the sidecar before being forwarded to the external hosts.
+	// +optional
+	TLS *EgressTLSSpec `json:"tls,omitempty"`
+
+	// RouteViaGateway defines whether the traffic matched by the Egress policy is routed
+	// from the sources to the OSM egress gateway over mTLS, instead of leaving the mesh
+	// directly from the sources. The egress gateway enforces the policy and forwards the
+	// traffic to the external hosts. Only HTTP and HTTPS ports with Hosts are supported.
+	// +optional
+	RouteViaGateway bool `json:"routeViaGateway,omitempty"`
+}
+
+// EgressTLSSpec is the type used to represent the TLS origination settings in an Egress policy specification.
+type EgressTLSSpec struct {
+	// Port defines the port on the external hosts TLS connections are originated to.
+	// Defaults to the port number of the HTTP port the traffic is matched on.
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// CABundleSecret defines the name of the Secret in the Egress policy's namespace
+	// holding the CA bundle under the 'ca.crt' key, used to validate the certificate
+	// presented by the external hosts. When unspecified, the certificate presented
+	// by the external hosts is validated using the system trust store of the sidecar.
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+
+	// InsecureSkipVerify defines whether the certificate presented by the external
+	// hosts is not validated. It cannot be set along with CABundleSecret.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// ClientCertificateSecret defines the name of the Secret in the Egress policy's
+	// namespace holding the client certificate and private key under the 'tls.crt'
+	// and 'tls.key' keys, presented to the external hosts for mutual TLS.
+	// +optional
+	ClientCertificateSecret string `json:"clientCertificateSecret,omitempty"`
 }
 
 // EgressSourceSpec is the type used to represent the Source in the list of Sources specified in an Egress policy specification.
@@ -87,3 +133,13 @@ type EgressList struct {
 
 	Items []Egress `json:"items"`
 }
+
+// EgressStatus is the type used to represent the status of an Egress resource.
+type EgressStatus struct {
+	// Conditions describe the current state of the Egress resource.
+	// Known condition types are Accepted, ResolvedRefs and Programmed.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
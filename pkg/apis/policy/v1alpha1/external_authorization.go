@@ -0,0 +1,131 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalAuthorization defines the external authorization policy applied
+// to inbound HTTP traffic directed to services in its namespace.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ExternalAuthorization struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the ExternalAuthorization policy specification
+	// +optional
+	Spec ExternalAuthorizationSpec `json:"spec,omitempty"`
+
+	// Status is the status of the ExternalAuthorization configuration.
+	// +optional
+	Status ExternalAuthorizationStatus `json:"status,omitempty"`
+}
+
+// ExternalAuthorizationSpec is the type used to represent the ExternalAuthorization policy specification.
+type ExternalAuthorizationSpec struct {
+	// Services specifies the names of the services in the namespace of the
+	// policy the external authorization policy is applicable to.
+	// If not specified, the policy applies to all the services in the namespace
+	// that are not targeted by a service specific policy.
+	// +optional
+	Services []string `json:"services,omitempty"`
+
+	// Disable specifies whether external authorization is disabled for the
+	// targeted services. When set, the inbound traffic to the targeted services
+	// is not authorized by any external authorization service, including the
+	// mesh-wide one configured in the MeshConfig.
+	// +optional
+	Disable bool `json:"disable,omitempty"`
+
+	// Protocol specifies the protocol used to communicate with the external
+	// authorization service. Must be one of 'grpc' or 'http'.
+	// Defaults to 'grpc' if not specified.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// Address specifies the address of the external authorization service.
+	// Required when the policy is not disabled.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port specifies the port of the external authorization service.
+	// Required when the policy is not disabled.
+	// +optional
+	Port uint16 `json:"port,omitempty"`
+
+	// PathPrefix specifies the prefix prepended to the path of the requests
+	// sent to the external authorization service when the protocol is 'http'.
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// StatPrefix specifies a prefix for the external authorization related metrics.
+	// +optional
+	StatPrefix string `json:"statPrefix,omitempty"`
+
+	// Timeout specifies the timeout for the external authorization service to respond.
+	// Defaults to 1s if not specified.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureModeAllow specifies whether requests are allowed when the external
+	// authorization service fails to respond.
+	// +optional
+	FailureModeAllow bool `json:"failureModeAllow,omitempty"`
+
+	// ForwardedHeaders specifies the names of the request headers forwarded to the
+	// external authorization service when the protocol is 'http'. If not specified,
+	// only the default set of headers is forwarded. All the request headers are
+	// always forwarded to a 'grpc' external authorization service.
+	// +optional
+	ForwardedHeaders []string `json:"forwardedHeaders,omitempty"`
+
+	// WithRequestBody specifies whether and how the request body is forwarded
+	// to the external authorization service. The request body is not forwarded
+	// if not specified.
+	// +optional
+	WithRequestBody *ExternalAuthorizationRequestBodySpec `json:"withRequestBody,omitempty"`
+
+	// ExemptPathPrefixes specifies the path prefixes of the requests that are not
+	// subject to external authorization, ex. '/healthz'.
+	// +optional
+	ExemptPathPrefixes []string `json:"exemptPathPrefixes,omitempty"`
+}
+
+// ExternalAuthorizationRequestBodySpec is the type used to represent the settings
+// used to forward the request body to the external authorization service.
+type ExternalAuthorizationRequestBodySpec struct {
+	// MaxRequestBytes specifies the maximum size of the request body buffered
+	// and forwarded to the external authorization service.
+	MaxRequestBytes uint32 `json:"maxRequestBytes"`
+
+	// AllowPartialMessage specifies whether a request body larger than
+	// MaxRequestBytes is forwarded partially. If not set, such requests
+	// are rejected with a 413 response code.
+	// +optional
+	AllowPartialMessage bool `json:"allowPartialMessage,omitempty"`
+}
+
+// ExternalAuthorizationStatus is the type used to represent the status of an ExternalAuthorization resource.
+type ExternalAuthorizationStatus struct {
+	// CurrentStatus defines the current status of an ExternalAuthorization resource.
+	// +optional
+	CurrentStatus string `json:"currentStatus,omitempty"`
+
+	// Reason defines the reason for the current status of an ExternalAuthorization resource.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ExternalAuthorizationList defines the list of ExternalAuthorization objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ExternalAuthorizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ExternalAuthorization `json:"items"`
+}
@@ -108,4 +108,11 @@ type IngressBackendStatus struct {
 	// Reason defines the reason for the current status of an IngressBackend resource.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// Conditions describe the current state of the IngressBackend resource.
+	// Known condition types are Accepted, ResolvedRefs and Programmed.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
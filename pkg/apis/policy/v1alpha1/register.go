@@ -39,10 +39,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Egress{},
 		&EgressList{},
+		&ExternalAuthorization{},
+		&ExternalAuthorizationList{},
 		&IngressBackend{},
 		&IngressBackendList{},
 		&Retry{},
 		&RetryList{},
+		&SidecarScope{},
+		&SidecarScopeList{},
 		&UpstreamTrafficSetting{},
 		&UpstreamTrafficSettingList{},
 	)
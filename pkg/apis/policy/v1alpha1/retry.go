@@ -20,6 +20,10 @@ type Retry struct {
 	// Spec is the Retry policy specification
 	// +optional
 	Spec RetrySpec `json:"spec,omitempty"`
+
+	// Status is the status of the Retry policy.
+	// +optional
+	Status RetryStatus `json:"status,omitempty"`
 }
 
 // RetrySpec is the type used to represent the Retry policy specification.
@@ -62,6 +66,16 @@ type RetryPolicySpec struct {
 	RetryBackoffBaseInterval string `json:"retryBackoffInterval"`
 }
 
+// RetryStatus is the type used to represent the status of a Retry resource.
+type RetryStatus struct {
+	// Conditions describe the current state of the Retry resource.
+	// Known condition types are Accepted, ResolvedRefs and Programmed.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // RetryList defines the list of Retry objects.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type RetryList struct {
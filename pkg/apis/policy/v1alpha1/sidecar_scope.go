@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SidecarScope defines the upstream services the sidecars of workloads in
+// its namespace are allowed to reach. The configuration of the selected
+// sidecars only includes the upstream services within the scope.
+//
+// Workloads are selected by service account rather than by pod labels. The
+// outbound configuration of a sidecar is computed and cached per service
+// identity, as is the case for all the other policies, so every workload
+// sharing a service account must share the same scope.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SidecarScope struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the SidecarScope policy specification
+	// +optional
+	Spec SidecarScopeSpec `json:"spec,omitempty"`
+
+	// Status is the status of the SidecarScope configuration.
+	// +optional
+	Status SidecarScopeStatus `json:"status,omitempty"`
+}
+
+// SidecarScopeSpec is the type used to represent the SidecarScope policy specification.
+type SidecarScopeSpec struct {
+	// ServiceAccounts specifies the names of the service accounts in the namespace
+	// of the policy whose workloads the scope is applicable to. Workloads that must
+	// be scoped differently must run with different service accounts.
+	// If not specified, the scope applies to all the workloads in the namespace
+	// that are not selected by a service account specific scope.
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// Upstreams specifies the upstream services the selected workloads are allowed to reach.
+	// Upstream services not specified are excluded from the configuration of the selected
+	// sidecars, including the services in the namespace of the policy.
+	Upstreams []SidecarScopeUpstreamSpec `json:"upstreams"`
+}
+
+// SidecarScopeUpstreamSpec is the type used to represent the upstream services
+// in a namespace that are within the scope of a SidecarScope policy.
+type SidecarScopeUpstreamSpec struct {
+	// Namespace defines the namespace of the upstream services.
+	Namespace string `json:"namespace"`
+
+	// Services specifies the names of the upstream services in the namespace.
+	// If not specified, all the services in the namespace are within the scope.
+	// +optional
+	Services []string `json:"services,omitempty"`
+}
+
+// SidecarScopeStatus is the type used to represent the status of a SidecarScope resource.
+type SidecarScopeStatus struct {
+	// Conditions describe the current state of the SidecarScope resource.
+	// Known condition types are Accepted, ResolvedRefs and Programmed.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SidecarScopeList defines the list of SidecarScope objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SidecarScopeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SidecarScope `json:"items"`
+}
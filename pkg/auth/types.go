@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -23,4 +24,36 @@ type ExtAuthConfig struct {
 
 	// FailureModeAllow allows specifying if traffic should succeed or fail if the external authorization endpoint fails to respond.
 	FailureModeAllow bool
+
+	// Protocol is the protocol used to communicate with the external authorization endpoint, either 'grpc' or 'http'.
+	// Defaults to 'grpc' if not specified.
+	Protocol string
+
+	// PathPrefix is the prefix prepended to the path of the authorization requests sent to an HTTP external authorization endpoint.
+	PathPrefix string
+
+	// AllowedHeaders are the names of the request headers forwarded to the external authorization endpoint.
+	// All the request headers are forwarded to a gRPC endpoint if not specified.
+	AllowedHeaders []string
+
+	// RequestBody configures forwarding the request body to the external authorization endpoint.
+	// The request body is not forwarded if not specified.
+	RequestBody *RequestBodyConfig
+
+	// ExemptPathPrefixes are the path prefixes of the requests that are not subject to external authorization.
+	ExemptPathPrefixes []string
+}
+
+// RequestBodyConfig defines how the request body is forwarded to the external authorization endpoint
+type RequestBodyConfig struct {
+	// MaxRequestBytes is the maximum size of the request body forwarded to the external authorization endpoint.
+	MaxRequestBytes uint32
+
+	// AllowPartialMessage allows forwarding a partial request body when the request body exceeds MaxRequestBytes.
+	AllowPartialMessage bool
+}
+
+// HTTPServiceClusterName returns the name of the cluster used to reach an HTTP external authorization endpoint
+func (c ExtAuthConfig) HTTPServiceClusterName() string {
+	return fmt.Sprintf("ext-authz|%s:%d", c.Address, c.Port)
 }
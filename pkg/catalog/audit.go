@@ -0,0 +1,134 @@
+package catalog
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// policyAuditModeCache memoizes the mesh services whose SMI access policies are audited, so that the audit mode
+// annotations of every service and namespace are resolved once per change instead of on every proxy configuration
+// build. It is invalidated synchronously by the message broker, like the traffic policy cache.
+type policyAuditModeCache struct {
+	sync.RWMutex
+
+	// generation is incremented on every invalidation
+	generation uint64
+
+	// valid is false when the cache is invalidated
+	valid bool
+
+	// services are the mesh services in audit mode
+	services []service.MeshService
+}
+
+// policyAuditModeCacheInvalidationEvents are the events that change the mesh services or their audit mode annotations
+var policyAuditModeCacheInvalidationEvents = map[announcements.Kind]struct{}{
+	announcements.NamespaceAdded:             {},
+	announcements.NamespaceDeleted:           {},
+	announcements.NamespaceUpdated:           {},
+	announcements.ServiceAdded:               {},
+	announcements.ServiceDeleted:             {},
+	announcements.ServiceUpdated:             {},
+	announcements.MultiClusterServiceAdded:   {},
+	announcements.MultiClusterServiceDeleted: {},
+	announcements.MultiClusterServiceUpdated: {},
+}
+
+func newPolicyAuditModeCache() *policyAuditModeCache {
+	return &policyAuditModeCache{}
+}
+
+// handleEvent invalidates the cached audited services if the given event changes them.
+// It is called synchronously by the message broker.
+func (c *policyAuditModeCache) handleEvent(msg events.PubSubMessage) {
+	if _, ok := policyAuditModeCacheInvalidationEvents[msg.Kind]; !ok {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	c.valid = false
+	c.services = nil
+}
+
+// get returns the cached audited services, or computes and caches them.
+// The returned slice is shared and must not be mutated by callers.
+func (c *policyAuditModeCache) get(compute func() []service.MeshService) []service.MeshService {
+	c.RLock()
+	valid, services, generation := c.valid, c.services, c.generation
+	c.RUnlock()
+	if valid {
+		return services
+	}
+
+	services = compute()
+
+	c.Lock()
+	defer c.Unlock()
+	// Do not cache the services if they changed while being computed
+	if generation == c.generation {
+		c.valid = true
+		c.services = services
+	}
+	return services
+}
+
+// listPolicyAuditedServices returns the mesh services whose SMI access policies are audited instead of enforced
+func (mc *MeshCatalog) listPolicyAuditedServices() []service.MeshService {
+	compute := func() []service.MeshService {
+		var services []service.MeshService
+		for _, svc := range mc.listMeshServices() {
+			if mc.IsPolicyAuditModeEnabled(svc) {
+				services = append(services, svc)
+			}
+		}
+		return services
+	}
+
+	if mc.policyAuditModeCache == nil {
+		return compute()
+	}
+	return mc.policyAuditModeCache.get(compute)
+}
+
+// IsPolicyAuditModeEnabled returns true if SMI access policies for the given upstream service must be audited
+// instead of enforced. In audit mode all traffic to the service is allowed, while the SMI access policies
+// are programmed as shadow rules so that requests they would deny are reported without being rejected.
+// Audit mode is enabled using the 'openservicemesh.io/policy-audit-mode' annotation on the service, or
+// on its namespace when the service is not annotated. Audit mode is only meaningful when permissive
+// traffic policy mode is disabled, callers are expected to check the traffic policy mode first.
+func (mc *MeshCatalog) IsPolicyAuditModeEnabled(svc service.MeshService) bool {
+	if k8sSvc := mc.kubeController.GetService(svc); k8sSvc != nil {
+		if value, ok := k8sSvc.Annotations[constants.PolicyAuditModeAnnotation]; ok {
+			return parsePolicyAuditModeAnnotation(value, svc.String())
+		}
+	}
+
+	if ns := mc.kubeController.GetNamespace(svc.Namespace); ns != nil {
+		if value, ok := ns.Annotations[constants.PolicyAuditModeAnnotation]; ok {
+			return parsePolicyAuditModeAnnotation(value, svc.Namespace)
+		}
+	}
+
+	return false
+}
+
+func parsePolicyAuditModeAnnotation(value string, resource string) bool {
+	switch strings.ToLower(value) {
+	case "enabled", "yes", "true":
+		return true
+	case "disabled", "no", "false":
+		return false
+	default:
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidPolicyAuditModeAnnotation)).
+			Msgf("Invalid value %q for annotation %q on %s, policies will be enforced", value, constants.PolicyAuditModeAnnotation, resource)
+		return false
+	}
+}
@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestIsPolicyAuditModeEnabled(t *testing.T) {
+	svc := service.MeshService{Name: "s1", Namespace: "ns1", Port: 80, TargetPort: 8080, Protocol: "http"}
+
+	testCases := []struct {
+		name                 string
+		serviceAnnotations   map[string]string
+		namespaceAnnotations map[string]string
+		expected             bool
+	}{
+		{
+			name:     "no annotations",
+			expected: false,
+		},
+		{
+			name:                 "namespace annotated",
+			namespaceAnnotations: map[string]string{constants.PolicyAuditModeAnnotation: "enabled"},
+			expected:             true,
+		},
+		{
+			name:               "service annotated",
+			serviceAnnotations: map[string]string{constants.PolicyAuditModeAnnotation: "true"},
+			expected:           true,
+		},
+		{
+			name:                 "service annotation overrides namespace annotation",
+			serviceAnnotations:   map[string]string{constants.PolicyAuditModeAnnotation: "disabled"},
+			namespaceAnnotations: map[string]string{constants.PolicyAuditModeAnnotation: "enabled"},
+			expected:             false,
+		},
+		{
+			name:                 "invalid annotation value",
+			namespaceAnnotations: map[string]string{constants.PolicyAuditModeAnnotation: "invalid"},
+			expected:             false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mc := MeshCatalog{
+				kubeController: mockKubeController,
+			}
+
+			mockKubeController.EXPECT().GetService(svc).Return(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: svc.Name, Namespace: svc.Namespace, Annotations: tc.serviceAnnotations},
+			}).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace(svc.Namespace).Return(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: svc.Namespace, Annotations: tc.namespaceAnnotations},
+			}).AnyTimes()
+
+			assert.Equal(tc.expected, mc.IsPolicyAuditModeEnabled(svc))
+		})
+	}
+}
+
+func TestAuditInboundTrafficPolicy(t *testing.T) {
+	assert := tassert.New(t)
+
+	svc := service.MeshService{Name: "s1", Namespace: "ns1", Port: 80, TargetPort: 8080, Protocol: "http"}
+	inboundPolicy := trafficpolicy.NewInboundTrafficPolicy(svc.FQDN(), []string{"s1", "s1.ns1"})
+	inboundPolicy.Rules = []*trafficpolicy.Rule{
+		{
+			Route:                    *trafficpolicy.NewRouteWeightedCluster(trafficpolicy.HTTPRouteMatch{Path: "/foo", PathMatchType: trafficpolicy.PathMatchRegex, Methods: []string{"GET"}}, nil),
+			AllowedServiceIdentities: mapset.NewSet(),
+		},
+	}
+
+	auditInboundTrafficPolicy(inboundPolicy, svc)
+
+	assert.True(inboundPolicy.AuditMode)
+	assert.Len(inboundPolicy.Rules, 2)
+
+	// The catch-all rule is last so that it only matches requests the SMI routes do not match
+	catchAll := inboundPolicy.Rules[1]
+	assert.Equal(trafficpolicy.WildCardRouteMatch, catchAll.Route.HTTPRouteMatch)
+	assert.Equal(0, catchAll.AllowedServiceIdentities.Cardinality())
+	assert.True(catchAll.Route.WeightedClusters.Contains(service.WeightedCluster{
+		ClusterName: service.ClusterName(svc.EnvoyLocalClusterName()),
+		Weight:      constants.ClusterWeightAcceptAll,
+	}))
+}
+
+func TestPolicyAuditModeCache(t *testing.T) {
+	assert := tassert.New(t)
+
+	c := newPolicyAuditModeCache()
+	audited := []service.MeshService{{Name: "s1", Namespace: "ns1"}}
+
+	computed := 0
+	compute := func() []service.MeshService {
+		computed++
+		return audited
+	}
+
+	// The audited services are computed once, including when none are audited
+	assert.Empty(c.get(func() []service.MeshService { computed++; return nil }))
+	assert.Empty(c.get(compute))
+	assert.Equal(1, computed)
+
+	// Events that do not change services or namespaces do not invalidate the cache
+	c.handleEvent(events.PubSubMessage{Kind: announcements.TrafficTargetUpdated})
+	assert.Empty(c.get(compute))
+	assert.Equal(1, computed)
+
+	// Service and namespace events invalidate the cache
+	for _, kind := range []announcements.Kind{announcements.ServiceUpdated, announcements.NamespaceUpdated} {
+		c.handleEvent(events.PubSubMessage{Kind: kind})
+		assert.Equal(audited, c.get(compute))
+		assert.Equal(audited, c.get(compute))
+	}
+	assert.Equal(3, computed)
+
+	// Services computed while the cache is invalidated are not cached
+	c.handleEvent(events.PubSubMessage{Kind: announcements.ServiceDeleted})
+	assert.Equal(audited, c.get(func() []service.MeshService {
+		c.handleEvent(events.PubSubMessage{Kind: announcements.ServiceAdded})
+		return audited
+	}))
+	assert.Equal(audited, c.get(compute))
+	assert.Equal(4, computed)
+}
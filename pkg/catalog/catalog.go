@@ -6,6 +6,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/messaging"
 	"github.com/openservicemesh/osm/pkg/policy"
@@ -16,19 +17,24 @@ import (
 
 // NewMeshCatalog creates a new service catalog
 func NewMeshCatalog(kubeController k8s.Controller, meshSpec smi.MeshSpec, certManager certificate.Manager,
-	policyController policy.Controller, stop <-chan struct{},
+	policyController policy.Controller, gatewayAPIController gatewayapi.Controller, stop <-chan struct{},
 	cfg configurator.Configurator, serviceProviders []service.Provider, endpointsProviders []endpoint.Provider,
 	msgBroker *messaging.Broker) *MeshCatalog {
 	mc := &MeshCatalog{
-		serviceProviders:   serviceProviders,
-		endpointsProviders: endpointsProviders,
-		meshSpec:           meshSpec,
-		certManager:        certManager,
-		policyController:   policyController,
-		configurator:       cfg,
+		serviceProviders:     serviceProviders,
+		endpointsProviders:   endpointsProviders,
+		meshSpec:             meshSpec,
+		certManager:          certManager,
+		policyController:     policyController,
+		gatewayAPIController: gatewayAPIController,
+		configurator:         cfg,
+		trafficPolicyCache:   newTrafficPolicyCache(),
+		policyAuditModeCache: newPolicyAuditModeCache(),
 
 		kubeController: kubeController,
 	}
+	msgBroker.AddSyncEventHandler(mc.trafficPolicyCache.handleEvent)
+	msgBroker.AddSyncEventHandler(mc.policyAuditModeCache.handleEvent)
 
 	// Start the Resync ticker to tick based on the resync interval.
 	// Starting the resync ticker only starts the ticker config watcher which
@@ -39,6 +45,14 @@ func NewMeshCatalog(kubeController k8s.Controller, meshSpec smi.MeshSpec, certMa
 	return mc
 }
 
+// WithConfigurator returns a MeshCataloger computing the traffic policies from the given configuration.
+// The traffic policy cache is shared, its entries are keyed by the rollout cohort of the configuration.
+func (mc *MeshCatalog) WithConfigurator(cfg configurator.Configurator) MeshCataloger {
+	scoped := *mc
+	scoped.configurator = cfg
+	return &scoped
+}
+
 // GetKubeController returns the kube controller instance handling the current cluster
 func (mc *MeshCatalog) GetKubeController() k8s.Controller {
 	return mc.kubeController
@@ -0,0 +1,133 @@
+package catalog
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetDNSRecords returns the DNS records answered by the DNS proxy of the sidecars with the given service identity.
+// The names of the mesh services the identity can reach resolve to the cluster IPs of the services, and the hosts of
+// the Egress policies applying to the identity resolve to their virtual IPs.
+func (mc *MeshCatalog) GetDNSRecords(serviceIdentity identity.ServiceIdentity) []*trafficpolicy.DNSRecord {
+	featureFlags := mc.configurator.GetFeatureFlags()
+	if !featureFlags.EnableDNSProxy {
+		return nil
+	}
+
+	recordsByHostname := make(map[string]*trafficpolicy.DNSRecord)
+
+	for _, meshSvc := range mc.ListOutboundServicesForIdentity(serviceIdentity) {
+		hostname := meshSvc.FQDN()
+		if _, ok := recordsByHostname[hostname]; ok {
+			// A service with multiple ports is listed once per port
+			continue
+		}
+
+		svc := mc.kubeController.GetService(meshSvc)
+		if svc == nil || svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+			// Headless services are resolved by the cluster DNS
+			continue
+		}
+		ips := svc.Spec.ClusterIPs
+		if len(ips) == 0 {
+			ips = []string{svc.Spec.ClusterIP}
+		}
+		recordsByHostname[hostname] = &trafficpolicy.DNSRecord{
+			Hostname: hostname,
+			IPs:      ips,
+		}
+	}
+
+	if featureFlags.EnableEgressPolicy {
+		virtualIPs := mc.getEgressVirtualIPs()
+		for _, egress := range mc.policyController.ListEgressPoliciesForSourceIdentity(serviceIdentity.ToK8sServiceAccount()) {
+			for _, host := range egress.Spec.Hosts {
+				virtualIP, ok := virtualIPs[host]
+				if !ok {
+					continue
+				}
+				recordsByHostname[host] = &trafficpolicy.DNSRecord{
+					Hostname: host,
+					IPs:      []string{virtualIP},
+				}
+			}
+		}
+	}
+
+	records := make([]*trafficpolicy.DNSRecord, 0, len(recordsByHostname))
+	for _, record := range recordsByHostname {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Hostname < records[j].Hostname
+	})
+
+	return records
+}
+
+// getEgressVirtualIPs returns the virtual IPs allocated to the hosts of the Egress policies in the mesh, keyed by host.
+// The virtual IP of a host is derived from the hash of the host, so that it is stable across controller restarts and
+// identical on all the sidecars. A host whose hash collides with the one of another host is allocated the next free IP,
+// in the lexicographic order of the hosts. Wildcard hosts cannot be resolved, so they are not allocated a virtual IP.
+func (mc *MeshCatalog) getEgressVirtualIPs() map[string]string {
+	hostSet := make(map[string]struct{})
+	for _, egress := range mc.policyController.ListEgressPolicies() {
+		for _, host := range egress.Spec.Hosts {
+			if !isWildcardHost(host) {
+				hostSet[host] = struct{}{}
+			}
+		}
+	}
+
+	hosts := make([]string, 0, len(hostSet))
+	for host := range hostSet {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	return allocateVirtualIPs(hosts, constants.EgressVirtualIPRange)
+}
+
+// allocateVirtualIPs allocates a virtual IP from the given IP range to each of the given hosts, keyed by host.
+// The network and broadcast addresses of the range are not allocated.
+func allocateVirtualIPs(hosts []string, ipRange string) map[string]string {
+	_, ipNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing virtual IP range %s", ipRange)
+		return nil
+	}
+	ones, bits := ipNet.Mask.Size()
+	base := binary.BigEndian.Uint32(ipNet.IP.To4())
+	size := uint32(1)<<(bits-ones) - 2
+
+	virtualIPs := make(map[string]string)
+	allocated := make(map[uint32]bool)
+	for _, host := range hosts {
+		if uint32(len(allocated)) == size {
+			log.Error().Msgf("Virtual IP range %s is exhausted, host %s is not allocated a virtual IP", ipRange, host)
+			break
+		}
+
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(host))
+		offset := hash.Sum32() % size
+		for allocated[offset] {
+			offset = (offset + 1) % size
+		}
+		allocated[offset] = true
+
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, base+1+offset)
+		virtualIPs[host] = ip.String()
+	}
+
+	return virtualIPs
+}
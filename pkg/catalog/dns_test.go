@@ -0,0 +1,141 @@
+package catalog
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetDNSRecords(t *testing.T) {
+	egressPolicies := []*policyv1alpha1.Egress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns1"},
+			Spec: policyv1alpha1.EgressSpec{
+				Hosts: []string{"db.example.com", "*.example.com"},
+				Ports: []policyv1alpha1.PortSpec{{Number: 5432, Protocol: "tcp"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "egress-2", Namespace: "ns2"},
+			Spec: policyv1alpha1.EgressSpec{
+				Hosts: []string{"cache.example.com"},
+				Ports: []policyv1alpha1.PortSpec{{Number: 6379, Protocol: "tcp"}},
+			},
+		},
+	}
+	virtualIPs := allocateVirtualIPs([]string{"cache.example.com", "db.example.com"}, constants.EgressVirtualIPRange)
+
+	testCases := []struct {
+		name            string
+		featureFlags    configv1alpha2.FeatureFlags
+		expectedRecords []*trafficpolicy.DNSRecord
+	}{
+		{
+			name:            "DNS proxy disabled",
+			featureFlags:    configv1alpha2.FeatureFlags{EnableEgressPolicy: true},
+			expectedRecords: nil,
+		},
+		{
+			name:         "DNS proxy enabled without Egress policies",
+			featureFlags: configv1alpha2.FeatureFlags{EnableDNSProxy: true},
+			expectedRecords: []*trafficpolicy.DNSRecord{
+				{Hostname: "bookstore.bookstore.svc.cluster.local", IPs: []string{"10.0.0.10"}},
+			},
+		},
+		{
+			name:         "DNS proxy enabled with Egress policies",
+			featureFlags: configv1alpha2.FeatureFlags{EnableDNSProxy: true, EnableEgressPolicy: true},
+			expectedRecords: []*trafficpolicy.DNSRecord{
+				{Hostname: "bookstore.bookstore.svc.cluster.local", IPs: []string{"10.0.0.10"}},
+				{Hostname: "db.example.com", IPs: []string{virtualIPs["db.example.com"]}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockCfg.EXPECT().ForNamespace(gomock.Any()).Return(mockCfg).AnyTimes()
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockPolicyController.EXPECT().GetSidecarScope(gomock.Any()).Return(nil).AnyTimes()
+			mockServiceProvider := service.NewMockProvider(mockCtrl)
+
+			bookstoreV1 := service.MeshService{Name: "bookstore", Namespace: "bookstore", Port: 80}
+			bookstoreV1Grpc := service.MeshService{Name: "bookstore", Namespace: "bookstore", Port: 9090}
+			headless := service.MeshService{Name: "headless", Namespace: "bookstore", Port: 80}
+
+			mockCfg.EXPECT().GetFeatureFlags().Return(tc.featureFlags).AnyTimes()
+			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+			mockServiceProvider.EXPECT().ListServices().Return([]service.MeshService{bookstoreV1, bookstoreV1Grpc, headless}).AnyTimes()
+			mockKubeController.EXPECT().GetService(bookstoreV1).Return(&corev1.Service{
+				Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.10", ClusterIPs: []string{"10.0.0.10"}},
+			}).AnyTimes()
+			mockKubeController.EXPECT().GetService(headless).Return(&corev1.Service{
+				Spec: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+			}).AnyTimes()
+			mockPolicyController.EXPECT().ListEgressPolicies().Return(egressPolicies).AnyTimes()
+			mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return(egressPolicies[:1]).AnyTimes()
+
+			mc := &MeshCatalog{
+				configurator:     mockCfg,
+				kubeController:   mockKubeController,
+				policyController: mockPolicyController,
+				serviceProviders: []service.Provider{mockServiceProvider},
+			}
+
+			actual := mc.GetDNSRecords(identity.ServiceIdentity("sa.ns1.cluster.local"))
+			if tc.expectedRecords == nil {
+				assert.Nil(actual)
+				return
+			}
+			assert.Equal(tc.expectedRecords, actual)
+		})
+	}
+}
+
+func TestAllocateVirtualIPs(t *testing.T) {
+	assert := tassert.New(t)
+
+	_, ipRange, err := net.ParseCIDR(constants.EgressVirtualIPRange)
+	assert.Nil(err)
+
+	hosts := []string{"a.example.com", "b.example.com", "c.example.com"}
+	virtualIPs := allocateVirtualIPs(hosts, constants.EgressVirtualIPRange)
+	assert.Len(virtualIPs, len(hosts))
+
+	allocated := make(map[string]bool)
+	for _, host := range hosts {
+		ip := net.ParseIP(virtualIPs[host])
+		assert.True(ipRange.Contains(ip))
+		assert.False(allocated[ip.String()], "virtual IP %s allocated twice", ip)
+		allocated[ip.String()] = true
+	}
+
+	// The virtual IP of a host does not depend on the other hosts
+	assert.Equal(virtualIPs["b.example.com"], allocateVirtualIPs([]string{"b.example.com"}, constants.EgressVirtualIPRange)["b.example.com"])
+
+	// Colliding hosts are allocated distinct IPs, and hosts are not allocated IPs once the range is exhausted
+	smallRangeIPs := allocateVirtualIPs(hosts, "10.0.0.0/30")
+	assert.Len(smallRangeIPs, 2)
+	assert.NotEqual(smallRangeIPs["a.example.com"], smallRangeIPs["b.example.com"])
+	assert.Contains([]string{"10.0.0.1", "10.0.0.2"}, smallRangeIPs["a.example.com"])
+	assert.Contains([]string{"10.0.0.1", "10.0.0.2"}, smallRangeIPs["b.example.com"])
+}
@@ -2,7 +2,9 @@ package catalog
 
 import (
 	"fmt"
+	"hash/fnv"
 	"net"
+	"sort"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
@@ -24,11 +26,15 @@ import (
 const (
 	// upstreamTrafficSettingKind is the upstreamTrafficSettingKind API kind
 	upstreamTrafficSettingKind = "UpstreamTrafficSetting"
+
+	// serviceAccountKind is the ServiceAccount API kind
+	serviceAccountKind = "ServiceAccount"
 )
 
 // GetEgressTrafficPolicy returns the Egress traffic policy associated with the given service identity
 func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
-	if !mc.configurator.GetFeatureFlags().EnableEgressPolicy {
+	featureFlags := mc.configurator.GetFeatureFlags()
+	if !featureFlags.EnableEgressPolicy {
 		return nil, nil
 	}
 
@@ -37,6 +43,13 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 	portToRouteConfigMap := make(map[int][]*trafficpolicy.EgressHTTPRouteConfig)
 	egressResources := mc.policyController.ListEgressPoliciesForSourceIdentity(serviceIdentity.ToK8sServiceAccount())
 
+	// The DNS proxy of the sidecars resolves the hosts of Egress policies to virtual IPs, so that TCP traffic to the hosts
+	// can be matched based on its destination IP
+	var virtualIPs map[string]string
+	if featureFlags.EnableDNSProxy {
+		virtualIPs = mc.getEgressVirtualIPs()
+	}
+
 	for _, egress := range egressResources {
 		upstreamTrafficSetting, err := mc.getUpstreamTrafficSettingForEgress(egress)
 		if err != nil {
@@ -44,12 +57,26 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 			continue
 		}
 
+		viaGateway := egress.Spec.RouteViaGateway
+		if viaGateway && !featureFlags.EnableEgressGateway {
+			log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidEgressGatewayRouting)).
+				Msgf("Egress policy %s/%s routes traffic via the egress gateway which is not enabled, ignoring it", egress.Namespace, egress.Name)
+			continue
+		}
+
 		for _, portSpec := range egress.Spec.Ports {
 			switch strings.ToLower(portSpec.Protocol) {
 			case constants.ProtocolHTTP:
 				// ---
 				// Build the HTTP route configs for the given Egress policy
 				httpRouteConfigs, httpClusterConfigs := mc.buildHTTPRouteConfigs(egress, portSpec.Number, upstreamTrafficSetting)
+				if viaGateway {
+					// TLS is originated by the egress gateway, the sidecar only forwards the traffic to it
+					for _, clusterConfig := range httpClusterConfigs {
+						clusterConfig.TLS = nil
+						clusterConfig.Gateway = mc.getEgressGatewayConfig(clusterConfig.Host, portSpec.Number)
+					}
+				}
 				portToRouteConfigMap[portSpec.Number] = append(portToRouteConfigMap[portSpec.Number], httpRouteConfigs...)
 				clusterConfigs = append(clusterConfigs, httpClusterConfigs...)
 
@@ -60,6 +87,13 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 				})
 
 			case constants.ProtocolTCP, constants.ProtocolTCPServerFirst:
+				if viaGateway {
+					log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidEgressGatewayRouting)).
+						Msgf("Port %d with protocol %s in Egress policy %s/%s cannot be routed via the egress gateway, ignoring it",
+							portSpec.Number, portSpec.Protocol, egress.Namespace, egress.Name)
+					continue
+				}
+
 				// ---
 				// Build the TCP cluster config for this port
 				clusterConfigs = append(clusterConfigs, &trafficpolicy.EgressClusterConfig{
@@ -76,7 +110,32 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 					Cluster:             fmt.Sprintf("%d", portSpec.Number),
 				})
 
+				// Configure port + virtual IP TrafficMatches for the hosts resolved by the DNS proxy
+				hostClusterConfigs, hostTrafficMatches := buildVirtualIPTrafficMatches(egress, portSpec, upstreamTrafficSetting, virtualIPs)
+				clusterConfigs = append(clusterConfigs, hostClusterConfigs...)
+				trafficMatches = append(trafficMatches, hostTrafficMatches...)
+
 			case constants.ProtocolHTTPS:
+				if viaGateway {
+					// ---
+					// The egress gateway routes HTTPS traffic based on the host, so build a cluster
+					// and a SNI based TrafficMatch per host
+					for _, clusterConfig := range buildHTTPSClusterConfigs(egress, portSpec.Number, upstreamTrafficSetting) {
+						clusterConfig.Gateway = mc.getEgressGatewayConfig(clusterConfig.Host, portSpec.Number)
+						clusterConfigs = append(clusterConfigs, clusterConfig)
+
+						trafficMatches = append(trafficMatches, &trafficpolicy.TrafficMatch{
+							Name:                clusterConfig.Name,
+							DestinationPort:     portSpec.Number,
+							DestinationProtocol: portSpec.Protocol,
+							DestinationIPRanges: egress.Spec.IPAddresses,
+							ServerNames:         []string{clusterConfig.Host},
+							Cluster:             clusterConfig.Name,
+						})
+					}
+					continue
+				}
+
 				// ---
 				// Build the HTTPS cluster config for this port
 				// HTTPS is TLS encrypted, so will be proxied as a TCP stream
@@ -94,6 +153,12 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 					ServerNames:         egress.Spec.Hosts,
 					Cluster:             fmt.Sprintf("%d", portSpec.Number),
 				})
+
+				// Configure port + virtual IP TrafficMatches for the hosts resolved by the DNS proxy, since
+				// the original destination of the traffic to these hosts is not routable
+				hostClusterConfigs, hostTrafficMatches := buildVirtualIPTrafficMatches(egress, portSpec, upstreamTrafficSetting, virtualIPs)
+				clusterConfigs = append(clusterConfigs, hostClusterConfigs...)
+				trafficMatches = append(trafficMatches, hostTrafficMatches...)
 			}
 		}
 	}
@@ -122,6 +187,155 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 	}, nil
 }
 
+// GetEgressGatewayTrafficPolicy returns the traffic policy of the egress gateway, corresponding to
+// the Egress policies routing traffic via the egress gateway.
+func (mc *MeshCatalog) GetEgressGatewayTrafficPolicy() (*trafficpolicy.EgressGatewayTrafficPolicy, error) {
+	featureFlags := mc.configurator.GetFeatureFlags()
+	if !featureFlags.EnableEgressPolicy || !featureFlags.EnableEgressGateway {
+		return nil, nil
+	}
+
+	destinationsByServerName := make(map[string]*trafficpolicy.EgressGatewayDestination)
+	allowedSources := make(map[string]mapset.Set)
+	addDestination := func(serverName string, clusterConfig *trafficpolicy.EgressClusterConfig, sources []identity.ServiceIdentity) {
+		destination, ok := destinationsByServerName[serverName]
+		if !ok {
+			destination = &trafficpolicy.EgressGatewayDestination{
+				ServerName: serverName,
+				Cluster:    clusterConfig,
+			}
+			destinationsByServerName[serverName] = destination
+			allowedSources[serverName] = mapset.NewSet()
+		}
+		// The same destination can be specified by multiple Egress policies, in which case
+		// the cluster of the first policy is used and the sources of all the policies are allowed.
+		for _, source := range sources {
+			if !allowedSources[serverName].Contains(source) {
+				allowedSources[serverName].Add(source)
+				destination.AllowedSourceIdentities = append(destination.AllowedSourceIdentities, source)
+			}
+		}
+	}
+
+	for _, egress := range mc.policyController.ListEgressPolicies() {
+		if !egress.Spec.RouteViaGateway {
+			continue
+		}
+
+		upstreamTrafficSetting, err := mc.getUpstreamTrafficSettingForEgress(egress)
+		if err != nil {
+			log.Error().Err(err).Msg("Ignoring invalid Egress policy")
+			continue
+		}
+
+		var sources []identity.ServiceIdentity
+		for _, source := range egress.Spec.Sources {
+			if source.Kind == serviceAccountKind {
+				sources = append(sources, identity.K8sServiceAccount{Name: source.Name, Namespace: source.Namespace}.ToServiceIdentity())
+			}
+		}
+
+		for _, portSpec := range egress.Spec.Ports {
+			var externalClusterConfigs []*trafficpolicy.EgressClusterConfig
+			switch strings.ToLower(portSpec.Protocol) {
+			case constants.ProtocolHTTP:
+				_, externalClusterConfigs = mc.buildHTTPRouteConfigs(egress, portSpec.Number, upstreamTrafficSetting)
+			case constants.ProtocolHTTPS:
+				externalClusterConfigs = buildHTTPSClusterConfigs(egress, portSpec.Number, upstreamTrafficSetting)
+			}
+
+			for _, clusterConfig := range externalClusterConfigs {
+				addDestination(getEgressGatewayServerName(clusterConfig.Host, portSpec.Number), clusterConfig, sources)
+			}
+		}
+	}
+
+	var destinations []*trafficpolicy.EgressGatewayDestination
+	for _, destination := range destinationsByServerName {
+		destinations = append(destinations, destination)
+	}
+	sort.Slice(destinations, func(i, j int) bool {
+		return destinations[i].ServerName < destinations[j].ServerName
+	})
+
+	return &trafficpolicy.EgressGatewayTrafficPolicy{
+		Destinations: destinations,
+	}, nil
+}
+
+// getEgressGatewayConfig returns the config used by sidecars to route traffic to the given external host and port
+// via the egress gateway.
+func (mc *MeshCatalog) getEgressGatewayConfig(host string, port int) *trafficpolicy.EgressGatewayConfig {
+	return &trafficpolicy.EgressGatewayConfig{
+		Service: service.MeshService{
+			Name:      constants.OSMEgressGatewayName,
+			Namespace: mc.configurator.GetOSMNamespace(),
+		},
+		Port:       constants.EgressGatewayPort,
+		ServerName: getEgressGatewayServerName(host, port),
+	}
+}
+
+// getEgressGatewayServerName returns the SNI used to route traffic for the given external host and port via the egress gateway.
+// The port is part of the name because the same host can be routed differently based on the port.
+func getEgressGatewayServerName(host string, port int) string {
+	return fmt.Sprintf("egress-%d.%s", port, host)
+}
+
+// buildHTTPSClusterConfigs returns the cluster configs for the hosts specified in the given Egress policy for an HTTPS port.
+// The clusters are resolved using DNS, so wildcard hosts are not supported.
+func buildHTTPSClusterConfigs(egressPolicy *policyv1alpha1.Egress, port int,
+	upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) []*trafficpolicy.EgressClusterConfig {
+	var clusterConfigs []*trafficpolicy.EgressClusterConfig
+	for _, host := range egressPolicy.Spec.Hosts {
+		if isWildcardHost(host) {
+			log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidEgressHost)).
+				Msgf("Wildcard host %s specified in Egress policy %s/%s cannot be routed via the egress gateway, ignoring it",
+					host, egressPolicy.Namespace, egressPolicy.Name)
+			continue
+		}
+
+		clusterConfigs = append(clusterConfigs, &trafficpolicy.EgressClusterConfig{
+			Name:                   fmt.Sprintf("%s:%d", host, port),
+			Host:                   host,
+			Port:                   port,
+			UpstreamTrafficSetting: upstreamTrafficSetting,
+		})
+	}
+	return clusterConfigs
+}
+
+// buildVirtualIPTrafficMatches returns the cluster configs and traffic matches for the hosts specified in the given Egress
+// policy for a TCP based port, when the hosts are resolved to the given virtual IPs by the DNS proxy of the sidecars.
+// Traffic to the virtual IP of a host is proxied to the host resolved using DNS.
+func buildVirtualIPTrafficMatches(egressPolicy *policyv1alpha1.Egress, portSpec policyv1alpha1.PortSpec,
+	upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting, virtualIPs map[string]string) ([]*trafficpolicy.EgressClusterConfig, []*trafficpolicy.TrafficMatch) {
+	var clusterConfigs []*trafficpolicy.EgressClusterConfig
+	var trafficMatches []*trafficpolicy.TrafficMatch
+	for _, host := range egressPolicy.Spec.Hosts {
+		virtualIP, ok := virtualIPs[host]
+		if !ok {
+			continue
+		}
+
+		clusterName := fmt.Sprintf("%s:%d", host, portSpec.Number)
+		clusterConfigs = append(clusterConfigs, &trafficpolicy.EgressClusterConfig{
+			Name:                   clusterName,
+			Host:                   host,
+			Port:                   portSpec.Number,
+			UpstreamTrafficSetting: upstreamTrafficSetting,
+		})
+		trafficMatches = append(trafficMatches, &trafficpolicy.TrafficMatch{
+			Name:                host,
+			DestinationPort:     portSpec.Number,
+			DestinationProtocol: portSpec.Protocol,
+			DestinationIPRanges: []string{virtualIP + "/32"},
+			Cluster:             clusterName,
+		})
+	}
+	return clusterConfigs, trafficMatches
+}
+
 func (mc *MeshCatalog) getUpstreamTrafficSettingForEgress(egressPolicy *policyv1alpha1.Egress) (*policyv1alpha1.UpstreamTrafficSetting, error) {
 	if egressPolicy == nil {
 		return nil, nil
@@ -205,6 +419,15 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyv1alpha1.Egress
 
 	// Parse the hosts specified and build routing rules for the specified hosts
 	for _, host := range egressPolicy.Spec.Hosts {
+		// Wildcard hosts cannot be resolved using DNS, so they are only supported for
+		// HTTPS traffic that is matched on the SNI.
+		if isWildcardHost(host) {
+			log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidEgressHost)).
+				Msgf("Wildcard host %s specified in Egress policy %s/%s is not supported for HTTP port %d, ignoring it",
+					host, egressPolicy.Namespace, egressPolicy.Name, port)
+			continue
+		}
+
 		// A route matching an HTTP host will include host header matching for the following:
 		// 1. host (ex. foo.com)
 		// 2. host:port (ex. foo.com:80)
@@ -212,13 +435,24 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyv1alpha1.Egress
 		hostnames := []string{host, hostnameWithPort}
 
 		// Create cluster config for this host and port combination
-		clusterName := hostnameWithPort
 		clusterConfig := &trafficpolicy.EgressClusterConfig{
-			Name:                   clusterName,
+			Name:                   hostnameWithPort,
 			Host:                   host,
 			Port:                   port,
 			UpstreamTrafficSetting: upstreamTrafficSetting,
 		}
+		if tlsSpec := egressPolicy.Spec.TLS; tlsSpec != nil {
+			// Originate TLS to the host, so that the application can send plaintext HTTP
+			// while the traffic leaving the sidecar is encrypted.
+			if tlsSpec.Port != 0 {
+				clusterConfig.Port = tlsSpec.Port
+			}
+			clusterConfig.TLS = getEgressTLSConfig(egressPolicy.Namespace, host, tlsSpec)
+			// Egress policies can originate TLS to the same host and port with different
+			// settings, so the cluster name must be unique for the TLS settings.
+			clusterConfig.Name = getEgressTLSClusterName(hostnameWithPort, clusterConfig.Port, clusterConfig.TLS)
+		}
+		clusterName := clusterConfig.Name
 		clusterConfigs = append(clusterConfigs, clusterConfig)
 
 		// Build egress routing rules from the given HTTP route matches and allowed destination attributes
@@ -250,6 +484,35 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyv1alpha1.Egress
 	return routeConfigs, clusterConfigs
 }
 
+// getEgressTLSConfig returns the TLS origination config for the given host, with the Secrets
+// referenced in the TLS spec resolved to the namespace of the Egress policy.
+func getEgressTLSConfig(namespace string, host string, tlsSpec *policyv1alpha1.EgressTLSSpec) *trafficpolicy.EgressTLSConfig {
+	tlsConfig := &trafficpolicy.EgressTLSConfig{
+		SNI:                host,
+		InsecureSkipVerify: tlsSpec.InsecureSkipVerify,
+	}
+	if tlsSpec.CABundleSecret != "" {
+		tlsConfig.CABundleSecret = &types.NamespacedName{Namespace: namespace, Name: tlsSpec.CABundleSecret}
+	}
+	if tlsSpec.ClientCertificateSecret != "" {
+		tlsConfig.ClientCertificateSecret = &types.NamespacedName{Namespace: namespace, Name: tlsSpec.ClientCertificateSecret}
+	}
+	return tlsConfig
+}
+
+// getEgressTLSClusterName returns the name of the cluster originating TLS to the given host and port,
+// which is unique for the given TLS settings
+func getEgressTLSClusterName(hostnameWithPort string, tlsPort int, tlsConfig *trafficpolicy.EgressTLSConfig) string {
+	h := fnv.New32a()
+	_, _ = fmt.Fprintf(h, "%d|%v|%v|%t", tlsPort, tlsConfig.CABundleSecret, tlsConfig.ClientCertificateSecret, tlsConfig.InsecureSkipVerify)
+	return fmt.Sprintf("%s|tls-%08x", hostnameWithPort, h.Sum32())
+}
+
+// isWildcardHost returns true if the given host is a wildcard domain of the form '*.example.com'
+func isWildcardHost(host string) bool {
+	return strings.HasPrefix(host, "*")
+}
+
 func getHTTPRouteMatchesFromHTTPRouteGroup(httpRouteGroup *smiSpecs.HTTPRouteGroup) []trafficpolicy.HTTPRouteMatch {
 	if httpRouteGroup == nil {
 		return nil
@@ -11,12 +11,14 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/policy"
 
@@ -297,6 +299,7 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 					Spec: policyv1alpha1.EgressSpec{
 						Hosts: []string{
 							"foo.com",
+							"*.bar.com",
 						},
 						Ports: []policyv1alpha1.PortSpec{
 							{
@@ -323,7 +326,7 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 					{
 						DestinationPort:     100,
 						DestinationProtocol: "https",
-						ServerNames:         []string{"foo.com"},
+						ServerNames:         []string{"foo.com", "*.bar.com"},
 						Cluster:             "100",
 					},
 					{
@@ -457,6 +460,271 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 	}
 }
 
+func TestGetEgressTrafficPolicyViaGateway(t *testing.T) {
+	egressPolicy := &policyv1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "egress-1",
+			Namespace: "ns1",
+		},
+		Spec: policyv1alpha1.EgressSpec{
+			Hosts: []string{"foo.com", "*.bar.com"},
+			Ports: []policyv1alpha1.PortSpec{
+				{Number: 80, Protocol: "http"},
+				{Number: 443, Protocol: "https"},
+				{Number: 5432, Protocol: "tcp"},
+			},
+			TLS:             &policyv1alpha1.EgressTLSSpec{Port: 443},
+			RouteViaGateway: true,
+		},
+	}
+	gatewaySvc := service.MeshService{Name: constants.OSMEgressGatewayName, Namespace: "osm-system"}
+
+	testCases := []struct {
+		name                 string
+		featureFlags         configv1alpha2.FeatureFlags
+		expectedEgressPolicy *trafficpolicy.EgressTrafficPolicy
+	}{
+		{
+			name:         "egress gateway is not enabled",
+			featureFlags: configv1alpha2.FeatureFlags{EnableEgressPolicy: true},
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{},
+			},
+		},
+		{
+			name:         "egress gateway is enabled",
+			featureFlags: configv1alpha2.FeatureFlags{EnableEgressPolicy: true, EnableEgressGateway: true},
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort:     80,
+						DestinationProtocol: "http",
+					},
+					{
+						Name:                "foo.com:443",
+						DestinationPort:     443,
+						DestinationProtocol: "https",
+						ServerNames:         []string{"foo.com"},
+						Cluster:             "foo.com:443",
+					},
+				},
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{
+					80: {
+						{
+							Name:      "foo.com",
+							Hostnames: []string{"foo.com", "foo.com:80"},
+							RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSetFromSlice([]interface{}{service.WeightedCluster{ClusterName: "foo.com:80|tls-7ba8522d", Weight: 100}}),
+									},
+								},
+							},
+						},
+					},
+				},
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+					{
+						// TLS is originated by the egress gateway
+						Name: "foo.com:80|tls-7ba8522d",
+						Host: "foo.com",
+						Port: 443,
+						Gateway: &trafficpolicy.EgressGatewayConfig{
+							Service:    gatewaySvc,
+							Port:       constants.EgressGatewayPort,
+							ServerName: "egress-80.foo.com",
+						},
+					},
+					{
+						Name: "foo.com:443",
+						Host: "foo.com",
+						Port: 443,
+						Gateway: &trafficpolicy.EgressGatewayConfig{
+							Service:    gatewaySvc,
+							Port:       constants.EgressGatewayPort,
+							ServerName: "egress-443.foo.com",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockCfg.EXPECT().GetFeatureFlags().Return(tc.featureFlags).Times(1)
+			mockCfg.EXPECT().GetOSMNamespace().Return("osm-system").AnyTimes()
+			mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return([]*policyv1alpha1.Egress{egressPolicy}).Times(1)
+
+			mc := &MeshCatalog{
+				configurator:     mockCfg,
+				policyController: mockPolicyController,
+			}
+
+			actual, err := mc.GetEgressTrafficPolicy(identity.ServiceIdentity("foo.bar.cluster.local"))
+			assert.Nil(err)
+			assert.ElementsMatch(tc.expectedEgressPolicy.TrafficMatches, actual.TrafficMatches)
+			assert.ElementsMatch(tc.expectedEgressPolicy.ClustersConfigs, actual.ClustersConfigs)
+			assert.Equal(tc.expectedEgressPolicy.HTTPRouteConfigsPerPort, actual.HTTPRouteConfigsPerPort)
+		})
+	}
+}
+
+func TestGetEgressTrafficPolicyWithDNSProxy(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+
+	egressPolicy := &policyv1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "egress-1",
+			Namespace: "ns1",
+		},
+		Spec: policyv1alpha1.EgressSpec{
+			Hosts: []string{"db.example.com", "*.example.com"},
+			Ports: []policyv1alpha1.PortSpec{
+				{Number: 5432, Protocol: "tcp"},
+				{Number: 443, Protocol: "https"},
+			},
+		},
+	}
+	mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{EnableEgressPolicy: true, EnableDNSProxy: true}).Times(1)
+	mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return([]*policyv1alpha1.Egress{egressPolicy}).Times(1)
+	mockPolicyController.EXPECT().ListEgressPolicies().Return([]*policyv1alpha1.Egress{egressPolicy}).Times(1)
+
+	mc := &MeshCatalog{
+		configurator:     mockCfg,
+		policyController: mockPolicyController,
+	}
+
+	virtualIP := allocateVirtualIPs([]string{"db.example.com"}, constants.EgressVirtualIPRange)["db.example.com"]
+	expectedTrafficMatches := []*trafficpolicy.TrafficMatch{
+		{
+			DestinationPort:     5432,
+			DestinationProtocol: "tcp",
+			Cluster:             "5432",
+		},
+		{
+			Name:                "db.example.com",
+			DestinationPort:     5432,
+			DestinationProtocol: "tcp",
+			DestinationIPRanges: []string{virtualIP + "/32"},
+			Cluster:             "db.example.com:5432",
+		},
+		{
+			DestinationPort:     443,
+			DestinationProtocol: "https",
+			ServerNames:         []string{"db.example.com", "*.example.com"},
+			Cluster:             "443",
+		},
+		{
+			Name:                "db.example.com",
+			DestinationPort:     443,
+			DestinationProtocol: "https",
+			DestinationIPRanges: []string{virtualIP + "/32"},
+			Cluster:             "db.example.com:443",
+		},
+	}
+	expectedClusterConfigs := []*trafficpolicy.EgressClusterConfig{
+		{Name: "5432", Port: 5432},
+		{Name: "db.example.com:5432", Host: "db.example.com", Port: 5432},
+		{Name: "443", Port: 443},
+		{Name: "db.example.com:443", Host: "db.example.com", Port: 443},
+	}
+
+	actual, err := mc.GetEgressTrafficPolicy(identity.ServiceIdentity("sa-1.ns1.cluster.local"))
+	assert.Nil(err)
+	assert.ElementsMatch(expectedTrafficMatches, actual.TrafficMatches)
+	assert.ElementsMatch(expectedClusterConfigs, actual.ClustersConfigs)
+}
+
+func TestGetEgressGatewayTrafficPolicy(t *testing.T) {
+	sa1 := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns1"}
+	sa2 := identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns2"}
+
+	newEgress := func(name string, source identity.K8sServiceAccount, routeViaGateway bool, ports ...policyv1alpha1.PortSpec) *policyv1alpha1.Egress {
+		return &policyv1alpha1.Egress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: source.Namespace,
+			},
+			Spec: policyv1alpha1.EgressSpec{
+				Sources: []policyv1alpha1.EgressSourceSpec{
+					{Kind: "ServiceAccount", Name: source.Name, Namespace: source.Namespace},
+				},
+				Hosts:           []string{"foo.com"},
+				Ports:           ports,
+				RouteViaGateway: routeViaGateway,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		featureFlags          configv1alpha2.FeatureFlags
+		egressPolicies        []*policyv1alpha1.Egress
+		expectedGatewayPolicy *trafficpolicy.EgressGatewayTrafficPolicy
+	}{
+		{
+			name:         "egress gateway is not enabled",
+			featureFlags: configv1alpha2.FeatureFlags{EnableEgressPolicy: true},
+		},
+		{
+			name:         "destinations of the policies routed via the egress gateway",
+			featureFlags: configv1alpha2.FeatureFlags{EnableEgressPolicy: true, EnableEgressGateway: true},
+			egressPolicies: []*policyv1alpha1.Egress{
+				newEgress("egress-1", sa1, true, policyv1alpha1.PortSpec{Number: 80, Protocol: "http"}, policyv1alpha1.PortSpec{Number: 443, Protocol: "https"}),
+				newEgress("egress-2", sa2, true, policyv1alpha1.PortSpec{Number: 443, Protocol: "https"}),
+				newEgress("egress-3", sa2, false, policyv1alpha1.PortSpec{Number: 8080, Protocol: "http"}),
+			},
+			expectedGatewayPolicy: &trafficpolicy.EgressGatewayTrafficPolicy{
+				Destinations: []*trafficpolicy.EgressGatewayDestination{
+					{
+						ServerName:              "egress-443.foo.com",
+						Cluster:                 &trafficpolicy.EgressClusterConfig{Name: "foo.com:443", Host: "foo.com", Port: 443},
+						AllowedSourceIdentities: []identity.ServiceIdentity{sa1.ToServiceIdentity(), sa2.ToServiceIdentity()},
+					},
+					{
+						ServerName:              "egress-80.foo.com",
+						Cluster:                 &trafficpolicy.EgressClusterConfig{Name: "foo.com:80", Host: "foo.com", Port: 80},
+						AllowedSourceIdentities: []identity.ServiceIdentity{sa1.ToServiceIdentity()},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockCfg.EXPECT().GetFeatureFlags().Return(tc.featureFlags).Times(1)
+			mockPolicyController.EXPECT().ListEgressPolicies().Return(tc.egressPolicies).AnyTimes()
+
+			mc := &MeshCatalog{
+				configurator:     mockCfg,
+				policyController: mockPolicyController,
+			}
+
+			actual, err := mc.GetEgressGatewayTrafficPolicy()
+			assert.Nil(err)
+			assert.Equal(tc.expectedGatewayPolicy, actual)
+		})
+	}
+}
+
 func TestBuildHTTPRouteConfigs(t *testing.T) {
 	assert := tassert.New(t)
 	mockCtrl := gomock.NewController(t)
@@ -820,6 +1088,66 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "egress policy with TLS origination and a wildcard host specified",
+			egressPolicy: &policyv1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+				Spec: policyv1alpha1.EgressSpec{
+					Hosts: []string{
+						"foo.com",
+						"*.bar.com",
+					},
+					Ports: []policyv1alpha1.PortSpec{
+						{
+							Number:   80,
+							Protocol: "http",
+						},
+					},
+					TLS: &policyv1alpha1.EgressTLSSpec{
+						Port:                    443,
+						CABundleSecret:          "ca",
+						ClientCertificateSecret: "client",
+					},
+				},
+			},
+			egressPort:      80,
+			httpRouteGroups: nil,
+			expectedRouteConfigs: []*trafficpolicy.EgressHTTPRouteConfig{
+				{
+					Name: "foo.com",
+					Hostnames: []string{
+						"foo.com",
+						"foo.com:80",
+					},
+					RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+						{
+							Route: trafficpolicy.RouteWeightedClusters{
+								HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+								WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+									service.WeightedCluster{ClusterName: service.ClusterName("foo.com:80|tls-3719e96e"), Weight: 100},
+								}),
+							},
+							AllowedDestinationIPRanges: nil,
+						},
+					},
+				},
+			},
+			expectedClusterConfigs: []*trafficpolicy.EgressClusterConfig{
+				{
+					Name: "foo.com:80|tls-3719e96e",
+					Host: "foo.com",
+					Port: 443,
+					TLS: &trafficpolicy.EgressTLSConfig{
+						SNI:                     "foo.com",
+						CABundleSecret:          &types.NamespacedName{Namespace: "test", Name: "ca"},
+						ClientCertificateSecret: &types.NamespacedName{Namespace: "test", Name: "client"},
+					},
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -841,6 +1169,26 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 	}
 }
 
+func TestGetEgressTLSClusterName(t *testing.T) {
+	assert := tassert.New(t)
+
+	tlsConfig := &trafficpolicy.EgressTLSConfig{SNI: "foo.com"}
+	name := getEgressTLSClusterName("foo.com:80", 443, tlsConfig)
+	assert.Equal(name, getEgressTLSClusterName("foo.com:80", 443, &trafficpolicy.EgressTLSConfig{SNI: "foo.com"}))
+
+	// Clusters with different TLS settings for the same host and port must not conflict
+	assert.NotEqual(name, getEgressTLSClusterName("foo.com:80", 8443, tlsConfig))
+	assert.NotEqual(name, getEgressTLSClusterName("foo.com:80", 443, &trafficpolicy.EgressTLSConfig{SNI: "foo.com", InsecureSkipVerify: true}))
+	assert.NotEqual(name, getEgressTLSClusterName("foo.com:80", 443, &trafficpolicy.EgressTLSConfig{
+		SNI:            "foo.com",
+		CABundleSecret: &types.NamespacedName{Namespace: "ns", Name: "ca"},
+	}))
+	assert.NotEqual(name, getEgressTLSClusterName("foo.com:80", 443, &trafficpolicy.EgressTLSConfig{
+		SNI:                     "foo.com",
+		ClientCertificateSecret: &types.NamespacedName{Namespace: "ns", Name: "client"},
+	}))
+}
+
 func TestGetHTTPRouteMatchesFromHTTPRouteGroup(t *testing.T) {
 	assert := tassert.New(t)
 
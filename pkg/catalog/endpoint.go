@@ -38,7 +38,17 @@ func (mc *MeshCatalog) ListAllowedUpstreamEndpointsForService(downstreamIdentity
 		return nil
 	}
 
-	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+	if mc.configurator.ForNamespace(downstreamIdentity.ToK8sServiceAccount().Namespace).IsPermissiveTrafficPolicyMode() {
+		return outboundEndpoints
+	}
+
+	// Access to services whose policies are audited is not restricted
+	if mc.IsPolicyAuditModeEnabled(upstreamSvc) {
+		return outboundEndpoints
+	}
+
+	// The OSM ingress gateway is allowed to access the backends of the routes it is programmed with
+	if mc.gatewayAPIController != nil && downstreamIdentity == mc.getIngressGatewayIdentity() && mc.isIngressGatewayBackend(upstreamSvc) {
 		return outboundEndpoints
 	}
 
@@ -167,6 +167,7 @@ func TestListAllowedUpstreamEndpointsForService(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
 			mockKubeController := k8s.NewMockController(mockCtrl)
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
@@ -227,6 +228,7 @@ func TestListAllowedUpstreamEndpointsForService(t *testing.T) {
 				}
 			}
 			mockKubeController.EXPECT().ListPods().Return(pods).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace(gomock.Any()).Return(nil).AnyTimes()
 
 			for sa, services := range tc.outboundServices {
 				for _, svc := range services {
@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"strings"
+	"time"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/auth"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// defaultExtAuthzTimeout is the default timeout for an external authorization service to respond
+	defaultExtAuthzTimeout = 1 * time.Second
+)
+
+// GetInboundExternalAuthConfig returns the external authorization configuration applicable to the inbound HTTP
+// traffic directed to the given upstream service, or nil if the traffic is not subject to external authorization.
+// An ExternalAuthorization policy targeting the service, or all the services in its namespace, takes precedence
+// over the mesh-wide external authorization configured in the MeshConfig.
+func (mc *MeshCatalog) GetInboundExternalAuthConfig(svc service.MeshService) *auth.ExtAuthConfig {
+	extAuthzPolicy := mc.policyController.GetExternalAuthorizationPolicy(svc)
+	if extAuthzPolicy == nil {
+		extAuthConfig := mc.configurator.GetInboundExternalAuthConfig()
+		if !extAuthConfig.Enable {
+			return nil
+		}
+		return &extAuthConfig
+	}
+
+	if extAuthzPolicy.Spec.Disable {
+		log.Debug().Msgf("External authorization disabled for service %s by ExternalAuthorization policy %s/%s",
+			svc, extAuthzPolicy.Namespace, extAuthzPolicy.Name)
+		return nil
+	}
+
+	return getExtAuthConfigFromPolicy(extAuthzPolicy)
+}
+
+// getExtAuthConfigFromPolicy returns the external authorization configuration corresponding to the given ExternalAuthorization policy
+func getExtAuthConfigFromPolicy(extAuthzPolicy *policyv1alpha1.ExternalAuthorization) *auth.ExtAuthConfig {
+	spec := extAuthzPolicy.Spec
+
+	extAuthConfig := &auth.ExtAuthConfig{
+		Enable:             true,
+		Address:            spec.Address,
+		Port:               spec.Port,
+		StatPrefix:         spec.StatPrefix,
+		AuthzTimeout:       defaultExtAuthzTimeout,
+		FailureModeAllow:   spec.FailureModeAllow,
+		Protocol:           strings.ToLower(spec.Protocol),
+		PathPrefix:         spec.PathPrefix,
+		AllowedHeaders:     spec.ForwardedHeaders,
+		ExemptPathPrefixes: spec.ExemptPathPrefixes,
+	}
+
+	if extAuthConfig.Protocol == "" {
+		extAuthConfig.Protocol = constants.ProtocolGRPC
+	}
+
+	if spec.Timeout != nil {
+		extAuthConfig.AuthzTimeout = spec.Timeout.Duration
+	}
+
+	if spec.WithRequestBody != nil {
+		extAuthConfig.RequestBody = &auth.RequestBodyConfig{
+			MaxRequestBytes:     spec.WithRequestBody.MaxRequestBytes,
+			AllowPartialMessage: spec.WithRequestBody.AllowPartialMessage,
+		}
+	}
+
+	return extAuthConfig
+}
@@ -0,0 +1,125 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/auth"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestGetInboundExternalAuthConfig(t *testing.T) {
+	svc := service.MeshService{Name: "s1", Namespace: "ns1", Port: 80, TargetPort: 8080, Protocol: "http"}
+
+	meshExtAuthConfig := auth.ExtAuthConfig{
+		Enable:       true,
+		Address:      "mesh-authz.osm-system.svc.cluster.local",
+		Port:         9000,
+		AuthzTimeout: 3 * time.Second,
+		Protocol:     "grpc",
+	}
+
+	testCases := []struct {
+		name              string
+		extAuthzPolicy    *policyv1alpha1.ExternalAuthorization
+		meshExtAuthConfig auth.ExtAuthConfig
+		expected          *auth.ExtAuthConfig
+	}{
+		{
+			name:              "no policy and mesh-wide external authorization disabled",
+			meshExtAuthConfig: auth.ExtAuthConfig{Enable: false, Address: "mesh-authz.osm-system.svc.cluster.local"},
+			expected:          nil,
+		},
+		{
+			name:              "no policy and mesh-wide external authorization enabled",
+			meshExtAuthConfig: meshExtAuthConfig,
+			expected:          &meshExtAuthConfig,
+		},
+		{
+			name: "policy disables external authorization",
+			extAuthzPolicy: &policyv1alpha1.ExternalAuthorization{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-authz", Namespace: "ns1"},
+				Spec:       policyv1alpha1.ExternalAuthorizationSpec{Services: []string{"s1"}, Disable: true},
+			},
+			meshExtAuthConfig: meshExtAuthConfig,
+			expected:          nil,
+		},
+		{
+			name: "policy with default settings",
+			extAuthzPolicy: &policyv1alpha1.ExternalAuthorization{
+				ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "ns1"},
+				Spec: policyv1alpha1.ExternalAuthorizationSpec{
+					Address: "authz.ns1.svc.cluster.local",
+					Port:    9000,
+				},
+			},
+			meshExtAuthConfig: meshExtAuthConfig,
+			expected: &auth.ExtAuthConfig{
+				Enable:       true,
+				Address:      "authz.ns1.svc.cluster.local",
+				Port:         9000,
+				AuthzTimeout: defaultExtAuthzTimeout,
+				Protocol:     "grpc",
+			},
+		},
+		{
+			name: "policy with an HTTP external authorization service",
+			extAuthzPolicy: &policyv1alpha1.ExternalAuthorization{
+				ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "ns1"},
+				Spec: policyv1alpha1.ExternalAuthorizationSpec{
+					Protocol:           "HTTP",
+					Address:            "authz.ns1.svc.cluster.local",
+					Port:               8080,
+					PathPrefix:         "/check",
+					StatPrefix:         "authz",
+					Timeout:            &metav1.Duration{Duration: 500 * time.Millisecond},
+					FailureModeAllow:   true,
+					ForwardedHeaders:   []string{"authorization", "x-request-id"},
+					WithRequestBody:    &policyv1alpha1.ExternalAuthorizationRequestBodySpec{MaxRequestBytes: 1024},
+					ExemptPathPrefixes: []string{"/healthz"},
+				},
+			},
+			expected: &auth.ExtAuthConfig{
+				Enable:             true,
+				Address:            "authz.ns1.svc.cluster.local",
+				Port:               8080,
+				StatPrefix:         "authz",
+				AuthzTimeout:       500 * time.Millisecond,
+				FailureModeAllow:   true,
+				Protocol:           "http",
+				PathPrefix:         "/check",
+				AllowedHeaders:     []string{"authorization", "x-request-id"},
+				RequestBody:        &auth.RequestBodyConfig{MaxRequestBytes: 1024},
+				ExemptPathPrefixes: []string{"/healthz"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mc := MeshCatalog{
+				policyController: mockPolicyController,
+				configurator:     mockConfigurator,
+			}
+
+			mockPolicyController.EXPECT().GetExternalAuthorizationPolicy(svc).Return(tc.extAuthzPolicy).Times(1)
+			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(tc.meshExtAuthConfig).AnyTimes()
+
+			assert.Equal(tc.expected, mc.GetInboundExternalAuthConfig(svc))
+		})
+	}
+}
@@ -23,14 +23,25 @@ const (
 	DisallowPartialHostnamesMatch bool = false
 )
 
-// GetInboundMeshTrafficPolicy returns the inbound mesh traffic policy for the given upstream identity and services
+// GetInboundMeshTrafficPolicy returns the inbound mesh traffic policy for the given upstream identity and services.
+// The policy is shared by the proxies with the same identity, services and rollout cohort and must not be mutated.
 func (mc *MeshCatalog) GetInboundMeshTrafficPolicy(upstreamIdentity identity.ServiceIdentity, upstreamServices []service.MeshService) *trafficpolicy.InboundMeshTrafficPolicy {
+	if mc.trafficPolicyCache == nil {
+		return mc.buildInboundMeshTrafficPolicy(upstreamIdentity, upstreamServices)
+	}
+	return mc.trafficPolicyCache.getInbound(mc.configurator.GetRolloutCohort(), upstreamIdentity, upstreamServices, func() *trafficpolicy.InboundMeshTrafficPolicy {
+		return mc.buildInboundMeshTrafficPolicy(upstreamIdentity, upstreamServices)
+	})
+}
+
+// buildInboundMeshTrafficPolicy builds the inbound mesh traffic policy for the given upstream identity and services
+func (mc *MeshCatalog) buildInboundMeshTrafficPolicy(upstreamIdentity identity.ServiceIdentity, upstreamServices []service.MeshService) *trafficpolicy.InboundMeshTrafficPolicy {
 	var trafficMatches []*trafficpolicy.TrafficMatch
 	var clusterConfigs []*trafficpolicy.MeshClusterConfig
 	var trafficTargets []*access.TrafficTarget
 	routeConfigPerPort := make(map[int][]*trafficpolicy.InboundTrafficPolicy)
 
-	permissiveMode := mc.configurator.IsPermissiveTrafficPolicyMode()
+	permissiveMode := mc.configurator.ForNamespace(upstreamIdentity.ToK8sServiceAccount().Namespace).IsPermissiveTrafficPolicyMode()
 	if !permissiveMode {
 		// Pre-computing the list of TrafficTarget optimizes to avoid repeated
 		// cache lookups for each upstream service.
@@ -78,6 +89,9 @@ func (mc *MeshCatalog) GetInboundMeshTrafficPolicy(upstreamIdentity identity.Ser
 		// and are wildcarded in permissive mode. The downstreams that can access this upstream
 		// on the configured routes is also determined based on the traffic policy mode.
 		inboundTrafficPolicies := mc.getInboundTrafficPoliciesForUpstream(upstreamSvc, permissiveMode, trafficTargets)
+		if !permissiveMode && mc.IsPolicyAuditModeEnabled(upstreamSvc) {
+			auditInboundTrafficPolicy(inboundTrafficPolicies, upstreamSvc)
+		}
 		routeConfigPerPort[int(upstreamSvc.TargetPort)] = append(routeConfigPerPort[int(upstreamSvc.TargetPort)], inboundTrafficPolicies)
 	}
 
@@ -108,6 +122,23 @@ func (mc *MeshCatalog) getInboundTrafficPoliciesForUpstream(upstreamSvc service.
 	return inboundPolicyForUpstreamSvc
 }
 
+// auditInboundTrafficPolicy marks the given inbound traffic policy as audited, and adds a catch-all rule
+// with no allowed downstream identities after the SMI routes. The catch-all rule matches requests the
+// SMI routes do not match so that they are forwarded to the upstream service while being reported as
+// denied.
+func auditInboundTrafficPolicy(inboundPolicy *trafficpolicy.InboundTrafficPolicy, upstreamSvc service.MeshService) {
+	inboundPolicy.AuditMode = true
+
+	localCluster := service.WeightedCluster{
+		ClusterName: service.ClusterName(upstreamSvc.EnvoyLocalClusterName()),
+		Weight:      constants.ClusterWeightAcceptAll,
+	}
+	inboundPolicy.Rules = append(inboundPolicy.Rules, &trafficpolicy.Rule{
+		Route:                    *trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{localCluster}),
+		AllowedServiceIdentities: mapset.NewSet(),
+	})
+}
+
 func (mc *MeshCatalog) buildInboundHTTPPolicyFromTrafficTarget(upstreamSvc service.MeshService, trafficTargets []*access.TrafficTarget) *trafficpolicy.InboundTrafficPolicy {
 	hostnames := k8s.GetHostnamesForService(upstreamSvc, true /* local namespace FQDN should always be allowed for inbound routes*/)
 	inboundPolicy := trafficpolicy.NewInboundTrafficPolicy(upstreamSvc.FQDN(), hostnames)
@@ -1592,6 +1592,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
 			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockCfg.EXPECT().ForNamespace(gomock.Any()).Return(mockCfg).AnyTimes()
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
@@ -1603,6 +1604,8 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 
 			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(tc.permissiveMode)
 			mockMeshSpec.EXPECT().ListTrafficTargets(gomock.Any()).Return(tc.trafficTargets).AnyTimes()
+			mockKubeController.EXPECT().GetService(gomock.Any()).Return(nil).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace(gomock.Any()).Return(nil).AnyTimes()
 			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return(tc.httpRouteGroups).AnyTimes()
 			tc.prepare(mockMeshSpec, tc.trafficSplits)
 
@@ -28,7 +28,8 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 	ingressBackendPolicy := mc.policyController.GetIngressBackendPolicy(svc)
 	if ingressBackendPolicy == nil {
 		log.Trace().Msgf("Did not find IngressBackend policy for service %s", svc)
-		return nil, nil
+		// Backends of HTTPRoutes are accessed by the OSM ingress gateway over mTLS
+		return mc.getIngressGatewayBackendTrafficPolicy(svc), nil
 	}
 
 	// The status field will be updated after the policy is processed.
@@ -59,10 +60,9 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 				sourceMeshSvc := service.MeshService{Name: source.Name, Namespace: source.Namespace}
 				endpoints := mc.listEndpointsForService(sourceMeshSvc)
 				if len(endpoints) == 0 {
-					ingressBackendWithStatus.Status = policyV1alpha1.IngressBackendStatus{
-						CurrentStatus: "error",
-						Reason:        fmt.Sprintf("endpoints not found for service %s/%s", source.Namespace, source.Name),
-					}
+					// The status conditions are maintained by the policy status reporter
+					ingressBackendWithStatus.Status.CurrentStatus = "error"
+					ingressBackendWithStatus.Status.Reason = fmt.Sprintf("endpoints not found for service %s/%s", source.Namespace, source.Name)
 					if _, err := mc.kubeController.UpdateStatus(&ingressBackendWithStatus); err != nil {
 						log.Error().Err(err).Msg("Error updating status for IngressBackend")
 					}
@@ -133,10 +133,8 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 		return nil, nil
 	}
 
-	ingressBackendWithStatus.Status = policyV1alpha1.IngressBackendStatus{
-		CurrentStatus: "committed",
-		Reason:        "successfully committed by the system",
-	}
+	ingressBackendWithStatus.Status.CurrentStatus = "committed"
+	ingressBackendWithStatus.Status.Reason = "successfully committed by the system"
 	if _, err := mc.kubeController.UpdateStatus(&ingressBackendWithStatus); err != nil {
 		log.Error().Err(err).Msg("Error updating status for IngressBackend")
 	}
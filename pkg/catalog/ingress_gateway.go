@@ -0,0 +1,581 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// ingressGatewayListenerNameFormat is the format of the name of an ingress gateway listener: ingress-gateway-<protocol>-<port>
+	ingressGatewayListenerNameFormat = "ingress-gateway-%s-%d"
+
+	// wildcardHostname is the hostname matching all hostnames
+	wildcardHostname = "*"
+)
+
+// GetIngressGatewayTrafficPolicy returns the traffic policy of the OSM ingress gateway, corresponding to
+// the Gateway API resources managed by OSM. A nil policy is returned when the Gateway API is not enabled.
+func (mc *MeshCatalog) GetIngressGatewayTrafficPolicy() (*trafficpolicy.IngressGatewayTrafficPolicy, error) {
+	if mc.gatewayAPIController == nil {
+		return nil, nil
+	}
+
+	b := &ingressGatewayPolicyBuilder{
+		meshServices:   mc.listMeshServices(),
+		listeners:      make(map[uint32]*trafficpolicy.IngressGatewayListener),
+		virtualHosts:   make(map[uint32]map[string]*trafficpolicy.OutboundTrafficPolicy),
+		serverNames:    make(map[uint32]mapset.Set),
+		clusterConfigs: make(map[string]*trafficpolicy.MeshClusterConfig),
+	}
+
+	for _, gateway := range mc.gatewayAPIController.ListGateways() {
+		for _, listener := range gateway.Spec.Listeners {
+			if !b.addListener(gateway, listener) {
+				continue
+			}
+			switch listener.Protocol {
+			case gwv1alpha2.HTTPProtocolType, gwv1alpha2.HTTPSProtocolType:
+				for _, route := range mc.gatewayAPIController.ListHTTPRoutes(gateway, listener) {
+					b.addHTTPRoute(listener, route)
+				}
+			case gwv1alpha2.TLSProtocolType:
+				for _, route := range mc.gatewayAPIController.ListTLSRoutes(gateway, listener) {
+					b.addTLSRoute(listener, route)
+				}
+			}
+		}
+	}
+
+	return b.build(), nil
+}
+
+// ingressGatewayPolicyBuilder accumulates the listeners, routes and clusters of the ingress gateway
+// while translating the Gateway API resources. Listeners of different Gateways on the same port share
+// the same Envoy listener.
+type ingressGatewayPolicyBuilder struct {
+	meshServices []service.MeshService
+
+	// listeners, virtualHosts and serverNames are keyed by port
+	listeners      map[uint32]*trafficpolicy.IngressGatewayListener
+	virtualHosts   map[uint32]map[string]*trafficpolicy.OutboundTrafficPolicy
+	serverNames    map[uint32]mapset.Set
+	clusterConfigs map[string]*trafficpolicy.MeshClusterConfig
+}
+
+// addListener adds the given Gateway listener to the policy and returns true if the listener was added.
+// Invalid listeners and listeners conflicting with another listener on the same port are ignored.
+func (b *ingressGatewayPolicyBuilder) addListener(gateway *gwv1alpha2.Gateway, listener gwv1alpha2.Listener) bool {
+	protocol, err := getIngressGatewayListenerProtocol(listener)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+			Msgf("Ignoring listener %s of Gateway %s/%s", listener.Name, gateway.Namespace, gateway.Name)
+		return false
+	}
+
+	port := uint32(listener.Port)
+	gwListener, ok := b.listeners[port]
+	if ok && gwListener.Protocol != protocol {
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+			Msgf("Ignoring listener %s of Gateway %s/%s, protocol %s conflicts with protocol %s of another listener on port %d",
+				listener.Name, gateway.Namespace, gateway.Name, protocol, gwListener.Protocol, port)
+		return false
+	}
+
+	var tlsTermination *trafficpolicy.IngressGatewayTLSTermination
+	if protocol == constants.ProtocolHTTPS {
+		certificateSecret, err := getIngressGatewayCertificateSecret(gateway, listener)
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+				Msgf("Ignoring listener %s of Gateway %s/%s", listener.Name, gateway.Namespace, gateway.Name)
+			return false
+		}
+
+		var hostnames []string
+		if listener.Hostname != nil {
+			hostnames = []string{string(*listener.Hostname)}
+		}
+		if !b.claimServerNames(port, hostnames) {
+			log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+				Msgf("Ignoring listener %s of Gateway %s/%s, hostnames %v conflict with another listener on port %d",
+					listener.Name, gateway.Namespace, gateway.Name, hostnames, port)
+			return false
+		}
+
+		tlsTermination = &trafficpolicy.IngressGatewayTLSTermination{
+			Name:              fmt.Sprintf("%s/%s/%s", gateway.Namespace, gateway.Name, listener.Name),
+			Hostnames:         hostnames,
+			CertificateSecret: certificateSecret,
+		}
+	}
+
+	if !ok {
+		gwListener = &trafficpolicy.IngressGatewayListener{
+			Name:     fmt.Sprintf(ingressGatewayListenerNameFormat, protocol, port),
+			Port:     port,
+			Protocol: protocol,
+		}
+		b.listeners[port] = gwListener
+		b.virtualHosts[port] = make(map[string]*trafficpolicy.OutboundTrafficPolicy)
+	}
+	if tlsTermination != nil {
+		gwListener.TLSTerminations = append(gwListener.TLSTerminations, tlsTermination)
+	}
+
+	return true
+}
+
+// addHTTPRoute adds the routes of the given HTTPRoute attached to the given listener
+func (b *ingressGatewayPolicyBuilder) addHTTPRoute(listener gwv1alpha2.Listener, route *gwv1alpha2.HTTPRoute) {
+	port := uint32(listener.Port)
+	hostnames := getIngressGatewayRouteHostnames(listener.Hostname, route.Spec.Hostnames)
+	if len(hostnames) == 0 {
+		log.Debug().Msgf("HTTPRoute %s/%s has no hostnames matching listener %s, skipping", route.Namespace, route.Name, listener.Name)
+		return
+	}
+
+	for _, rule := range route.Spec.Rules {
+		var backendRefs []gwv1alpha2.BackendRef
+		for _, ref := range rule.BackendRefs {
+			backendRefs = append(backendRefs, ref.BackendRef)
+		}
+		weightedClusters := b.getWeightedClusters("HTTPRoute", route.Namespace, route.Name, backendRefs, false)
+		if len(weightedClusters) == 0 {
+			log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+				Msgf("Ignoring rule of HTTPRoute %s/%s without valid backends", route.Namespace, route.Name)
+			continue
+		}
+
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gwv1alpha2.HTTPRouteMatch{{}}
+		}
+		for _, match := range matches {
+			routeMatch := getIngressGatewayHTTPRouteMatch(match)
+			for _, hostname := range hostnames {
+				virtualHost, ok := b.virtualHosts[port][hostname]
+				if !ok {
+					virtualHost = trafficpolicy.NewOutboundTrafficPolicy(hostname, []string{hostname})
+					b.virtualHosts[port][hostname] = virtualHost
+				}
+				virtualHost.Routes = append(virtualHost.Routes, trafficpolicy.NewRouteWeightedCluster(routeMatch, weightedClusters))
+			}
+		}
+	}
+}
+
+// addTLSRoute adds the given TLSRoute attached to the given listener. The TLS connections matching the
+// hostnames of the route are passed through to the backends of the route.
+func (b *ingressGatewayPolicyBuilder) addTLSRoute(listener gwv1alpha2.Listener, route *gwv1alpha2.TLSRoute) {
+	port := uint32(listener.Port)
+	hostnames := getIngressGatewayRouteHostnames(listener.Hostname, route.Spec.Hostnames)
+	if len(hostnames) == 0 {
+		log.Debug().Msgf("TLSRoute %s/%s has no hostnames matching listener %s, skipping", route.Namespace, route.Name, listener.Name)
+		return
+	}
+	if len(hostnames) == 1 && hostnames[0] == wildcardHostname {
+		// A filter chain without server names matches all the connections
+		hostnames = nil
+	}
+
+	var backendRefs []gwv1alpha2.BackendRef
+	for _, rule := range route.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs...)
+	}
+	weightedClusters := b.getWeightedClusters("TLSRoute", route.Namespace, route.Name, backendRefs, true)
+	if len(weightedClusters) == 0 {
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+			Msgf("Ignoring TLSRoute %s/%s without valid backends", route.Namespace, route.Name)
+		return
+	}
+
+	if !b.claimServerNames(port, hostnames) {
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+			Msgf("Ignoring TLSRoute %s/%s, hostnames %v conflict with another route on port %d", route.Namespace, route.Name, hostnames, port)
+		return
+	}
+
+	b.listeners[port].TLSRoutes = append(b.listeners[port].TLSRoutes, &trafficpolicy.IngressGatewayTLSRoute{
+		Name:             fmt.Sprintf("%s/%s", route.Namespace, route.Name),
+		Hostnames:        hostnames,
+		WeightedClusters: weightedClusters,
+	})
+}
+
+// claimServerNames records the given TLS server names as used on the given port. It returns false if any of
+// the server names is already used on the port, in which case none of them are recorded.
+// No server names corresponds to a filter chain matching all the connections.
+func (b *ingressGatewayPolicyBuilder) claimServerNames(port uint32, serverNames []string) bool {
+	if _, ok := b.serverNames[port]; !ok {
+		b.serverNames[port] = mapset.NewSet()
+	}
+
+	keys := serverNames
+	if len(keys) == 0 {
+		keys = []string{wildcardHostname}
+	}
+	for _, key := range keys {
+		if b.serverNames[port].Contains(key) {
+			return false
+		}
+	}
+	for _, key := range keys {
+		b.serverNames[port].Add(key)
+	}
+	return true
+}
+
+// getWeightedClusters returns the weighted clusters corresponding to the given backends of a route, and
+// records the clusters in the policy. Backends that cannot be resolved to a mesh service are ignored.
+// TLS passthrough routes only support backends with the TCP app protocol.
+func (b *ingressGatewayPolicyBuilder) getWeightedClusters(routeKind, routeNamespace, routeName string, backendRefs []gwv1alpha2.BackendRef, tlsPassthrough bool) []service.WeightedCluster {
+	var weightedClusters []service.WeightedCluster
+	for _, ref := range backendRefs {
+		meshSvc, err := b.getBackendService(routeNamespace, ref.BackendObjectReference)
+		if err == nil && tlsPassthrough && !isTCPProtocol(meshSvc.Protocol) {
+			err = errors.Errorf("backend service %s must use the %s app protocol", meshSvc, constants.ProtocolTCP)
+		}
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidGatewayAPIResource)).
+				Msgf("Ignoring backend %s of %s %s/%s", ref.Name, routeKind, routeNamespace, routeName)
+			continue
+		}
+
+		weight := 1
+		if ref.Weight != nil {
+			weight = int(*ref.Weight)
+		}
+		if weight == 0 {
+			continue
+		}
+
+		clusterName := meshSvc.EnvoyClusterName()
+		if _, ok := b.clusterConfigs[clusterName]; !ok {
+			b.clusterConfigs[clusterName] = &trafficpolicy.MeshClusterConfig{
+				Name:    clusterName,
+				Service: meshSvc,
+			}
+		}
+		weightedClusters = append(weightedClusters, service.WeightedCluster{
+			ClusterName: service.ClusterName(clusterName),
+			Weight:      weight,
+		})
+	}
+	return weightedClusters
+}
+
+// getBackendService returns the mesh service referenced by the given backend of a route in the given namespace
+func (b *ingressGatewayPolicyBuilder) getBackendService(routeNamespace string, ref gwv1alpha2.BackendObjectReference) (service.MeshService, error) {
+	if ref.Group != nil && *ref.Group != "" {
+		return service.MeshService{}, errors.Errorf("unsupported backend group %s", *ref.Group)
+	}
+	if ref.Kind != nil && *ref.Kind != gatewayapi.KindService {
+		return service.MeshService{}, errors.Errorf("unsupported backend kind %s", *ref.Kind)
+	}
+	if ref.Namespace != nil && string(*ref.Namespace) != routeNamespace {
+		return service.MeshService{}, errors.Errorf("cross namespace backend references are not supported")
+	}
+	if ref.Port == nil {
+		return service.MeshService{}, errors.Errorf("backend port must be specified")
+	}
+
+	for _, svc := range b.meshServices {
+		if svc.Namespace == routeNamespace && svc.Name == string(ref.Name) && svc.Port == uint16(*ref.Port) {
+			return svc, nil
+		}
+	}
+	return service.MeshService{}, errors.Errorf("service %s/%s with port %d not found in the mesh", routeNamespace, ref.Name, *ref.Port)
+}
+
+// build returns the ingress gateway traffic policy, with listeners sorted by port and routes sorted by precedence
+func (b *ingressGatewayPolicyBuilder) build() *trafficpolicy.IngressGatewayTrafficPolicy {
+	policy := &trafficpolicy.IngressGatewayTrafficPolicy{}
+
+	for port, listener := range b.listeners {
+		for _, virtualHost := range b.virtualHosts[port] {
+			sortIngressGatewayRoutes(virtualHost.Routes)
+			listener.HTTPRouteConfigs = append(listener.HTTPRouteConfigs, virtualHost)
+		}
+		sort.Slice(listener.HTTPRouteConfigs, func(i, j int) bool {
+			return listener.HTTPRouteConfigs[i].Name < listener.HTTPRouteConfigs[j].Name
+		})
+		policy.Listeners = append(policy.Listeners, listener)
+	}
+	sort.Slice(policy.Listeners, func(i, j int) bool {
+		return policy.Listeners[i].Port < policy.Listeners[j].Port
+	})
+
+	for _, clusterConfig := range b.clusterConfigs {
+		policy.ClustersConfigs = append(policy.ClustersConfigs, clusterConfig)
+	}
+	sort.Slice(policy.ClustersConfigs, func(i, j int) bool {
+		return policy.ClustersConfigs[i].Name < policy.ClustersConfigs[j].Name
+	})
+
+	return policy
+}
+
+// getIngressGatewayListenerProtocol returns the protocol of the ingress gateway listener for the given Gateway listener.
+// HTTPS listeners terminate TLS, while TLS listeners pass the TLS connections through to the backends.
+func getIngressGatewayListenerProtocol(listener gwv1alpha2.Listener) (string, error) {
+	switch listener.Protocol {
+	case gwv1alpha2.HTTPProtocolType:
+		return constants.ProtocolHTTP, nil
+
+	case gwv1alpha2.HTTPSProtocolType:
+		if listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode != gwv1alpha2.TLSModeTerminate {
+			return "", errors.Errorf("unsupported TLS mode %s for protocol %s", *listener.TLS.Mode, listener.Protocol)
+		}
+		return constants.ProtocolHTTPS, nil
+
+	case gwv1alpha2.TLSProtocolType:
+		if listener.TLS == nil || listener.TLS.Mode == nil || *listener.TLS.Mode != gwv1alpha2.TLSModePassthrough {
+			return "", errors.Errorf("protocol %s is only supported with TLS mode %s", listener.Protocol, gwv1alpha2.TLSModePassthrough)
+		}
+		return constants.ProtocolTLS, nil
+
+	default:
+		return "", errors.Errorf("unsupported protocol %s", listener.Protocol)
+	}
+}
+
+// getIngressGatewayCertificateSecret returns the Secret holding the certificate used to terminate TLS on the given listener
+func getIngressGatewayCertificateSecret(gateway *gwv1alpha2.Gateway, listener gwv1alpha2.Listener) (types.NamespacedName, error) {
+	if listener.TLS == nil || len(listener.TLS.CertificateRefs) == 0 || listener.TLS.CertificateRefs[0] == nil {
+		return types.NamespacedName{}, errors.Errorf("a certificate reference is required for protocol %s", listener.Protocol)
+	}
+
+	ref := listener.TLS.CertificateRefs[0]
+	if ref.Group != nil && *ref.Group != "" {
+		return types.NamespacedName{}, errors.Errorf("unsupported certificate reference group %s", *ref.Group)
+	}
+	if ref.Kind != nil && *ref.Kind != gatewayapi.KindSecret {
+		return types.NamespacedName{}, errors.Errorf("unsupported certificate reference kind %s", *ref.Kind)
+	}
+	if ref.Namespace != nil && string(*ref.Namespace) != gateway.Namespace {
+		return types.NamespacedName{}, errors.Errorf("cross namespace certificate references are not supported")
+	}
+
+	return types.NamespacedName{Namespace: gateway.Namespace, Name: string(ref.Name)}, nil
+}
+
+// getIngressGatewayRouteHostnames returns the hostnames of a route attached to a listener with the given hostname.
+// The route hostnames are intersected with the listener hostname, which may be a wildcard hostname.
+func getIngressGatewayRouteHostnames(listenerHostname *gwv1alpha2.Hostname, routeHostnames []gwv1alpha2.Hostname) []string {
+	listenerHost := ""
+	if listenerHostname != nil {
+		listenerHost = string(*listenerHostname)
+	}
+
+	if len(routeHostnames) == 0 {
+		if listenerHost == "" {
+			return []string{wildcardHostname}
+		}
+		return []string{listenerHost}
+	}
+
+	hostnameSet := mapset.NewSet()
+	var hostnames []string
+	for _, routeHostname := range routeHostnames {
+		routeHost := string(routeHostname)
+		hostname := ""
+		switch {
+		case listenerHost == "" || routeHost == listenerHost || isWildcardHostnameMatch(listenerHost, routeHost):
+			hostname = routeHost
+		case isWildcardHostnameMatch(routeHost, listenerHost):
+			hostname = listenerHost
+		}
+		if hostname != "" && !hostnameSet.Contains(hostname) {
+			hostnameSet.Add(hostname)
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// isWildcardHostnameMatch returns true if the given hostname is matched by the given wildcard hostname of the form *.<domain>
+func isWildcardHostnameMatch(wildcard, hostname string) bool {
+	return strings.HasPrefix(wildcard, "*.") && strings.HasSuffix(hostname, wildcard[1:]) && len(hostname) > len(wildcard)-1
+}
+
+// getIngressGatewayHTTPRouteMatch returns the route match corresponding to the given HTTPRoute match
+func getIngressGatewayHTTPRouteMatch(match gwv1alpha2.HTTPRouteMatch) trafficpolicy.HTTPRouteMatch {
+	// Requests match the path prefix / by default
+	routeMatch := trafficpolicy.HTTPRouteMatch{
+		Path:          "/",
+		PathMatchType: trafficpolicy.PathMatchPrefix,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+
+	if match.Path != nil {
+		if match.Path.Value != nil {
+			routeMatch.Path = *match.Path.Value
+		}
+		if match.Path.Type != nil {
+			switch *match.Path.Type {
+			case gwv1alpha2.PathMatchExact:
+				routeMatch.PathMatchType = trafficpolicy.PathMatchExact
+			case gwv1alpha2.PathMatchRegularExpression:
+				routeMatch.PathMatchType = trafficpolicy.PathMatchRegex
+			}
+		}
+	}
+
+	if match.Method != nil {
+		routeMatch.Methods = []string{string(*match.Method)}
+	}
+
+	for _, header := range match.Headers {
+		if routeMatch.Headers == nil {
+			routeMatch.Headers = make(map[string]string)
+		}
+		// Header values are matched as regular expressions
+		value := regexp.QuoteMeta(header.Value)
+		if header.Type != nil && *header.Type == gwv1alpha2.HeaderMatchRegularExpression {
+			value = header.Value
+		}
+		routeMatch.Headers[strings.ToLower(string(header.Name))] = value
+	}
+
+	return routeMatch
+}
+
+// sortIngressGatewayRoutes sorts the routes of a virtual host by precedence: exact path matches first,
+// followed by regex path matches and prefix path matches by decreasing length. Routes with the same
+// precedence retain their order.
+func sortIngressGatewayRoutes(routes []*trafficpolicy.RouteWeightedClusters) {
+	rank := func(r *trafficpolicy.RouteWeightedClusters) int {
+		switch r.HTTPRouteMatch.PathMatchType {
+		case trafficpolicy.PathMatchExact:
+			return 0
+		case trafficpolicy.PathMatchRegex:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		ri, rj := rank(routes[i]), rank(routes[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == 2 {
+			return len(routes[i].HTTPRouteMatch.Path) > len(routes[j].HTTPRouteMatch.Path)
+		}
+		return false
+	})
+}
+
+// getIngressGatewayIdentity returns the service identity of the OSM ingress gateway
+func (mc *MeshCatalog) getIngressGatewayIdentity() identity.ServiceIdentity {
+	return identity.K8sServiceAccount{Name: constants.OSMIngressGatewayName, Namespace: mc.configurator.GetOSMNamespace()}.ToServiceIdentity()
+}
+
+// listIngressGatewayBackends returns the mesh services the ingress gateway routes traffic to
+func (mc *MeshCatalog) listIngressGatewayBackends() []service.MeshService {
+	if mc.gatewayAPIController == nil {
+		return nil
+	}
+
+	policy, err := mc.GetIngressGatewayTrafficPolicy()
+	if err != nil || policy == nil {
+		return nil
+	}
+
+	var backends []service.MeshService
+	for _, clusterConfig := range policy.ClustersConfigs {
+		backends = append(backends, clusterConfig.Service)
+	}
+	return backends
+}
+
+// isIngressGatewayBackend returns true if the ingress gateway routes traffic to the given service.
+// Services are compared by their target port since EDS requests only identify the target port.
+func (mc *MeshCatalog) isIngressGatewayBackend(svc service.MeshService) bool {
+	for _, backend := range mc.listIngressGatewayBackends() {
+		if backend.Namespace == svc.Namespace && backend.Name == svc.Name && backend.TargetPort == svc.TargetPort {
+			return true
+		}
+	}
+	return false
+}
+
+// getIngressGatewayBackendTrafficPolicy returns the ingress traffic policy allowing the ingress gateway to access the
+// given service over mTLS, when the service is the backend of an HTTPRoute. TCP backends of TLSRoutes are accessed
+// via the in-mesh TCP filter chain and authorized using traffic targets instead.
+func (mc *MeshCatalog) getIngressGatewayBackendTrafficPolicy(svc service.MeshService) *trafficpolicy.IngressTrafficPolicy {
+	if mc.gatewayAPIController == nil || isTCPProtocol(svc.Protocol) || !mc.isIngressGatewayBackend(svc) {
+		return nil
+	}
+
+	trafficMatch := &trafficpolicy.IngressTrafficMatch{
+		Name:        fmt.Sprintf("ingress_%s_%d_%s", svc, svc.TargetPort, constants.ProtocolHTTPS),
+		Port:        uint32(svc.TargetPort),
+		Protocol:    constants.ProtocolHTTPS,
+		ServerNames: []string{svc.ServerName()},
+	}
+
+	return &trafficpolicy.IngressTrafficPolicy{
+		TrafficMatches: []*trafficpolicy.IngressTrafficMatch{trafficMatch},
+		HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
+			{
+				Name:      fmt.Sprintf("%s_from_%s", svc, constants.OSMIngressGatewayName),
+				Hostnames: []string{wildcardHostname},
+				Rules: []*trafficpolicy.Rule{
+					{
+						Route: trafficpolicy.RouteWeightedClusters{
+							HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+							WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: service.ClusterName(svc.EnvoyLocalClusterName()), Weight: constants.ClusterWeightAcceptAll}),
+						},
+						AllowedServiceIdentities: mapset.NewSet(mc.getIngressGatewayIdentity()),
+					},
+				},
+			},
+		},
+	}
+}
+
+// getIngressGatewayTrafficTarget returns the traffic target allowing the ingress gateway to access the target ports
+// of the TCP services routed by TLSRoutes and backed by the given upstream identity.
+func (mc *MeshCatalog) getIngressGatewayTrafficTarget(upstream identity.ServiceIdentity) *trafficpolicy.TrafficTargetWithRoutes {
+	var ports []int
+	for _, backend := range mc.listIngressGatewayBackends() {
+		if !isTCPProtocol(backend.Protocol) {
+			continue
+		}
+		for _, svcIdentity := range mc.ListServiceIdentitiesForService(backend) {
+			if svcIdentity == upstream {
+				ports = append(ports, int(backend.TargetPort))
+				break
+			}
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return &trafficpolicy.TrafficTargetWithRoutes{
+		Name:            fmt.Sprintf("%s/%s", mc.configurator.GetOSMNamespace(), constants.OSMIngressGatewayName),
+		Destination:     upstream,
+		Sources:         []identity.ServiceIdentity{mc.getIngressGatewayIdentity()},
+		TCPRouteMatches: []trafficpolicy.TCPRouteMatch{{Ports: ports}},
+	}
+}
+
+// isTCPProtocol returns true if the given app protocol is proxied at L4
+func isTCPProtocol(protocol string) bool {
+	return protocol == constants.ProtocolTCP || protocol == constants.ProtocolTCPServerFirst
+}
@@ -0,0 +1,360 @@
+package catalog
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var (
+	gatewayHTTPBackend = service.MeshService{Namespace: "ns-1", Name: "web", Port: 80, TargetPort: 8080, Protocol: constants.ProtocolHTTP}
+	gatewayTCPBackend  = service.MeshService{Namespace: "ns-1", Name: "db", Port: 5432, TargetPort: 5432, Protocol: constants.ProtocolTCP}
+)
+
+func newGatewayBackendRef(name string, port int32, weight *int32) gwv1alpha2.BackendRef {
+	portNumber := gwv1alpha2.PortNumber(port)
+	return gwv1alpha2.BackendRef{
+		BackendObjectReference: gwv1alpha2.BackendObjectReference{Name: gwv1alpha2.ObjectName(name), Port: &portNumber},
+		Weight:                 weight,
+	}
+}
+
+func TestGetIngressGatewayTrafficPolicy(t *testing.T) {
+	hostname := gwv1alpha2.Hostname("foo.com")
+	pathExact := gwv1alpha2.PathMatchExact
+	exactPath := "/login"
+	postMethod := gwv1alpha2.HTTPMethodPost
+	tlsTerminate := gwv1alpha2.TLSModeTerminate
+	tlsPassthrough := gwv1alpha2.TLSModePassthrough
+	weight := int32(3)
+	zeroWeight := int32(0)
+
+	gateway := &gwv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns-1"},
+		Spec: gwv1alpha2.GatewaySpec{
+			Listeners: []gwv1alpha2.Listener{
+				{Name: "http", Port: 80, Protocol: gwv1alpha2.HTTPProtocolType},
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: gwv1alpha2.HTTPSProtocolType,
+					Hostname: &hostname,
+					TLS: &gwv1alpha2.GatewayTLSConfig{
+						Mode:            &tlsTerminate,
+						CertificateRefs: []*gwv1alpha2.SecretObjectReference{{Name: "foo-cert"}},
+					},
+				},
+				{
+					Name:     "tls",
+					Port:     8443,
+					Protocol: gwv1alpha2.TLSProtocolType,
+					TLS:      &gwv1alpha2.GatewayTLSConfig{Mode: &tlsPassthrough},
+				},
+				// Conflicts with the protocol of the listener on port 80
+				{Name: "tcp", Port: 80, Protocol: gwv1alpha2.TLSProtocolType, TLS: &gwv1alpha2.GatewayTLSConfig{Mode: &tlsPassthrough}},
+				// HTTPS listeners require a certificate
+				{Name: "no-cert", Port: 9443, Protocol: gwv1alpha2.HTTPSProtocolType},
+			},
+		},
+	}
+
+	httpRoute := &gwv1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns-1"},
+		Spec: gwv1alpha2.HTTPRouteSpec{
+			Hostnames: []gwv1alpha2.Hostname{"foo.com"},
+			Rules: []gwv1alpha2.HTTPRouteRule{
+				{
+					BackendRefs: []gwv1alpha2.HTTPBackendRef{
+						{BackendRef: newGatewayBackendRef("web", 80, nil)},
+					},
+				},
+				{
+					Matches: []gwv1alpha2.HTTPRouteMatch{
+						{
+							Path:   &gwv1alpha2.HTTPPathMatch{Type: &pathExact, Value: &exactPath},
+							Method: &postMethod,
+							Headers: []gwv1alpha2.HTTPHeaderMatch{
+								{Name: "X-Version", Value: "v1.0"},
+							},
+						},
+					},
+					BackendRefs: []gwv1alpha2.HTTPBackendRef{
+						{BackendRef: newGatewayBackendRef("web", 80, &weight)},
+						// Backends with a weight of 0 do not receive traffic
+						{BackendRef: newGatewayBackendRef("web", 80, &zeroWeight)},
+						// Services not in the mesh are ignored
+						{BackendRef: newGatewayBackendRef("unknown", 80, nil)},
+					},
+				},
+				{
+					// Rules without valid backends are ignored
+					BackendRefs: []gwv1alpha2.HTTPBackendRef{
+						{BackendRef: newGatewayBackendRef("web", 8080, nil)},
+					},
+				},
+			},
+		},
+	}
+
+	tlsRoute := &gwv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns-1"},
+		Spec: gwv1alpha2.TLSRouteSpec{
+			Hostnames: []gwv1alpha2.Hostname{"db.foo.com"},
+			Rules: []gwv1alpha2.TLSRouteRule{
+				{
+					BackendRefs: []gwv1alpha2.BackendRef{
+						newGatewayBackendRef("db", 5432, nil),
+						// TLS passthrough is only supported for TCP backends
+						newGatewayBackendRef("web", 80, nil),
+					},
+				},
+			},
+		},
+	}
+
+	webCluster := service.WeightedCluster{ClusterName: service.ClusterName(gatewayHTTPBackend.EnvoyClusterName()), Weight: 1}
+	weightedWebCluster := service.WeightedCluster{ClusterName: service.ClusterName(gatewayHTTPBackend.EnvoyClusterName()), Weight: 3}
+	dbCluster := service.WeightedCluster{ClusterName: service.ClusterName(gatewayTCPBackend.EnvoyClusterName()), Weight: 1}
+
+	exactRouteMatch := trafficpolicy.HTTPRouteMatch{
+		Path:          "/login",
+		PathMatchType: trafficpolicy.PathMatchExact,
+		Methods:       []string{"POST"},
+		Headers:       map[string]string{"x-version": `v1\.0`},
+	}
+	defaultRouteMatch := trafficpolicy.HTTPRouteMatch{
+		Path:          "/",
+		PathMatchType: trafficpolicy.PathMatchPrefix,
+		Methods:       []string{constants.WildcardHTTPMethod},
+	}
+	routeConfigs := []*trafficpolicy.OutboundTrafficPolicy{
+		{
+			Name:      "foo.com",
+			Hostnames: []string{"foo.com"},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				trafficpolicy.NewRouteWeightedCluster(exactRouteMatch, []service.WeightedCluster{weightedWebCluster}),
+				trafficpolicy.NewRouteWeightedCluster(defaultRouteMatch, []service.WeightedCluster{webCluster}),
+			},
+		},
+	}
+
+	expected := &trafficpolicy.IngressGatewayTrafficPolicy{
+		Listeners: []*trafficpolicy.IngressGatewayListener{
+			{
+				Name:             "ingress-gateway-http-80",
+				Port:             80,
+				Protocol:         constants.ProtocolHTTP,
+				HTTPRouteConfigs: routeConfigs,
+			},
+			{
+				Name:     "ingress-gateway-https-443",
+				Port:     443,
+				Protocol: constants.ProtocolHTTPS,
+				TLSTerminations: []*trafficpolicy.IngressGatewayTLSTermination{
+					{
+						Name:              "ns-1/gw/https",
+						Hostnames:         []string{"foo.com"},
+						CertificateSecret: types.NamespacedName{Namespace: "ns-1", Name: "foo-cert"},
+					},
+				},
+				HTTPRouteConfigs: routeConfigs,
+			},
+			{
+				Name:     "ingress-gateway-tls-8443",
+				Port:     8443,
+				Protocol: constants.ProtocolTLS,
+				TLSRoutes: []*trafficpolicy.IngressGatewayTLSRoute{
+					{
+						Name:             "ns-1/db",
+						Hostnames:        []string{"db.foo.com"},
+						WeightedClusters: []service.WeightedCluster{dbCluster},
+					},
+				},
+			},
+		},
+		ClustersConfigs: []*trafficpolicy.MeshClusterConfig{
+			{Name: gatewayTCPBackend.EnvoyClusterName(), Service: gatewayTCPBackend},
+			{Name: gatewayHTTPBackend.EnvoyClusterName(), Service: gatewayHTTPBackend},
+		},
+	}
+
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockServiceProvider := service.NewMockProvider(mockCtrl)
+	mockGatewayAPIController := gatewayapi.NewMockController(mockCtrl)
+
+	mockServiceProvider.EXPECT().ListServices().Return([]service.MeshService{gatewayHTTPBackend, gatewayTCPBackend}).AnyTimes()
+	mockGatewayAPIController.EXPECT().ListGateways().Return([]*gwv1alpha2.Gateway{gateway}).Times(1)
+	mockGatewayAPIController.EXPECT().ListHTTPRoutes(gateway, gomock.Any()).Return([]*gwv1alpha2.HTTPRoute{httpRoute}).Times(2)
+	mockGatewayAPIController.EXPECT().ListTLSRoutes(gateway, gomock.Any()).Return([]*gwv1alpha2.TLSRoute{tlsRoute}).Times(1)
+
+	mc := &MeshCatalog{
+		serviceProviders:     []service.Provider{mockServiceProvider},
+		gatewayAPIController: mockGatewayAPIController,
+	}
+
+	actual, err := mc.GetIngressGatewayTrafficPolicy()
+	assert.Nil(err)
+	assert.Equal(expected, actual)
+
+	// The policy is nil when the Gateway API is not enabled
+	mc.gatewayAPIController = nil
+	actual, err = mc.GetIngressGatewayTrafficPolicy()
+	assert.Nil(err)
+	assert.Nil(actual)
+}
+
+func TestGetIngressGatewayRouteHostnames(t *testing.T) {
+	testCases := []struct {
+		name              string
+		listenerHostname  string
+		routeHostnames    []gwv1alpha2.Hostname
+		expectedHostnames []string
+	}{
+		{
+			name:              "no listener and route hostnames",
+			expectedHostnames: []string{"*"},
+		},
+		{
+			name:              "listener hostname without route hostnames",
+			listenerHostname:  "foo.com",
+			expectedHostnames: []string{"foo.com"},
+		},
+		{
+			name:              "route hostnames without listener hostname",
+			routeHostnames:    []gwv1alpha2.Hostname{"foo.com", "bar.com", "foo.com"},
+			expectedHostnames: []string{"foo.com", "bar.com"},
+		},
+		{
+			name:              "route hostnames matching a wildcard listener hostname",
+			listenerHostname:  "*.foo.com",
+			routeHostnames:    []gwv1alpha2.Hostname{"a.foo.com", "foo.com", "a.bar.com"},
+			expectedHostnames: []string{"a.foo.com"},
+		},
+		{
+			name:              "wildcard route hostname matching the listener hostname",
+			listenerHostname:  "a.foo.com",
+			routeHostnames:    []gwv1alpha2.Hostname{"*.foo.com", "b.foo.com"},
+			expectedHostnames: []string{"a.foo.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var listenerHostname *gwv1alpha2.Hostname
+			if tc.listenerHostname != "" {
+				hostname := gwv1alpha2.Hostname(tc.listenerHostname)
+				listenerHostname = &hostname
+			}
+			assert.Equal(tc.expectedHostnames, getIngressGatewayRouteHostnames(listenerHostname, tc.routeHostnames))
+		})
+	}
+}
+
+func TestGetIngressTrafficPolicyForGatewayBackend(t *testing.T) {
+	gateway := &gwv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns-1"},
+		Spec: gwv1alpha2.GatewaySpec{
+			Listeners: []gwv1alpha2.Listener{{Name: "http", Port: 80, Protocol: gwv1alpha2.HTTPProtocolType}},
+		},
+	}
+	httpRoute := &gwv1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns-1"},
+		Spec: gwv1alpha2.HTTPRouteSpec{
+			Rules: []gwv1alpha2.HTTPRouteRule{
+				{BackendRefs: []gwv1alpha2.HTTPBackendRef{{BackendRef: newGatewayBackendRef("web", 80, nil)}}},
+			},
+		},
+	}
+	gatewayIdentity := identity.K8sServiceAccount{Name: constants.OSMIngressGatewayName, Namespace: "osm-system"}.ToServiceIdentity()
+
+	testCases := []struct {
+		name           string
+		svc            service.MeshService
+		expectedPolicy *trafficpolicy.IngressTrafficPolicy
+	}{
+		{
+			name: "backend of an HTTPRoute",
+			svc:  gatewayHTTPBackend,
+			expectedPolicy: &trafficpolicy.IngressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.IngressTrafficMatch{
+					{
+						Name:        "ingress_ns-1/web_8080_https",
+						Port:        8080,
+						Protocol:    constants.ProtocolHTTPS,
+						ServerNames: []string{gatewayHTTPBackend.ServerName()},
+					},
+				},
+				HTTPRoutePolicies: []*trafficpolicy.InboundTrafficPolicy{
+					{
+						Name:      "ns-1/web_from_osm-ingress-gateway",
+						Hostnames: []string{"*"},
+						Rules: []*trafficpolicy.Rule{
+							{
+								Route: trafficpolicy.RouteWeightedClusters{
+									HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+									WeightedClusters: mapset.NewSet(service.WeightedCluster{
+										ClusterName: service.ClusterName(gatewayHTTPBackend.EnvoyLocalClusterName()),
+										Weight:      constants.ClusterWeightAcceptAll,
+									}),
+								},
+								AllowedServiceIdentities: mapset.NewSet(gatewayIdentity),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "service not routed by the ingress gateway",
+			svc:  gatewayTCPBackend,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockServiceProvider := service.NewMockProvider(mockCtrl)
+			mockGatewayAPIController := gatewayapi.NewMockController(mockCtrl)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			mockPolicyController.EXPECT().GetIngressBackendPolicy(tc.svc).Return(nil).Times(1)
+			mockServiceProvider.EXPECT().ListServices().Return([]service.MeshService{gatewayHTTPBackend, gatewayTCPBackend}).AnyTimes()
+			mockGatewayAPIController.EXPECT().ListGateways().Return([]*gwv1alpha2.Gateway{gateway}).AnyTimes()
+			mockGatewayAPIController.EXPECT().ListHTTPRoutes(gateway, gomock.Any()).Return([]*gwv1alpha2.HTTPRoute{httpRoute}).AnyTimes()
+			mockCfg.EXPECT().GetOSMNamespace().Return("osm-system").AnyTimes()
+
+			mc := &MeshCatalog{
+				serviceProviders:     []service.Provider{mockServiceProvider},
+				policyController:     mockPolicyController,
+				gatewayAPIController: mockGatewayAPIController,
+				configurator:         mockCfg,
+			}
+
+			actual, err := mc.GetIngressTrafficPolicy(tc.svc)
+			assert.Nil(err)
+			assert.Equal(tc.expectedPolicy, actual)
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	auth "github.com/openservicemesh/osm/pkg/auth"
+	configurator "github.com/openservicemesh/osm/pkg/configurator"
 	endpoint "github.com/openservicemesh/osm/pkg/endpoint"
 	identity "github.com/openservicemesh/osm/pkg/identity"
 	k8s "github.com/openservicemesh/osm/pkg/k8s"
@@ -38,6 +40,35 @@ func (m *MockMeshCataloger) EXPECT() *MockMeshCatalogerMockRecorder {
 	return m.recorder
 }
 
+// GetDNSRecords mocks base method.
+func (m *MockMeshCataloger) GetDNSRecords(arg0 identity.ServiceIdentity) []*trafficpolicy.DNSRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSRecords", arg0)
+	ret0, _ := ret[0].([]*trafficpolicy.DNSRecord)
+	return ret0
+}
+
+// GetDNSRecords indicates an expected call of GetDNSRecords.
+func (mr *MockMeshCatalogerMockRecorder) GetDNSRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSRecords", reflect.TypeOf((*MockMeshCataloger)(nil).GetDNSRecords), arg0)
+}
+
+// GetEgressGatewayTrafficPolicy mocks base method.
+func (m *MockMeshCataloger) GetEgressGatewayTrafficPolicy() (*trafficpolicy.EgressGatewayTrafficPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressGatewayTrafficPolicy")
+	ret0, _ := ret[0].(*trafficpolicy.EgressGatewayTrafficPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEgressGatewayTrafficPolicy indicates an expected call of GetEgressGatewayTrafficPolicy.
+func (mr *MockMeshCatalogerMockRecorder) GetEgressGatewayTrafficPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressGatewayTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetEgressGatewayTrafficPolicy))
+}
+
 // GetEgressTrafficPolicy mocks base method.
 func (m *MockMeshCataloger) GetEgressTrafficPolicy(arg0 identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +84,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetEgressTrafficPolicy(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetEgressTrafficPolicy), arg0)
 }
 
+// GetInboundExternalAuthConfig mocks base method.
+func (m *MockMeshCataloger) GetInboundExternalAuthConfig(arg0 service.MeshService) *auth.ExtAuthConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundExternalAuthConfig", arg0)
+	ret0, _ := ret[0].(*auth.ExtAuthConfig)
+	return ret0
+}
+
+// GetInboundExternalAuthConfig indicates an expected call of GetInboundExternalAuthConfig.
+func (mr *MockMeshCatalogerMockRecorder) GetInboundExternalAuthConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundExternalAuthConfig", reflect.TypeOf((*MockMeshCataloger)(nil).GetInboundExternalAuthConfig), arg0)
+}
+
 // GetInboundMeshTrafficPolicy mocks base method.
 func (m *MockMeshCataloger) GetInboundMeshTrafficPolicy(arg0 identity.ServiceIdentity, arg1 []service.MeshService) *trafficpolicy.InboundMeshTrafficPolicy {
 	m.ctrl.T.Helper()
@@ -67,6 +112,21 @@ func (mr *MockMeshCatalogerMockRecorder) GetInboundMeshTrafficPolicy(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundMeshTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetInboundMeshTrafficPolicy), arg0, arg1)
 }
 
+// GetIngressGatewayTrafficPolicy mocks base method.
+func (m *MockMeshCataloger) GetIngressGatewayTrafficPolicy() (*trafficpolicy.IngressGatewayTrafficPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngressGatewayTrafficPolicy")
+	ret0, _ := ret[0].(*trafficpolicy.IngressGatewayTrafficPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngressGatewayTrafficPolicy indicates an expected call of GetIngressGatewayTrafficPolicy.
+func (mr *MockMeshCatalogerMockRecorder) GetIngressGatewayTrafficPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressGatewayTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetIngressGatewayTrafficPolicy))
+}
+
 // GetIngressTrafficPolicy mocks base method.
 func (m *MockMeshCataloger) GetIngressTrafficPolicy(arg0 service.MeshService) (*trafficpolicy.IngressTrafficPolicy, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +170,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetOutboundMeshTrafficPolicy(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundMeshTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetOutboundMeshTrafficPolicy), arg0)
 }
 
+// IsPolicyAuditModeEnabled mocks base method.
+func (m *MockMeshCataloger) IsPolicyAuditModeEnabled(arg0 service.MeshService) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPolicyAuditModeEnabled", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPolicyAuditModeEnabled indicates an expected call of IsPolicyAuditModeEnabled.
+func (mr *MockMeshCatalogerMockRecorder) IsPolicyAuditModeEnabled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPolicyAuditModeEnabled", reflect.TypeOf((*MockMeshCataloger)(nil).IsPolicyAuditModeEnabled), arg0)
+}
+
 // ListAllowedUpstreamEndpointsForService mocks base method.
 func (m *MockMeshCataloger) ListAllowedUpstreamEndpointsForService(arg0 identity.ServiceIdentity, arg1 service.MeshService) []endpoint.Endpoint {
 	m.ctrl.T.Helper()
@@ -208,3 +282,17 @@ func (mr *MockMeshCatalogerMockRecorder) ListServiceIdentitiesForService(arg0 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceIdentitiesForService", reflect.TypeOf((*MockMeshCataloger)(nil).ListServiceIdentitiesForService), arg0)
 }
+
+// WithConfigurator mocks base method.
+func (m *MockMeshCataloger) WithConfigurator(arg0 configurator.Configurator) MeshCataloger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithConfigurator", arg0)
+	ret0, _ := ret[0].(MeshCataloger)
+	return ret0
+}
+
+// WithConfigurator indicates an expected call of WithConfigurator.
+func (mr *MockMeshCatalogerMockRecorder) WithConfigurator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithConfigurator", reflect.TypeOf((*MockMeshCataloger)(nil).WithConfigurator), arg0)
+}
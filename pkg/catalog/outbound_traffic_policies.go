@@ -16,7 +16,18 @@ import (
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
-// GetOutboundMeshTrafficPolicy returns the outbound mesh traffic policy for the given downstream identity
+// GetOutboundMeshTrafficPolicy returns the outbound mesh traffic policy for the given downstream identity.
+// The policy is shared by the proxies with the same identity and rollout cohort and must not be mutated.
+func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.ServiceIdentity) *trafficpolicy.OutboundMeshTrafficPolicy {
+	if mc.trafficPolicyCache == nil {
+		return mc.buildOutboundMeshTrafficPolicy(downstreamIdentity)
+	}
+	return mc.trafficPolicyCache.getOutbound(mc.configurator.GetRolloutCohort(), downstreamIdentity, func() *trafficpolicy.OutboundMeshTrafficPolicy {
+		return mc.buildOutboundMeshTrafficPolicy(downstreamIdentity)
+	})
+}
+
+// buildOutboundMeshTrafficPolicy builds the outbound mesh traffic policy for the given downstream identity
 //
 // The function works as follows:
 // 1. If permissive mode is enabled, builds outbound mesh traffic policies to reach every upstream service
@@ -29,7 +40,7 @@ import (
 // The route configurations are consolidated per port, such that upstream services using the same port are a part
 // of the same route configuration. This is required to avoid route conflicts that can occur when the same hostname
 // needs to be routed differently based on the port used.
-func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.ServiceIdentity) *trafficpolicy.OutboundMeshTrafficPolicy {
+func (mc *MeshCatalog) buildOutboundMeshTrafficPolicy(downstreamIdentity identity.ServiceIdentity) *trafficpolicy.OutboundMeshTrafficPolicy {
 	var trafficMatches []*trafficpolicy.TrafficMatch
 	var clusterConfigs []*trafficpolicy.MeshClusterConfig
 	routeConfigPerPort := make(map[int][]*trafficpolicy.OutboundTrafficPolicy)
@@ -142,10 +153,16 @@ func (mc *MeshCatalog) ListOutboundServicesForMulticlusterGateway() []service.Me
 	return mc.listMeshServices()
 }
 
-// ListOutboundServicesForIdentity list the services the given service account is allowed to initiate outbound connections to
+// ListOutboundServicesForIdentity list the services the given service account is allowed to initiate outbound connections to,
+// limited to the services within the SidecarScope applicable to the service account if any.
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
 func (mc *MeshCatalog) ListOutboundServicesForIdentity(serviceIdentity identity.ServiceIdentity) []service.MeshService {
-	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+	return mc.applySidecarScope(serviceIdentity, mc.listOutboundServicesForIdentity(serviceIdentity))
+}
+
+// listOutboundServicesForIdentity list the services the given service account is allowed to initiate outbound connections to
+func (mc *MeshCatalog) listOutboundServicesForIdentity(serviceIdentity identity.ServiceIdentity) []service.MeshService {
+	if mc.configurator.ForNamespace(serviceIdentity.ToK8sServiceAccount().Namespace).IsPermissiveTrafficPolicyMode() {
 		return mc.listMeshServices()
 	}
 
@@ -173,6 +190,13 @@ func (mc *MeshCatalog) ListOutboundServicesForIdentity(serviceIdentity identity.
 		}
 	}
 
+	// Services whose access policies are audited accept traffic from any downstream
+	for _, svc := range mc.listPolicyAuditedServices() {
+		if added := serviceSet.Add(svc); added {
+			allowedServices = append(allowedServices, svc)
+		}
+	}
+
 	return allowedServices
 }
 
@@ -241,5 +265,6 @@ func (mc *MeshCatalog) listAllowedUpstreamServicesIncludeApex(downstreamIdentity
 		dstServices = append(dstServices, svc)
 	}
 
-	return dstServices
+	// Apex services must also be within the sidecar scope
+	return mc.applySidecarScope(downstreamIdentity, dstServices)
 }
@@ -582,8 +582,10 @@ func TestGetOutboundMeshTrafficPolicy(t *testing.T) {
 			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
 			mockServiceProvider := service.NewMockProvider(mockCtrl)
 			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockCfg.EXPECT().ForNamespace(gomock.Any()).Return(mockCfg).AnyTimes()
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockPolicyController.EXPECT().GetSidecarScope(gomock.Any()).Return(nil).AnyTimes()
 
 			mc := MeshCatalog{
 				kubeController:     mockKubeController,
@@ -599,6 +601,8 @@ func TestGetOutboundMeshTrafficPolicy(t *testing.T) {
 			mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{}).AnyTimes()
 			mockServiceProvider.EXPECT().ListServices().Return(allMeshServices).AnyTimes()
 			mockMeshSpec.EXPECT().ListTrafficTargets().Return(trafficTargets).AnyTimes()
+			mockKubeController.EXPECT().GetService(gomock.Any()).Return(nil).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace(gomock.Any()).Return(nil).AnyTimes()
 			mockServiceProvider.EXPECT().GetID().Return("test").AnyTimes()
 			mockEndpointProvider.EXPECT().GetID().Return("test").AnyTimes()
 			firstSplitCall := mockMeshSpec.EXPECT().ListTrafficSplits().Return(trafficSplits).Times(1)
@@ -772,8 +776,11 @@ func TestListAllowedUpstreamServicesIncludeApex(t *testing.T) {
 
 	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
 	mockController := k8s.NewMockController(mockCtrl)
 	mockServiceProvider := service.NewMockProvider(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockPolicyController.EXPECT().GetSidecarScope(gomock.Any()).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{EnableMulticlusterMode: true}).AnyTimes()
 	mockConfigurator.EXPECT().GetOSMNamespace().Return("osm-system").AnyTimes()
 
@@ -781,6 +788,7 @@ func TestListAllowedUpstreamServicesIncludeApex(t *testing.T) {
 		meshSpec:         mockMeshSpec,
 		kubeController:   mockController,
 		configurator:     mockConfigurator,
+		policyController: mockPolicyController,
 		serviceProviders: []service.Provider{mockServiceProvider},
 	}
 
@@ -0,0 +1,211 @@
+package catalog
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// trafficPolicyCache memoizes the mesh traffic policies computed per service identity and staged rollout cohort,
+// so that the proxies sharing a service identity and a configuration reuse the same policies instead of recomputing
+// them on every push.
+// Entries are invalidated synchronously by the message broker before the events result in proxy updates, so
+// that proxy configurations are never computed from invalidated entries. Each invalidation increments the
+// generation counters of the changed inputs, so that policies computed from outdated inputs are not cached.
+// The cached policies are shared and must not be mutated by callers.
+type trafficPolicyCache struct {
+	sync.RWMutex
+
+	// configGeneration is incremented when a resource the policies are computed from changes, other than endpoints
+	configGeneration uint64
+
+	// endpointGeneration is incremented when endpoints change, which only affect outbound policies
+	endpointGeneration uint64
+
+	outbound map[string]*outboundPolicyEntry
+	inbound  map[string]*trafficpolicy.InboundMeshTrafficPolicy
+}
+
+// outboundPolicyEntry is a cached outbound policy, with the upstream services it was computed from
+type outboundPolicyEntry struct {
+	policy *trafficpolicy.OutboundMeshTrafficPolicy
+	// upstreamServices are the namespaced names of the upstream services of the policy's clusters
+	upstreamServices map[string]struct{}
+}
+
+// trafficPolicyCacheInvalidationEvents are the events that invalidate every cached traffic policy.
+// Endpoint events and the events only affecting inbound policies are handled separately.
+var trafficPolicyCacheInvalidationEvents = map[announcements.Kind]struct{}{
+	announcements.ProxyUpdate:                   {},
+	announcements.NamespaceAdded:                {},
+	announcements.NamespaceDeleted:              {},
+	announcements.NamespaceUpdated:              {},
+	announcements.ServiceAdded:                  {},
+	announcements.ServiceDeleted:                {},
+	announcements.ServiceUpdated:                {},
+	announcements.TrafficSplitAdded:             {},
+	announcements.TrafficSplitDeleted:           {},
+	announcements.TrafficSplitUpdated:           {},
+	announcements.TCPRouteAdded:                 {},
+	announcements.TCPRouteDeleted:               {},
+	announcements.TCPRouteUpdated:               {},
+	announcements.TrafficTargetAdded:            {},
+	announcements.TrafficTargetDeleted:          {},
+	announcements.TrafficTargetUpdated:          {},
+	announcements.MeshConfigAdded:               {},
+	announcements.MeshConfigDeleted:             {},
+	announcements.MeshConfigUpdated:             {},
+	announcements.MeshConfigRolloutUpdated:      {},
+	announcements.NamespaceConfigAdded:          {},
+	announcements.NamespaceConfigDeleted:        {},
+	announcements.NamespaceConfigUpdated:        {},
+	announcements.RetryPolicyAdded:              {},
+	announcements.RetryPolicyDeleted:            {},
+	announcements.RetryPolicyUpdated:            {},
+	announcements.UpstreamTrafficSettingAdded:   {},
+	announcements.UpstreamTrafficSettingDeleted: {},
+	announcements.UpstreamTrafficSettingUpdated: {},
+	announcements.SidecarScopeAdded:             {},
+	announcements.SidecarScopeDeleted:           {},
+	announcements.SidecarScopeUpdated:           {},
+	announcements.MultiClusterServiceAdded:      {},
+	announcements.MultiClusterServiceDeleted:    {},
+	announcements.MultiClusterServiceUpdated:    {},
+}
+
+func newTrafficPolicyCache() *trafficPolicyCache {
+	return &trafficPolicyCache{
+		outbound: make(map[string]*outboundPolicyEntry),
+		inbound:  make(map[string]*trafficpolicy.InboundMeshTrafficPolicy),
+	}
+}
+
+// handleEvent invalidates the cached traffic policies computed from the inputs changed by the given event.
+// It is called synchronously by the message broker.
+func (c *trafficPolicyCache) handleEvent(msg events.PubSubMessage) {
+	switch msg.Kind {
+	case announcements.EndpointAdded, announcements.EndpointDeleted, announcements.EndpointUpdated:
+		// Endpoints only affect the outbound policies of the clients of their service
+		var upstreamServices []string
+		for _, obj := range []interface{}{msg.OldObj, msg.NewObj} {
+			if endpoints, ok := obj.(*corev1.Endpoints); ok {
+				upstreamServices = append(upstreamServices, namespacedName(endpoints.Namespace, endpoints.Name))
+			}
+		}
+		c.invalidateOutbound(upstreamServices)
+
+	case announcements.ServiceAccountAdded, announcements.ServiceAccountDeleted, announcements.ServiceAccountUpdated,
+		announcements.RouteGroupAdded, announcements.RouteGroupDeleted, announcements.RouteGroupUpdated:
+		// Outbound policies use wildcard routes to the upstream services
+		c.invalidateInbound()
+
+	default:
+		if _, ok := trafficPolicyCacheInvalidationEvents[msg.Kind]; ok {
+			c.invalidateAll()
+		}
+	}
+}
+
+// invalidateOutbound invalidates the cached outbound policies referencing any of the given upstream services,
+// or every outbound policy if no upstream service is given
+func (c *trafficPolicyCache) invalidateOutbound(upstreamServices []string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.endpointGeneration++
+	if len(upstreamServices) == 0 {
+		c.outbound = make(map[string]*outboundPolicyEntry)
+		return
+	}
+	for key, entry := range c.outbound {
+		for _, upstreamSvc := range upstreamServices {
+			if _, ok := entry.upstreamServices[upstreamSvc]; ok {
+				delete(c.outbound, key)
+				break
+			}
+		}
+	}
+}
+
+// invalidateInbound invalidates every cached inbound policy
+func (c *trafficPolicyCache) invalidateInbound() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.configGeneration++
+	c.inbound = make(map[string]*trafficpolicy.InboundMeshTrafficPolicy)
+}
+
+// invalidateAll invalidates every cached policy
+func (c *trafficPolicyCache) invalidateAll() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.configGeneration++
+	c.endpointGeneration++
+	c.outbound = make(map[string]*outboundPolicyEntry)
+	c.inbound = make(map[string]*trafficpolicy.InboundMeshTrafficPolicy)
+}
+
+// getOutbound returns the cached outbound policy for the given rollout cohort and identity, or computes and caches it
+func (c *trafficPolicyCache) getOutbound(cohort string, downstreamIdentity identity.ServiceIdentity, compute func() *trafficpolicy.OutboundMeshTrafficPolicy) *trafficpolicy.OutboundMeshTrafficPolicy {
+	key := fmt.Sprintf("%s|%s", cohort, downstreamIdentity)
+
+	c.RLock()
+	entry, ok := c.outbound[key]
+	configGeneration, endpointGeneration := c.configGeneration, c.endpointGeneration
+	c.RUnlock()
+	if ok {
+		return entry.policy
+	}
+
+	policy := compute()
+	entry = &outboundPolicyEntry{policy: policy, upstreamServices: make(map[string]struct{})}
+	if policy != nil {
+		for _, clusterConfig := range policy.ClustersConfigs {
+			entry.upstreamServices[namespacedName(clusterConfig.Service.Namespace, clusterConfig.Service.Name)] = struct{}{}
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	// Do not cache the policy if its inputs changed while it was being computed
+	if configGeneration == c.configGeneration && endpointGeneration == c.endpointGeneration {
+		c.outbound[key] = entry
+	}
+	return policy
+}
+
+// getInbound returns the cached inbound policy for the given rollout cohort, identity and services, or computes and caches it
+func (c *trafficPolicyCache) getInbound(cohort string, upstreamIdentity identity.ServiceIdentity, upstreamServices []service.MeshService, compute func() *trafficpolicy.InboundMeshTrafficPolicy) *trafficpolicy.InboundMeshTrafficPolicy {
+	// The proxies with the same identity can belong to different services
+	key := fmt.Sprintf("%s|%s|%v", cohort, upstreamIdentity, upstreamServices)
+
+	c.RLock()
+	policy, ok := c.inbound[key]
+	configGeneration := c.configGeneration
+	c.RUnlock()
+	if ok {
+		return policy
+	}
+
+	policy = compute()
+
+	c.Lock()
+	defer c.Unlock()
+	if configGeneration == c.configGeneration {
+		c.inbound[key] = policy
+	}
+	return policy
+}
+
+func namespacedName(namespace, name string) string {
+	return namespace + "/" + name
+}
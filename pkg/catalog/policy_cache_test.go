@@ -0,0 +1,232 @@
+package catalog
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestTrafficPolicyCache(t *testing.T) {
+	assert := tassert.New(t)
+
+	c := newTrafficPolicyCache()
+	svcIdentity := identity.K8sServiceAccount{Name: "sa", Namespace: "ns"}.ToServiceIdentity()
+	services := []service.MeshService{{Name: "s", Namespace: "ns"}}
+	upstreamSvc := service.MeshService{Name: "upstream", Namespace: "ns"}
+
+	outboundComputed := 0
+	computeOutbound := func() *trafficpolicy.OutboundMeshTrafficPolicy {
+		outboundComputed++
+		return &trafficpolicy.OutboundMeshTrafficPolicy{
+			ClustersConfigs: []*trafficpolicy.MeshClusterConfig{{Name: "ns/upstream|80", Service: upstreamSvc}},
+		}
+	}
+	inboundComputed := 0
+	computeInbound := func() *trafficpolicy.InboundMeshTrafficPolicy {
+		inboundComputed++
+		return &trafficpolicy.InboundMeshTrafficPolicy{}
+	}
+	endpointsEvent := func(name string) events.PubSubMessage {
+		return events.PubSubMessage{
+			Kind:   announcements.EndpointUpdated,
+			NewObj: &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}},
+		}
+	}
+
+	// Policies are computed once per identity
+	outbound := c.getOutbound("", svcIdentity, computeOutbound)
+	assert.Same(outbound, c.getOutbound("", svcIdentity, computeOutbound))
+	inbound := c.getInbound("", svcIdentity, services, computeInbound)
+	assert.Same(inbound, c.getInbound("", svcIdentity, services, computeInbound))
+	assert.Equal(1, outboundComputed)
+	assert.Equal(1, inboundComputed)
+
+	// Inbound policies are keyed by identity and services
+	c.getInbound("", svcIdentity, nil, computeInbound)
+	assert.Equal(2, inboundComputed)
+
+	// Endpoint events only invalidate the outbound policies referencing their service
+	c.handleEvent(endpointsEvent("other"))
+	c.getOutbound("", svcIdentity, computeOutbound)
+	assert.Equal(1, outboundComputed)
+	c.handleEvent(endpointsEvent("upstream"))
+	c.getOutbound("", svcIdentity, computeOutbound)
+	c.getInbound("", svcIdentity, services, computeInbound)
+	assert.Equal(2, outboundComputed)
+	assert.Equal(2, inboundComputed)
+
+	// Route events only invalidate inbound policies
+	c.handleEvent(events.PubSubMessage{Kind: announcements.RouteGroupUpdated})
+	c.getOutbound("", svcIdentity, computeOutbound)
+	c.getInbound("", svcIdentity, services, computeInbound)
+	assert.Equal(2, outboundComputed)
+	assert.Equal(3, inboundComputed)
+
+	// Other policy events invalidate every policy
+	c.handleEvent(events.PubSubMessage{Kind: announcements.TrafficTargetUpdated})
+	c.getOutbound("", svcIdentity, computeOutbound)
+	c.getInbound("", svcIdentity, services, computeInbound)
+	assert.Equal(3, outboundComputed)
+	assert.Equal(4, inboundComputed)
+
+	// Events not affecting traffic policies do not invalidate the cache
+	c.handleEvent(events.PubSubMessage{Kind: announcements.CertificateRotated})
+	c.getOutbound("", svcIdentity, computeOutbound)
+	assert.Equal(3, outboundComputed)
+
+	// Policies are keyed by rollout cohort, so canary proxies do not share the policies of stable proxies
+	outbound = c.getOutbound("", svcIdentity, computeOutbound)
+	assert.NotSame(outbound, c.getOutbound("canary-1", svcIdentity, computeOutbound))
+	assert.Equal(4, outboundComputed)
+
+	// Policies whose inputs changed while being computed are not cached
+	c.getOutbound("", identity.ServiceIdentity("other.ns"), func() *trafficpolicy.OutboundMeshTrafficPolicy {
+		c.handleEvent(endpointsEvent("other"))
+		return &trafficpolicy.OutboundMeshTrafficPolicy{}
+	})
+	_, ok := c.outbound["|other.ns"]
+	assert.False(ok)
+}
+
+func TestTrafficPolicyCacheInvalidatedBeforeProxyUpdates(t *testing.T) {
+	assert := tassert.New(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	msgBroker := messaging.NewBroker(stop)
+
+	c := newTrafficPolicyCache()
+	msgBroker.AddSyncEventHandler(c.handleEvent)
+
+	svcIdentity := identity.K8sServiceAccount{Name: "sa", Namespace: "ns"}.ToServiceIdentity()
+	c.getOutbound("", svcIdentity, func() *trafficpolicy.OutboundMeshTrafficPolicy {
+		return &trafficpolicy.OutboundMeshTrafficPolicy{}
+	})
+
+	// The cache is invalidated by the time the proxy update resulting from the event is published
+	proxyUpdatePubSub := msgBroker.GetProxyUpdatePubSub()
+	proxyUpdateChan := proxyUpdatePubSub.Sub(announcements.ProxyUpdate.String())
+	defer msgBroker.Unsub(proxyUpdatePubSub, proxyUpdateChan)
+	msgBroker.GetQueue().Add(events.PubSubMessage{Kind: announcements.TrafficTargetUpdated})
+
+	select {
+	case <-proxyUpdateChan:
+	case <-time.After(5 * time.Second):
+		assert.Fail("expected a proxy update")
+	}
+	c.RLock()
+	defer c.RUnlock()
+	assert.Empty(c.outbound)
+	assert.EqualValues(1, c.configGeneration)
+}
+
+// newBenchmarkMeshCatalog returns a MeshCatalog in permissive mode with the given number of services,
+// each with a single endpoint.
+func newBenchmarkMeshCatalog(b *testing.B, numServices int, withCache bool) *MeshCatalog {
+	mockCtrl := gomock.NewController(b)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+	mockServiceProvider := service.NewMockProvider(mockCtrl)
+	mockCfg := configurator.NewMockConfigurator(mockCtrl)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockPolicyController.EXPECT().GetSidecarScope(gomock.Any()).Return(nil).AnyTimes()
+
+	var services []service.MeshService
+	for i := 0; i < numServices; i++ {
+		services = append(services, service.MeshService{
+			Name:       fmt.Sprintf("s%d", i),
+			Namespace:  fmt.Sprintf("ns%d", i%10),
+			Port:       80,
+			TargetPort: 8080,
+			Protocol:   "http",
+		})
+	}
+
+	mockCfg.EXPECT().ForNamespace(gomock.Any()).Return(mockCfg).AnyTimes()
+	mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockCfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{}).AnyTimes()
+	mockServiceProvider.EXPECT().ListServices().Return(services).AnyTimes()
+	mockServiceProvider.EXPECT().GetID().Return("bench").AnyTimes()
+	mockEndpointProvider.EXPECT().GetID().Return("bench").AnyTimes()
+	mockEndpointProvider.EXPECT().GetResolvableEndpointsForService(gomock.Any()).Return(
+		[]endpoint.Endpoint{{IP: net.ParseIP("10.0.0.1"), Port: 8080}}).AnyTimes()
+	mockMeshSpec.EXPECT().ListTrafficSplits(gomock.Any()).Return(nil).AnyTimes()
+	mockKubeController.EXPECT().GetService(gomock.Any()).Return(nil).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace(gomock.Any()).Return(nil).AnyTimes()
+	mockPolicyController.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(nil).AnyTimes()
+
+	mc := &MeshCatalog{
+		kubeController:     mockKubeController,
+		endpointsProviders: []endpoint.Provider{mockEndpointProvider},
+		serviceProviders:   []service.Provider{mockServiceProvider},
+		configurator:       mockCfg,
+		meshSpec:           mockMeshSpec,
+		policyController:   mockPolicyController,
+	}
+	if withCache {
+		mc.trafficPolicyCache = newTrafficPolicyCache()
+	}
+	return mc
+}
+
+// BenchmarkMeshTrafficPolicies measures the computation of the mesh traffic policies of 1000 proxies
+// sharing 50 service identities, as done on a mesh-wide config push.
+func BenchmarkMeshTrafficPolicies(b *testing.B) {
+	const (
+		numProxies    = 1000
+		numIdentities = 50
+		numServices   = 200
+	)
+
+	for _, withCache := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cache=%t", withCache), func(b *testing.B) {
+			mc := newBenchmarkMeshCatalog(b, numServices, withCache)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// Each iteration is a push to every proxy following a change
+				if withCache {
+					mc.trafficPolicyCache.handleEvent(events.PubSubMessage{Kind: announcements.TrafficTargetUpdated})
+				}
+				for p := 0; p < numProxies; p++ {
+					svcIdentity := identity.K8sServiceAccount{
+						Name:      fmt.Sprintf("sa%d", p%numIdentities),
+						Namespace: fmt.Sprintf("ns%d", p%10),
+					}.ToServiceIdentity()
+					proxyServices := []service.MeshService{{
+						Name:       fmt.Sprintf("s%d", p%numIdentities),
+						Namespace:  fmt.Sprintf("ns%d", p%10),
+						Port:       80,
+						TargetPort: 8080,
+						Protocol:   "http",
+					}}
+
+					mc.GetOutboundMeshTrafficPolicy(svcIdentity)
+					mc.GetInboundMeshTrafficPolicy(svcIdentity, proxyServices)
+				}
+			}
+		})
+	}
+}
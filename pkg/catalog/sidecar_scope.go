@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// applySidecarScope returns the given upstream services that are within the SidecarScope policy applicable to
+// the given downstream identity, or all the given upstream services if no SidecarScope policy is applicable.
+func (mc *MeshCatalog) applySidecarScope(downstreamIdentity identity.ServiceIdentity, upstreamServices []service.MeshService) []service.MeshService {
+	scope := mc.policyController.GetSidecarScope(downstreamIdentity.ToK8sServiceAccount())
+	if scope == nil {
+		return upstreamServices
+	}
+
+	var scopedServices []service.MeshService
+	for _, svc := range upstreamServices {
+		if isInSidecarScope(scope, svc) {
+			scopedServices = append(scopedServices, svc)
+		}
+	}
+
+	log.Trace().Msgf("SidecarScope %s/%s limits the upstream services of identity %s to %d of %d services",
+		scope.Namespace, scope.Name, downstreamIdentity, len(scopedServices), len(upstreamServices))
+	return scopedServices
+}
+
+// isInSidecarScope returns whether the given service is within the given SidecarScope policy
+func isInSidecarScope(scope *policyv1alpha1.SidecarScope, svc service.MeshService) bool {
+	for _, upstream := range scope.Spec.Upstreams {
+		if upstream.Namespace != svc.Namespace {
+			continue
+		}
+		if len(upstream.Services) == 0 {
+			return true
+		}
+		for _, name := range upstream.Services {
+			if name == svc.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
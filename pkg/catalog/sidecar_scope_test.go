@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestApplySidecarScope(t *testing.T) {
+	s1 := service.MeshService{Name: "s1", Namespace: "ns1", Port: 80}
+	s1Grpc := service.MeshService{Name: "s1", Namespace: "ns1", Port: 9090}
+	s2 := service.MeshService{Name: "s2", Namespace: "ns1", Port: 80}
+	s3 := service.MeshService{Name: "s3", Namespace: "ns2", Port: 80}
+	s4 := service.MeshService{Name: "s4", Namespace: "ns3", Port: 80}
+	allServices := []service.MeshService{s1, s1Grpc, s2, s3, s4}
+
+	downstreamIdentity := identity.K8sServiceAccount{Name: "sa", Namespace: "ns1"}.ToServiceIdentity()
+
+	testCases := []struct {
+		name     string
+		scope    *policyv1alpha1.SidecarScope
+		expected []service.MeshService
+	}{
+		{
+			name:     "no SidecarScope includes every service",
+			scope:    nil,
+			expected: allServices,
+		},
+		{
+			name: "namespace upstreams include every service in the namespace",
+			scope: &policyv1alpha1.SidecarScope{
+				ObjectMeta: metav1.ObjectMeta{Name: "scope", Namespace: "ns1"},
+				Spec: policyv1alpha1.SidecarScopeSpec{
+					Upstreams: []policyv1alpha1.SidecarScopeUpstreamSpec{{Namespace: "ns1"}, {Namespace: "ns3"}},
+				},
+			},
+			expected: []service.MeshService{s1, s1Grpc, s2, s4},
+		},
+		{
+			name: "service upstreams include every port of the listed services",
+			scope: &policyv1alpha1.SidecarScope{
+				ObjectMeta: metav1.ObjectMeta{Name: "scope", Namespace: "ns1"},
+				Spec: policyv1alpha1.SidecarScopeSpec{
+					Upstreams: []policyv1alpha1.SidecarScopeUpstreamSpec{
+						{Namespace: "ns1", Services: []string{"s1"}},
+						{Namespace: "ns2", Services: []string{"s3"}},
+					},
+				},
+			},
+			expected: []service.MeshService{s1, s1Grpc, s3},
+		},
+		{
+			name: "scope without matching upstreams excludes every service",
+			scope: &policyv1alpha1.SidecarScope{
+				ObjectMeta: metav1.ObjectMeta{Name: "scope", Namespace: "ns1"},
+				Spec: policyv1alpha1.SidecarScopeSpec{
+					Upstreams: []policyv1alpha1.SidecarScopeUpstreamSpec{{Namespace: "ns4"}},
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockPolicyController.EXPECT().GetSidecarScope(downstreamIdentity.ToK8sServiceAccount()).Return(tc.scope).Times(1)
+
+			mc := MeshCatalog{
+				policyController: mockPolicyController,
+			}
+
+			assert.Equal(tc.expected, mc.applySidecarScope(downstreamIdentity, allServices))
+		})
+	}
+}
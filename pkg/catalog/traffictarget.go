@@ -29,7 +29,7 @@ func (mc *MeshCatalog) ListOutboundServiceIdentities(downstream identity.Service
 func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.ServiceIdentity) ([]trafficpolicy.TrafficTargetWithRoutes, error) {
 	var trafficTargets []trafficpolicy.TrafficTargetWithRoutes
 
-	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+	if mc.configurator.ForNamespace(upstream.ToK8sServiceAccount().Namespace).IsPermissiveTrafficPolicyMode() {
 		return nil, nil
 	}
 
@@ -65,6 +65,11 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.Ser
 		}
 	}
 
+	// The OSM ingress gateway is allowed to access the TCP backends of TLSRoutes
+	if trafficTarget := mc.getIngressGatewayTrafficTarget(upstream); trafficTarget != nil {
+		trafficTargets = append(trafficTargets, *trafficTarget)
+	}
+
 	return trafficTargets, nil
 }
 
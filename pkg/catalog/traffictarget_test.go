@@ -845,6 +845,7 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 			// Initialize test objects
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			mockCfg.EXPECT().ForNamespace(gomock.Any()).Return(mockCfg).AnyTimes()
 			meshCatalog := MeshCatalog{
 				meshSpec:     mockMeshSpec,
 				configurator: mockCfg,
@@ -5,9 +5,11 @@
 package catalog
 
 import (
+	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gatewayapi"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -37,6 +39,16 @@ type MeshCatalog struct {
 	// policyController implements the functionality related to the resources part of the policy.openservicemesh.io
 	// API group, such as egress.
 	policyController policy.Controller
+
+	// gatewayAPIController implements the functionality related to the resources part of the gateway.networking.k8s.io
+	// API group. It is nil when the Gateway API is not enabled.
+	gatewayAPIController gatewayapi.Controller
+
+	// trafficPolicyCache memoizes the mesh traffic policies per service identity, nil to disable caching
+	trafficPolicyCache *trafficPolicyCache
+
+	// policyAuditModeCache memoizes the services whose access policies are audited, nil to disable caching
+	policyAuditModeCache *policyAuditModeCache
 }
 
 // MeshCataloger is the mechanism by which the Service Mesh controller discovers all Envoy proxies connected to the catalog.
@@ -69,6 +81,15 @@ type MeshCataloger interface {
 	// GetEgressTrafficPolicy returns the Egress traffic policy associated with the given service identity.
 	GetEgressTrafficPolicy(identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error)
 
+	// GetDNSRecords returns the DNS records answered by the DNS proxy of the sidecars with the given service identity
+	GetDNSRecords(identity.ServiceIdentity) []*trafficpolicy.DNSRecord
+
+	// GetEgressGatewayTrafficPolicy returns the traffic policy of the egress gateway
+	GetEgressGatewayTrafficPolicy() (*trafficpolicy.EgressGatewayTrafficPolicy, error)
+
+	// GetIngressGatewayTrafficPolicy returns the traffic policy of the ingress gateway programmed from Gateway API resources
+	GetIngressGatewayTrafficPolicy() (*trafficpolicy.IngressGatewayTrafficPolicy, error)
+
 	// GetKubeController returns the kube controller instance handling the current cluster
 	GetKubeController() k8s.Controller
 
@@ -77,6 +98,16 @@ type MeshCataloger interface {
 
 	// GetInboundMeshTrafficPolicy returns the inbound mesh traffic policy for the given upstream identity and services
 	GetInboundMeshTrafficPolicy(identity.ServiceIdentity, []service.MeshService) *trafficpolicy.InboundMeshTrafficPolicy
+
+	// IsPolicyAuditModeEnabled returns true if SMI access policies for the given upstream service are audited instead of enforced
+	IsPolicyAuditModeEnabled(service.MeshService) bool
+
+	// GetInboundExternalAuthConfig returns the external authorization configuration for inbound HTTP traffic directed to the given upstream service
+	GetInboundExternalAuthConfig(service.MeshService) *auth.ExtAuthConfig
+
+	// WithConfigurator returns a MeshCataloger computing the traffic policies from the given configuration, such as
+	// the configuration resolved for a proxy's staged rollout cohort. The traffic policy cache is shared.
+	WithConfigurator(configurator.Configurator) MeshCataloger
 }
 
 type trafficDirection string
@@ -39,6 +39,13 @@ func NewManager(
 	return m, nil
 }
 
+// SetStore sets the store persisting the certificates issued, and reloads the valid certificates persisted
+// in it so that they are reused instead of being issued again.
+func (m *manager) SetStore(store Store) {
+	m.store = store
+	LoadFromStore(store, m.ca, &m.cache)
+}
+
 func (m *manager) getFromCache(cn CommonName) *Certificate {
 	certInterface, exists := m.cache.Load(cn)
 	if !exists {
@@ -67,6 +74,9 @@ func (m *manager) IssueCertificate(cn CommonName, validityPeriod time.Duration)
 	}
 
 	m.cache.Store(cn, cert)
+	if m.store != nil {
+		m.store.Save(cert)
+	}
 
 	log.Trace().Msgf("It took %s to issue certificate with SerialNumber=%s", time.Since(start), cert.GetSerialNumber())
 
@@ -77,6 +87,9 @@ func (m *manager) IssueCertificate(cn CommonName, validityPeriod time.Duration)
 func (m *manager) ReleaseCertificate(cn CommonName) {
 	log.Trace().Msgf("Releasing certificate %s", cn)
 	m.cache.Delete(cn)
+	if m.store != nil {
+		m.store.Delete(cn)
+	}
 }
 
 // GetCertificate returns a certificate given its Common Name (CN)
@@ -107,6 +120,9 @@ func (m *manager) RotateCertificate(cn CommonName) (*Certificate, error) {
 	}
 
 	m.cache.Store(cn, newCert)
+	if m.store != nil {
+		m.store.Save(newCert)
+	}
 
 	m.msgBroker.GetCertPubSub().Pub(events.PubSubMessage{
 		Kind:   announcements.CertificateRotated,
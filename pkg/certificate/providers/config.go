@@ -39,7 +39,7 @@ const (
 // NewCertificateProvider returns a new certificate provider and associated config
 func NewCertificateProvider(kubeClient kubernetes.Interface, kubeConfig *rest.Config, cfg configurator.Configurator, providerKind Kind,
 	providerNamespace string, caBundleSecretName string, tresorOptions TresorOptions, vaultOptions VaultOptions,
-	certManagerOptions CertManagerOptions, msgBroker *messaging.Broker) (certificate.Manager, debugger.CertificateManagerDebugger, *Config, error) {
+	certManagerOptions CertManagerOptions, certStore certificate.Store, msgBroker *messaging.Broker) (certificate.Manager, debugger.CertificateManagerDebugger, *Config, error) {
 	config := &Config{
 		kubeClient:         kubeClient,
 		kubeConfig:         kubeConfig,
@@ -52,6 +52,7 @@ func NewCertificateProvider(kubeClient kubernetes.Interface, kubeConfig *rest.Co
 		vaultOptions:       vaultOptions,
 		certManagerOptions: certManagerOptions,
 
+		certStore: certStore,
 		msgBroker: msgBroker,
 	}
 
@@ -249,6 +250,10 @@ func (c *Config) getTresorOSMCertificateManager() (certificate.Manager, debugger
 		return nil, nil, errors.Errorf("Failed to instantiate Tresor as a Certificate Manager")
 	}
 
+	if c.certStore != nil {
+		certManager.SetStore(c.certStore)
+	}
+
 	return certManager, certManager, nil
 }
 
@@ -308,6 +313,10 @@ func (c *Config) getHashiVaultOSMCertificateManager(options VaultOptions) (certi
 		return nil, nil, errors.Errorf("Error instantiating Hashicorp Vault as a Certificate Manager: %+v", err)
 	}
 
+	if c.certStore != nil {
+		log.Warn().Msgf("Persisting certificates is not supported with cert issuer %s, certificates will be issued again on restart", c.providerKind)
+	}
+
 	return vaultCertManager, vaultCertManager, nil
 }
 
@@ -353,6 +362,10 @@ func (c *Config) getCertManagerOSMCertificateManager(options CertManagerOptions)
 		return nil, nil, errors.Errorf("error instantiating osm certificate.Manager for Jetstack cert-manager : %+v", err)
 	}
 
+	if c.certStore != nil {
+		certManager.SetStore(c.certStore)
+	}
+
 	// TODO(#4533): push this into the certificate.manager object.
 	rotor.New(certManager).Start(checkCertificateExpirationInterval)
 
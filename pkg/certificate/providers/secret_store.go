@@ -0,0 +1,299 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+const (
+	// persistedCertSecretPrefix is the prefix of the names of the secrets persisting issued certificates
+	persistedCertSecretPrefix = "osm-issued-cert-"
+
+	// persistedCertCommonNameAnnotation is the annotation holding the Common Name of a persisted certificate
+	persistedCertCommonNameAnnotation = "openservicemesh.io/common-name"
+
+	// persistRetryInterval is the interval at which the changes that failed to be persisted are retried
+	persistRetryInterval = 10 * time.Second
+)
+
+// SecretStore is a certificate.Store persisting each issued certificate in a Kubernetes secret.
+// The secrets are stored in the control plane namespace, which already holds the secret with the private key
+// of the CA: access to that namespace is as sensitive as access to the CA, which can sign any certificate, so
+// the persisted private keys add no exposure beyond it. Workload namespaces never hold these secrets.
+type SecretStore struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	informer   cache.SharedIndexInformer
+
+	mu sync.Mutex
+	// leading is whether the store is run by the leader, the changes are only persisted while leading
+	leading bool
+	// pending holds the latest change to persist for each Common Name, a nil certificate removes the secret.
+	pending     map[certificate.CommonName]*certificate.Certificate
+	pendingChan chan struct{}
+}
+
+// NewSecretStore returns a new SecretStore persisting certificates in secrets in the given namespace.
+// It watches the secrets until the stop channel is closed, so that the certificates persisted by other
+// replicas are reloaded.
+func NewSecretStore(kubeClient kubernetes.Interface, namespace string, stop <-chan struct{}) *SecretStore {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opt *metav1.ListOptions) {
+			opt.LabelSelector = fmt.Sprintf("%s=true", constants.OSMIssuedCertificateLabelKey)
+		}))
+
+	s := &SecretStore{
+		kubeClient:  kubeClient,
+		namespace:   namespace,
+		informer:    informerFactory.Core().V1().Secrets().Informer(),
+		pending:     make(map[certificate.CommonName]*certificate.Certificate),
+		pendingChan: make(chan struct{}, 1),
+	}
+
+	go s.informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, s.informer.HasSynced) {
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrLoadingPersistedCerts)).
+			Msg("Failed initial cache sync for persisted certificate secrets informer")
+	}
+
+	return s
+}
+
+// List returns all the certificates persisted in secrets. Secrets with invalid data are skipped.
+func (s *SecretStore) List() ([]*certificate.Certificate, error) {
+	var certs []*certificate.Certificate
+	for _, obj := range s.informer.GetStore().List() {
+		if cert := s.certificateFromObj(obj); cert != nil {
+			certs = append(certs, cert)
+		}
+	}
+	return certs, nil
+}
+
+// OnSave calls the given handler with each certificate persisted in a secret, including the ones
+// persisted by other replicas after this call.
+func (s *SecretStore) OnSave(handler func(*certificate.Certificate)) {
+	s.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if cert := s.certificateFromObj(obj); cert != nil {
+				handler(cert)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if cert := s.certificateFromObj(newObj); cert != nil {
+				handler(cert)
+			}
+		},
+	})
+}
+
+// Save persists the given certificate in a secret, replacing any certificate persisted with the same Common Name.
+// The secret is written asynchronously, and only if the store is run by this replica.
+func (s *SecretStore) Save(cert *certificate.Certificate) {
+	s.enqueue(cert.GetCommonName(), cert)
+}
+
+// Delete removes the secret persisting the certificate with the given Common Name, if any.
+// The secret is removed asynchronously, and only if the store is run by this replica.
+func (s *SecretStore) Delete(cn certificate.CommonName) {
+	s.enqueue(cn, nil)
+}
+
+// Run writes the changes to the secrets until the stop channel is closed, retrying the failed ones periodically.
+// It must only be run by the leader, so that the replicas do not overwrite each other's secrets; the changes made
+// while the store is not run are dropped, as they may be stale by the time this replica becomes the leader, and
+// only the latest change for a Common Name is written.
+func (s *SecretStore) Run(stop <-chan struct{}) {
+	s.setLeading(true)
+	defer s.setLeading(false)
+
+	ticker := time.NewTicker(persistRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		s.flush()
+		select {
+		case <-s.pendingChan:
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// setLeading sets whether the store is run by the leader, dropping the pending changes
+func (s *SecretStore) setLeading(leading bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leading = leading
+	s.pending = make(map[certificate.CommonName]*certificate.Certificate)
+}
+
+func (s *SecretStore) enqueue(cn certificate.CommonName, cert *certificate.Certificate) {
+	s.mu.Lock()
+	if !s.leading {
+		s.mu.Unlock()
+		return
+	}
+	s.pending[cn] = cert
+	s.mu.Unlock()
+
+	select {
+	case s.pendingChan <- struct{}{}:
+	default:
+	}
+}
+
+// flush writes the pending changes, keeping the failed ones pending unless they were superseded meanwhile
+func (s *SecretStore) flush() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[certificate.CommonName]*certificate.Certificate)
+	s.mu.Unlock()
+
+	for cn, cert := range pending {
+		var err error
+		if cert == nil {
+			err = s.deleteSecret(cn)
+		} else {
+			err = s.saveSecret(cert)
+		}
+		if err == nil {
+			continue
+		}
+
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrPersistingCert)).
+			Msgf("Error persisting changes to certificate %s, retrying", cn)
+		s.mu.Lock()
+		if _, superseded := s.pending[cn]; s.leading && !superseded {
+			s.pending[cn] = cert
+		}
+		s.mu.Unlock()
+	}
+}
+
+// saveSecret writes the secret persisting the given certificate, unless it already holds the certificate
+func (s *SecretStore) saveSecret(cert *certificate.Certificate) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      persistedCertSecretName(cert.GetCommonName()),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				constants.OSMAppNameLabelKey:           constants.OSMAppNameLabelValue,
+				constants.OSMAppVersionLabelKey:        version.Version,
+				constants.OSMIssuedCertificateLabelKey: "true",
+			},
+			Annotations: map[string]string{
+				persistedCertCommonNameAnnotation: cert.GetCommonName().String(),
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:                     cert.GetCertificateChain(),
+			corev1.TLSPrivateKeyKey:               cert.GetPrivateKey(),
+			constants.KubernetesOpaqueSecretCAKey: cert.GetIssuingCA(),
+		},
+	}
+
+	if existing, exists := s.getCachedSecret(secret.Name); exists &&
+		equality.Semantic.DeepEqual(existing.Data, secret.Data) &&
+		existing.Annotations[persistedCertCommonNameAnnotation] == cert.GetCommonName().String() {
+		return nil
+	}
+
+	_, err := s.kubeClient.CoreV1().Secrets(s.namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = s.kubeClient.CoreV1().Secrets(s.namespace).Update(context.Background(), secret, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// deleteSecret removes the secret persisting the certificate with the given Common Name, if it exists
+func (s *SecretStore) deleteSecret(cn certificate.CommonName) error {
+	name := persistedCertSecretName(cn)
+	if _, exists := s.getCachedSecret(name); !exists {
+		return nil
+	}
+
+	err := s.kubeClient.CoreV1().Secrets(s.namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *SecretStore) getCachedSecret(name string) (*corev1.Secret, bool) {
+	obj, exists, err := s.informer.GetStore().GetByKey(fmt.Sprintf("%s/%s", s.namespace, name))
+	if err != nil || !exists {
+		return nil, false
+	}
+	secret, ok := obj.(*corev1.Secret)
+	return secret, ok
+}
+
+func (s *SecretStore) certificateFromObj(obj interface{}) *certificate.Certificate {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+	cert, err := certificateFromSecret(secret)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Skipping invalid certificate secret %s/%s", secret.Namespace, secret.Name)
+		return nil
+	}
+	return cert
+}
+
+// persistedCertSecretName returns the name of the secret persisting the certificate with the given Common Name.
+// The Common Name is hashed as it may be longer than the names allowed for secrets.
+func persistedCertSecretName(cn certificate.CommonName) string {
+	return fmt.Sprintf("%s%x", persistedCertSecretPrefix, sha256.Sum256([]byte(cn)))
+}
+
+// certificateFromSecret returns the certificate persisted in the given secret
+func certificateFromSecret(secret *corev1.Secret) (*certificate.Certificate, error) {
+	cn, ok := secret.Annotations[persistedCertCommonNameAnnotation]
+	if !ok || cn == "" {
+		return nil, errInvalidCertSecret
+	}
+	pemCert, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, errInvalidCertSecret
+	}
+	pemKey, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return nil, errInvalidCertSecret
+	}
+
+	x509Cert, err := certificate.DecodePEMCertificate(pemCert)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificate.Certificate{
+		CommonName:   certificate.CommonName(cn),
+		SerialNumber: certificate.SerialNumber(x509Cert.SerialNumber.String()),
+		CertChain:    pemCert,
+		PrivateKey:   pemKey,
+		IssuingCA:    pem.RootCertificate(secret.Data[constants.KubernetesOpaqueSecretCAKey]),
+		Expiration:   x509Cert.NotAfter,
+	}, nil
+}
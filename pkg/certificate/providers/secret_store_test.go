@@ -0,0 +1,191 @@
+package providers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestSecretStore(t *testing.T) {
+	assert := tassert.New(t)
+	kubeClient := fake.NewSimpleClientset()
+
+	var secretWrites int32
+	kubeClient.PrependReactor("*", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch action.GetVerb() {
+		case "create", "update", "delete":
+			atomic.AddInt32(&secretWrites, 1)
+		}
+		return false, nil, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	store := NewSecretStore(kubeClient, "osm-system", stop)
+
+	ca, err := tresor.NewCA("osm-ca", time.Hour, "US", "CA", "Open Service Mesh")
+	assert.Nil(err)
+	certManager, err := tresor.NewCertManager(ca, "Open Service Mesh", nil, time.Hour, 2048, nil)
+	assert.Nil(err)
+
+	cn := certificate.CommonName("bookbuyer.bookbuyer.cluster.local")
+	cert, err := certManager.IssueCertificate(cn, time.Hour)
+	assert.Nil(err)
+
+	// The certificate is not persisted when the store is not run by the leader, even once it becomes the leader
+	store.Save(cert)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(int32(0), atomic.LoadInt32(&secretWrites))
+
+	leaderStop := make(chan struct{})
+	defer close(leaderStop)
+	go store.Run(leaderStop)
+	waitLeading(t, store)
+	assert.Equal(int32(0), atomic.LoadInt32(&secretWrites))
+
+	// The certificate is persisted once the store is run by the leader
+	store.Save(cert)
+
+	listCerts := func() []*certificate.Certificate {
+		certs, err := store.List()
+		assert.Nil(err)
+		return certs
+	}
+	assert.Eventually(func() bool {
+		return len(listCerts()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	certs := listCerts()
+	assert.Equal(cert.GetCommonName(), certs[0].GetCommonName())
+	assert.Equal(cert.GetSerialNumber(), certs[0].GetSerialNumber())
+	assert.Equal(cert.GetCertificateChain(), certs[0].GetCertificateChain())
+	assert.Equal(cert.GetPrivateKey(), certs[0].GetPrivateKey())
+	assert.Equal(cert.GetIssuingCA(), certs[0].GetIssuingCA())
+	assert.WithinDuration(cert.GetExpiration(), certs[0].GetExpiration(), time.Second)
+
+	// Saving the same certificate again does not write the secret
+	store.Save(cert)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(int32(1), atomic.LoadInt32(&secretWrites))
+
+	// Saving a certificate with the same CN replaces the persisted one
+	certManager.ReleaseCertificate(cn)
+	newCert, err := certManager.IssueCertificate(cn, time.Hour)
+	assert.Nil(err)
+	assert.NotEqual(cert.GetSerialNumber(), newCert.GetSerialNumber())
+	store.Save(newCert)
+	assert.Eventually(func() bool {
+		certs := listCerts()
+		return len(certs) == 1 && certs[0].GetSerialNumber() == newCert.GetSerialNumber()
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Secrets with invalid data are skipped
+	_, err = kubeClient.CoreV1().Secrets("osm-system").Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "invalid",
+			Namespace: "osm-system",
+			Labels:    map[string]string{constants.OSMIssuedCertificateLabelKey: "true"},
+		},
+	}, metav1.CreateOptions{})
+	assert.Nil(err)
+	assert.Eventually(func() bool {
+		_, exists, _ := store.informer.GetStore().GetByKey("osm-system/invalid")
+		return exists
+	}, 2*time.Second, 10*time.Millisecond)
+	certs = listCerts()
+	assert.Len(certs, 1)
+	assert.Equal(newCert.GetSerialNumber(), certs[0].GetSerialNumber())
+
+	// Deleting the certificate removes its secret
+	store.Delete(cn)
+	assert.Eventually(func() bool {
+		_, err := kubeClient.CoreV1().Secrets("osm-system").Get(context.Background(), persistedCertSecretName(cn), metav1.GetOptions{})
+		return err != nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestPersistedCertificatesAreReused(t *testing.T) {
+	assert := tassert.New(t)
+	kubeClient := fake.NewSimpleClientset()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	ca, err := tresor.NewCA("osm-ca", time.Hour, "US", "CA", "Open Service Mesh")
+	assert.Nil(err)
+
+	cn := certificate.CommonName("bookbuyer.bookbuyer.cluster.local")
+	releasedCN := certificate.CommonName("bookstore.bookstore.cluster.local")
+
+	// A follower replica reuses the certificates persisted by the leader
+	followerCertManager, err := tresor.NewCertManager(ca, "Open Service Mesh", nil, time.Hour, 2048, nil)
+	assert.Nil(err)
+	followerCertManager.SetStore(NewSecretStore(kubeClient, "osm-system", stop))
+
+	store := NewSecretStore(kubeClient, "osm-system", stop)
+	go store.Run(stop)
+	waitLeading(t, store)
+	certManager, err := tresor.NewCertManager(ca, "Open Service Mesh", nil, time.Hour, 2048, nil)
+	assert.Nil(err)
+	certManager.SetStore(store)
+	cert, err := certManager.IssueCertificate(cn, time.Hour)
+	assert.Nil(err)
+	_, err = certManager.IssueCertificate(releasedCN, time.Hour)
+	assert.Nil(err)
+	certManager.ReleaseCertificate(releasedCN)
+
+	assert.Eventually(func() bool {
+		followerCert, err := followerCertManager.GetCertificate(cn)
+		return err == nil && followerCert.GetSerialNumber() == cert.GetSerialNumber()
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Eventually(func() bool {
+		certs, err := store.List()
+		return err == nil && len(certs) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// A restarted certificate manager reuses the persisted certificates
+	restartedCertManager, err := tresor.NewCertManager(ca, "Open Service Mesh", nil, time.Hour, 2048, nil)
+	assert.Nil(err)
+	restartedCertManager.SetStore(NewSecretStore(kubeClient, "osm-system", stop))
+
+	reloadedCert, err := restartedCertManager.IssueCertificate(cn, time.Hour)
+	assert.Nil(err)
+	assert.Equal(cert.GetSerialNumber(), reloadedCert.GetSerialNumber())
+	assert.Equal(cert.GetPrivateKey(), reloadedCert.GetPrivateKey())
+
+	_, err = restartedCertManager.GetCertificate(releasedCN)
+	assert.NotNil(err)
+
+	// Certificates issued by a different CA are not reused
+	newCA, err := tresor.NewCA("osm-ca", time.Hour, "US", "CA", "Open Service Mesh")
+	assert.Nil(err)
+	newCACertManager, err := tresor.NewCertManager(newCA, "Open Service Mesh", nil, time.Hour, 2048, nil)
+	assert.Nil(err)
+	newCACertManager.SetStore(NewSecretStore(kubeClient, "osm-system", stop))
+
+	_, err = newCACertManager.GetCertificate(cn)
+	assert.NotNil(err)
+	newCert, err := newCACertManager.IssueCertificate(cn, time.Hour)
+	assert.Nil(err)
+	assert.NotEqual(cert.GetSerialNumber(), newCert.GetSerialNumber())
+}
+
+// waitLeading waits for the given store to be run by the leader
+func waitLeading(t *testing.T, store *SecretStore) {
+	tassert.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return store.leading
+	}, 2*time.Second, 10*time.Millisecond)
+}
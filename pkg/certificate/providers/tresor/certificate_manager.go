@@ -148,6 +148,13 @@ func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod time.Dura
 	return cert, nil
 }
 
+// SetStore sets the store persisting the certificates issued, and reloads the valid certificates persisted
+// in it so that they are reused instead of being issued again.
+func (cm *CertManager) SetStore(store certificate.Store) {
+	cm.store = store
+	certificate.LoadFromStore(store, cm.ca, &cm.cache)
+}
+
 func (cm *CertManager) deleteFromCache(cn certificate.CommonName) {
 	cm.cache.Delete(cn)
 }
@@ -179,6 +186,9 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPerio
 	}
 
 	cm.cache.Store(cn, cert)
+	if cm.store != nil {
+		cm.store.Save(cert)
+	}
 
 	log.Trace().Msgf("It took %+v to issue certificate with SerialNumber=%s", time.Since(start), cert.GetSerialNumber())
 
@@ -189,6 +199,9 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPerio
 func (cm *CertManager) ReleaseCertificate(cn certificate.CommonName) {
 	log.Trace().Msgf("Releasing certificate %s", cn)
 	cm.deleteFromCache(cn)
+	if cm.store != nil {
+		cm.store.Delete(cn)
+	}
 }
 
 // GetCertificate returns a certificate given its Common Name (CN)
@@ -226,6 +239,9 @@ func (cm *CertManager) RotateCertificate(cn certificate.CommonName) (*certificat
 	}
 
 	cm.cache.Store(cn, newCert)
+	if cm.store != nil {
+		cm.store.Save(newCert)
+	}
 
 	cm.msgBroker.GetCertPubSub().Pub(events.PubSubMessage{
 		Kind:   announcements.CertificateRotated,
@@ -34,6 +34,9 @@ type CertManager struct {
 	// Types: map[certificate.CommonName]*certificate.Certificate
 	cache sync.Map
 
+	// Store persisting the certificates issued, nil if the certificates are not persisted
+	store certificate.Store
+
 	certificatesOrganization string
 
 	cfg configurator.Configurator
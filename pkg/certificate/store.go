@@ -0,0 +1,49 @@
+package certificate
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/openservicemesh/osm/pkg/errcode"
+)
+
+// LoadFromStore loads into the given cache the certificates persisted in the given store, and keeps loading the
+// ones persisted later on, e.g. by another replica, so that they are reused instead of being issued again.
+// Only the certificates issued by the given CA that are not due for rotation are loaded, and a certificate
+// already cached with the same Common Name is kept. Certificates issued by a different CA, e.g. before the CA
+// was rotated, are ignored.
+func LoadFromStore(store Store, ca *Certificate, cache *sync.Map) {
+	load := func(cert *Certificate) bool {
+		if !bytes.Equal(cert.GetIssuingCA(), ca.GetIssuingCA()) {
+			log.Debug().Msgf("Ignoring persisted certificate with SerialNumber=%s issued by a different CA", cert.GetSerialNumber())
+			return false
+		}
+		if cert.ShouldRotate() {
+			log.Debug().Msgf("Ignoring persisted certificate with SerialNumber=%s due for rotation", cert.GetSerialNumber())
+			return false
+		}
+		_, cached := cache.LoadOrStore(cert.GetCommonName(), cert)
+		return !cached
+	}
+
+	persisted, err := store.List()
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrLoadingPersistedCerts)).
+			Msg("Error listing persisted certificates")
+	}
+	loaded := 0
+	for _, cert := range persisted {
+		if load(cert) {
+			loaded++
+		}
+	}
+	log.Info().Msgf("Loaded %d of %d persisted certificates", loaded, len(persisted))
+
+	store.OnSave(func(cert *Certificate) {
+		if load(cert) {
+			log.Debug().Msgf("Loaded persisted certificate with SerialNumber=%s", cert.GetSerialNumber())
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package certificate
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+)
+
+type fakeStore struct {
+	certs    map[CommonName]*Certificate
+	listErr  error
+	handlers []func(*Certificate)
+}
+
+func (s *fakeStore) List() ([]*Certificate, error) {
+	var certs []*Certificate
+	for _, cert := range s.certs {
+		certs = append(certs, cert)
+	}
+	return certs, s.listErr
+}
+
+func (s *fakeStore) OnSave(handler func(*Certificate)) {
+	s.handlers = append(s.handlers, handler)
+}
+
+func (s *fakeStore) Save(cert *Certificate) {
+	s.certs[cert.GetCommonName()] = cert
+	for _, handler := range s.handlers {
+		handler(cert)
+	}
+}
+
+func (s *fakeStore) Delete(cn CommonName) {
+	delete(s.certs, cn)
+}
+
+func TestLoadFromStore(t *testing.T) {
+	ca := &Certificate{
+		CommonName: "Test CA",
+		IssuingCA:  pem.RootCertificate("ca"),
+		Expiration: time.Now().Add(24 * time.Hour),
+	}
+	cached := &Certificate{
+		CommonName: "foo",
+		IssuingCA:  pem.RootCertificate("ca"),
+		Expiration: time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name         string
+		cert         *Certificate
+		cached       *Certificate
+		listErr      error
+		expectLoaded bool
+	}{
+		{
+			name: "valid certificate is loaded",
+			cert: &Certificate{
+				CommonName: "foo",
+				IssuingCA:  pem.RootCertificate("ca"),
+				Expiration: time.Now().Add(time.Hour),
+			},
+			expectLoaded: true,
+		},
+		{
+			name: "certificate issued by a different CA is ignored",
+			cert: &Certificate{
+				CommonName: "foo",
+				IssuingCA:  pem.RootCertificate("other-ca"),
+				Expiration: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "certificate due for rotation is ignored",
+			cert: &Certificate{
+				CommonName: "foo",
+				IssuingCA:  pem.RootCertificate("ca"),
+				Expiration: time.Now().Add(time.Second),
+			},
+		},
+		{
+			name: "cached certificate is kept",
+			cert: &Certificate{
+				CommonName: "foo",
+				IssuingCA:  pem.RootCertificate("ca"),
+				Expiration: time.Now().Add(time.Hour),
+			},
+			cached: cached,
+		},
+		{
+			name:    "error listing certificates",
+			listErr: errors.New("list error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			// Certificates persisted before and after loading the store are loaded the same way
+			for _, persistedLater := range []bool{false, true} {
+				var cache sync.Map
+				if tc.cached != nil {
+					cache.Store(tc.cached.GetCommonName(), tc.cached)
+				}
+
+				store := &fakeStore{certs: map[CommonName]*Certificate{}, listErr: tc.listErr}
+				if tc.cert != nil && !persistedLater {
+					store.certs[tc.cert.GetCommonName()] = tc.cert
+				}
+
+				LoadFromStore(store, ca, &cache)
+				if tc.cert != nil && persistedLater {
+					store.Save(tc.cert)
+				}
+
+				certInterface, ok := cache.Load(CommonName("foo"))
+				switch {
+				case tc.expectLoaded:
+					assert.Same(tc.cert, certInterface)
+				case tc.cached != nil:
+					assert.Same(tc.cached, certInterface)
+				default:
+					assert.False(ok)
+				}
+			}
+		})
+	}
+}
+
+func TestManagerStore(t *testing.T) {
+	assert := tassert.New(t)
+	ca := &Certificate{
+		CommonName: "Test CA",
+		IssuingCA:  pem.RootCertificate("ca"),
+		Expiration: time.Now().Add(24 * time.Hour),
+	}
+	persisted := &Certificate{
+		CommonName: "persisted",
+		IssuingCA:  pem.RootCertificate("ca"),
+		Expiration: time.Now().Add(time.Hour),
+	}
+	store := &fakeStore{certs: map[CommonName]*Certificate{persisted.GetCommonName(): persisted}}
+
+	m, err := NewManager(ca, &fakeIssuer{}, time.Hour, nil)
+	assert.Nil(err)
+	m.SetStore(store)
+
+	// The persisted certificate is reused instead of being issued again
+	cert, err := m.IssueCertificate(persisted.GetCommonName(), time.Hour)
+	assert.Nil(err)
+	assert.Same(persisted, cert)
+
+	// Newly issued certificates are persisted
+	cert, err = m.IssueCertificate("issued", time.Hour)
+	assert.Nil(err)
+	assert.Same(cert, store.certs["issued"])
+
+	// Released certificates are removed from the store
+	m.ReleaseCertificate("issued")
+	assert.NotContains(store.certs, CommonName("issued"))
+
+	// Certificates persisted later on, e.g. by another replica, are reused
+	persistedLater := &Certificate{
+		CommonName: "persisted-later",
+		IssuingCA:  pem.RootCertificate("ca"),
+		Expiration: time.Now().Add(time.Hour),
+	}
+	store.Save(persistedLater)
+	cert, err = m.IssueCertificate(persistedLater.GetCommonName(), time.Hour)
+	assert.Nil(err)
+	assert.Same(persistedLater, cert)
+}
@@ -77,6 +77,22 @@ type Manager interface {
 	ReleaseCertificate(CommonName)
 }
 
+// Store is the interface declaring the methods for persisting issued certificates, so that they can be reused
+// across restarts of the certificate manager and across replicas instead of being issued again.
+type Store interface {
+	// List returns all the persisted certificates.
+	List() ([]*Certificate, error)
+
+	// OnSave calls the given handler with each certificate persisted, including the ones persisted later on.
+	OnSave(func(*Certificate))
+
+	// Save persists the given certificate asynchronously, replacing any certificate persisted with the same Common Name (CN).
+	Save(*Certificate)
+
+	// Delete asynchronously removes the certificate persisted with the given Common Name (CN).
+	Delete(CommonName)
+}
+
 type client interface {
 	// IssueCertificate issues a new certificate.
 	IssueCertificate(CommonName, time.Duration) (*Certificate, error)
@@ -94,6 +110,9 @@ type manager struct {
 	// Types: map[certificate.CommonName]*certificate.Certificate
 	cache sync.Map
 
+	// Store persisting the certificates issued, nil if the certificates are not persisted
+	store Store
+
 	serviceCertValidityDuration time.Duration
 	msgBroker                   *messaging.Broker
 }
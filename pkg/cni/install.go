@@ -0,0 +1,212 @@
+package cni
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// Installer installs the osm-cni plugin on the node it runs on
+type Installer struct {
+	// CNIBinDir is the CNI binary directory of the node, as mounted in the installer's container
+	CNIBinDir string
+
+	// CNIConfDir is the CNI network configuration directory of the node, as mounted in the installer's container
+	CNIConfDir string
+
+	// HostCNIConfDir is the CNI network configuration directory on the node, used by the plugin to locate its kubeconfig file
+	HostCNIConfDir string
+
+	// OSMNamespace is the namespace of the OSM control plane
+	OSMNamespace string
+
+	// LogLevel is the log level of the plugin
+	LogLevel string
+}
+
+// Install installs the osm-cni plugin binary and its kubeconfig file on the node, and chains the plugin
+// after the primary network plugin in the network configuration list of the node
+func (i *Installer) Install(restConfig *rest.Config) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "Error locating the osm-cni binary")
+	}
+	if err := copyFile(binary, filepath.Join(i.CNIBinDir, PluginName), 0755); err != nil {
+		return errors.Wrap(err, "Error installing the osm-cni binary")
+	}
+
+	if err := writeKubeconfig(restConfig, filepath.Join(i.CNIConfDir, KubeconfigFileName)); err != nil {
+		return errors.Wrap(err, "Error writing the osm-cni kubeconfig file")
+	}
+
+	confListPath, err := getNetworkConfListPath(i.CNIConfDir)
+	if err != nil {
+		return err
+	}
+
+	return updateNetworkConfList(confListPath, func(plugins []interface{}) []interface{} {
+		return append(removePlugin(plugins), map[string]interface{}{
+			"type":         PluginName,
+			"kubeconfig":   filepath.Join(i.HostCNIConfDir, KubeconfigFileName),
+			"osmNamespace": i.OSMNamespace,
+			"logLevel":     i.LogLevel,
+		})
+	})
+}
+
+// Uninstall removes the osm-cni plugin from the network configuration list of the node, and removes its binary and kubeconfig file
+func (i *Installer) Uninstall() error {
+	confListPath, err := getNetworkConfListPath(i.CNIConfDir)
+	if err != nil {
+		return err
+	}
+	if err := updateNetworkConfList(confListPath, removePlugin); err != nil {
+		return err
+	}
+
+	for _, path := range []string{filepath.Join(i.CNIBinDir, PluginName), filepath.Join(i.CNIConfDir, KubeconfigFileName)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "Error removing %s", path)
+		}
+	}
+	return nil
+}
+
+// getNetworkConfListPath returns the path to the network configuration list used by the container runtime,
+// which is the first one in lexicographic order in the given directory
+func getNetworkConfListPath(confDir string) (string, error) {
+	confLists, err := filepath.Glob(filepath.Join(confDir, "*.conflist"))
+	if err != nil {
+		return "", errors.Wrapf(err, "Error listing network configuration lists in %s", confDir)
+	}
+	if len(confLists) == 0 {
+		return "", errors.Errorf("No network configuration list found in %s, osm-cni must be chained after the primary network plugin", confDir)
+	}
+	sort.Strings(confLists)
+	return confLists[0], nil
+}
+
+// updateNetworkConfList updates the list of plugins of the given network configuration list using the given function
+func updateNetworkConfList(path string, update func(plugins []interface{}) []interface{}) error {
+	content, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "Error reading network configuration list %s", path)
+	}
+
+	var confList map[string]interface{}
+	if err := json.Unmarshal(content, &confList); err != nil {
+		return errors.Wrapf(err, "Error parsing network configuration list %s", path)
+	}
+	plugins, ok := confList["plugins"].([]interface{})
+	if !ok {
+		return errors.Errorf("Network configuration list %s has no plugins", path)
+	}
+	confList["plugins"] = update(plugins)
+
+	content, err = json.MarshalIndent(confList, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "Error marshaling network configuration list %s", path)
+	}
+
+	// The container runtime watches the directory, so the file is replaced atomically
+	return writeFileAtomically(path, content, 0644)
+}
+
+// removePlugin returns the given list of plugins without the osm-cni plugin
+func removePlugin(plugins []interface{}) []interface{} {
+	var remaining []interface{}
+	for _, plugin := range plugins {
+		if conf, ok := plugin.(map[string]interface{}); ok && conf["type"] == PluginName {
+			continue
+		}
+		remaining = append(remaining, plugin)
+	}
+	return remaining
+}
+
+// writeKubeconfig writes a kubeconfig file with the credentials of the given config to the given path
+func writeKubeconfig(restConfig *rest.Config, path string) error {
+	caData := restConfig.CAData
+	if len(caData) == 0 && restConfig.CAFile != "" {
+		var err error
+		if caData, err = os.ReadFile(restConfig.CAFile); err != nil {
+			return err
+		}
+	}
+	token := restConfig.BearerToken
+	if token == "" && restConfig.BearerTokenFile != "" {
+		tokenData, err := os.ReadFile(restConfig.BearerTokenFile)
+		if err != nil {
+			return err
+		}
+		token = string(tokenData)
+	}
+
+	kubeconfig := clientcmdv1.Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name: PluginName,
+			Cluster: clientcmdv1.Cluster{
+				Server:                   restConfig.Host,
+				CertificateAuthorityData: caData,
+			},
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{
+			Name: PluginName,
+			AuthInfo: clientcmdv1.AuthInfo{
+				Token: token,
+			},
+		}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name: PluginName,
+			Context: clientcmdv1.Context{
+				Cluster:  PluginName,
+				AuthInfo: PluginName,
+			},
+		}},
+		CurrentContext: PluginName,
+	}
+
+	content, err := yaml.Marshal(kubeconfig)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(path, content, 0600)
+}
+
+// copyFile copies the file at the given source path to the given destination path
+func copyFile(src string, dst string, perm os.FileMode) error {
+	content, err := os.ReadFile(src) // #nosec G304
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(dst, content, perm)
+}
+
+// writeFileAtomically writes the given content to the given path by renaming a temporary file
+func writeFileAtomically(path string, content []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint: errcheck
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close() //nolint: errcheck,gosec
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
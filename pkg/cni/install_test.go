@@ -0,0 +1,106 @@
+package cni
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestInstallUninstall(t *testing.T) {
+	assert := tassert.New(t)
+
+	binDir := t.TempDir()
+	confDir := t.TempDir()
+
+	confList := `{
+  "cniVersion": "0.4.0",
+  "name": "k8s-pod-network",
+  "plugins": [
+    {
+      "type": "ptp"
+    },
+    {
+      "type": "portmap"
+    }
+  ]
+}`
+	confListPath := filepath.Join(confDir, "10-ptp.conflist")
+	assert.Nil(os.WriteFile(confListPath, []byte(confList), 0600))
+	assert.Nil(os.WriteFile(filepath.Join(confDir, "99-other.conflist"), []byte(confList), 0600))
+
+	installer := &Installer{
+		CNIBinDir:      binDir,
+		CNIConfDir:     confDir,
+		HostCNIConfDir: "/etc/cni/net.d",
+		OSMNamespace:   "osm-system",
+		LogLevel:       "debug",
+	}
+	restConfig := &rest.Config{
+		Host:        "https://10.0.0.1:443",
+		BearerToken: "token",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: []byte("ca"),
+		},
+	}
+
+	// Installing twice chains the plugin only once
+	assert.Nil(installer.Install(restConfig))
+	assert.Nil(installer.Install(restConfig))
+
+	assert.FileExists(filepath.Join(binDir, PluginName))
+
+	kubeconfig, err := clientcmd.LoadFromFile(filepath.Join(confDir, KubeconfigFileName))
+	assert.Nil(err)
+	assert.Equal("https://10.0.0.1:443", kubeconfig.Clusters[PluginName].Server)
+	assert.Equal([]byte("ca"), kubeconfig.Clusters[PluginName].CertificateAuthorityData)
+	assert.Equal("token", kubeconfig.AuthInfos[PluginName].Token)
+
+	content, err := os.ReadFile(confListPath)
+	assert.Nil(err)
+	assert.JSONEq(`{
+  "cniVersion": "0.4.0",
+  "name": "k8s-pod-network",
+  "plugins": [
+    {
+      "type": "ptp"
+    },
+    {
+      "type": "portmap"
+    },
+    {
+      "type": "osm-cni",
+      "kubeconfig": "/etc/cni/net.d/osm-cni.kubeconfig",
+      "osmNamespace": "osm-system",
+      "logLevel": "debug"
+    }
+  ]
+}`, string(content))
+
+	// Only the first network configuration list is used by the container runtime
+	content, err = os.ReadFile(filepath.Join(confDir, "99-other.conflist"))
+	assert.Nil(err)
+	assert.Equal(confList, string(content))
+
+	assert.Nil(installer.Uninstall())
+
+	content, err = os.ReadFile(confListPath)
+	assert.Nil(err)
+	assert.JSONEq(confList, string(content))
+	assert.NoFileExists(filepath.Join(binDir, PluginName))
+	assert.NoFileExists(filepath.Join(confDir, KubeconfigFileName))
+}
+
+func TestInstallWithoutNetworkConfList(t *testing.T) {
+	assert := tassert.New(t)
+
+	installer := &Installer{
+		CNIBinDir:  t.TempDir(),
+		CNIConfDir: t.TempDir(),
+	}
+
+	assert.NotNil(installer.Install(&rest.Config{Host: "https://10.0.0.1:443"}))
+}
@@ -0,0 +1,207 @@
+package cni
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	"github.com/openservicemesh/osm/pkg/injector"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+// Plugin is the osm-cni plugin
+type Plugin struct {
+	kubeClient   kubernetes.Interface
+	configClient configClientset.Interface
+	osmNamespace string
+
+	// applyRules applies the given iptables rules in the given network namespace
+	applyRules func(netns string, rules string) error
+}
+
+// NewPlugin returns a new osm-cni plugin for the given network configuration
+func NewPlugin(conf PluginConf) (*Plugin, error) {
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", conf.Kubeconfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error creating kube config from kubeconfig file %s", conf.Kubeconfig)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating kube client")
+	}
+
+	configClient, err := configClientset.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating config client")
+	}
+
+	return &Plugin{
+		kubeClient:   kubeClient,
+		configClient: configClient,
+		osmNamespace: conf.OSMNamespace,
+		applyRules:   applyIptablesRules,
+	}, nil
+}
+
+// Add redirects the traffic of the pod whose network is set up to its sidecar, if the pod is meshed and
+// the traffic redirection mode configured in the MeshConfig is CNI
+func (p *Plugin) Add(args Args) error {
+	if args.PodName == "" {
+		log.Debug().Msgf("Container %s is not a Kubernetes pod, skipping", args.ContainerID)
+		return nil
+	}
+
+	pod, err := p.kubeClient.CoreV1().Pods(args.PodNamespace).Get(context.Background(), args.PodName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Error getting pod %s/%s", args.PodNamespace, args.PodName)
+	}
+
+	if !hasSidecar(pod) {
+		log.Debug().Msgf("Pod %s/%s is not meshed, skipping", args.PodNamespace, args.PodName)
+		return nil
+	}
+
+	meshConfig, err := p.configClient.ConfigV1alpha2().MeshConfigs(p.osmNamespace).Get(context.Background(), constants.OSMMeshConfig, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Error getting MeshConfig %s/%s", p.osmNamespace, constants.OSMMeshConfig)
+	}
+
+	if meshConfig.Spec.Sidecar.TrafficRedirectionMode != configv1alpha2.TrafficRedirectionModeCNI {
+		log.Debug().Msgf("Traffic redirection mode is not %s, skipping pod %s/%s", configv1alpha2.TrafficRedirectionModeCNI, args.PodNamespace, args.PodName)
+		return nil
+	}
+
+	rules, err := injector.GetIptablesRulesForPod(pod, meshConfig.Spec)
+	if err != nil {
+		return errors.Wrapf(err, "Error generating iptables rules for pod %s/%s", args.PodNamespace, args.PodName)
+	}
+
+	if err := p.applyRules(args.Netns, rules); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Redirected the traffic of pod %s/%s to its sidecar", args.PodNamespace, args.PodName)
+	return nil
+}
+
+// hasSidecar returns true if the given pod has an Envoy sidecar injected
+func hasSidecar(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == constants.EnvoyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// applyIptablesRules applies the given iptables rules in the given network namespace
+func applyIptablesRules(netns string, rules string) error {
+	cmd := exec.Command("nsenter", "--net="+netns, "iptables-restore", "--noflush") // #nosec G204
+	cmd.Stdin = strings.NewReader(rules)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "Error applying iptables rules in network namespace %s: %s", netns, output)
+	}
+	return nil
+}
+
+// Execute executes the CNI command of the given arguments with the given network configuration, and returns the
+// result to write to stdout. newPlugin returns the plugin used to execute the ADD command.
+func Execute(args Args, stdin []byte, newPlugin func(PluginConf) (*Plugin, error)) ([]byte, error) {
+	if args.Command == commandVersion {
+		return json.Marshal(versionResult{
+			CNIVersion:        supportedVersions[len(supportedVersions)-1],
+			SupportedVersions: supportedVersions,
+		})
+	}
+
+	var conf PluginConf
+	if err := json.Unmarshal(stdin, &conf); err != nil {
+		return nil, errors.Wrap(err, "Error parsing network configuration")
+	}
+	if conf.LogLevel != "" {
+		if err := logger.SetLogLevel(conf.LogLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	switch args.Command {
+	case commandAdd:
+		plugin, err := newPlugin(conf)
+		if err != nil {
+			return nil, err
+		}
+		if err := plugin.Add(args); err != nil {
+			return nil, err
+		}
+
+		// As a chained plugin, the result of the previous plugin is returned as is
+		if len(conf.PrevResult) > 0 {
+			return conf.PrevResult, nil
+		}
+		return json.Marshal(map[string]string{"cniVersion": conf.CNIVersion})
+
+	case commandDel, commandCheck:
+		// The redirection rules are deleted with the network namespace of the pod
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("Unknown CNI command %s", args.Command)
+	}
+}
+
+// ErrorResult returns the result to write to stdout when the plugin fails with the given error
+func ErrorResult(cniVersion string, err error) []byte {
+	// Marshaling a struct of strings and an int cannot fail
+	result, _ := json.Marshal(errorResult{
+		CNIVersion: cniVersion,
+		Code:       errCodePluginFailure,
+		Msg:        err.Error(),
+	})
+	return result
+}
+
+// ArgsFromEnv returns the arguments of the plugin from the given environment lookup function
+func ArgsFromEnv(getenv func(string) string) (Args, error) {
+	args := Args{
+		Command:     getenv("CNI_COMMAND"),
+		ContainerID: getenv("CNI_CONTAINERID"),
+		Netns:       getenv("CNI_NETNS"),
+	}
+
+	switch args.Command {
+	case "":
+		return Args{}, errNoCNICommand
+	case commandAdd:
+		if args.ContainerID == "" || args.Netns == "" {
+			return Args{}, errors.New("CNI_CONTAINERID and CNI_NETNS environment variables are required for the ADD command")
+		}
+	}
+
+	// CNI_ARGS is a semicolon separated list of key=value pairs, which identify the pod for Kubernetes runtimes
+	for _, kv := range strings.Split(getenv("CNI_ARGS"), ";") {
+		keyValue := strings.SplitN(kv, "=", 2)
+		if len(keyValue) != 2 {
+			continue
+		}
+		switch keyValue[0] {
+		case "K8S_POD_NAMESPACE":
+			args.PodNamespace = keyValue[1]
+		case "K8S_POD_NAME":
+			args.PodName = keyValue[1]
+		}
+	}
+
+	return args, nil
+}
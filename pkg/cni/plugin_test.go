@@ -0,0 +1,231 @@
+package cni
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+const (
+	osmNamespace = "osm-system"
+	podNamespace = "bookstore"
+	podName      = "bookstore-v1"
+)
+
+func newPod(containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: podNamespace,
+			Name:      podName,
+			Annotations: map[string]string{
+				"openservicemesh.io/outbound-port-exclusion-list": "6379",
+			},
+		},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func newMeshConfig(mode string) *configv1alpha2.MeshConfig {
+	return &configv1alpha2.MeshConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: osmNamespace,
+			Name:      constants.OSMMeshConfig,
+		},
+		Spec: configv1alpha2.MeshConfigSpec{
+			Sidecar: configv1alpha2.SidecarSpec{
+				TrafficRedirectionMode: mode,
+			},
+		},
+	}
+}
+
+func TestAdd(t *testing.T) {
+	testCases := []struct {
+		name            string
+		args            Args
+		pod             *corev1.Pod
+		meshConfig      *configv1alpha2.MeshConfig
+		expectedApplied bool
+		expectedErr     bool
+	}{
+		{
+			name:            "meshed pod in CNI mode",
+			args:            Args{Netns: "/var/run/netns/cni-1", PodNamespace: podNamespace, PodName: podName},
+			pod:             newPod("bookstore", constants.EnvoyContainerName),
+			meshConfig:      newMeshConfig(configv1alpha2.TrafficRedirectionModeCNI),
+			expectedApplied: true,
+		},
+		{
+			name:            "meshed pod in init container mode",
+			args:            Args{Netns: "/var/run/netns/cni-1", PodNamespace: podNamespace, PodName: podName},
+			pod:             newPod("bookstore", constants.EnvoyContainerName),
+			meshConfig:      newMeshConfig(configv1alpha2.TrafficRedirectionModeInitContainer),
+			expectedApplied: false,
+		},
+		{
+			name:            "pod without sidecar",
+			args:            Args{Netns: "/var/run/netns/cni-1", PodNamespace: podNamespace, PodName: podName},
+			pod:             newPod("bookstore"),
+			meshConfig:      newMeshConfig(configv1alpha2.TrafficRedirectionModeCNI),
+			expectedApplied: false,
+		},
+		{
+			name:            "container which is not a pod",
+			args:            Args{Netns: "/var/run/netns/cni-1"},
+			pod:             newPod("bookstore", constants.EnvoyContainerName),
+			meshConfig:      newMeshConfig(configv1alpha2.TrafficRedirectionModeCNI),
+			expectedApplied: false,
+		},
+		{
+			name:        "pod not found",
+			args:        Args{Netns: "/var/run/netns/cni-1", PodNamespace: podNamespace, PodName: "unknown"},
+			pod:         newPod("bookstore", constants.EnvoyContainerName),
+			meshConfig:  newMeshConfig(configv1alpha2.TrafficRedirectionModeCNI),
+			expectedErr: true,
+		},
+		{
+			name:        "MeshConfig not found",
+			args:        Args{Netns: "/var/run/netns/cni-1", PodNamespace: podNamespace, PodName: podName},
+			pod:         newPod("bookstore", constants.EnvoyContainerName),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var configObjects []runtime.Object
+			if tc.meshConfig != nil {
+				configObjects = append(configObjects, tc.meshConfig)
+			}
+
+			var appliedNetns, appliedRules string
+			plugin := &Plugin{
+				kubeClient:   fake.NewSimpleClientset(tc.pod),
+				configClient: configFake.NewSimpleClientset(configObjects...),
+				osmNamespace: osmNamespace,
+				applyRules: func(netns string, rules string) error {
+					appliedNetns, appliedRules = netns, rules
+					return nil
+				},
+			}
+
+			err := plugin.Add(tc.args)
+			assert.Equal(tc.expectedErr, err != nil)
+			if !tc.expectedApplied {
+				assert.Empty(appliedRules)
+				return
+			}
+			assert.Equal(tc.args.Netns, appliedNetns)
+			assert.Contains(appliedRules, "-A OSM_PROXY_OUTBOUND -p tcp --match multiport --dports 6379 -j RETURN")
+			assert.True(len(appliedRules) > 0 && appliedRules[len(appliedRules)-1] == '\n')
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	assert := tassert.New(t)
+
+	newPlugin := func(PluginConf) (*Plugin, error) {
+		return &Plugin{
+			kubeClient:   fake.NewSimpleClientset(newPod("bookstore")),
+			configClient: configFake.NewSimpleClientset(),
+			osmNamespace: osmNamespace,
+		}, nil
+	}
+
+	result, err := Execute(Args{Command: commandVersion}, nil, newPlugin)
+	assert.Nil(err)
+	assert.JSONEq(`{"cniVersion":"0.4.0","supportedVersions":["0.3.0","0.3.1","0.4.0"]}`, string(result))
+
+	conf := []byte(`{"cniVersion":"0.4.0","name":"k8s-pod-network","type":"osm-cni","prevResult":{"cniVersion":"0.4.0","ips":[{"version":"4","address":"10.0.0.5/24"}]}}`)
+	args := Args{Command: commandAdd, ContainerID: "abc", Netns: "/var/run/netns/cni-1", PodNamespace: podNamespace, PodName: podName}
+	result, err = Execute(args, conf, newPlugin)
+	assert.Nil(err)
+	assert.JSONEq(`{"cniVersion":"0.4.0","ips":[{"version":"4","address":"10.0.0.5/24"}]}`, string(result))
+
+	result, err = Execute(Args{Command: commandAdd}, []byte(`{"cniVersion":"0.4.0"}`), newPlugin)
+	assert.Nil(err)
+	assert.JSONEq(`{"cniVersion":"0.4.0"}`, string(result))
+
+	result, err = Execute(Args{Command: commandDel}, conf, newPlugin)
+	assert.Nil(err)
+	assert.Nil(result)
+
+	_, err = Execute(Args{Command: commandAdd}, []byte(`not json`), newPlugin)
+	assert.NotNil(err)
+
+	_, err = Execute(Args{Command: "UNKNOWN"}, conf, newPlugin)
+	assert.NotNil(err)
+}
+
+func TestErrorResult(t *testing.T) {
+	assert := tassert.New(t)
+
+	result := ErrorResult("0.4.0", errNoCNICommand)
+	assert.JSONEq(`{"cniVersion":"0.4.0","code":100,"msg":"CNI_COMMAND environment variable is not set"}`, string(result))
+}
+
+func TestArgsFromEnv(t *testing.T) {
+	testCases := []struct {
+		name         string
+		env          map[string]string
+		expectedArgs Args
+		expectedErr  bool
+	}{
+		{
+			name: "ADD command for a pod",
+			env: map[string]string{
+				"CNI_COMMAND":     "ADD",
+				"CNI_CONTAINERID": "abc",
+				"CNI_NETNS":       "/var/run/netns/cni-1",
+				"CNI_ARGS":        "IgnoreUnknown=1;K8S_POD_NAMESPACE=bookstore;K8S_POD_NAME=bookstore-v1;K8S_POD_INFRA_CONTAINER_ID=abc",
+			},
+			expectedArgs: Args{
+				Command:      "ADD",
+				ContainerID:  "abc",
+				Netns:        "/var/run/netns/cni-1",
+				PodNamespace: "bookstore",
+				PodName:      "bookstore-v1",
+			},
+		},
+		{
+			name:         "VERSION command",
+			env:          map[string]string{"CNI_COMMAND": "VERSION"},
+			expectedArgs: Args{Command: "VERSION"},
+		},
+		{
+			name:        "ADD command without network namespace",
+			env:         map[string]string{"CNI_COMMAND": "ADD", "CNI_CONTAINERID": "abc"},
+			expectedErr: true,
+		},
+		{
+			name:        "no command",
+			env:         map[string]string{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			args, err := ArgsFromEnv(func(key string) string { return tc.env[key] })
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expectedArgs, args)
+		})
+	}
+}
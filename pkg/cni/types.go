@@ -0,0 +1,95 @@
+// Package cni implements the osm-cni plugin, which redirects the traffic of meshed pods to their sidecar when the
+// network of the pods is set up, instead of the init container injected in the pods by the sidecar injector.
+// The plugin is chained after the primary network plugin of the cluster nodes, and programs in the network namespace
+// of the pods the same iptables rules as the ones programmed by the init container.
+package cni
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("osm-cni")
+
+var errNoCNICommand = errors.New("CNI_COMMAND environment variable is not set")
+
+const (
+	// PluginName is the name of the osm-cni plugin, and of its binary in the CNI binary directory of the nodes
+	PluginName = "osm-cni"
+
+	// KubeconfigFileName is the name of the kubeconfig file used by the plugin, in the CNI network configuration directory of the nodes
+	KubeconfigFileName = "osm-cni.kubeconfig"
+)
+
+// Commands of the CNI specification
+const (
+	commandAdd     = "ADD"
+	commandDel     = "DEL"
+	commandCheck   = "CHECK"
+	commandVersion = "VERSION"
+)
+
+// supportedVersions is the list of versions of the CNI specification supported by the plugin
+var supportedVersions = []string{"0.3.0", "0.3.1", "0.4.0"}
+
+// errCodePluginFailure is the error code returned to the container runtime when the plugin fails.
+// Error codes 0-99 are reserved by the CNI specification.
+const errCodePluginFailure = 100
+
+// PluginConf is the network configuration of the osm-cni plugin, passed by the container runtime on stdin
+type PluginConf struct {
+	// CNIVersion is the version of the CNI specification of the network configuration
+	CNIVersion string `json:"cniVersion"`
+
+	// Name is the name of the network
+	Name string `json:"name"`
+
+	// Type is the type of the plugin, i.e. osm-cni
+	Type string `json:"type"`
+
+	// PrevResult is the result of the previous plugin in the chain, which the plugin returns as is
+	PrevResult json.RawMessage `json:"prevResult,omitempty"`
+
+	// Kubeconfig is the path to the kubeconfig file used by the plugin to access the Kubernetes API
+	Kubeconfig string `json:"kubeconfig"`
+
+	// OSMNamespace is the namespace of the OSM control plane
+	OSMNamespace string `json:"osmNamespace"`
+
+	// LogLevel is the log level of the plugin
+	LogLevel string `json:"logLevel,omitempty"`
+}
+
+// Args are the arguments of the plugin, passed by the container runtime in the environment
+type Args struct {
+	// Command is the CNI command to execute
+	Command string
+
+	// ContainerID is the ID of the container whose network is set up
+	ContainerID string
+
+	// Netns is the path to the network namespace of the container
+	Netns string
+
+	// PodNamespace is the namespace of the pod whose network is set up, empty if the container is not a Kubernetes pod
+	PodNamespace string
+
+	// PodName is the name of the pod whose network is set up, empty if the container is not a Kubernetes pod
+	PodName string
+}
+
+// versionResult is the result of the VERSION command
+type versionResult struct {
+	CNIVersion        string   `json:"cniVersion"`
+	SupportedVersions []string `json:"supportedVersions"`
+}
+
+// errorResult is the result returned to the container runtime when the plugin fails
+type errorResult struct {
+	CNIVersion string `json:"cniVersion"`
+	Code       int    `json:"code"`
+	Msg        string `json:"msg"`
+}
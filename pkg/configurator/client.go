@@ -37,11 +37,18 @@ func newConfigurator(meshConfigClientSet configClientset.Interface, stop <-chan
 		listOption,
 	)
 	informer := informerFactory.Config().V1alpha2().MeshConfigs().Informer()
+
+	// NamespaceConfig resources are watched in all namespaces
+	namespaceConfigInformer := configInformers.NewSharedInformerFactory(meshConfigClientSet, k8s.DefaultKubeEventResyncInterval).
+		Config().V1alpha2().NamespaceConfigs().Informer()
+
 	c := &client{
-		informer:       informer,
-		cache:          informer.GetStore(),
-		osmNamespace:   osmNamespace,
-		meshConfigName: meshConfigName,
+		informer:                informer,
+		cache:                   informer.GetStore(),
+		namespaceConfigInformer: namespaceConfigInformer,
+		osmNamespace:            osmNamespace,
+		meshConfigName:          meshConfigName,
+		rollout:                 newRollout(msgBroker, meshConfigClientSet, osmNamespace, meshConfigName),
 	}
 
 	// configure listener
@@ -50,9 +57,18 @@ func newConfigurator(meshConfigClientSet configClientset.Interface, stop <-chan
 		Update: announcements.MeshConfigUpdated,
 		Delete: announcements.MeshConfigDeleted,
 	}
+	// The rollout state must be updated before the MeshConfig event triggers proxy updates
+	informer.AddEventHandler(c.rollout.eventHandler())
 	informer.AddEventHandler(k8s.GetEventHandlerFuncs(nil, eventTypes, msgBroker))
 	informer.AddEventHandler(c.metricsHandler())
 
+	namespaceConfigEventTypes := k8s.EventTypes{
+		Add:    announcements.NamespaceConfigAdded,
+		Update: announcements.NamespaceConfigUpdated,
+		Delete: announcements.NamespaceConfigDeleted,
+	}
+	namespaceConfigInformer.AddEventHandler(k8s.GetEventHandlerFuncs(nil, namespaceConfigEventTypes, msgBroker))
+
 	c.run(stop)
 
 	return c
@@ -60,9 +76,11 @@ func newConfigurator(meshConfigClientSet configClientset.Interface, stop <-chan
 
 func (c *client) run(stop <-chan struct{}) {
 	go c.informer.Run(stop) // run the informer synchronization
-	log.Debug().Msgf("Started OSM MeshConfig informer")
-	log.Debug().Msg("[MeshConfig client] Waiting for MeshConfig informer's cache to sync")
-	if !cache.WaitForCacheSync(stop, c.informer.HasSynced) {
+	go c.namespaceConfigInformer.Run(stop)
+	go c.rollout.run(stop)
+	log.Debug().Msgf("Started OSM MeshConfig and NamespaceConfig informers")
+	log.Debug().Msg("[MeshConfig client] Waiting for MeshConfig and NamespaceConfig informers' caches to sync")
+	if !cache.WaitForCacheSync(stop, c.informer.HasSynced, c.namespaceConfigInformer.HasSynced) {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMeshConfigInformerInitCache)).Msg("Failed initial cache sync for MeshConfig informer")
 		return
@@ -95,6 +113,75 @@ func (c *client) getMeshConfig() configv1alpha2.MeshConfig {
 	return meshConfig
 }
 
+// Returns the MeshConfig applicable to the client's rollout cohort, with the overrides of the NamespaceConfig of the
+// client's namespace applied. While a change is rolled out, only the clients resolved for canary proxies return it.
+func (c *client) getEffectiveMeshConfig() configv1alpha2.MeshConfig {
+	meshConfig := c.getMeshConfig()
+	if c.rollout != nil {
+		meshConfig = c.rollout.resolve(meshConfig, c.canaryEpoch)
+	}
+	if c.namespace == "" {
+		return meshConfig
+	}
+
+	namespaceConfig := c.getNamespaceConfig(c.namespace)
+	if namespaceConfig == nil {
+		return meshConfig
+	}
+
+	applyNamespaceConfig(&meshConfig.Spec, namespaceConfig.Spec)
+	return meshConfig
+}
+
+// Returns the NamespaceConfig for the given namespace, nil if none exists.
+// When multiple NamespaceConfig resources exist in the namespace, the oldest one is returned.
+func (c *client) getNamespaceConfig(namespace string) *configv1alpha2.NamespaceConfig {
+	items, err := c.namespaceConfigInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting NamespaceConfig for namespace %s from cache", namespace)
+		return nil
+	}
+
+	var namespaceConfig *configv1alpha2.NamespaceConfig
+	for _, item := range items {
+		nsConfig := item.(*configv1alpha2.NamespaceConfig)
+		if namespaceConfig == nil || nsConfig.CreationTimestamp.Before(&namespaceConfig.CreationTimestamp) ||
+			(nsConfig.CreationTimestamp.Equal(&namespaceConfig.CreationTimestamp) && nsConfig.Name < namespaceConfig.Name) {
+			namespaceConfig = nsConfig
+		}
+	}
+
+	return namespaceConfig
+}
+
+// applyNamespaceConfig overrides the fields of the MeshConfig spec that are set in the NamespaceConfig spec
+func applyNamespaceConfig(spec *configv1alpha2.MeshConfigSpec, overrides configv1alpha2.NamespaceConfigSpec) {
+	if overrides.Sidecar.LogLevel != nil {
+		spec.Sidecar.LogLevel = *overrides.Sidecar.LogLevel
+	}
+	if overrides.Sidecar.TLSMinProtocolVersion != nil {
+		spec.Sidecar.TLSMinProtocolVersion = *overrides.Sidecar.TLSMinProtocolVersion
+	}
+	if overrides.Sidecar.TLSMaxProtocolVersion != nil {
+		spec.Sidecar.TLSMaxProtocolVersion = *overrides.Sidecar.TLSMaxProtocolVersion
+	}
+	if overrides.Sidecar.CipherSuites != nil {
+		spec.Sidecar.CipherSuites = overrides.Sidecar.CipherSuites
+	}
+	if overrides.Sidecar.ECDHCurves != nil {
+		spec.Sidecar.ECDHCurves = overrides.Sidecar.ECDHCurves
+	}
+	if overrides.Traffic.EnableEgress != nil {
+		spec.Traffic.EnableEgress = *overrides.Traffic.EnableEgress
+	}
+	if overrides.Traffic.EnablePermissiveTrafficPolicyMode != nil {
+		spec.Traffic.EnablePermissiveTrafficPolicyMode = *overrides.Traffic.EnablePermissiveTrafficPolicyMode
+	}
+	if overrides.Observability.Tracing != nil {
+		spec.Observability.Tracing = *overrides.Observability.Tracing
+	}
+}
+
 func (c *client) metricsHandler() cache.ResourceEventHandlerFuncs {
 	handleMetrics := func(obj interface{}) {
 		config := obj.(*configv1alpha2.MeshConfig)
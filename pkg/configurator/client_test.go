@@ -2,6 +2,7 @@ package configurator
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -103,3 +104,74 @@ func TestMetricsHandler(t *testing.T) {
 	a.True(metricsstore.DefaultMetricsStore.Contains(`osm_feature_flag_enabled{feature_flag="enableRetryPolicy"} 0` + "\n"))
 	a.True(metricsstore.DefaultMetricsStore.Contains(`osm_feature_flag_enabled{feature_flag="enableSnapshotCacheMode"} 0` + "\n"))
 }
+
+func TestForNamespace(t *testing.T) {
+	a := assert.New(t)
+
+	meshConfigClient := fakeConfig.NewSimpleClientset()
+	stop := make(chan struct{})
+	defer close(stop)
+	c := newConfigurator(meshConfigClient, stop, osmNamespace, osmMeshConfigName, nil)
+
+	meshConfig := &configv1alpha2.MeshConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: osmNamespace,
+			Name:      osmMeshConfigName,
+		},
+		Spec: configv1alpha2.MeshConfigSpec{
+			Sidecar: configv1alpha2.SidecarSpec{
+				LogLevel:              "error",
+				TLSMinProtocolVersion: "TLSv1_2",
+			},
+			Traffic: configv1alpha2.TrafficSpec{
+				EnableEgress:                      false,
+				EnablePermissiveTrafficPolicyMode: false,
+			},
+		},
+	}
+	a.Nil(c.cache.Add(meshConfig))
+
+	enabled := true
+	debug := "debug"
+	trace := "trace"
+	now := metav1.Now()
+	older := metav1.NewTime(now.Add(-time.Minute))
+	for _, nsConfig := range []*configv1alpha2.NamespaceConfig{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "newer", CreationTimestamp: now},
+			Spec: configv1alpha2.NamespaceConfigSpec{
+				Sidecar: configv1alpha2.NamespaceSidecarSpec{LogLevel: &trace},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "older", CreationTimestamp: older},
+			Spec: configv1alpha2.NamespaceConfigSpec{
+				Sidecar: configv1alpha2.NamespaceSidecarSpec{LogLevel: &debug},
+				Traffic: configv1alpha2.NamespaceTrafficSpec{
+					EnableEgress:                      &enabled,
+					EnablePermissiveTrafficPolicyMode: &enabled,
+				},
+			},
+		},
+	} {
+		a.Nil(c.namespaceConfigInformer.GetIndexer().Add(nsConfig))
+	}
+
+	// The mesh-wide configuration is not affected by the NamespaceConfig resources
+	a.Equal("error", c.GetEnvoyLogLevel())
+	a.False(c.IsEgressEnabled())
+	a.False(c.IsPermissiveTrafficPolicyMode())
+
+	// The oldest NamespaceConfig in the namespace overrides the fields it sets
+	nsCfg := c.ForNamespace("ns-1")
+	a.Equal("debug", nsCfg.GetEnvoyLogLevel())
+	a.True(nsCfg.IsEgressEnabled())
+	a.True(nsCfg.IsPermissiveTrafficPolicyMode())
+	a.Equal("TLSv1_2", nsCfg.GetMeshConfig().Spec.Sidecar.TLSMinProtocolVersion)
+
+	// Namespaces without a NamespaceConfig use the MeshConfig
+	nsCfg = c.ForNamespace("ns-2")
+	a.Equal("error", nsCfg.GetEnvoyLogLevel())
+	a.False(nsCfg.IsEgressEnabled())
+	a.False(nsCfg.IsPermissiveTrafficPolicyMode())
+}
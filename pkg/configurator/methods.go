@@ -27,13 +27,70 @@ const (
 
 	// maxCertKeyBitSize is the maximum certificate key bit size
 	maxCertKeyBitSize = 4096
+
+	// defaultExtAuthzMaxRequestBytes is the maximum size of the request body forwarded to the mesh-wide external authorization endpoint
+	defaultExtAuthzMaxRequestBytes = 8192
+
+	// defaultProxyDrainDuration is the default duration during which proxies gracefully drain their inbound listeners on termination
+	defaultProxyDrainDuration = 5 * time.Second
+
+	// defaultDataPlaneConnectionMaxQueueDuration is the default maximum duration a new data plane connection waits to be admitted
+	defaultDataPlaneConnectionMaxQueueDuration = 10 * time.Second
 )
 
 // The functions in this file implement the configurator.Configurator interface
 
 // GetMeshConfig returns the MeshConfig resource corresponding to the control plane
 func (c *client) GetMeshConfig() configv1alpha2.MeshConfig {
-	return c.getMeshConfig()
+	return c.getEffectiveMeshConfig()
+}
+
+// ForNamespace returns a Configurator that resolves the effective configuration for the workloads in the given namespace.
+// The overrides of the namespace's NamespaceConfig are applied to the MeshConfig each time a configuration is read,
+// so the returned Configurator reflects changes to both resources. The rollout cohort of the receiver is preserved,
+// so that the configurations derived from a proxy's Configurator are consistent with it.
+func (c *client) ForNamespace(namespace string) Configurator {
+	namespaced := *c
+	namespaced.namespace = namespace
+	return &namespaced
+}
+
+// ForProxy returns a Configurator that resolves the effective configuration for the proxy with the given UUID in the given namespace.
+// While a MeshConfig change is rolled out, only the canary proxies are configured with it. The proxy's cohort is resolved
+// once, so the returned Configurator must be resolved again for each configuration generated for the proxy.
+func (c *client) ForProxy(namespace string, proxyUUID string) Configurator {
+	scoped := *c
+	scoped.namespace = namespace
+	scoped.canaryEpoch = 0
+	if c.rollout != nil {
+		scoped.canaryEpoch = c.rollout.cohort(namespace, proxyUUID)
+	}
+	return &scoped
+}
+
+// GetRolloutCohort returns the staged rollout cohort the configuration is resolved for
+func (c *client) GetRolloutCohort() string {
+	if c.canaryEpoch == 0 {
+		return ""
+	}
+	return fmt.Sprintf("canary-%d", c.canaryEpoch)
+}
+
+// RecordProxyNACK records a configuration rejection (NACK) by the proxy with the given UUID in the given namespace.
+// NACKs from the canary proxies roll back the MeshConfig change being rolled out once they exceed the tolerated number.
+func (c *client) RecordProxyNACK(namespace string, proxyUUID string) {
+	if c.rollout == nil {
+		return
+	}
+	c.rollout.recordNACK(namespace, proxyUUID, time.Now())
+}
+
+// GetRolloutStatus returns the status of the staged rollout of MeshConfig changes
+func (c *client) GetRolloutStatus() RolloutStatus {
+	if c.rollout == nil {
+		return RolloutStatus{Phase: RolloutPhaseStable}
+	}
+	return c.rollout.status()
 }
 
 // GetOSMNamespace returns the namespace in which the OSM controller pod resides.
@@ -51,9 +108,9 @@ func marshalConfigToJSON(config configv1alpha2.MeshConfigSpec) (string, error) {
 
 // GetMeshConfigJSON returns the MeshConfig in pretty JSON.
 func (c *client) GetMeshConfigJSON() (string, error) {
-	cm, err := marshalConfigToJSON(c.getMeshConfig().Spec)
+	cm, err := marshalConfigToJSON(c.getEffectiveMeshConfig().Spec)
 	if err != nil {
-		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMeshConfigMarshaling)).Msgf("Error marshaling MeshConfig %s: %+v", c.getMeshConfigCacheKey(), c.getMeshConfig())
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMeshConfigMarshaling)).Msgf("Error marshaling MeshConfig %s: %+v", c.getMeshConfigCacheKey(), c.getEffectiveMeshConfig())
 		return "", err
 	}
 	return cm, nil
@@ -64,27 +121,27 @@ func (c *client) GetMeshConfigJSON() (string, error) {
 // or it is in SMI Spec mode, in which only traffic between source/destinations
 // referenced in SMI policies is allowed.
 func (c *client) IsPermissiveTrafficPolicyMode() bool {
-	return c.getMeshConfig().Spec.Traffic.EnablePermissiveTrafficPolicyMode
+	return c.getEffectiveMeshConfig().Spec.Traffic.EnablePermissiveTrafficPolicyMode
 }
 
 // IsEgressEnabled determines whether egress is globally enabled in the mesh or not.
 func (c *client) IsEgressEnabled() bool {
-	return c.getMeshConfig().Spec.Traffic.EnableEgress
+	return c.getEffectiveMeshConfig().Spec.Traffic.EnableEgress
 }
 
 // IsDebugServerEnabled determines whether osm debug HTTP server is enabled
 func (c *client) IsDebugServerEnabled() bool {
-	return c.getMeshConfig().Spec.Observability.EnableDebugServer
+	return c.getEffectiveMeshConfig().Spec.Observability.EnableDebugServer
 }
 
 // IsTracingEnabled returns whether tracing is enabled
 func (c *client) IsTracingEnabled() bool {
-	return c.getMeshConfig().Spec.Observability.Tracing.Enable
+	return c.getEffectiveMeshConfig().Spec.Observability.Tracing.Enable
 }
 
 // GetTracingHost is the host to which we send tracing spans
 func (c *client) GetTracingHost() string {
-	tracingAddress := c.getMeshConfig().Spec.Observability.Tracing.Address
+	tracingAddress := c.getEffectiveMeshConfig().Spec.Observability.Tracing.Address
 	if tracingAddress != "" {
 		return tracingAddress
 	}
@@ -93,7 +150,7 @@ func (c *client) GetTracingHost() string {
 
 // GetTracingPort returns the tracing listener port
 func (c *client) GetTracingPort() uint32 {
-	tracingPort := c.getMeshConfig().Spec.Observability.Tracing.Port
+	tracingPort := c.getEffectiveMeshConfig().Spec.Observability.Tracing.Port
 	if tracingPort != 0 {
 		return uint32(tracingPort)
 	}
@@ -102,7 +159,7 @@ func (c *client) GetTracingPort() uint32 {
 
 // GetTracingEndpoint returns the listener's collector endpoint
 func (c *client) GetTracingEndpoint() string {
-	tracingEndpoint := c.getMeshConfig().Spec.Observability.Tracing.Endpoint
+	tracingEndpoint := c.getEffectiveMeshConfig().Spec.Observability.Tracing.Endpoint
 	if tracingEndpoint != "" {
 		return tracingEndpoint
 	}
@@ -111,12 +168,38 @@ func (c *client) GetTracingEndpoint() string {
 
 // GetMaxDataPlaneConnections returns the max data plane connections allowed, 0 if disabled
 func (c *client) GetMaxDataPlaneConnections() int {
-	return c.getMeshConfig().Spec.Sidecar.MaxDataPlaneConnections
+	return c.getEffectiveMeshConfig().Spec.Sidecar.MaxDataPlaneConnections
+}
+
+// GetDataPlaneConnectionAdmission returns the admission control configuration of new data plane connections,
+// with defaults in case of unset or invalid values
+func (c *client) GetDataPlaneConnectionAdmission() DataPlaneConnectionAdmission {
+	spec := c.getEffectiveMeshConfig().Spec.Sidecar.DataPlaneConnectionAdmission
+
+	admission := DataPlaneConnectionAdmission{
+		ConnectionsPerSecond:           spec.ConnectionsPerSecond,
+		Burst:                          spec.Burst,
+		MaxQueueDuration:               defaultDataPlaneConnectionMaxQueueDuration,
+		MaxConcurrentConfigGenerations: spec.MaxConcurrentConfigGenerations,
+	}
+	if admission.Burst <= 0 {
+		admission.Burst = admission.ConnectionsPerSecond
+	}
+	if spec.MaxQueueDuration != "" {
+		maxQueueDuration, err := time.ParseDuration(spec.MaxQueueDuration)
+		if err != nil || maxQueueDuration < 0 {
+			log.Error().Err(err).Msgf("Error parsing data plane connection max queue duration %s", spec.MaxQueueDuration)
+		} else {
+			admission.MaxQueueDuration = maxQueueDuration
+		}
+	}
+
+	return admission
 }
 
 // GetEnvoyLogLevel returns the envoy log level
 func (c *client) GetEnvoyLogLevel() string {
-	logLevel := c.getMeshConfig().Spec.Sidecar.LogLevel
+	logLevel := c.getEffectiveMeshConfig().Spec.Sidecar.LogLevel
 	if logLevel != "" {
 		return logLevel
 	}
@@ -125,7 +208,7 @@ func (c *client) GetEnvoyLogLevel() string {
 
 // GetEnvoyImage returns the envoy image
 func (c *client) GetEnvoyImage() string {
-	image := c.getMeshConfig().Spec.Sidecar.EnvoyImage
+	image := c.getEffectiveMeshConfig().Spec.Sidecar.EnvoyImage
 	if image == "" {
 		image = os.Getenv("OSM_DEFAULT_ENVOY_IMAGE")
 	}
@@ -134,7 +217,7 @@ func (c *client) GetEnvoyImage() string {
 
 // GetEnvoyWindowsImage returns the envoy windows image
 func (c *client) GetEnvoyWindowsImage() string {
-	image := c.getMeshConfig().Spec.Sidecar.EnvoyWindowsImage
+	image := c.getEffectiveMeshConfig().Spec.Sidecar.EnvoyWindowsImage
 	if image == "" {
 		image = os.Getenv("OSM_DEFAULT_ENVOY_WINDOWS_IMAGE")
 	}
@@ -143,7 +226,7 @@ func (c *client) GetEnvoyWindowsImage() string {
 
 // GetInitContainerImage returns the init container image
 func (c *client) GetInitContainerImage() string {
-	image := c.getMeshConfig().Spec.Sidecar.InitContainerImage
+	image := c.getEffectiveMeshConfig().Spec.Sidecar.InitContainerImage
 	if image == "" {
 		image = os.Getenv("OSM_DEFAULT_INIT_CONTAINER_IMAGE")
 	}
@@ -152,7 +235,7 @@ func (c *client) GetInitContainerImage() string {
 
 // GetServiceCertValidityPeriod returns the validity duration for service certificates, and a default in case of invalid duration
 func (c *client) GetServiceCertValidityPeriod() time.Duration {
-	durationStr := c.getMeshConfig().Spec.Certificate.ServiceCertValidityDuration
+	durationStr := c.getEffectiveMeshConfig().Spec.Certificate.ServiceCertValidityDuration
 	validityDuration, err := time.ParseDuration(durationStr)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error parsing service certificate validity duration %s", durationStr)
@@ -164,7 +247,7 @@ func (c *client) GetServiceCertValidityPeriod() time.Duration {
 
 // GetCertKeyBitSize returns the certificate key bit size to be used
 func (c *client) GetCertKeyBitSize() int {
-	bitSize := c.getMeshConfig().Spec.Certificate.CertKeyBitSize
+	bitSize := c.getEffectiveMeshConfig().Spec.Certificate.CertKeyBitSize
 	if bitSize < minCertKeyBitSize || bitSize > maxCertKeyBitSize {
 		log.Error().Msgf("Invalid key bit size: %d", bitSize)
 		return defaultCertKeyBitSize
@@ -175,13 +258,13 @@ func (c *client) GetCertKeyBitSize() int {
 
 // IsPrivilegedInitContainer returns whether init containers should be privileged
 func (c *client) IsPrivilegedInitContainer() bool {
-	return c.getMeshConfig().Spec.Sidecar.EnablePrivilegedInitContainer
+	return c.getEffectiveMeshConfig().Spec.Sidecar.EnablePrivilegedInitContainer
 }
 
 // GetConfigResyncInterval returns the duration for resync interval.
 // If error or non-parsable value, returns 0 duration
 func (c *client) GetConfigResyncInterval() time.Duration {
-	resyncDuration := c.getMeshConfig().Spec.Sidecar.ConfigResyncInterval
+	resyncDuration := c.getEffectiveMeshConfig().Spec.Sidecar.ConfigResyncInterval
 	duration, err := time.ParseDuration(resyncDuration)
 	if err != nil {
 		log.Debug().Err(err).Msgf("Error parsing config resync interval: %s", duration)
@@ -192,13 +275,29 @@ func (c *client) GetConfigResyncInterval() time.Duration {
 
 // GetProxyResources returns the `Resources` configured for proxies, if any
 func (c *client) GetProxyResources() corev1.ResourceRequirements {
-	return c.getMeshConfig().Spec.Sidecar.Resources
+	return c.getEffectiveMeshConfig().Spec.Sidecar.Resources
+}
+
+// GetProxyDrainDuration returns the duration during which proxies gracefully drain their inbound listeners on termination,
+// and a default in case of unset or invalid duration
+func (c *client) GetProxyDrainDuration() time.Duration {
+	durationStr := c.getEffectiveMeshConfig().Spec.Sidecar.DrainDuration
+	if durationStr == "" {
+		return defaultProxyDrainDuration
+	}
+	drainDuration, err := time.ParseDuration(durationStr)
+	if err != nil || drainDuration < 0 {
+		log.Error().Err(err).Msgf("Error parsing proxy drain duration %s", durationStr)
+		return defaultProxyDrainDuration
+	}
+
+	return drainDuration
 }
 
 // GetInboundExternalAuthConfig returns the External Authentication configuration for incoming traffic, if any
 func (c *client) GetInboundExternalAuthConfig() auth.ExtAuthConfig {
 	extAuthConfig := auth.ExtAuthConfig{}
-	inboundExtAuthzMeshConfig := c.getMeshConfig().Spec.Traffic.InboundExternalAuthorization
+	inboundExtAuthzMeshConfig := c.getEffectiveMeshConfig().Spec.Traffic.InboundExternalAuthorization
 
 	extAuthConfig.Enable = inboundExtAuthzMeshConfig.Enable
 	extAuthConfig.Address = inboundExtAuthzMeshConfig.Address
@@ -213,15 +312,22 @@ func (c *client) GetInboundExternalAuthConfig() auth.ExtAuthConfig {
 	}
 	extAuthConfig.AuthzTimeout = duration
 
+	// The mesh-wide external authorization endpoint is always accessed over gRPC with the request body forwarded
+	extAuthConfig.Protocol = constants.ProtocolGRPC
+	extAuthConfig.RequestBody = &auth.RequestBodyConfig{
+		MaxRequestBytes:     defaultExtAuthzMaxRequestBytes,
+		AllowPartialMessage: true,
+	}
+
 	return extAuthConfig
 }
 
 // GetFeatureFlags returns OSM's feature flags
 func (c *client) GetFeatureFlags() configv1alpha2.FeatureFlags {
-	return c.getMeshConfig().Spec.FeatureFlags
+	return c.getEffectiveMeshConfig().Spec.FeatureFlags
 }
 
 // GetOSMLogLevel returns the configured OSM log level
 func (c *client) GetOSMLogLevel() string {
-	return c.getMeshConfig().Spec.Observability.OSMLogLevel
+	return c.getEffectiveMeshConfig().Spec.Observability.OSMLogLevel
 }
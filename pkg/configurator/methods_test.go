@@ -341,6 +341,36 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(interval, time.Duration(0))
 			},
 		},
+		{
+			name:                  "GetProxyDrainDuration",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(defaultProxyDrainDuration, cfg.GetProxyDrainDuration())
+			},
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Sidecar: configv1alpha2.SidecarSpec{
+					DrainDuration: "30s",
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(30*time.Second, cfg.GetProxyDrainDuration())
+			},
+		},
+		{
+			name:                  "NegativeGetProxyDrainDuration",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(defaultProxyDrainDuration, cfg.GetProxyDrainDuration())
+			},
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Sidecar: configv1alpha2.SidecarSpec{
+					DrainDuration: "Non-duration string",
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(defaultProxyDrainDuration, cfg.GetProxyDrainDuration())
+			},
+		},
 		{
 			name:                  "GetMaxDataplaneConnections",
 			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
@@ -356,6 +386,30 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(1000, cfg.GetMaxDataPlaneConnections())
 			},
 		},
+		{
+			name:                  "GetDataPlaneConnectionAdmission",
+			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(DataPlaneConnectionAdmission{MaxQueueDuration: 10 * time.Second}, cfg.GetDataPlaneConnectionAdmission())
+			},
+			updatedMeshConfigData: &configv1alpha2.MeshConfigSpec{
+				Sidecar: configv1alpha2.SidecarSpec{
+					DataPlaneConnectionAdmission: configv1alpha2.DataPlaneConnectionAdmissionSpec{
+						ConnectionsPerSecond:           50,
+						MaxQueueDuration:               "30s",
+						MaxConcurrentConfigGenerations: 8,
+					},
+				},
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(DataPlaneConnectionAdmission{
+					ConnectionsPerSecond:           50,
+					Burst:                          50,
+					MaxQueueDuration:               30 * time.Second,
+					MaxConcurrentConfigGenerations: 8,
+				}, cfg.GetDataPlaneConnectionAdmission())
+			},
+		},
 		{
 			name:                  "GetProxyResources",
 			initialMeshConfigData: &configv1alpha2.MeshConfigSpec{},
@@ -37,6 +37,34 @@ func (m *MockConfigurator) EXPECT() *MockConfiguratorMockRecorder {
 	return m.recorder
 }
 
+// ForNamespace mocks base method.
+func (m *MockConfigurator) ForNamespace(arg0 string) Configurator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForNamespace", arg0)
+	ret0, _ := ret[0].(Configurator)
+	return ret0
+}
+
+// ForNamespace indicates an expected call of ForNamespace.
+func (mr *MockConfiguratorMockRecorder) ForNamespace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForNamespace", reflect.TypeOf((*MockConfigurator)(nil).ForNamespace), arg0)
+}
+
+// ForProxy mocks base method.
+func (m *MockConfigurator) ForProxy(arg0, arg1 string) Configurator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForProxy", arg0, arg1)
+	ret0, _ := ret[0].(Configurator)
+	return ret0
+}
+
+// ForProxy indicates an expected call of ForProxy.
+func (mr *MockConfiguratorMockRecorder) ForProxy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForProxy", reflect.TypeOf((*MockConfigurator)(nil).ForProxy), arg0, arg1)
+}
+
 // GetCertKeyBitSize mocks base method.
 func (m *MockConfigurator) GetCertKeyBitSize() int {
 	m.ctrl.T.Helper()
@@ -65,6 +93,20 @@ func (mr *MockConfiguratorMockRecorder) GetConfigResyncInterval() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigResyncInterval", reflect.TypeOf((*MockConfigurator)(nil).GetConfigResyncInterval))
 }
 
+// GetDataPlaneConnectionAdmission mocks base method.
+func (m *MockConfigurator) GetDataPlaneConnectionAdmission() DataPlaneConnectionAdmission {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataPlaneConnectionAdmission")
+	ret0, _ := ret[0].(DataPlaneConnectionAdmission)
+	return ret0
+}
+
+// GetDataPlaneConnectionAdmission indicates an expected call of GetDataPlaneConnectionAdmission.
+func (mr *MockConfiguratorMockRecorder) GetDataPlaneConnectionAdmission() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataPlaneConnectionAdmission", reflect.TypeOf((*MockConfigurator)(nil).GetDataPlaneConnectionAdmission))
+}
+
 // GetEnvoyImage mocks base method.
 func (m *MockConfigurator) GetEnvoyImage() string {
 	m.ctrl.T.Helper()
@@ -220,6 +262,20 @@ func (mr *MockConfiguratorMockRecorder) GetOSMNamespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOSMNamespace", reflect.TypeOf((*MockConfigurator)(nil).GetOSMNamespace))
 }
 
+// GetProxyDrainDuration mocks base method.
+func (m *MockConfigurator) GetProxyDrainDuration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyDrainDuration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetProxyDrainDuration indicates an expected call of GetProxyDrainDuration.
+func (mr *MockConfiguratorMockRecorder) GetProxyDrainDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyDrainDuration", reflect.TypeOf((*MockConfigurator)(nil).GetProxyDrainDuration))
+}
+
 // GetProxyResources mocks base method.
 func (m *MockConfigurator) GetProxyResources() v1.ResourceRequirements {
 	m.ctrl.T.Helper()
@@ -234,6 +290,34 @@ func (mr *MockConfiguratorMockRecorder) GetProxyResources() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyResources", reflect.TypeOf((*MockConfigurator)(nil).GetProxyResources))
 }
 
+// GetRolloutCohort mocks base method.
+func (m *MockConfigurator) GetRolloutCohort() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolloutCohort")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRolloutCohort indicates an expected call of GetRolloutCohort.
+func (mr *MockConfiguratorMockRecorder) GetRolloutCohort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolloutCohort", reflect.TypeOf((*MockConfigurator)(nil).GetRolloutCohort))
+}
+
+// GetRolloutStatus mocks base method.
+func (m *MockConfigurator) GetRolloutStatus() RolloutStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolloutStatus")
+	ret0, _ := ret[0].(RolloutStatus)
+	return ret0
+}
+
+// GetRolloutStatus indicates an expected call of GetRolloutStatus.
+func (mr *MockConfiguratorMockRecorder) GetRolloutStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolloutStatus", reflect.TypeOf((*MockConfigurator)(nil).GetRolloutStatus))
+}
+
 // GetServiceCertValidityPeriod mocks base method.
 func (m *MockConfigurator) GetServiceCertValidityPeriod() time.Duration {
 	m.ctrl.T.Helper()
@@ -359,3 +443,15 @@ func (mr *MockConfiguratorMockRecorder) IsTracingEnabled() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTracingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsTracingEnabled))
 }
+
+// RecordProxyNACK mocks base method.
+func (m *MockConfigurator) RecordProxyNACK(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordProxyNACK", arg0, arg1)
+}
+
+// RecordProxyNACK indicates an expected call of RecordProxyNACK.
+func (mr *MockConfiguratorMockRecorder) RecordProxyNACK(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordProxyNACK", reflect.TypeOf((*MockConfigurator)(nil).RecordProxyNACK), arg0, arg1)
+}
@@ -0,0 +1,476 @@
+package configurator
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+const (
+	// defaultRolloutBakeDuration is the bake duration used when the rollout spec does not specify a valid one
+	defaultRolloutBakeDuration = 5 * time.Minute
+
+	// rolloutCheckInterval is the interval at which a rollout in progress is checked for promotion
+	rolloutCheckInterval = 10 * time.Second
+)
+
+// rollout tracks the staged rollout of MeshConfig changes.
+// The state is persisted in the MeshConfig status, so that it is shared by the controller replicas and preserved
+// across their restarts: each replica adopts the transitions and NACKs persisted by the others, and restores the
+// state from the status when it starts.
+type rollout struct {
+	sync.RWMutex
+
+	msgBroker *messaging.Broker
+
+	// meshConfigClient persists the state in the MeshConfig status, nil to keep the state in memory only
+	meshConfigClient configClientset.Interface
+	osmNamespace     string
+	meshConfigName   string
+
+	// persistChan signals that the state changed and must be persisted
+	persistChan chan struct{}
+
+	// observedGeneration is the generation of the last MeshConfig observed
+	observedGeneration int64
+
+	// stable is the MeshConfig applied to the proxies that are not canaries, nil until a MeshConfig is observed
+	stable *configv1alpha2.MeshConfig
+
+	// canary is the MeshConfig being rolled out when progressing, or the rejected MeshConfig when rolled back
+	canary *configv1alpha2.MeshConfig
+
+	phase              RolloutPhase
+	message            string
+	lastTransitionTime time.Time
+	startedAt          time.Time
+	nacks              int
+
+	// pendingNACKs are the NACKs recorded by this replica that are not persisted yet, they are included in nacks
+	pendingNACKs int
+
+	// epoch identifies the rollout in progress, it is incremented each time a rollout starts
+	epoch uint64
+}
+
+func newRollout(msgBroker *messaging.Broker, meshConfigClient configClientset.Interface, osmNamespace string, meshConfigName string) *rollout {
+	return &rollout{
+		msgBroker:        msgBroker,
+		meshConfigClient: meshConfigClient,
+		osmNamespace:     osmNamespace,
+		meshConfigName:   meshConfigName,
+		persistChan:      make(chan struct{}, 1),
+		phase:            RolloutPhaseStable,
+	}
+}
+
+// eventHandler returns the handler that updates the rollout state when the MeshConfig changes
+func (r *rollout) eventHandler() cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			r.observe(obj.(*configv1alpha2.MeshConfig), time.Now())
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			r.observe(newObj.(*configv1alpha2.MeshConfig), time.Now())
+		},
+		DeleteFunc: func(_ interface{}) {
+			r.Lock()
+			defer r.Unlock()
+			r.stable = nil
+			r.canary = nil
+			r.setPhase(RolloutPhaseStable, "MeshConfig deleted", time.Now())
+		},
+	}
+}
+
+// run periodically promotes the rollout in progress once its bake duration has elapsed, and persists the state
+// when it changes. Failed updates of the MeshConfig status are retried periodically.
+func (r *rollout) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(rolloutCheckInterval)
+	defer ticker.Stop()
+
+	persistFailed := false
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			r.evaluate(now)
+			if persistFailed {
+				persistFailed = r.persist() != nil
+			}
+		case <-r.persistChan:
+			persistFailed = r.persist() != nil
+		}
+	}
+}
+
+// observe updates the rollout state for the given MeshConfig.
+// A MeshConfig change starts a new rollout when staged rollouts are enabled, otherwise it becomes stable immediately.
+func (r *rollout) observe(meshConfig *configv1alpha2.MeshConfig, now time.Time) {
+	r.Lock()
+	// The NACKs are only adopted when persisting, as the observed status may include the NACKs being persisted
+	announce := r.adoptStatus(meshConfig, false)
+	r.observedGeneration = meshConfig.Generation
+
+	persist := true
+	switch {
+	case r.stable == nil || !meshConfig.Spec.Rollout.Enable:
+		r.stable = meshConfig
+		r.canary = nil
+		if r.phase != RolloutPhaseStable {
+			r.setPhase(RolloutPhaseStable, "Staged rollout disabled, MeshConfig applied to every proxy", now)
+		}
+
+	case sameProxyConfig(r.stable, meshConfig):
+		r.stable = meshConfig
+		if r.canary != nil {
+			r.canary = nil
+			r.setPhase(RolloutPhaseStable, "MeshConfig reverted to the stable configuration", now)
+		}
+
+	case r.canary != nil && sameProxyConfig(r.canary, meshConfig):
+		// Only the rollout spec, the status or the metadata changed, the rollout state is unchanged
+		persist = r.canary.Generation != meshConfig.Generation
+		r.canary = meshConfig
+
+	default:
+		r.canary = meshConfig
+		r.nacks = 0
+		r.pendingNACKs = 0
+		r.startedAt = now
+		r.epoch++
+		r.setPhase(RolloutPhaseProgressing, fmt.Sprintf("Rolling out MeshConfig generation %d to canary proxies", meshConfig.Generation), now)
+		log.Info().Msgf("Started staged rollout of MeshConfig generation %d", meshConfig.Generation)
+		// The canary proxies must be updated whatever MeshConfig field changed
+		announce = true
+	}
+	r.Unlock()
+
+	if announce {
+		r.announce()
+	}
+	if persist && meshConfig.Spec.Rollout.Enable {
+		r.requestPersist()
+	}
+}
+
+// adoptStatus adopts the rollout state persisted in the status of the given MeshConfig, and returns whether the
+// proxies must be updated. When the state is not initialized, it is restored from the status. Otherwise, the
+// transitions persisted by the other replicas for the rollout in progress are adopted, and their NACKs if adoptNACKs is set.
+// It must be called with the lock held.
+func (r *rollout) adoptStatus(meshConfig *configv1alpha2.MeshConfig, adoptNACKs bool) bool {
+	status := meshConfig.Status.Rollout
+	if status == nil || status.StableSpec == nil || !meshConfig.Spec.Rollout.Enable {
+		return false
+	}
+
+	if r.stable == nil {
+		stable := meshConfig.DeepCopy()
+		stable.Generation = status.StableGeneration
+		stable.Spec = *status.StableSpec.DeepCopy()
+		r.stable = stable
+
+		// The rollout in progress is resumed if the MeshConfig did not change since it was persisted, otherwise
+		// the MeshConfig change is rolled out from the stable MeshConfig
+		if RolloutPhase(status.Phase) != RolloutPhaseStable && status.CanaryGeneration == meshConfig.Generation {
+			r.canary = meshConfig
+			r.nacks = status.NACKs
+			r.epoch++
+			if status.StartedAt != nil {
+				r.startedAt = status.StartedAt.Time
+			}
+			r.setPhase(RolloutPhase(status.Phase), status.Message, status.LastTransitionTime.Time)
+			log.Info().Msgf("Restored staged rollout of MeshConfig generation %d in phase %s", meshConfig.Generation, status.Phase)
+		}
+		return false
+	}
+
+	if r.phase != RolloutPhaseProgressing || r.canary == nil || status.CanaryGeneration != r.canary.Generation {
+		return false
+	}
+
+	switch RolloutPhase(status.Phase) {
+	case RolloutPhaseProgressing:
+		if persisted := r.nacks - r.pendingNACKs; adoptNACKs && status.NACKs > persisted {
+			r.nacks = status.NACKs + r.pendingNACKs
+		}
+		// The bake duration starts when the first replica observed the MeshConfig change
+		if status.StartedAt != nil && status.StartedAt.Time.Before(r.startedAt) {
+			r.startedAt = status.StartedAt.Time
+		}
+		return false
+
+	case RolloutPhaseRolledBack:
+		r.nacks = status.NACKs
+		r.pendingNACKs = 0
+		r.setPhase(RolloutPhaseRolledBack, status.Message, status.LastTransitionTime.Time)
+		log.Warn().Msgf("Adopted rollback of staged rollout of MeshConfig generation %d", r.canary.Generation)
+		return true
+
+	case RolloutPhaseStable:
+		if status.StableGeneration != r.canary.Generation {
+			return false
+		}
+		r.stable = r.canary
+		r.canary = nil
+		r.setPhase(RolloutPhaseStable, status.Message, status.LastTransitionTime.Time)
+		log.Info().Msgf("Adopted promotion of staged rollout of MeshConfig generation %d", r.stable.Generation)
+		return true
+	}
+	return false
+}
+
+// evaluate promotes the rollout in progress if the canary proxies ran the MeshConfig change for the bake duration
+func (r *rollout) evaluate(now time.Time) {
+	r.Lock()
+	if r.phase != RolloutPhaseProgressing || now.Sub(r.startedAt) < getBakeDuration(r.canary.Spec.Rollout) {
+		r.Unlock()
+		return
+	}
+
+	r.stable = r.canary
+	r.canary = nil
+	r.setPhase(RolloutPhaseStable, fmt.Sprintf("Promoted MeshConfig generation %d to every proxy", r.stable.Generation), now)
+	log.Info().Msgf("Promoted staged rollout of MeshConfig generation %d", r.stable.Generation)
+	r.Unlock()
+
+	r.announce()
+	r.requestPersist()
+}
+
+// recordNACK records a NACK from the given proxy, and rolls back the rollout in progress if the proxy is a canary
+// and the canary proxies rejected the MeshConfig change more times than tolerated.
+func (r *rollout) recordNACK(namespace string, proxyUUID string, now time.Time) {
+	r.Lock()
+	if r.phase != RolloutPhaseProgressing || !isCanary(r.canary.Spec.Rollout, namespace, proxyUUID) {
+		r.Unlock()
+		return
+	}
+
+	r.nacks++
+	r.pendingNACKs++
+	rolledBack := r.rollbackIfRejected(now)
+	r.Unlock()
+
+	if rolledBack {
+		r.announce()
+	}
+	r.requestPersist()
+}
+
+// rollbackIfRejected rolls back the rollout in progress if the canary proxies rejected the MeshConfig change more
+// times than tolerated, and returns whether it was rolled back. It must be called with the lock held.
+func (r *rollout) rollbackIfRejected(now time.Time) bool {
+	if r.phase != RolloutPhaseProgressing || r.nacks <= r.canary.Spec.Rollout.MaxNACKs {
+		return false
+	}
+
+	r.setPhase(RolloutPhaseRolledBack, fmt.Sprintf("Rolled back MeshConfig generation %d after %d NACKs from canary proxies", r.canary.Generation, r.nacks), now)
+	log.Warn().Msgf("Rolled back staged rollout of MeshConfig generation %d after %d NACKs from canary proxies", r.canary.Generation, r.nacks)
+	return true
+}
+
+// requestPersist requests the state to be persisted in the MeshConfig status
+func (r *rollout) requestPersist() {
+	if r.meshConfigClient == nil {
+		return
+	}
+	select {
+	case r.persistChan <- struct{}{}:
+	default:
+		// A request is already pending
+	}
+}
+
+// persist persists the state in the status of the MeshConfig. The NACKs and transitions persisted by the other
+// replicas are adopted first, and the status is only updated if it changed.
+func (r *rollout) persist() error {
+	if r.meshConfigClient == nil {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		meshConfig, err := r.meshConfigClient.ConfigV1alpha2().MeshConfigs(r.osmNamespace).Get(context.Background(), r.meshConfigName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		r.Lock()
+		if r.stable == nil || meshConfig.Generation != r.observedGeneration || !meshConfig.Spec.Rollout.Enable {
+			// The MeshConfig changed since it was observed, its state is persisted once the change is observed
+			r.Unlock()
+			return nil
+		}
+		announce := r.adoptStatus(meshConfig, true)
+		announce = r.rollbackIfRejected(time.Now()) || announce
+		status := r.persistedStatus()
+		pendingNACKs := r.pendingNACKs
+		r.Unlock()
+
+		if announce {
+			r.announce()
+		}
+		if equality.Semantic.DeepEqual(meshConfig.Status.Rollout, status) {
+			return nil
+		}
+
+		meshConfig.Status.Rollout = status
+		if _, err := r.meshConfigClient.ConfigV1alpha2().MeshConfigs(r.osmNamespace).UpdateStatus(context.Background(), meshConfig, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+
+		r.Lock()
+		r.pendingNACKs -= pendingNACKs
+		r.Unlock()
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMeshConfigStatusUpdate)).
+			Msgf("Error persisting the staged rollout state in the status of MeshConfig %s/%s", r.osmNamespace, r.meshConfigName)
+	}
+	return err
+}
+
+// persistedStatus returns the state persisted in the MeshConfig status, must be called with the lock held
+func (r *rollout) persistedStatus() *configv1alpha2.RolloutStatus {
+	status := &configv1alpha2.RolloutStatus{
+		Phase:              string(r.phase),
+		Message:            r.message,
+		LastTransitionTime: metav1.NewTime(r.lastTransitionTime).Rfc3339Copy(),
+		StableGeneration:   r.stable.Generation,
+		StableSpec:         r.stable.Spec.DeepCopy(),
+		NACKs:              r.nacks,
+	}
+	if r.canary != nil {
+		startedAt := metav1.NewTime(r.startedAt).Rfc3339Copy()
+		status.CanaryGeneration = r.canary.Generation
+		status.StartedAt = &startedAt
+	}
+	return status
+}
+
+// cohort returns the epoch of the rollout in progress if the proxy with the given namespace and UUID is one of its
+// canaries, 0 otherwise
+func (r *rollout) cohort(namespace string, proxyUUID string) uint64 {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.phase != RolloutPhaseProgressing || !isCanary(r.canary.Spec.Rollout, namespace, proxyUUID) {
+		return 0
+	}
+	return r.epoch
+}
+
+// resolve returns the MeshConfig applicable to the proxies in the given cohort: the MeshConfig being rolled out
+// for the canaries of the rollout in progress, the stable MeshConfig otherwise
+func (r *rollout) resolve(current configv1alpha2.MeshConfig, canaryEpoch uint64) configv1alpha2.MeshConfig {
+	r.RLock()
+	defer r.RUnlock()
+
+	switch {
+	case r.stable == nil || r.phase == RolloutPhaseStable:
+		return current
+	case r.phase == RolloutPhaseProgressing && canaryEpoch != 0 && canaryEpoch == r.epoch:
+		return *r.canary
+	default:
+		return *r.stable
+	}
+}
+
+// status returns the status of the rollout
+func (r *rollout) status() RolloutStatus {
+	r.RLock()
+	defer r.RUnlock()
+
+	status := RolloutStatus{
+		Phase:              r.phase,
+		Message:            r.message,
+		LastTransitionTime: r.lastTransitionTime,
+	}
+	if r.stable != nil {
+		status.StableGeneration = r.stable.Generation
+	}
+	if r.canary != nil {
+		rolloutSpec := r.canary.Spec.Rollout
+		status.CanaryGeneration = r.canary.Generation
+		status.CanaryPercentage = rolloutSpec.CanaryPercentage
+		status.CanaryNamespaces = rolloutSpec.CanaryNamespaces
+		status.StartedAt = r.startedAt
+		status.BakeDuration = getBakeDuration(rolloutSpec).String()
+		status.NACKs = r.nacks
+		status.MaxNACKs = rolloutSpec.MaxNACKs
+	}
+	return status
+}
+
+// setPhase sets the phase of the rollout, must be called with the lock held
+func (r *rollout) setPhase(phase RolloutPhase, message string, now time.Time) {
+	r.phase = phase
+	r.message = message
+	r.lastTransitionTime = now
+}
+
+// announce notifies the proxies that the rollout was promoted or rolled back, so that their configuration is updated
+func (r *rollout) announce() {
+	if r.msgBroker == nil {
+		return
+	}
+	// The workqueue hashes the messages, the status is passed by pointer as it is not hashable
+	status := r.status()
+	r.msgBroker.GetQueue().AddRateLimited(events.PubSubMessage{
+		Kind:   announcements.MeshConfigRolloutUpdated,
+		NewObj: &status,
+	})
+}
+
+// isCanary returns whether the proxy with the given namespace and UUID is a canary for the rollout.
+// Proxies are selected by namespace, or by percentage using a hash of their UUID so that the selection is stable.
+func isCanary(rolloutSpec configv1alpha2.RolloutSpec, namespace string, proxyUUID string) bool {
+	for _, ns := range rolloutSpec.CanaryNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	if proxyUUID == "" {
+		return false
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(proxyUUID))
+	return int(h.Sum32()%100) < rolloutSpec.CanaryPercentage
+}
+
+// sameProxyConfig returns whether the given MeshConfigs have the same spec, ignoring the rollout spec.
+// It matches the MeshConfig changes the message broker updates the proxies for.
+func sameProxyConfig(a, b *configv1alpha2.MeshConfig) bool {
+	return !messaging.IsProxyConfigChanged(a, b)
+}
+
+// getBakeDuration returns the bake duration of the rollout spec, or the default one if not set or invalid
+func getBakeDuration(rolloutSpec configv1alpha2.RolloutSpec) time.Duration {
+	if rolloutSpec.BakeDuration == "" {
+		return defaultRolloutBakeDuration
+	}
+	duration, err := time.ParseDuration(rolloutSpec.BakeDuration)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing rollout bake duration %s, using default %s", rolloutSpec.BakeDuration, defaultRolloutBakeDuration)
+		return defaultRolloutBakeDuration
+	}
+	return duration
+}
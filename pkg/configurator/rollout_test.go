@@ -0,0 +1,344 @@
+package configurator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	fakeConfig "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+func newRolloutMeshConfig(generation int64, logLevel string, rolloutSpec configv1alpha2.RolloutSpec) *configv1alpha2.MeshConfig {
+	return &configv1alpha2.MeshConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  osmNamespace,
+			Name:       osmMeshConfigName,
+			Generation: generation,
+		},
+		Spec: configv1alpha2.MeshConfigSpec{
+			Sidecar: configv1alpha2.SidecarSpec{
+				LogLevel: logLevel,
+			},
+			Rollout: rolloutSpec,
+		},
+	}
+}
+
+func TestRollout(t *testing.T) {
+	rolloutSpec := configv1alpha2.RolloutSpec{
+		Enable:           true,
+		CanaryNamespaces: []string{"canary"},
+		BakeDuration:     "1m",
+		MaxNACKs:         1,
+	}
+	start := time.Now()
+
+	testCases := []struct {
+		name                string
+		updates             []*configv1alpha2.MeshConfig
+		nacks               []string
+		evaluateAfter       time.Duration
+		expectedPhase       RolloutPhase
+		expectedCanaryLevel string
+		expectedStableLevel string
+	}{
+		{
+			name: "change applied to every proxy when staged rollouts are disabled",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", configv1alpha2.RolloutSpec{}),
+				newRolloutMeshConfig(2, "debug", configv1alpha2.RolloutSpec{}),
+			},
+			expectedPhase:       RolloutPhaseStable,
+			expectedCanaryLevel: "debug",
+			expectedStableLevel: "debug",
+		},
+		{
+			name: "change applied to canary proxies only while progressing",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "debug", rolloutSpec),
+			},
+			expectedPhase:       RolloutPhaseProgressing,
+			expectedCanaryLevel: "debug",
+			expectedStableLevel: "error",
+		},
+		{
+			name: "change promoted after the bake duration",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "debug", rolloutSpec),
+			},
+			evaluateAfter:       time.Minute,
+			expectedPhase:       RolloutPhaseStable,
+			expectedCanaryLevel: "debug",
+			expectedStableLevel: "debug",
+		},
+		{
+			name: "change not promoted before the bake duration",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "debug", rolloutSpec),
+			},
+			evaluateAfter:       30 * time.Second,
+			expectedPhase:       RolloutPhaseProgressing,
+			expectedCanaryLevel: "debug",
+			expectedStableLevel: "error",
+		},
+		{
+			name: "change rolled back after too many NACKs from canary proxies",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "debug", rolloutSpec),
+			},
+			nacks:               []string{"canary", "canary"},
+			evaluateAfter:       time.Minute,
+			expectedPhase:       RolloutPhaseRolledBack,
+			expectedCanaryLevel: "error",
+			expectedStableLevel: "error",
+		},
+		{
+			name: "NACKs from proxies that are not canaries are ignored",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "debug", rolloutSpec),
+			},
+			nacks:               []string{"stable", "stable"},
+			expectedPhase:       RolloutPhaseProgressing,
+			expectedCanaryLevel: "debug",
+			expectedStableLevel: "error",
+		},
+		{
+			name: "change reverted while progressing",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "debug", rolloutSpec),
+				newRolloutMeshConfig(3, "error", rolloutSpec),
+			},
+			expectedPhase:       RolloutPhaseStable,
+			expectedCanaryLevel: "error",
+			expectedStableLevel: "error",
+		},
+		{
+			name: "rollout spec change does not start a new rollout",
+			updates: []*configv1alpha2.MeshConfig{
+				newRolloutMeshConfig(1, "error", rolloutSpec),
+				newRolloutMeshConfig(2, "error", configv1alpha2.RolloutSpec{Enable: true, CanaryPercentage: 50}),
+			},
+			expectedPhase:       RolloutPhaseStable,
+			expectedCanaryLevel: "error",
+			expectedStableLevel: "error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			r := newRollout(nil, nil, "", "")
+			for _, meshConfig := range tc.updates {
+				r.observe(meshConfig, start)
+			}
+			for _, namespace := range tc.nacks {
+				r.recordNACK(namespace, "", start)
+			}
+			r.evaluate(start.Add(tc.evaluateAfter))
+
+			current := *tc.updates[len(tc.updates)-1]
+			assert.Equal(tc.expectedPhase, r.status().Phase)
+			assert.Equal(tc.expectedCanaryLevel, r.resolve(current, r.cohort("canary", "")).Spec.Sidecar.LogLevel)
+			assert.Equal(tc.expectedStableLevel, r.resolve(current, r.cohort("stable", "")).Spec.Sidecar.LogLevel)
+		})
+	}
+}
+
+// Tests starting a rollout updates the canary proxies, whatever MeshConfig field changed
+func TestRolloutStartAnnounced(t *testing.T) {
+	assert := tassert.New(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	msgBroker := messaging.NewBroker(stop)
+	rolloutChan := msgBroker.GetKubeEventPubSub().Sub(announcements.MeshConfigRolloutUpdated.String())
+	defer msgBroker.Unsub(msgBroker.GetKubeEventPubSub(), rolloutChan)
+
+	rolloutSpec := configv1alpha2.RolloutSpec{Enable: true, CanaryNamespaces: []string{"canary"}}
+	stable := newRolloutMeshConfig(1, "error", rolloutSpec)
+	canary := newRolloutMeshConfig(2, "error", rolloutSpec)
+	canary.Spec.Sidecar.TLSMinProtocolVersion = "TLSv1_3"
+
+	r := newRollout(msgBroker, nil, "", "")
+	r.observe(stable, time.Now())
+	r.observe(canary, time.Now())
+	assert.Equal(RolloutPhaseProgressing, r.status().Phase)
+
+	select {
+	case msg := <-rolloutChan:
+		assert.Equal(RolloutPhaseProgressing, msg.(events.PubSubMessage).NewObj.(*RolloutStatus).Phase)
+	case <-time.After(5 * time.Second):
+		assert.Fail("Expected the start of the rollout to be announced")
+	}
+}
+
+func TestRolloutAfterRollback(t *testing.T) {
+	assert := tassert.New(t)
+
+	rolloutSpec := configv1alpha2.RolloutSpec{
+		Enable:           true,
+		CanaryNamespaces: []string{"canary"},
+	}
+	now := time.Now()
+
+	r := newRollout(nil, nil, "", "")
+	r.observe(newRolloutMeshConfig(1, "error", rolloutSpec), now)
+	r.observe(newRolloutMeshConfig(2, "debug", rolloutSpec), now)
+	r.recordNACK("canary", "", now)
+	assert.Equal(RolloutPhaseRolledBack, r.status().Phase)
+
+	// A new MeshConfig change is rolled out from the stable configuration
+	r.observe(newRolloutMeshConfig(3, "info", rolloutSpec), now)
+	status := r.status()
+	assert.Equal(RolloutPhaseProgressing, status.Phase)
+	assert.Equal(int64(1), status.StableGeneration)
+	assert.Equal(int64(3), status.CanaryGeneration)
+	assert.Equal(0, status.NACKs)
+	assert.Equal(defaultRolloutBakeDuration.String(), status.BakeDuration)
+}
+
+func TestIsCanary(t *testing.T) {
+	assert := tassert.New(t)
+
+	rolloutSpec := configv1alpha2.RolloutSpec{
+		CanaryNamespaces: []string{"ns-1"},
+	}
+	assert.True(isCanary(rolloutSpec, "ns-1", ""))
+	assert.False(isCanary(rolloutSpec, "ns-2", ""))
+	assert.False(isCanary(rolloutSpec, "ns-2", "some-uuid"))
+
+	// Every proxy is a canary with 100%, none with 0%
+	rolloutSpec.CanaryPercentage = 100
+	assert.True(isCanary(rolloutSpec, "ns-2", "some-uuid"))
+	rolloutSpec.CanaryPercentage = 0
+	assert.False(isCanary(rolloutSpec, "ns-2", "some-uuid"))
+
+	// The selection by percentage is stable and selects roughly the given percentage of proxies
+	rolloutSpec.CanaryPercentage = 50
+	canaries := 0
+	for i := 0; i < 1000; i++ {
+		proxyUUID := time.Duration(i).String()
+		selected := isCanary(rolloutSpec, "ns-2", proxyUUID)
+		assert.Equal(selected, isCanary(rolloutSpec, "ns-2", proxyUUID))
+		if selected {
+			canaries++
+		}
+	}
+	assert.InDelta(500, canaries, 100)
+}
+
+func TestForProxy(t *testing.T) {
+	assert := tassert.New(t)
+
+	meshConfigClient := fakeConfig.NewSimpleClientset()
+	stop := make(chan struct{})
+	defer close(stop)
+	c := newConfigurator(meshConfigClient, stop, osmNamespace, osmMeshConfigName, nil)
+
+	rolloutSpec := configv1alpha2.RolloutSpec{
+		Enable:           true,
+		CanaryPercentage: 100,
+	}
+	stable := newRolloutMeshConfig(1, "error", rolloutSpec)
+	canary := newRolloutMeshConfig(2, "debug", rolloutSpec)
+	assert.Nil(c.cache.Add(canary))
+	c.rollout.observe(stable, time.Now())
+	c.rollout.observe(canary, time.Now())
+
+	// The mesh-wide configuration is the stable MeshConfig
+	assert.Equal("error", c.GetEnvoyLogLevel())
+	assert.Empty(c.GetRolloutCohort())
+
+	// Proxies are canaries, namespaces without proxies are not
+	proxyCfg := c.ForProxy("ns", "proxy-uuid")
+	assert.Equal("debug", proxyCfg.GetEnvoyLogLevel())
+	assert.NotEmpty(proxyCfg.GetRolloutCohort())
+	assert.Equal("error", c.ForNamespace("ns").GetEnvoyLogLevel())
+
+	// The configurations derived from a canary proxy's configuration keep its cohort
+	assert.Equal("debug", proxyCfg.ForNamespace("other").GetEnvoyLogLevel())
+	assert.Equal("debug", proxyCfg.ForNamespace("").GetEnvoyLogLevel())
+
+	c.RecordProxyNACK("ns", "proxy-uuid")
+	assert.Equal(RolloutPhaseRolledBack, c.GetRolloutStatus().Phase)
+	assert.Equal("error", c.ForProxy("ns", "proxy-uuid").GetEnvoyLogLevel())
+	assert.Equal("error", proxyCfg.GetEnvoyLogLevel())
+}
+
+func TestRolloutPersistence(t *testing.T) {
+	assert := tassert.New(t)
+
+	rolloutSpec := configv1alpha2.RolloutSpec{
+		Enable:           true,
+		CanaryNamespaces: []string{"canary"},
+		MaxNACKs:         1,
+	}
+	now := time.Now()
+	meshConfigClient := fakeConfig.NewSimpleClientset(newRolloutMeshConfig(1, "error", rolloutSpec))
+	getMeshConfig := func() *configv1alpha2.MeshConfig {
+		meshConfig, err := meshConfigClient.ConfigV1alpha2().MeshConfigs(osmNamespace).Get(context.Background(), osmMeshConfigName, metav1.GetOptions{})
+		assert.Nil(err)
+		return meshConfig
+	}
+	updateMeshConfig := func(generation int64, logLevel string) {
+		meshConfig := getMeshConfig()
+		meshConfig.Generation = generation
+		meshConfig.Spec.Sidecar.LogLevel = logLevel
+		_, err := meshConfigClient.ConfigV1alpha2().MeshConfigs(osmNamespace).Update(context.Background(), meshConfig, metav1.UpdateOptions{})
+		assert.Nil(err)
+	}
+
+	// The stable MeshConfig and the rollout in progress are persisted in the status
+	r1 := newRollout(nil, meshConfigClient, osmNamespace, osmMeshConfigName)
+	r1.observe(getMeshConfig(), now)
+	updateMeshConfig(2, "debug")
+	r1.observe(getMeshConfig(), now)
+	assert.Nil(r1.persist())
+
+	status := getMeshConfig().Status.Rollout
+	assert.NotNil(status)
+	assert.Equal(string(RolloutPhaseProgressing), status.Phase)
+	assert.Equal(int64(1), status.StableGeneration)
+	assert.Equal("error", status.StableSpec.Sidecar.LogLevel)
+	assert.Equal(int64(2), status.CanaryGeneration)
+
+	// A replica starting while the rollout is in progress restores it from the status
+	r2 := newRollout(nil, meshConfigClient, osmNamespace, osmMeshConfigName)
+	r2.observe(getMeshConfig(), now)
+	assert.Equal(RolloutPhaseProgressing, r2.status().Phase)
+	assert.Equal("debug", r2.resolve(*getMeshConfig(), r2.cohort("canary", "")).Spec.Sidecar.LogLevel)
+	assert.Equal("error", r2.resolve(*getMeshConfig(), r2.cohort("stable", "")).Spec.Sidecar.LogLevel)
+
+	// The NACKs recorded by the replicas add up, and the rollback is adopted by the other replica
+	r1.recordNACK("canary", "", now)
+	assert.Nil(r1.persist())
+	assert.Equal(RolloutPhaseProgressing, r1.status().Phase)
+	r2.recordNACK("canary", "", now)
+	assert.Nil(r2.persist())
+	assert.Equal(RolloutPhaseRolledBack, r2.status().Phase)
+	assert.Equal(2, getMeshConfig().Status.Rollout.NACKs)
+
+	r1.observe(getMeshConfig(), now)
+	assert.Equal(RolloutPhaseRolledBack, r1.status().Phase)
+	assert.Equal("error", r1.resolve(*getMeshConfig(), r1.cohort("canary", "")).Spec.Sidecar.LogLevel)
+
+	// Persisting an unchanged state does not update the status
+	resourceVersion := getMeshConfig().ResourceVersion
+	assert.Nil(r1.persist())
+	assert.Equal(resourceVersion, getMeshConfig().ResourceVersion)
+}
@@ -19,10 +19,87 @@ var (
 
 // client is the type used to represent the Kubernetes client for the config.openservicemesh.io API group
 type client struct {
-	osmNamespace   string
-	informer       cache.SharedIndexInformer
-	cache          cache.Store
-	meshConfigName string
+	osmNamespace            string
+	informer                cache.SharedIndexInformer
+	cache                   cache.Store
+	namespaceConfigInformer cache.SharedIndexInformer
+	meshConfigName          string
+
+	// namespace is the namespace whose NamespaceConfig overrides are applied to the MeshConfig,
+	// empty for the mesh-wide configuration
+	namespace string
+
+	// canaryEpoch is the epoch of the rollout the client was resolved as a canary for, 0 if the client
+	// resolves the stable configuration
+	canaryEpoch uint64
+
+	// rollout tracks the staged rollout of MeshConfig changes, shared by the namespace and proxy scoped clients
+	rollout *rollout
+}
+
+// RolloutPhase is the phase of the staged rollout of a MeshConfig change
+type RolloutPhase string
+
+const (
+	// RolloutPhaseStable indicates that every proxy is configured with the same MeshConfig
+	RolloutPhaseStable RolloutPhase = "Stable"
+
+	// RolloutPhaseProgressing indicates that a MeshConfig change is applied to the canary proxies only
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+
+	// RolloutPhaseRolledBack indicates that a MeshConfig change was rejected by the canary proxies and is not applied to any proxy
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+)
+
+// RolloutStatus is the status of the staged rollout of MeshConfig changes
+type RolloutStatus struct {
+	// Phase is the phase of the rollout
+	Phase RolloutPhase `json:"phase"`
+
+	// Message describes the last transition of the rollout
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the time of the last transition of the rollout
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+
+	// StableGeneration is the generation of the MeshConfig applied to the proxies that are not canaries
+	StableGeneration int64 `json:"stableGeneration"`
+
+	// CanaryGeneration is the generation of the MeshConfig being rolled out or rolled back
+	CanaryGeneration int64 `json:"canaryGeneration,omitempty"`
+
+	// CanaryPercentage is the percentage of proxies selected as canaries
+	CanaryPercentage int `json:"canaryPercentage,omitempty"`
+
+	// CanaryNamespaces are the namespaces whose proxies are selected as canaries
+	CanaryNamespaces []string `json:"canaryNamespaces,omitempty"`
+
+	// StartedAt is the time the rollout started
+	StartedAt time.Time `json:"startedAt,omitempty"`
+
+	// BakeDuration is the duration the canary proxies run the MeshConfig change before it is promoted
+	BakeDuration string `json:"bakeDuration,omitempty"`
+
+	// NACKs is the number of NACKs received from the canary proxies
+	NACKs int `json:"nacks"`
+
+	// MaxNACKs is the number of NACKs from the canary proxies tolerated before the rollout is rolled back
+	MaxNACKs int `json:"maxNACKs"`
+}
+
+// DataPlaneConnectionAdmission is the admission control configuration of new data plane connections
+type DataPlaneConnectionAdmission struct {
+	// ConnectionsPerSecond is the rate at which new data plane connections are admitted, 0 if admission control is disabled
+	ConnectionsPerSecond int
+
+	// Burst is the number of new data plane connections admitted at once before being rate limited
+	Burst int
+
+	// MaxQueueDuration is the maximum duration a new data plane connection waits to be admitted before being rejected
+	MaxQueueDuration time.Duration
+
+	// MaxConcurrentConfigGenerations is the maximum number of full proxy configurations generated concurrently, 0 if unbounded
+	MaxConcurrentConfigGenerations int
 }
 
 // Configurator is the controller interface for K8s namespaces
@@ -60,6 +137,9 @@ type Configurator interface {
 	// GetMaxDataPlaneConnections returns the max data plane connections allowed, 0 if disabled
 	GetMaxDataPlaneConnections() int
 
+	// GetDataPlaneConnectionAdmission returns the admission control configuration of new data plane connections
+	GetDataPlaneConnectionAdmission() DataPlaneConnectionAdmission
+
 	// GetOsmLogLevel returns the configured OSM log level
 	GetOSMLogLevel() string
 
@@ -91,9 +171,30 @@ type Configurator interface {
 	// GetProxyResources returns the `Resources` configured for proxies, if any
 	GetProxyResources() corev1.ResourceRequirements
 
+	// GetProxyDrainDuration returns the duration during which proxies gracefully drain their inbound listeners on termination
+	GetProxyDrainDuration() time.Duration
+
 	// GetInboundExternalAuthConfig returns the External Authentication configuration for incoming traffic, if any
 	GetInboundExternalAuthConfig() auth.ExtAuthConfig
 
 	// GetFeatureFlags returns OSM's feature flags
 	GetFeatureFlags() configv1alpha2.FeatureFlags
+
+	// ForNamespace returns a Configurator that resolves the effective configuration for the workloads in the given namespace,
+	// with the overrides of the namespace's NamespaceConfig applied to the MeshConfig. The rollout cohort is preserved.
+	ForNamespace(namespace string) Configurator
+
+	// ForProxy returns a Configurator that resolves the effective configuration for the proxy with the given UUID in the given namespace,
+	// taking into account the staged rollout of MeshConfig changes in addition to the namespace's NamespaceConfig overrides
+	ForProxy(namespace string, proxyUUID string) Configurator
+
+	// GetRolloutCohort returns the staged rollout cohort the configuration is resolved for: empty for the stable
+	// MeshConfig, or an identifier of the rollout in progress for its canary proxies
+	GetRolloutCohort() string
+
+	// RecordProxyNACK records a configuration rejection (NACK) by the proxy with the given UUID in the given namespace
+	RecordProxyNACK(namespace string, proxyUUID string)
+
+	// GetRolloutStatus returns the status of the staged rollout of MeshConfig changes
+	GetRolloutStatus() RolloutStatus
 }
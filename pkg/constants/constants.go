@@ -61,6 +61,10 @@ const (
 	// EnvoyPrometheusInboundListenerPort is Envoy's inbound listener port number for prometheus
 	EnvoyPrometheusInboundListenerPort = 15010
 
+	// EnvoyDNSListenerPort is Envoy's DNS listener port number, to which the DNS queries of the app are redirected
+	// when the DNS proxy is enabled
+	EnvoyDNSListenerPort = 15053
+
 	// InjectorWebhookPort is the port on which the sidecar injection webhook listens
 	InjectorWebhookPort = 9090
 
@@ -76,12 +80,26 @@ const (
 	// OSMControllerName is the name of the OSM Controller (formerly ADS service).
 	OSMControllerName = "osm-controller"
 
+	// OSMControllerLeaderLeaseName is the name of the Lease used to elect the osm-controller replica running
+	// the singleton duties.
+	OSMControllerLeaderLeaseName = "osm-controller-leader"
+
 	// OSMInjectorName is the name of the OSM Injector.
 	OSMInjectorName = "osm-injector"
 
 	// OSMBootstrapName is the name of the OSM Bootstrap.
 	OSMBootstrapName = "osm-bootstrap"
 
+	// OSMEgressGatewayName is the name of the OSM egress gateway, used for its service and service account.
+	OSMEgressGatewayName = "osm-egress-gateway"
+
+	// EgressGatewayPort is the port on which the OSM egress gateway accepts mTLS traffic from sidecars
+	EgressGatewayPort = 15444
+
+	// OSMIngressGatewayName is the name of the OSM ingress gateway programmed from Gateway API resources,
+	// used for its service and service account.
+	OSMIngressGatewayName = "osm-ingress-gateway"
+
 	// ADSServerPort is the port on which the Aggregated Discovery Service (ADS) listens for new gRPC connections from Envoy proxies
 	ADSServerPort = 15128
 
@@ -109,6 +127,10 @@ const (
 	// CrdConverterCertificateSecretName is the default value for conversion webhook secret name
 	CrdConverterCertificateSecretName = "crd-converter-cert-secret" // #nosec G101: Potential hardcoded credentials
 
+	// EgressVirtualIPRange is the IP range from which the virtual IPs of the hosts of Egress policies are allocated,
+	// when the DNS proxy is enabled. The range is reserved for future use (RFC 1112), so it never conflicts with real IPs.
+	EgressVirtualIPRange = "240.240.0.0/16"
+
 	// RegexMatchAll is a regex pattern match for all
 	RegexMatchAll = ".*"
 
@@ -124,6 +146,9 @@ const (
 	// KubernetesOpaqueSecretRootPrivateKeyKey is the key which holds the CA's private key in a Kubernetes secret.
 	KubernetesOpaqueSecretRootPrivateKeyKey = "private.key"
 
+	// OSMIssuedCertificateLabelKey is the label applied to the Kubernetes secrets persisting certificates issued to workloads.
+	OSMIssuedCertificateLabelKey = "openservicemesh.io/issued-certificate"
+
 	// EnvoyUniqueIDLabelName is the label applied to pods with the unique ID of the Envoy sidecar.
 	EnvoyUniqueIDLabelName = "osm-proxy-uuid"
 
@@ -168,6 +193,14 @@ const (
 
 	// MetricsAnnotation is the annotation used for enabling/disabling metrics
 	MetricsAnnotation = "openservicemesh.io/metrics"
+
+	// PolicyAuditModeAnnotation is the annotation on a namespace or service used to audit SMI access
+	// policies instead of enforcing them
+	PolicyAuditModeAnnotation = "openservicemesh.io/policy-audit-mode"
+
+	// DNSCaptureAnnotation is the annotation recorded on a pod by the sidecar injector to indicate whether
+	// the DNS queries of the pod are redirected to its sidecar
+	DNSCaptureAnnotation = "openservicemesh.io/dns-capture"
 )
 
 // Labels used by the control plane
@@ -216,6 +249,9 @@ const (
 	// gRPC protocol
 	ProtocolGRPC = "grpc"
 
+	// TLS protocol, used for TLS traffic passed through without being terminated
+	ProtocolTLS = "tls"
+
 	// ProtocolTCPServerFirst implies TCP based server first protocols
 	// Ex. MySQL, SMTP, PostgreSQL etc. where the server initiates the first
 	// byte in a TCP connection.
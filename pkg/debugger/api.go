@@ -0,0 +1,415 @@
+package debugger
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/munnerz/goautoneg"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+const (
+	// APIVersion is the version of the JSON debug API.
+	APIVersion = "v1"
+
+	// APIPrefix is the URL prefix under which the versioned JSON debug API is served.
+	APIPrefix = "/debug/api/" + APIVersion
+
+	contentTypeJSON = "application/json"
+)
+
+// CertificateInfo is the v1 API representation of a certificate issued by OSM.
+type CertificateInfo struct {
+	CommonName            string     `json:"commonName"`
+	SerialNumber          string     `json:"serialNumber"`
+	Expiration            time.Time  `json:"expiration"`
+	IssuingCASHA256       string     `json:"issuingCASHA256"`
+	CertChainSHA256       string     `json:"certChainSHA256"`
+	SignatureAlgorithm    string     `json:"signatureAlgorithm,omitempty"`
+	PublicKeyAlgorithm    string     `json:"publicKeyAlgorithm,omitempty"`
+	Version               int        `json:"version,omitempty"`
+	X509SerialNumber      string     `json:"x509SerialNumber,omitempty"`
+	Issuer                string     `json:"issuer,omitempty"`
+	Subject               string     `json:"subject,omitempty"`
+	NotBefore             *time.Time `json:"notBefore,omitempty"`
+	NotAfter              *time.Time `json:"notAfter,omitempty"`
+	BasicConstraintsValid bool       `json:"basicConstraintsValid"`
+	IsCA                  bool       `json:"isCA"`
+	DNSNames              []string   `json:"dnsNames,omitempty"`
+}
+
+// ProxyInfo is the v1 API representation of a proxy connected to the controller.
+type ProxyInfo struct {
+	CommonName   string    `json:"commonName"`
+	SerialNumber string    `json:"serialNumber"`
+	Kind         string    `json:"kind"`
+	ConnectedAt  time.Time `json:"connectedAt"`
+	PodName      string    `json:"podName,omitempty"`
+	PodNamespace string    `json:"podNamespace,omitempty"`
+	PodUID       string    `json:"podUID,omitempty"`
+}
+
+// XDSLogInfo is the v1 API representation of the xDS responses sent to a single proxy.
+type XDSLogInfo struct {
+	CommonName string `json:"commonName"`
+
+	// Responses maps the xDS type URI to the times a response of that type was sent, most recent first.
+	Responses map[string][]time.Time `json:"responses"`
+}
+
+// PoliciesInfo is the v1 API representation of the SMI policies detected by OSM.
+type PoliciesInfo struct {
+	TrafficSplits   []TrafficSplitInfo   `json:"trafficSplits"`
+	ServiceAccounts []ServiceAccountInfo `json:"serviceAccounts"`
+	RouteGroups     []HTTPRouteGroupInfo `json:"routeGroups"`
+	TrafficTargets  []TrafficTargetInfo  `json:"trafficTargets"`
+}
+
+// TrafficSplitInfo is the v1 API representation of an SMI TrafficSplit.
+type TrafficSplitInfo struct {
+	Name      string                    `json:"name"`
+	Namespace string                    `json:"namespace"`
+	Service   string                    `json:"service"`
+	Backends  []TrafficSplitBackendInfo `json:"backends"`
+}
+
+// TrafficSplitBackendInfo is the v1 API representation of a backend of an SMI TrafficSplit.
+type TrafficSplitBackendInfo struct {
+	Service string `json:"service"`
+	Weight  int    `json:"weight"`
+}
+
+// ServiceAccountInfo is the v1 API representation of a service account referenced by the SMI policies.
+type ServiceAccountInfo struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// HTTPRouteGroupInfo is the v1 API representation of an SMI HTTPRouteGroup.
+type HTTPRouteGroupInfo struct {
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Matches   []HTTPMatchInfo `json:"matches"`
+}
+
+// HTTPMatchInfo is the v1 API representation of a match in an SMI HTTPRouteGroup.
+type HTTPMatchInfo struct {
+	Name      string            `json:"name"`
+	Methods   []string          `json:"methods,omitempty"`
+	PathRegex string            `json:"pathRegex,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// TrafficTargetInfo is the v1 API representation of an SMI TrafficTarget.
+type TrafficTargetInfo struct {
+	Name        string                  `json:"name"`
+	Namespace   string                  `json:"namespace"`
+	Destination IdentitySubjectInfo     `json:"destination"`
+	Sources     []IdentitySubjectInfo   `json:"sources"`
+	Rules       []TrafficTargetRuleInfo `json:"rules"`
+}
+
+// IdentitySubjectInfo is the v1 API representation of an identity referenced by an SMI TrafficTarget.
+type IdentitySubjectInfo struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// TrafficTargetRuleInfo is the v1 API representation of a rule of an SMI TrafficTarget.
+type TrafficTargetRuleInfo struct {
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	Matches []string `json:"matches,omitempty"`
+}
+
+// FeatureFlagsInfo is the v1 API representation of the feature flags in the MeshConfig.
+type FeatureFlagsInfo struct {
+	EnableWASMStats                bool `json:"enableWASMStats"`
+	EnableEgressPolicy             bool `json:"enableEgressPolicy"`
+	EnableMulticlusterMode         bool `json:"enableMulticlusterMode"`
+	EnableEgressGateway            bool `json:"enableEgressGateway"`
+	EnableGatewayAPI               bool `json:"enableGatewayAPI"`
+	EnableSnapshotCacheMode        bool `json:"enableSnapshotCacheMode"`
+	EnableAsyncProxyServiceMapping bool `json:"enableAsyncProxyServiceMapping"`
+	EnableIngressBackendPolicy     bool `json:"enableIngressBackendPolicy"`
+	EnableEnvoyActiveHealthChecks  bool `json:"enableEnvoyActiveHealthChecks"`
+	EnableRetryPolicy              bool `json:"enableRetryPolicy"`
+	EnableDNSProxy                 bool `json:"enableDNSProxy"`
+}
+
+// ConfigRolloutInfo is the v1 API representation of the staged rollout of MeshConfig changes.
+type ConfigRolloutInfo struct {
+	Phase              string     `json:"phase"`
+	Message            string     `json:"message,omitempty"`
+	LastTransitionTime *time.Time `json:"lastTransitionTime,omitempty"`
+	StableGeneration   int64      `json:"stableGeneration"`
+	CanaryGeneration   int64      `json:"canaryGeneration,omitempty"`
+	CanaryPercentage   int        `json:"canaryPercentage,omitempty"`
+	CanaryNamespaces   []string   `json:"canaryNamespaces,omitempty"`
+	StartedAt          *time.Time `json:"startedAt,omitempty"`
+	BakeDuration       string     `json:"bakeDuration,omitempty"`
+	NACKs              int        `json:"nacks"`
+	MaxNACKs           int        `json:"maxNACKs"`
+}
+
+// getAPIHandlers returns the handlers for the versioned JSON debug API, keyed by URL.
+func (ds DebugConfig) getAPIHandlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		APIPrefix + "/certs": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, ds.listCertificates())
+		}),
+		APIPrefix + "/proxies": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, ds.listProxies())
+		}),
+		APIPrefix + "/xds": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, ds.listXDSLog())
+		}),
+		APIPrefix + "/policies": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, ds.listPolicies())
+		}),
+		APIPrefix + "/feature-flags": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, ds.getFeatureFlagsInfo())
+		}),
+		APIPrefix + "/config-rollout": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, newConfigRolloutInfo(ds.configurator.GetRolloutStatus()))
+		}),
+	}
+}
+
+// listCertificates returns the certificates in OSM's cache, sorted by common name.
+func (ds DebugConfig) listCertificates() []CertificateInfo {
+	certs := ds.certDebugger.ListIssuedCertificates()
+
+	sort.Slice(certs, func(i, j int) bool {
+		return certs[i].GetCommonName() < certs[j].GetCommonName()
+	})
+
+	infos := make([]CertificateInfo, 0, len(certs))
+	for _, cert := range certs {
+		chain := cert.GetCertificateChain()
+		info := CertificateInfo{
+			CommonName:      cert.GetCommonName().String(),
+			SerialNumber:    cert.GetSerialNumber().String(),
+			Expiration:      cert.GetExpiration(),
+			IssuingCASHA256: fmt.Sprintf("%x", sha256.Sum256(cert.GetIssuingCA())),
+			CertChainSHA256: fmt.Sprintf("%x", sha256.Sum256(chain)),
+		}
+
+		x509, err := certificate.DecodePEMCertificate(chain)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error decoding PEM to x509 SerialNumber=%s", cert.GetSerialNumber())
+		} else {
+			info.SignatureAlgorithm = x509.SignatureAlgorithm.String()
+			info.PublicKeyAlgorithm = x509.PublicKeyAlgorithm.String()
+			info.Version = x509.Version
+			info.X509SerialNumber = fmt.Sprintf("%x", x509.SerialNumber)
+			info.Issuer = x509.Issuer.String()
+			info.Subject = x509.Subject.String()
+			info.NotBefore = &x509.NotBefore
+			info.NotAfter = &x509.NotAfter
+			info.BasicConstraintsValid = x509.BasicConstraintsValid
+			info.IsCA = x509.IsCA
+			info.DNSNames = x509.DNSNames
+		}
+
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// listProxies returns the proxies connected to the controller, sorted by common name.
+func (ds DebugConfig) listProxies() []ProxyInfo {
+	connected := ds.proxyRegistry.ListConnectedProxies()
+
+	infos := make([]ProxyInfo, 0, len(connected))
+	for cn, proxy := range connected {
+		info := ProxyInfo{
+			CommonName:   cn.String(),
+			SerialNumber: proxy.GetCertificateSerialNumber().String(),
+			Kind:         string(proxy.Kind()),
+			ConnectedAt:  proxy.GetConnectedAt(),
+		}
+		if proxy.HasPodMetadata() {
+			info.PodName = proxy.PodMetadata.Name
+			info.PodNamespace = proxy.PodMetadata.Namespace
+			info.PodUID = proxy.PodMetadata.UID
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CommonName < infos[j].CommonName
+	})
+	return infos
+}
+
+// listXDSLog returns the log of xDS responses sent to each proxy, sorted by common name.
+func (ds DebugConfig) listXDSLog() []XDSLogInfo {
+	xdsLog := ds.xdsDebugger.GetXDSLog()
+
+	infos := make([]XDSLogInfo, 0, len(*xdsLog))
+	for proxyCN, xdsTypeWithTimestamps := range *xdsLog {
+		info := XDSLogInfo{
+			CommonName: proxyCN.String(),
+			Responses:  make(map[string][]time.Time, len(xdsTypeWithTimestamps)),
+		}
+		for xdsType, timeStamps := range xdsTypeWithTimestamps {
+			sorted := make([]time.Time, len(timeStamps))
+			copy(sorted, timeStamps)
+			sort.Slice(sorted, func(i, j int) bool {
+				return sorted[i].After(sorted[j])
+			})
+			info.Responses[xdsType.String()] = sorted
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CommonName < infos[j].CommonName
+	})
+	return infos
+}
+
+// listPolicies returns the SMI policies detected by OSM.
+func (ds DebugConfig) listPolicies() PoliciesInfo {
+	trafficSplits, serviceAccounts, routeGroups, trafficTargets := ds.meshCatalogDebugger.ListSMIPolicies()
+
+	info := PoliciesInfo{
+		TrafficSplits:   make([]TrafficSplitInfo, 0, len(trafficSplits)),
+		ServiceAccounts: make([]ServiceAccountInfo, 0, len(serviceAccounts)),
+		RouteGroups:     make([]HTTPRouteGroupInfo, 0, len(routeGroups)),
+		TrafficTargets:  make([]TrafficTargetInfo, 0, len(trafficTargets)),
+	}
+
+	for _, ts := range trafficSplits {
+		split := TrafficSplitInfo{
+			Name:      ts.Name,
+			Namespace: ts.Namespace,
+			Service:   ts.Spec.Service,
+			Backends:  make([]TrafficSplitBackendInfo, 0, len(ts.Spec.Backends)),
+		}
+		for _, backend := range ts.Spec.Backends {
+			split.Backends = append(split.Backends, TrafficSplitBackendInfo{
+				Service: backend.Service,
+				Weight:  backend.Weight,
+			})
+		}
+		info.TrafficSplits = append(info.TrafficSplits, split)
+	}
+
+	for _, sa := range serviceAccounts {
+		info.ServiceAccounts = append(info.ServiceAccounts, ServiceAccountInfo{
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		})
+	}
+
+	for _, rg := range routeGroups {
+		group := HTTPRouteGroupInfo{
+			Name:      rg.Name,
+			Namespace: rg.Namespace,
+			Matches:   make([]HTTPMatchInfo, 0, len(rg.Spec.Matches)),
+		}
+		for _, match := range rg.Spec.Matches {
+			group.Matches = append(group.Matches, HTTPMatchInfo{
+				Name:      match.Name,
+				Methods:   match.Methods,
+				PathRegex: match.PathRegex,
+				Headers:   match.Headers,
+			})
+		}
+		info.RouteGroups = append(info.RouteGroups, group)
+	}
+
+	for _, tt := range trafficTargets {
+		target := TrafficTargetInfo{
+			Name:        tt.Name,
+			Namespace:   tt.Namespace,
+			Destination: IdentitySubjectInfo(tt.Spec.Destination),
+			Sources:     make([]IdentitySubjectInfo, 0, len(tt.Spec.Sources)),
+			Rules:       make([]TrafficTargetRuleInfo, 0, len(tt.Spec.Rules)),
+		}
+		for _, source := range tt.Spec.Sources {
+			target.Sources = append(target.Sources, IdentitySubjectInfo(source))
+		}
+		for _, rule := range tt.Spec.Rules {
+			target.Rules = append(target.Rules, TrafficTargetRuleInfo(rule))
+		}
+		info.TrafficTargets = append(info.TrafficTargets, target)
+	}
+
+	return info
+}
+
+// getFeatureFlagsInfo returns the feature flags in the MeshConfig.
+func (ds DebugConfig) getFeatureFlagsInfo() FeatureFlagsInfo {
+	flags := ds.configurator.GetFeatureFlags()
+	return FeatureFlagsInfo{
+		EnableWASMStats:                flags.EnableWASMStats,
+		EnableEgressPolicy:             flags.EnableEgressPolicy,
+		EnableMulticlusterMode:         flags.EnableMulticlusterMode,
+		EnableEgressGateway:            flags.EnableEgressGateway,
+		EnableGatewayAPI:               flags.EnableGatewayAPI,
+		EnableSnapshotCacheMode:        flags.EnableSnapshotCacheMode,
+		EnableAsyncProxyServiceMapping: flags.EnableAsyncProxyServiceMapping,
+		EnableIngressBackendPolicy:     flags.EnableIngressBackendPolicy,
+		EnableEnvoyActiveHealthChecks:  flags.EnableEnvoyActiveHealthChecks,
+		EnableRetryPolicy:              flags.EnableRetryPolicy,
+		EnableDNSProxy:                 flags.EnableDNSProxy,
+	}
+}
+
+// newConfigRolloutInfo returns the v1 API representation of the given rollout status.
+func newConfigRolloutInfo(status configurator.RolloutStatus) ConfigRolloutInfo {
+	info := ConfigRolloutInfo{
+		Phase:            string(status.Phase),
+		Message:          status.Message,
+		StableGeneration: status.StableGeneration,
+		CanaryGeneration: status.CanaryGeneration,
+		CanaryPercentage: status.CanaryPercentage,
+		CanaryNamespaces: status.CanaryNamespaces,
+		BakeDuration:     status.BakeDuration,
+		NACKs:            status.NACKs,
+		MaxNACKs:         status.MaxNACKs,
+	}
+	if !status.LastTransitionTime.IsZero() {
+		lastTransitionTime := status.LastTransitionTime
+		info.LastTransitionTime = &lastTransitionTime
+	}
+	if !status.StartedAt.IsZero() {
+		startedAt := status.StartedAt
+		info.StartedAt = &startedAt
+	}
+	return info
+}
+
+// wantsJSON returns true if the request prefers a JSON response over the default representation.
+func wantsJSON(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+	return goautoneg.Negotiate(accept, []string{"text/html", "text/plain", contentTypeJSON}) == contentTypeJSON
+}
+
+// writeJSON writes the given value as a JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling %T to JSON", v)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(body)
+}
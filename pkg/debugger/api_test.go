@@ -0,0 +1,199 @@
+package debugger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestWantsJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		accept   string
+		expected bool
+	}{
+		{
+			name:     "no accept header",
+			accept:   "",
+			expected: false,
+		},
+		{
+			name:     "json only",
+			accept:   "application/json",
+			expected: true,
+		},
+		{
+			name:     "html preferred",
+			accept:   "text/html,application/json;q=0.9",
+			expected: false,
+		},
+		{
+			name:     "json preferred",
+			accept:   "text/html;q=0.5,application/json",
+			expected: true,
+		},
+		{
+			name:     "wildcard",
+			accept:   "*/*",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/certs", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			assert.Equal(tc.expected, wantsJSON(req))
+		})
+	}
+
+	tassert.False(t, wantsJSON(nil))
+}
+
+// Tests the certificate views return the same certificates through the versioned API and content negotiation
+func TestGetCertHandlerJSON(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mock := NewMockCertificateManagerDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		certDebugger: mock,
+	}
+
+	testCert, err := tresor.NewCA("commonName", 1*time.Hour, "Country", "Locale", "Org")
+	assert.Nil(err)
+
+	mock.EXPECT().ListIssuedCertificates().Return([]*certificate.Certificate{
+		testCert,
+	}).Times(2)
+
+	for _, handler := range []http.Handler{ds.getCertHandler(), ds.getAPIHandlers()[APIPrefix+"/certs"]} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/certs", nil)
+		req.Header.Set("Accept", "application/json")
+		responseRecorder := httptest.NewRecorder()
+		handler.ServeHTTP(responseRecorder, req)
+
+		assert.Equal("application/json", responseRecorder.Header().Get("Content-Type"))
+
+		var certs []CertificateInfo
+		assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &certs))
+		assert.Len(certs, 1)
+		assert.Equal("commonName", certs[0].CommonName)
+		assert.True(certs[0].IsCA)
+		assert.NotEmpty(certs[0].CertChainSHA256)
+		assert.NotNil(certs[0].NotBefore)
+		assert.NotNil(certs[0].NotAfter)
+	}
+}
+
+// Tests the versioned API returns the SMI policies as v1 API types rather than the SMI types
+func TestListPolicies(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		meshCatalogDebugger: mock,
+	}
+
+	mock.EXPECT().ListSMIPolicies().Return(
+		[]*split.TrafficSplit{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      "bar",
+				},
+				Spec: split.TrafficSplitSpec{
+					Service: "bookstore",
+					Backends: []split.TrafficSplitBackend{
+						{Service: "bookstore-v1", Weight: 100},
+					},
+				},
+			},
+		},
+		[]identity.K8sServiceAccount{
+			tests.BookbuyerServiceAccount,
+		},
+		[]*spec.HTTPRouteGroup{
+			&tests.HTTPRouteGroup,
+		},
+		[]*access.TrafficTarget{
+			&tests.TrafficTarget,
+		},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, APIPrefix+"/policies", nil)
+	responseRecorder := httptest.NewRecorder()
+	ds.getAPIHandlers()[APIPrefix+"/policies"].ServeHTTP(responseRecorder, req)
+
+	var actual PoliciesInfo
+	assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &actual))
+	assert.Equal([]TrafficSplitInfo{
+		{
+			Name:      "bar",
+			Namespace: "foo",
+			Service:   "bookstore",
+			Backends:  []TrafficSplitBackendInfo{{Service: "bookstore-v1", Weight: 100}},
+		},
+	}, actual.TrafficSplits)
+	assert.Equal([]ServiceAccountInfo{{Name: "bookbuyer", Namespace: "default"}}, actual.ServiceAccounts)
+	assert.Len(actual.RouteGroups, 1)
+	assert.Equal("bookstore-service-routes", actual.RouteGroups[0].Name)
+	assert.Equal(HTTPMatchInfo{
+		Name:      "buy-books",
+		Methods:   []string{"GET"},
+		PathRegex: "/buy",
+		Headers:   map[string]string{"user-agent": "test-UA"},
+	}, actual.RouteGroups[0].Matches[0])
+	assert.Equal([]TrafficTargetInfo{
+		{
+			Name:        "bookbuyer-access-bookstore",
+			Namespace:   "default",
+			Destination: IdentitySubjectInfo{Kind: "ServiceAccount", Name: "bookstore", Namespace: "default"},
+			Sources:     []IdentitySubjectInfo{{Kind: "ServiceAccount", Name: "bookbuyer", Namespace: "default"}},
+			Rules: []TrafficTargetRuleInfo{
+				{Kind: "HTTPRouteGroup", Name: "bookstore-service-routes", Matches: []string{"buy-books", "sell-books"}},
+			},
+		},
+	}, actual.TrafficTargets)
+}
+
+func TestGetFeatureFlagsInfo(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfig := configurator.NewMockConfigurator(mockCtrl)
+
+	ds := DebugConfig{
+		configurator: mockConfig,
+	}
+
+	mockConfig.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
+		EnableEgressPolicy: true,
+		EnableRetryPolicy:  true,
+	})
+
+	assert.Equal(FeatureFlagsInfo{
+		EnableEgressPolicy: true,
+		EnableRetryPolicy:  true,
+	}, ds.getFeatureFlagsInfo())
+}
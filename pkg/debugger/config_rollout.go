@@ -0,0 +1,37 @@
+package debugger
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (ds DebugConfig) getConfigRolloutHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := ds.configurator.GetRolloutStatus()
+		if wantsJSON(r) {
+			writeJSON(w, newConfigRolloutInfo(status))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "Phase: %s\n", status.Phase)
+		if status.Message != "" {
+			_, _ = fmt.Fprintf(w, "Message: %s\n", status.Message)
+		}
+		if !status.LastTransitionTime.IsZero() {
+			_, _ = fmt.Fprintf(w, "Last transition: %+v (%+v ago)\n", status.LastTransitionTime, time.Since(status.LastTransitionTime))
+		}
+		_, _ = fmt.Fprintf(w, "Stable MeshConfig generation: %d\n", status.StableGeneration)
+		if status.CanaryGeneration == 0 {
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "Canary MeshConfig generation: %d\n", status.CanaryGeneration)
+		_, _ = fmt.Fprintf(w, "Canary percentage: %d%%\n", status.CanaryPercentage)
+		_, _ = fmt.Fprintf(w, "Canary namespaces: %s\n", strings.Join(status.CanaryNamespaces, ", "))
+		_, _ = fmt.Fprintf(w, "Started: %+v (%+v ago)\n", status.StartedAt, time.Since(status.StartedAt))
+		_, _ = fmt.Fprintf(w, "Bake duration: %s\n", status.BakeDuration)
+		_, _ = fmt.Fprintf(w, "NACKs: %d/%d\n", status.NACKs, status.MaxNACKs)
+	})
+}
@@ -0,0 +1,78 @@
+package debugger
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+func TestGetConfigRolloutHandler(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfig := configurator.NewMockConfigurator(mockCtrl)
+
+	ds := DebugConfig{
+		configurator: mockConfig,
+	}
+
+	status := configurator.RolloutStatus{
+		Phase:              configurator.RolloutPhaseProgressing,
+		Message:            "Rolling out MeshConfig generation 3 to canary proxies",
+		LastTransitionTime: time.Now(),
+		StableGeneration:   2,
+		CanaryGeneration:   3,
+		CanaryPercentage:   10,
+		CanaryNamespaces:   []string{"bookbuyer"},
+		StartedAt:          time.Now(),
+		BakeDuration:       "5m0s",
+		NACKs:              1,
+		MaxNACKs:           2,
+	}
+	mockConfig.EXPECT().GetRolloutStatus().Return(status).Times(2)
+
+	handler := ds.getConfigRolloutHandler()
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, nil)
+	body := responseRecorder.Body.String()
+	assert.Contains(body, "Phase: Progressing")
+	assert.Contains(body, "Canary MeshConfig generation: 3")
+	assert.Contains(body, "Canary namespaces: bookbuyer")
+	assert.Contains(body, "NACKs: 1/2")
+
+	req := httptest.NewRequest("GET", "/debug/config-rollout", nil)
+	req.Header.Set("Accept", contentTypeJSON)
+	responseRecorder = httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+	assert.Equal(contentTypeJSON, responseRecorder.Header().Get("Content-Type"))
+
+	var actual ConfigRolloutInfo
+	assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &actual))
+	assert.Equal(string(status.Phase), actual.Phase)
+	assert.Equal(status.CanaryGeneration, actual.CanaryGeneration)
+	assert.Equal(status.NACKs, actual.NACKs)
+	assert.NotNil(actual.StartedAt)
+}
+
+func TestNewConfigRolloutInfo(t *testing.T) {
+	assert := tassert.New(t)
+
+	info := newConfigRolloutInfo(configurator.RolloutStatus{
+		Phase:            configurator.RolloutPhaseStable,
+		StableGeneration: 2,
+	})
+	assert.Equal(string(configurator.RolloutPhaseStable), info.Phase)
+	assert.Nil(info.LastTransitionTime)
+	assert.Nil(info.StartedAt)
+
+	body, err := json.Marshal(info)
+	assert.Nil(err)
+	assert.NotContains(string(body), "lastTransitionTime")
+	assert.NotContains(string(body), "startedAt")
+}
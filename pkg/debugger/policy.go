@@ -1,7 +1,6 @@
 package debugger
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -34,12 +33,6 @@ func (ds DebugConfig) getSMIPoliciesHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var p policies
 		p.TrafficSplits, p.ServiceAccounts, p.RouteGroups, p.TrafficTargets = ds.meshCatalogDebugger.ListSMIPolicies()
-
-		jsonPolicies, err := json.Marshal(p)
-		if err != nil {
-			log.Error().Err(err).Msgf("Error marshalling policy %+v", p)
-		}
-
-		_, _ = fmt.Fprint(w, string(jsonPolicies))
+		writeJSON(w, p)
 	})
 }
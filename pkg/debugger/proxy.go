@@ -3,7 +3,6 @@ package debugger
 import (
 	"fmt"
 	"net/http"
-	"sort"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -16,43 +15,28 @@ const (
 )
 
 func (ds DebugConfig) getProxies() http.Handler {
-	// This function is needed to convert the list of connected proxies to
-	// the type (map) required by the printProxies function.
-	listConnected := func() map[certificate.CommonName]time.Time {
-		proxies := make(map[certificate.CommonName]time.Time)
-		for cn, proxy := range ds.proxyRegistry.ListConnectedProxies() {
-			proxies[cn] = (*proxy).GetConnectedAt()
-		}
-		return proxies
-	}
-
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
 		if proxyConfigDump, ok := r.URL.Query()[proxyConfigQueryKey]; ok {
 			ds.getConfigDump(certificate.CommonName(proxyConfigDump[0]), w)
 		} else if specificProxy, ok := r.URL.Query()[specificProxyQueryKey]; ok {
 			ds.getProxy(certificate.CommonName(specificProxy[0]), w)
+		} else if wantsJSON(r) {
+			writeJSON(w, ds.listProxies())
 		} else {
-			printProxies(w, listConnected(), "Connected")
+			w.Header().Set("Content-Type", "text/html")
+			printProxies(w, ds.listProxies(), "Connected")
 		}
 	})
 }
 
-func printProxies(w http.ResponseWriter, proxies map[certificate.CommonName]time.Time, category string) {
-	var commonNames []string
-	for cn := range proxies {
-		commonNames = append(commonNames, cn.String())
-	}
-
-	sort.Strings(commonNames)
-
+func printProxies(w http.ResponseWriter, proxies []ProxyInfo, category string) {
 	_, _ = fmt.Fprintf(w, "<h1>%s Proxies (%d):</h1>", category, len(proxies))
 	_, _ = fmt.Fprint(w, `<table>`)
 	_, _ = fmt.Fprint(w, "<tr><td>#</td><td>Envoy's certificate CN</td><td>Connected At</td><td>How long ago</td><td>tools</td></tr>")
-	for idx, cn := range commonNames {
-		ts := proxies[certificate.CommonName(cn)]
+	for idx, proxy := range proxies {
+		cn := proxy.CommonName
 		_, _ = fmt.Fprintf(w, `<tr><td>%d:</td><td>%s</td><td>%+v</td><td>(%+v ago)</td><td><a href="/debug/proxy?%s=%s">certs</a></td><td><a href="/debug/proxy?%s=%s">cfg</a></td></tr>`,
-			idx, cn, ts, time.Since(ts), specificProxyQueryKey, cn, proxyConfigQueryKey, cn)
+			idx, cn, proxy.ConnectedAt, time.Since(proxy.ConnectedAt), specificProxyQueryKey, cn, proxyConfigQueryKey, cn)
 	}
 	_, _ = fmt.Fprint(w, `</table>`)
 }
@@ -16,13 +16,14 @@ import (
 // GetHandlers implements DebugConfig interface and returns the rest of URLs and the handling functions.
 func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 	handlers := map[string]http.Handler{
-		"/debug/certs":         ds.getCertHandler(),
-		"/debug/xds":           ds.getXDSHandler(),
-		"/debug/proxy":         ds.getProxies(),
-		"/debug/policies":      ds.getSMIPoliciesHandler(),
-		"/debug/config":        ds.getOSMConfigHandler(),
-		"/debug/namespaces":    ds.getMonitoredNamespacesHandler(),
-		"/debug/feature-flags": ds.getFeatureFlags(),
+		"/debug/certs":          ds.getCertHandler(),
+		"/debug/xds":            ds.getXDSHandler(),
+		"/debug/proxy":          ds.getProxies(),
+		"/debug/policies":       ds.getSMIPoliciesHandler(),
+		"/debug/config":         ds.getOSMConfigHandler(),
+		"/debug/namespaces":     ds.getMonitoredNamespacesHandler(),
+		"/debug/feature-flags":  ds.getFeatureFlags(),
+		"/debug/config-rollout": ds.getConfigRolloutHandler(),
 
 		// Pprof handlers
 		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
@@ -32,6 +33,11 @@ func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 		"/debug/pprof/trace":   http.HandlerFunc(pprof.Trace),
 	}
 
+	// The versioned JSON API backs the views above
+	for url, handler := range ds.getAPIHandlers() {
+		handlers[url] = handler
+	}
+
 	// provides an index of the available /debug endpoints
 	handlers["/debug"] = ds.getDebugIndex(handlers)
 
@@ -44,6 +44,14 @@ func TestGetHandlers(t *testing.T) {
 		"/debug/policies",
 		"/debug/config",
 		"/debug/namespaces",
+		"/debug/config-rollout",
+		// Versioned JSON API
+		"/debug/api/v1/certs",
+		"/debug/api/v1/proxies",
+		"/debug/api/v1/xds",
+		"/debug/api/v1/policies",
+		"/debug/api/v1/feature-flags",
+		"/debug/api/v1/config-rollout",
 		// Pprof handlers
 		"/debug/pprof/",
 		"/debug/pprof/cmdline",
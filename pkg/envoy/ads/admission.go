@@ -0,0 +1,140 @@
+package ads
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// admissionController protects the control plane from reconnect storms, such as when the proxies reconnect after
+// the controller restarts. New streams are admitted at the rate of a token bucket, and the number of concurrent
+// full configuration generations is bounded. Gateways are prioritized and bypass both limits.
+type admissionController struct {
+	cfg configurator.Configurator
+
+	limiterMu sync.Mutex
+	// limiter is the token bucket admitting new streams, recreated when the admission rate or burst changes
+	limiter *rate.Limiter
+
+	generationsMu sync.Mutex
+	generations   int
+	// generationReleased is closed and replaced each time a configuration generation completes
+	generationReleased chan struct{}
+}
+
+func newAdmissionController(cfg configurator.Configurator) *admissionController {
+	return &admissionController{
+		cfg:                cfg,
+		generationReleased: make(chan struct{}),
+	}
+}
+
+// isPrioritized returns whether the given proxy is admitted ahead of the others
+func isPrioritized(proxy *envoy.Proxy) bool {
+	switch proxy.Kind() {
+	case envoy.KindGateway, envoy.KindEgressGateway, envoy.KindIngressGateway:
+		return true
+	default:
+		return false
+	}
+}
+
+// admit waits until the new stream of the given proxy can be admitted. Streams that would wait longer than the
+// configured max queue duration are rejected with an error hinting the proxy to retry after a backoff.
+func (a *admissionController) admit(ctx context.Context, proxy *envoy.Proxy) error {
+	settings := a.cfg.GetDataPlaneConnectionAdmission()
+	if settings.ConnectionsPerSecond <= 0 || isPrioritized(proxy) {
+		return nil
+	}
+
+	a.limiterMu.Lock()
+	limit := rate.Limit(settings.ConnectionsPerSecond)
+	if a.limiter == nil || a.limiter.Limit() != limit || a.limiter.Burst() != settings.Burst {
+		a.limiter = rate.NewLimiter(limit, settings.Burst)
+	}
+	reservation := a.limiter.Reserve()
+	a.limiterMu.Unlock()
+
+	if !reservation.OK() {
+		metricsstore.DefaultMetricsStore.ProxyConnectionsThrottled.Inc()
+		return newThrottledError(settings.MaxQueueDuration)
+	}
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if delay > settings.MaxQueueDuration {
+		reservation.Cancel()
+		metricsstore.DefaultMetricsStore.ProxyConnectionsThrottled.Inc()
+		log.Debug().Str("proxy", proxy.String()).Msgf("Rejecting new stream, retry after %s", delay)
+		return newThrottledError(delay)
+	}
+
+	log.Debug().Str("proxy", proxy.String()).Msgf("Queueing new stream for %s", delay)
+	metricsstore.DefaultMetricsStore.ProxyConnectionsQueued.Inc()
+	defer metricsstore.DefaultMetricsStore.ProxyConnectionsQueued.Dec()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		metricsstore.DefaultMetricsStore.ProxyConnectionsDelayed.Inc()
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+// newThrottledError returns the gRPC status error of a rejected stream, hinting the proxy to retry after the given delay
+func newThrottledError(retryDelay time.Duration) error {
+	return newRetryError(codes.ResourceExhausted, errTooManyNewConnections, retryDelay)
+}
+
+// acquireGeneration waits until a full configuration can be generated for the given proxy without exceeding the
+// configured max concurrent configuration generations. The returned function must be called once the generation completes.
+func (a *admissionController) acquireGeneration(ctx context.Context, proxy *envoy.Proxy) (func(), error) {
+	prioritized := isPrioritized(proxy)
+	queued := false
+	for {
+		a.generationsMu.Lock()
+		maxGenerations := a.cfg.GetDataPlaneConnectionAdmission().MaxConcurrentConfigGenerations
+		if prioritized || maxGenerations <= 0 || a.generations < maxGenerations {
+			a.generations++
+			a.generationsMu.Unlock()
+			if queued {
+				metricsstore.DefaultMetricsStore.ProxyConfigGenerationsQueued.Dec()
+			}
+			return a.releaseGeneration, nil
+		}
+		released := a.generationReleased
+		a.generationsMu.Unlock()
+
+		if !queued {
+			queued = true
+			metricsstore.DefaultMetricsStore.ProxyConfigGenerationsQueued.Inc()
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			metricsstore.DefaultMetricsStore.ProxyConfigGenerationsQueued.Dec()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// releaseGeneration releases a configuration generation acquired with acquireGeneration
+func (a *admissionController) releaseGeneration() {
+	a.generationsMu.Lock()
+	defer a.generationsMu.Unlock()
+	a.generations--
+	close(a.generationReleased)
+	a.generationReleased = make(chan struct{})
+}
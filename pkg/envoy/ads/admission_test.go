@@ -0,0 +1,172 @@
+package ads
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func newTestProxy(t *testing.T, kind envoy.ProxyKind) *envoy.Proxy {
+	proxy, err := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.sa.ns", uuid.New(), kind)), "123", nil)
+	tassert.Nil(t, err)
+	return proxy
+}
+
+func TestAdmit(t *testing.T) {
+	testCases := []struct {
+		name              string
+		admission         configurator.DataPlaneConnectionAdmission
+		kind              envoy.ProxyKind
+		expectAdmitted    int
+		expectMinDuration time.Duration
+	}{
+		{
+			name:           "admission control disabled",
+			admission:      configurator.DataPlaneConnectionAdmission{},
+			kind:           envoy.KindSidecar,
+			expectAdmitted: 3,
+		},
+		{
+			name: "streams beyond the burst are rejected when they would wait beyond the max queue duration",
+			admission: configurator.DataPlaneConnectionAdmission{
+				ConnectionsPerSecond: 10,
+				Burst:                2,
+				MaxQueueDuration:     50 * time.Millisecond,
+			},
+			kind:           envoy.KindSidecar,
+			expectAdmitted: 2,
+		},
+		{
+			name: "streams beyond the burst are delayed",
+			admission: configurator.DataPlaneConnectionAdmission{
+				ConnectionsPerSecond: 10,
+				Burst:                1,
+				MaxQueueDuration:     time.Second,
+			},
+			kind:              envoy.KindSidecar,
+			expectAdmitted:    3,
+			expectMinDuration: 150 * time.Millisecond,
+		},
+		{
+			name: "gateways are not throttled",
+			admission: configurator.DataPlaneConnectionAdmission{
+				ConnectionsPerSecond: 10,
+				Burst:                1,
+				MaxQueueDuration:     0,
+			},
+			kind:           envoy.KindGateway,
+			expectAdmitted: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetDataPlaneConnectionAdmission().Return(tc.admission).AnyTimes()
+
+			a := newAdmissionController(mockConfigurator)
+
+			start := time.Now()
+			admitted := 0
+			for i := 0; i < 3; i++ {
+				err := a.admit(context.Background(), newTestProxy(t, tc.kind))
+				if err == nil {
+					admitted++
+					continue
+				}
+
+				// Rejected streams are hinted to retry after a backoff
+				st := status.Convert(err)
+				assert.Equal(codes.ResourceExhausted, st.Code())
+				assert.Len(st.Details(), 1)
+				retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+				assert.True(ok)
+				assert.Greater(retryInfo.RetryDelay.AsDuration(), tc.admission.MaxQueueDuration)
+			}
+
+			assert.Equal(tc.expectAdmitted, admitted)
+			assert.GreaterOrEqual(time.Since(start), tc.expectMinDuration)
+		})
+	}
+}
+
+func TestAdmitCanceled(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetDataPlaneConnectionAdmission().Return(configurator.DataPlaneConnectionAdmission{
+		ConnectionsPerSecond: 1,
+		Burst:                1,
+		MaxQueueDuration:     time.Minute,
+	}).AnyTimes()
+
+	a := newAdmissionController(mockConfigurator)
+	assert.Nil(a.admit(context.Background(), newTestProxy(t, envoy.KindSidecar)))
+
+	// A queued stream ending stops waiting for admission
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(a.admit(ctx, newTestProxy(t, envoy.KindSidecar)), context.DeadlineExceeded)
+}
+
+func TestAcquireGeneration(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetDataPlaneConnectionAdmission().Return(configurator.DataPlaneConnectionAdmission{
+		MaxConcurrentConfigGenerations: 1,
+	}).AnyTimes()
+
+	a := newAdmissionController(mockConfigurator)
+	sidecar := newTestProxy(t, envoy.KindSidecar)
+
+	release, err := a.acquireGeneration(context.Background(), sidecar)
+	assert.Nil(err)
+
+	// Gateways are not bounded by the max concurrent generations
+	releaseGateway, err := a.acquireGeneration(context.Background(), newTestProxy(t, envoy.KindGateway))
+	assert.Nil(err)
+	releaseGateway()
+
+	// A generation waits for a slot and gives up once its stream ends
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = a.acquireGeneration(ctx, sidecar)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	// A generation waiting for a slot proceeds once it is released
+	acquired := make(chan struct{})
+	go func() {
+		releaseNext, err := a.acquireGeneration(context.Background(), sidecar)
+		assert.Nil(err)
+		close(acquired)
+		releaseNext()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("generation should wait for a slot to be released")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("generation should proceed once a slot is released")
+	}
+}
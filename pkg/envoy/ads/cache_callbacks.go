@@ -2,8 +2,13 @@ package ads
 
 import (
 	"context"
+	"sync"
 
 	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // Callbacks is an implementation of xDS server callbacks required by go-control-plane
@@ -12,24 +17,77 @@ import (
 // debug and instrument additional functionality on top of the cache.
 // Sample implementation from https://github.com/envoyproxy/go-control-plane/blob/main/docs/cache/Server.md
 type Callbacks struct {
-	// empty
+	server *Server
+
+	// streamNodeIDs maps the ID of an open stream to the node ID of the proxy, once known
+	streamNodeIDs   map[int64]string
+	streamNodeIDsMu sync.Mutex
+}
+
+func newCallbacks(server *Server) *Callbacks {
+	return &Callbacks{
+		server:        server,
+		streamNodeIDs: make(map[int64]string),
+	}
 }
 
 // OnStreamOpen is called on stream open
-func (cb *Callbacks) OnStreamOpen(_ context.Context, id int64, typ string) error {
+func (cb *Callbacks) OnStreamOpen(ctx context.Context, id int64, typ string) error {
 	// TODO: Validate context
 	log.Debug().Msgf("OnStreamOpen id: %d typ: %s", id, typ)
+	if cb.server == nil {
+		return nil
+	}
+
+	// Track the stream to end it when the control plane shuts down. New streams are rejected while shutting down.
+	drain, err := cb.server.drainer.register(id, utils.GetIPFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	if cancel, ok := ctx.Value(streamCancelKey{}).(context.CancelFunc); ok {
+		go func() {
+			select {
+			case <-drain:
+				log.Debug().Msgf("Closing stream id: %d, control plane is shutting down", id)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
 	return nil
 }
 
 // OnStreamClosed is called on stream closed
 func (cb *Callbacks) OnStreamClosed(id int64) {
 	log.Debug().Msgf("OnStreamClosed id: %d", id)
+	if cb.server != nil {
+		cb.server.drainer.unregister(id)
+	}
+
+	cb.streamNodeIDsMu.Lock()
+	nodeID, ok := cb.streamNodeIDs[id]
+	delete(cb.streamNodeIDs, id)
+	cb.streamNodeIDsMu.Unlock()
+	if !ok || cb.server == nil {
+		return
+	}
+	// The node ID is the certificate common name of the proxy
+	proxy, err := envoy.NewProxy(certificate.CommonName(nodeID), "NoSerial", nil)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Error releasing proxy for stream id: %d", id)
+		return
+	}
+	cb.server.releaseProxy(proxy)
 }
 
 // OnStreamRequest is called when a request happens on an open string
 func (cb *Callbacks) OnStreamRequest(a int64, req *discovery.DiscoveryRequest) error {
 	log.Debug().Msgf("OnStreamRequest node: %s, type: %s, v: %s, nonce: %s, resNames: %s", req.Node.Id, req.TypeUrl, req.VersionInfo, req.ResponseNonce, req.ResourceNames)
+	if req.Node != nil {
+		cb.streamNodeIDsMu.Lock()
+		cb.streamNodeIDs[a] = req.Node.Id
+		cb.streamNodeIDsMu.Unlock()
+	}
 	return nil
 }
 
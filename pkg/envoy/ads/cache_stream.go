@@ -2,8 +2,8 @@ package ads
 
 import (
 	"context"
-	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
@@ -16,6 +16,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 // Routine which fulfills listening to proxy broadcasts
@@ -84,25 +85,88 @@ func GetProxyFromPod(pod *v1.Pod) (*envoy.Proxy, error) {
 	return tempProxy, err
 }
 
+// proxySnapshot records the versions and hashes of the resources of the last snapshot stored for a proxy, by TypeURI
+type proxySnapshot struct {
+	versions map[string]string
+	hashes   map[string]map[string]uint64
+}
+
 // RecordFullSnapshot stores a group of resources as a new Snapshot with a new version in the cache.
+// Resource types unchanged since the last snapshot of the proxy keep their version so that they are not pushed
+// again, and no new snapshot is stored when all of them are unchanged.
 // It also runs a consistency check on the snapshot (will warn if there are missing resources referenced in
 // the snapshot)
 func (s *Server) RecordFullSnapshot(proxy *envoy.Proxy, snapshotResources map[string][]types.Resource) error {
+	proxyCN := proxy.GetCertificateCommonName().String()
+
+	hashes := make(map[string]map[string]uint64, len(snapshotResources))
+	for typeURL, resources := range snapshotResources {
+		typeHashes, err := hashResources(resources)
+		if err != nil {
+			log.Warn().Err(err).Str("proxy", proxy.String()).Msgf("Error hashing resources for typeURI: %s", envoy.TypeURI(typeURL).Short())
+			continue
+		}
+		hashes[typeURL] = typeHashes
+	}
+
 	s.configVerMutex.Lock()
-	s.configVersion[proxy.GetCertificateCommonName().String()]++
+	lastSnapshot := s.lastSnapshots[proxyCN]
+	version := strconv.FormatUint(s.configVersion[proxyCN]+1, 10)
+	versions := make(map[string]string, len(snapshotResources))
+	changed := lastSnapshot == nil || len(lastSnapshot.versions) != len(snapshotResources)
+	// Envoy re-initializes changed clusters, which remain warming until they receive their endpoints,
+	// so the endpoints of the changed clusters are always given a new version even when unchanged
+	var changedClusters map[string]struct{}
+	if lastSnapshot != nil {
+		changedClusters = changedResourceNames(lastSnapshot.hashes[envoy.TypeCDS.String()], hashes[envoy.TypeCDS.String()])
+	}
+	for typeURL := range snapshotResources {
+		if lastSnapshot != nil {
+			lastHashes, ok := lastSnapshot.hashes[typeURL]
+			typeHashes, hashed := hashes[typeURL]
+			if ok && hashed && resourceHashesEqual(lastHashes, typeHashes) &&
+				!(typeURL == envoy.TypeEDS.String() && containsAnyResource(typeHashes, changedClusters)) {
+				versions[typeURL] = lastSnapshot.versions[typeURL]
+				metricsstore.DefaultMetricsStore.ProxyXDSPushSkippedCount.WithLabelValues(typeURL).Inc()
+				continue
+			}
+		}
+		versions[typeURL] = version
+		changed = true
+	}
+	if !changed {
+		s.configVerMutex.Unlock()
+		log.Debug().Str("proxy", proxy.String()).Msg("Skipping snapshot of unchanged resources")
+		return nil
+	}
+	s.configVersion[proxyCN]++
+	s.lastSnapshots[proxyCN] = &proxySnapshot{versions: versions, hashes: hashes}
 	s.configVerMutex.Unlock()
 
-	snapshot, err := cache.NewSnapshot(
-		fmt.Sprintf("%d", s.configVersion[proxy.GetCertificateCommonName().String()]),
-		snapshotResources,
-	)
-	if err != nil {
-		return err
+	var snapshot cache.Snapshot
+	for typeURL, resources := range snapshotResources {
+		index := cache.GetResponseType(typeURL)
+		if index == types.UnknownType {
+			s.forgetSnapshot(proxyCN)
+			return errors.Errorf("unknown resource type: %s", typeURL)
+		}
+		snapshot.Resources[index] = cache.NewResources(versions[typeURL], resources)
 	}
 
 	if err := snapshot.Consistent(); err != nil {
 		log.Warn().Err(err).Str("proxy", proxy.String()).Msgf("Snapshot for proxy not consistent")
 	}
 
-	return s.ch.SetSnapshot(context.TODO(), proxy.GetCertificateCommonName().String(), snapshot)
+	if err := s.ch.SetSnapshot(context.TODO(), proxyCN, snapshot); err != nil {
+		s.forgetSnapshot(proxyCN)
+		return err
+	}
+	return nil
+}
+
+// forgetSnapshot forgets the last snapshot of the given proxy, so that its next snapshot is stored in full
+func (s *Server) forgetSnapshot(proxyCN string) {
+	s.configVerMutex.Lock()
+	defer s.configVerMutex.Unlock()
+	delete(s.lastSnapshots, proxyCN)
 }
@@ -3,6 +3,9 @@ package ads
 import (
 	"testing"
 
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
@@ -11,6 +14,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
 )
 
 func TestGetProxyFromPod(t *testing.T) {
@@ -95,3 +99,59 @@ func TestGetProxyFromPod(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordFullSnapshot(t *testing.T) {
+	assert := tassert.New(t)
+
+	s := &Server{
+		ch:               cachev3.NewSnapshotCache(false, cachev3.IDHash{}, &scLogger{}),
+		configVersion:    make(map[string]uint64),
+		lastSnapshots:    make(map[string]*proxySnapshot),
+		routeConfigCache: rds.NewRouteConfigCache(),
+	}
+	proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "sa", "ns"), "123", nil)
+	assert.Nil(err)
+	proxyCN := proxy.GetCertificateCommonName().String()
+
+	getVersions := func() (string, string, string) {
+		snapshot, err := s.ch.GetSnapshot(proxyCN)
+		assert.Nil(err)
+		return snapshot.GetVersion(envoy.TypeCDS.String()), snapshot.GetVersion(envoy.TypeEDS.String()), snapshot.GetVersion(envoy.TypeLDS.String())
+	}
+	newResources := func(connectTimeoutSeconds int64) map[string][]types.Resource {
+		return map[string][]types.Resource{
+			envoy.TypeCDS.String(): newTestClusters(connectTimeoutSeconds),
+			envoy.TypeEDS.String(): {&xds_endpoint.ClusterLoadAssignment{ClusterName: "bar"}},
+			envoy.TypeLDS.String(): nil,
+		}
+	}
+
+	assert.Nil(s.RecordFullSnapshot(proxy, newResources(1)))
+	cdsVersion, edsVersion, ldsVersion := getVersions()
+	assert.Equal("1", cdsVersion)
+	assert.Equal("1", edsVersion)
+	assert.Equal("1", ldsVersion)
+
+	// Unchanged resources do not result in a new snapshot
+	assert.Nil(s.RecordFullSnapshot(proxy, newResources(1)))
+	assert.Equal(uint64(1), s.configVersion[proxyCN])
+
+	// Only the changed resource types get a new version, and the unchanged endpoints of changed clusters
+	assert.Nil(s.RecordFullSnapshot(proxy, newResources(2)))
+	cdsVersion, edsVersion, ldsVersion = getVersions()
+	assert.Equal("2", cdsVersion)
+	assert.Equal("2", edsVersion)
+	assert.Equal("1", ldsVersion)
+
+	// The last snapshot is forgotten when the stream of the proxy is closed, while its config version is kept
+	s.releaseProxy(proxy)
+	assert.NotContains(s.lastSnapshots, proxyCN)
+	assert.Equal(uint64(2), s.configVersion[proxyCN])
+
+	// The next snapshot is stored in full with a new version
+	assert.Nil(s.RecordFullSnapshot(proxy, newResources(2)))
+	cdsVersion, edsVersion, ldsVersion = getVersions()
+	assert.Equal("3", cdsVersion)
+	assert.Equal("3", edsVersion)
+	assert.Equal("3", ldsVersion)
+}
@@ -0,0 +1,264 @@
+package ads
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// drainBatchInterval is the minimum interval between two batches of streams closed while draining
+	drainBatchInterval = time.Second
+
+	// drainStreamTimeout is the maximum duration to wait for a drained stream to end before closing its connection
+	drainStreamTimeout = 5 * time.Second
+)
+
+// streamDrainer tracks the ADS streams and their connections, so that they can be closed in batches when the
+// control plane shuts down instead of all at once, which would cause all the proxies to reconnect simultaneously.
+type streamDrainer struct {
+	batchInterval time.Duration
+
+	mu       sync.Mutex
+	draining bool
+	// streams are keyed by the proxy for the streams served by the Server, or by the stream ID for the streams
+	// served by the snapshot cache server
+	streams map[interface{}]*drainedStream
+	// conns are the connections accepted by the ADS server, keyed by their remote address
+	conns map[string]net.Conn
+}
+
+// drainedStream is an ADS stream that can be drained
+type drainedStream struct {
+	// addr is the remote address of the stream's connection
+	addr string
+	// drain is closed to signal the stream to end
+	drain chan struct{}
+	// done is closed once the stream has ended
+	done chan struct{}
+}
+
+func newStreamDrainer() *streamDrainer {
+	return &streamDrainer{
+		batchInterval: drainBatchInterval,
+		streams:       make(map[interface{}]*drainedStream),
+		conns:         make(map[string]net.Conn),
+	}
+}
+
+// isDraining returns whether the streams are being drained
+func (d *streamDrainer) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// register registers the stream with the given key, and returns the channel closed when the stream must end.
+// New streams are rejected once draining has started.
+func (d *streamDrainer) register(key interface{}, addr net.Addr) (<-chan struct{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return nil, newRetryError(codes.Unavailable, errShuttingDown, 0)
+	}
+
+	stream := &drainedStream{
+		drain: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if addr != nil {
+		stream.addr = addr.String()
+	}
+	d.streams[key] = stream
+	return stream.drain, nil
+}
+
+// unregister unregisters the stream with the given key once it has ended
+func (d *streamDrainer) unregister(key interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if stream, ok := d.streams[key]; ok {
+		close(stream.done)
+		delete(d.streams, key)
+	}
+}
+
+// drain stops accepting new streams and connections, and closes the existing streams in batches spread over the
+// given window. It returns once all the streams have been closed.
+func (d *streamDrainer) drain(window time.Duration) {
+	d.mu.Lock()
+	d.draining = true
+	streams := make([]*drainedStream, 0, len(d.streams))
+	for _, stream := range d.streams {
+		streams = append(streams, stream)
+	}
+	d.mu.Unlock()
+
+	if len(streams) == 0 {
+		return
+	}
+
+	batches := int(window / d.batchInterval)
+	if batches < 1 {
+		batches = 1
+	}
+	if batches > len(streams) {
+		batches = len(streams)
+	}
+	batchSize := (len(streams) + batches - 1) / batches
+	interval := window / time.Duration(batches)
+
+	log.Info().Msgf("Draining %d ADS streams in %d batches over %s", len(streams), batches, window)
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(streams); i += batchSize {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		end := i + batchSize
+		if end > len(streams) {
+			end = len(streams)
+		}
+		for _, stream := range streams[i:end] {
+			wg.Add(1)
+			go func(stream *drainedStream) {
+				defer wg.Done()
+				d.closeStream(stream)
+			}(stream)
+		}
+	}
+	wg.Wait()
+
+	log.Info().Msgf("Drained %d ADS streams", len(streams))
+}
+
+// closeStream signals the given stream to end, and closes its connection once it has ended. Closing the connection
+// hints the proxy to reconnect through a new connection, which is routed to another control plane instance, instead
+// of opening a new stream on the connection to this instance.
+func (d *streamDrainer) closeStream(stream *drainedStream) {
+	close(stream.drain)
+	select {
+	case <-stream.done:
+	case <-time.After(drainStreamTimeout):
+		log.Warn().Msgf("Timed out waiting for the ADS stream from %s to end, closing its connection", stream.addr)
+	}
+
+	d.mu.Lock()
+	conn, ok := d.conns[stream.addr]
+	d.mu.Unlock()
+	if ok {
+		_ = conn.Close()
+	}
+}
+
+// listener returns a net.Listener tracking the connections accepted by the given listener, and rejecting new
+// connections once draining has started
+func (d *streamDrainer) listener(lis net.Listener) net.Listener {
+	return &drainListener{Listener: lis, drainer: d}
+}
+
+// drainListener is a net.Listener tracking the accepted connections for the streamDrainer
+type drainListener struct {
+	net.Listener
+	drainer *streamDrainer
+}
+
+// Accept waits for and returns the next connection, closing the connections accepted while draining
+func (l *drainListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		tracked := &drainConn{Conn: conn, drainer: l.drainer}
+		l.drainer.mu.Lock()
+		draining := l.drainer.draining
+		if !draining {
+			l.drainer.conns[conn.RemoteAddr().String()] = tracked
+		}
+		l.drainer.mu.Unlock()
+
+		if draining {
+			_ = conn.Close()
+			continue
+		}
+		return tracked, nil
+	}
+}
+
+// drainConn is a net.Conn untracked from the streamDrainer once closed
+type drainConn struct {
+	net.Conn
+	drainer   *streamDrainer
+	closeOnce sync.Once
+}
+
+// Close closes the connection and untracks it
+func (c *drainConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.drainer.mu.Lock()
+		defer c.drainer.mu.Unlock()
+		addr := c.RemoteAddr().String()
+		if c.drainer.conns[addr] == c {
+			delete(c.drainer.conns, addr)
+		}
+	})
+	return c.Conn.Close()
+}
+
+// drainableServer wraps the snapshot cache server so that its streams can be ended through their context, as the
+// snapshot cache server only ends a stream when the stream fails to receive a request
+type drainableServer struct {
+	serverv3.Server
+}
+
+// streamCancelKey is the context key of the function ending a stream served by the drainableServer
+type streamCancelKey struct{}
+
+// StreamAggregatedResources serves the given stream with the snapshot cache server until the stream's context is done
+func (s *drainableServer) StreamAggregatedResources(stream xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	return s.Server.StreamAggregatedResources(&drainableStream{
+		AggregatedDiscoveryService_StreamAggregatedResourcesServer: stream,
+		ctx: context.WithValue(ctx, streamCancelKey{}, cancel),
+	})
+}
+
+// drainableStream is an ADS stream whose requests can no longer be received once its context is done
+type drainableStream struct {
+	xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer
+	ctx context.Context
+}
+
+// Context returns the context of the stream
+func (s *drainableStream) Context() context.Context {
+	return s.ctx
+}
+
+// Recv waits for the next request on the stream, and returns an error once the context of the stream is done.
+// The pending receive ends when the stream is closed on return from its handler.
+func (s *drainableStream) Recv() (*xds_discovery.DiscoveryRequest, error) {
+	type recvResult struct {
+		req *xds_discovery.DiscoveryRequest
+		err error
+	}
+	result := make(chan recvResult, 1)
+	go func() {
+		req, err := s.AggregatedDiscoveryService_StreamAggregatedResourcesServer.Recv()
+		result <- recvResult{req: req, err: err}
+	}()
+
+	select {
+	case r := <-result:
+		return r.req, r.err
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
@@ -0,0 +1,154 @@
+package ads
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestStreamDrainer(t *testing.T) {
+	assert := tassert.New(t)
+	d := newStreamDrainer()
+	d.batchInterval = 10 * time.Millisecond
+
+	// Streams end once signaled to
+	var proxies []*envoy.Proxy
+	for i := 0; i < 4; i++ {
+		proxy := newTestProxy(t, envoy.KindSidecar)
+		drain, err := d.register(proxy, nil)
+		assert.Nil(err)
+		go func() {
+			<-drain
+			d.unregister(proxy)
+		}()
+		proxies = append(proxies, proxy)
+	}
+
+	// The streams are closed in batches spread over the window
+	start := time.Now()
+	d.drain(40 * time.Millisecond)
+	assert.GreaterOrEqual(time.Since(start), 30*time.Millisecond)
+	assert.True(d.isDraining())
+	assert.Empty(d.streams)
+
+	// New streams are rejected while draining
+	_, err := d.register(newTestProxy(t, envoy.KindSidecar), nil)
+	assert.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestDrainListener(t *testing.T) {
+	assert := tassert.New(t)
+	d := newStreamDrainer()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	drainLis := d.listener(lis)
+	defer drainLis.Close() //nolint: errcheck
+
+	accepted := make(chan net.Conn)
+	go func() {
+		for {
+			conn, err := drainLis.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	client, err := net.Dial("tcp", lis.Addr().String())
+	assert.Nil(err)
+	defer client.Close() //nolint: errcheck
+	conn := <-accepted
+
+	// The connection of a drained stream is closed once the stream has ended
+	proxy := newTestProxy(t, envoy.KindSidecar)
+	drain, err := d.register(proxy, conn.RemoteAddr())
+	assert.Nil(err)
+	go func() {
+		<-drain
+		d.unregister(proxy)
+	}()
+	d.drain(0)
+
+	_, err = client.Read(make([]byte, 1))
+	assert.Equal(io.EOF, err)
+	assert.Empty(d.conns)
+
+	// New connections are closed while draining
+	newClient, err := net.Dial("tcp", lis.Addr().String())
+	assert.Nil(err)
+	defer newClient.Close() //nolint: errcheck
+	_, err = newClient.Read(make([]byte, 1))
+	assert.Equal(io.EOF, err)
+}
+
+// fakeADSStream is an ADS stream on which no request is received until its context is done
+type fakeADSStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeADSStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeADSStream) Recv() (*xds_discovery.DiscoveryRequest, error) {
+	<-s.ctx.Done()
+	return nil, io.EOF
+}
+
+func (s *fakeADSStream) Send(*xds_discovery.DiscoveryResponse) error {
+	return nil
+}
+
+func TestDrainSnapshotCacheStreams(t *testing.T) {
+	assert := tassert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &Server{drainer: newStreamDrainer()}
+	s.drainer.batchInterval = 10 * time.Millisecond
+	srv := &drainableServer{Server: serverv3.NewServer(ctx, cachev3.NewSnapshotCache(false, cachev3.IDHash{}, &scLogger{}), newCallbacks(s))}
+
+	// The streams served by the snapshot cache server are tracked
+	const numStreams = 3
+	errs := make(chan error, numStreams)
+	for i := 0; i < numStreams; i++ {
+		go func() {
+			errs <- srv.StreamAggregatedResources(&fakeADSStream{ctx: ctx})
+		}()
+	}
+	assert.Eventually(func() bool {
+		s.drainer.mu.Lock()
+		defer s.drainer.mu.Unlock()
+		return len(s.drainer.streams) == numStreams
+	}, time.Second, 10*time.Millisecond)
+
+	// The streams are ended through their context while draining
+	s.Drain(20 * time.Millisecond)
+	for i := 0; i < numStreams; i++ {
+		select {
+		case <-errs:
+		case <-time.After(time.Second):
+			assert.Fail("stream not ended after draining")
+		}
+	}
+	assert.Empty(s.drainer.streams)
+
+	// New streams are rejected while draining
+	err := srv.StreamAggregatedResources(&fakeADSStream{ctx: ctx})
+	assert.Equal(codes.Unavailable, status.Code(err))
+}
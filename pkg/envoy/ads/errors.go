@@ -1,12 +1,29 @@
 package ads
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errGrpcClosed = errors.New("grpc closed")
 var errTooManyConnections = errors.New("too many connections")
+var errTooManyNewConnections = errors.New("too many new connections, retry after a backoff")
+var errShuttingDown = errors.New("control plane is shutting down, reconnect to another instance")
 var errServiceAccountMismatch = errors.New("service account mismatch in nodeid vs xds certificate common name")
 var errUnsuportedXDSRequest = errors.New("Unsupported XDS server connection type")
+
+// newRetryError returns a gRPC status error with the given code, hinting the proxy to retry after the given delay
+func newRetryError(code codes.Code, err error, retryDelay time.Duration) error {
+	st := status.New(code, err.Error())
+	if withDetails, detailsErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)}); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -6,8 +6,9 @@ func (s *Server) Liveness() bool {
 }
 
 // Readiness is the Kubernetes readiness probe handler.
+// The server is not ready while draining, so that new connections are routed to the other instances.
 func (s *Server) Readiness() bool {
-	return s.ready
+	return s.ready && !s.drainer.isDraining()
 }
 
 // GetID returns the ID of the probe
@@ -13,7 +13,11 @@ func TestLiveness(t *testing.T) {
 
 func TestReadiness(t *testing.T) {
 	assert := tassert.New(t)
-	assert.True((&Server{ready: true}).Readiness())
+	s := &Server{ready: true, drainer: newStreamDrainer()}
+	assert.True(s.Readiness())
+
+	s.Drain(0)
+	assert.False(s.Readiness())
 }
 
 func TestServerGetID(t *testing.T) {
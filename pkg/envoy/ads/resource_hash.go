@@ -0,0 +1,61 @@
+package ads
+
+import (
+	"hash/fnv"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// hashResources returns the hashes of the given xDS resources, keyed by resource name.
+// Resources are marshalled deterministically so that unchanged resources hash the same across generations.
+func hashResources(resources []types.Resource) (map[string]uint64, error) {
+	hashes := make(map[string]uint64, len(resources))
+	marshalOpts := proto.MarshalOptions{Deterministic: true}
+	for _, res := range resources {
+		bytes, err := marshalOpts.Marshal(res.(proto.Message))
+		if err != nil {
+			return nil, err
+		}
+		h := fnv.New64a()
+		_, _ = h.Write(bytes)
+		hashes[cache.GetResourceName(res)] = h.Sum64()
+	}
+	return hashes, nil
+}
+
+// resourceHashesEqual returns whether the given resource hashes are the same
+func resourceHashesEqual(a, b map[string]uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, hash := range a {
+		if otherHash, ok := b[name]; !ok || hash != otherHash {
+			return false
+		}
+	}
+	return true
+}
+
+// changedResourceNames returns the names of the resources whose current hash differs from their last hash,
+// including the resources that did not exist before
+func changedResourceNames(last, current map[string]uint64) map[string]struct{} {
+	changed := make(map[string]struct{})
+	for name, hash := range current {
+		if lastHash, ok := last[name]; !ok || hash != lastHash {
+			changed[name] = struct{}{}
+		}
+	}
+	return changed
+}
+
+// containsAnyResource returns whether any of the resources with the given hashes is one of the given names
+func containsAnyResource(hashes map[string]uint64, names map[string]struct{}) bool {
+	for name := range hashes {
+		if _, ok := names[name]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package ads
+
+import (
+	"testing"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func newTestClusters(connectTimeoutSeconds int64) []types.Resource {
+	return []types.Resource{
+		&xds_cluster.Cluster{Name: "foo", ConnectTimeout: &durationpb.Duration{Seconds: 1}},
+		&xds_cluster.Cluster{Name: "bar", ConnectTimeout: &durationpb.Duration{Seconds: connectTimeoutSeconds}},
+	}
+}
+
+func TestHashResources(t *testing.T) {
+	assert := tassert.New(t)
+
+	hashes, err := hashResources(newTestClusters(1))
+	assert.Nil(err)
+	assert.Len(hashes, 2)
+
+	// Regenerated resources hash the same
+	sameHashes, err := hashResources(newTestClusters(1))
+	assert.Nil(err)
+	assert.Equal(hashes, sameHashes)
+
+	// Only the changed resource hashes differently
+	changedHashes, err := hashResources(newTestClusters(2))
+	assert.Nil(err)
+	assert.Equal(hashes["foo"], changedHashes["foo"])
+	assert.NotEqual(hashes["bar"], changedHashes["bar"])
+}
+
+func TestResourceHashesEqual(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        map[string]uint64
+		b        map[string]uint64
+		expected bool
+	}{
+		{
+			name:     "empty hashes",
+			expected: true,
+		},
+		{
+			name:     "same hashes",
+			a:        map[string]uint64{"foo": 1, "bar": 2},
+			b:        map[string]uint64{"bar": 2, "foo": 1},
+			expected: true,
+		},
+		{
+			name:     "different hash",
+			a:        map[string]uint64{"foo": 1, "bar": 2},
+			b:        map[string]uint64{"foo": 1, "bar": 3},
+			expected: false,
+		},
+		{
+			name:     "different resources",
+			a:        map[string]uint64{"foo": 1},
+			b:        map[string]uint64{"bar": 1},
+			expected: false,
+		},
+		{
+			name:     "additional resource",
+			a:        map[string]uint64{"foo": 1},
+			b:        map[string]uint64{"foo": 1, "bar": 2},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, resourceHashesEqual(tc.a, tc.b))
+		})
+	}
+}
+
+func TestSendResponseSkipsUnchangedResources(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsDebugServerEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().ForProxy(gomock.Any(), gomock.Any()).Return(mockConfigurator).AnyTimes()
+	mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().WithConfigurator(mockConfigurator).Return(mockCatalog).AnyTimes()
+
+	skippedCount := metricsstore.DefaultMetricsStore.ProxyXDSPushSkippedCount.WithLabelValues(envoy.TypeCDS.String())
+	skippedBefore := testutil.ToFloat64(skippedCount)
+
+	clusters := newTestClusters(1)
+	s := &Server{
+		catalog: mockCatalog,
+		cfg:     mockConfigurator,
+		xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error){
+			envoy.TypeCDS: func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error) {
+				return clusters, nil
+			},
+			envoy.TypeEDS: func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error) {
+				return []types.Resource{&xds_endpoint.ClusterLoadAssignment{ClusterName: "bar"}}, nil
+			},
+		},
+	}
+	proxy := newTestProxy(t, envoy.KindSidecar)
+	server, responses := tests.NewFakeXDSServer(nil, nil, nil)
+
+	// Resources never sent are pushed
+	assert.Nil(s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS))
+	assert.Len(*responses, 1)
+
+	// Unchanged resources are not pushed again
+	assert.Nil(s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS))
+	assert.Len(*responses, 1)
+	assert.Equal(skippedBefore+1, testutil.ToFloat64(skippedCount))
+
+	// Requests are responded to even when the resources are unchanged
+	assert.Nil(s.sendResponse(proxy, &server, &xds_discovery.DiscoveryRequest{TypeUrl: envoy.TypeCDS.String()}, mockConfigurator, envoy.TypeCDS))
+	assert.Len(*responses, 2)
+
+	// Changed resources are pushed
+	clusters = newTestClusters(2)
+	assert.Nil(s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS))
+	assert.Len(*responses, 3)
+	assert.Equal("3", (*responses)[2].VersionInfo)
+
+	assert.Nil(s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS, envoy.TypeEDS))
+	assert.Len(*responses, 4)
+	assert.Equal(envoy.TypeEDS.String(), (*responses)[3].TypeUrl)
+
+	// Unchanged endpoints are pushed when their cluster changes in the same push
+	clusters = newTestClusters(3)
+	assert.Nil(s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS, envoy.TypeEDS))
+	assert.Len(*responses, 6)
+	assert.Equal(envoy.TypeEDS.String(), (*responses)[5].TypeUrl)
+
+	// The last sent resources are forgotten when the stream of the proxy is closed
+	s.routeConfigCache = rds.NewRouteConfigCache()
+	s.lastSnapshots = make(map[string]*proxySnapshot)
+	s.releaseProxy(proxy)
+	assert.Nil(s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS))
+	assert.Len(*responses, 7)
+}
+
+func TestChangedResourceNames(t *testing.T) {
+	assert := tassert.New(t)
+
+	changed := changedResourceNames(map[string]uint64{"foo": 1, "bar": 2, "removed": 3}, map[string]uint64{"foo": 1, "bar": 3, "added": 4})
+	assert.Equal(map[string]struct{}{"bar": {}, "added": {}}, changed)
+	assert.True(containsAnyResource(map[string]uint64{"bar": 1}, changed))
+	assert.False(containsAnyResource(map[string]uint64{"foo": 1}, changed))
+}
@@ -11,14 +11,16 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
-// getTypeResource invokes the XDS handler (LDS, CDS etc.) to respond to the XDS request containing the requests' type and associated resources
-func (s *Server) getTypeResources(proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest) ([]types.Resource, error) {
+// getTypeResource invokes the XDS handler (LDS, CDS etc.) to respond to the XDS request containing the requests' type and associated resources.
+// The handler generates the resources with the given configuration and catalog resolved for the proxy.
+func (s *Server) getTypeResources(proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, proxyCfg configurator.Configurator, proxyCatalog catalog.MeshCataloger) ([]types.Resource, error) {
 	// Tracks the success of this TypeURI response operation; accounts also for receipt on envoy server side
 	startedAt := time.Now()
 	typeURI := envoy.TypeURI(request.TypeUrl)
@@ -34,7 +36,7 @@ func (s *Server) getTypeResources(proxy *envoy.Proxy, request *xds_discovery.Dis
 	}
 
 	// Invoke XDS handler
-	resources, err := handler(s.catalog, proxy, request, s.cfg, s.certManager, s.proxyRegistry)
+	resources, err := handler(proxyCatalog, proxy, request, proxyCfg, s.certManager, s.proxyRegistry)
 	if err != nil {
 		xdsPathTimeTrack(startedAt, typeURI, proxy, false)
 		return nil, errCreatingResponse
@@ -56,6 +58,14 @@ func (s *Server) sendResponse(proxy *envoy.Proxy, server *xds_discovery.Aggregat
 	osmDrivenUpdate := request == nil
 	cacheResourceMap := map[string][]types.Resource{}
 
+	// Resolve the configuration for the proxy once, so that every resource sent in this response is generated
+	// from the same staged rollout cohort
+	proxyCfg, proxyCatalog := s.resolveProxyConfig(proxy, cfg)
+
+	// Clusters changed by the CDS response of this push. Envoy re-initializes changed clusters, which remain
+	// warming until they receive their endpoints, so EDS is always sent for them even when unchanged.
+	changedClusters := make(map[string]struct{})
+
 	// Order is important: CDS, EDS, LDS, RDS
 	// See: https://github.com/envoyproxy/go-control-plane/issues/59
 	for _, typeURI := range typeURIsToSend {
@@ -77,7 +87,7 @@ func (s *Server) sendResponse(proxy *envoy.Proxy, server *xds_discovery.Aggregat
 		}
 
 		// Generate the resources for this request
-		resources, err := s.getTypeResources(proxy, finalReq)
+		resources, err := s.getTypeResources(proxy, finalReq, proxyCfg, proxyCatalog)
 		if err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGeneratingReqResource)).Str("proxy", proxy.String()).
 				Msgf("Error generating response for typeURI: %s", typeURI.Short())
@@ -89,11 +99,31 @@ func (s *Server) sendResponse(proxy *envoy.Proxy, server *xds_discovery.Aggregat
 			// Keep a reference to later set the full snapshot in the cache
 			cacheResourceMap[typeURI.String()] = resources
 		} else {
+			hashes, err := hashResources(resources)
+			if err != nil {
+				log.Warn().Err(err).Str("proxy", proxy.String()).Msgf("Error hashing resources for typeURI: %s", typeURI.Short())
+			}
+
+			// Skip pushing resources unchanged since they were last sent, as the proxy would needlessly re-apply them.
+			// Requests from the proxy are always responded to, as the proxy expects a response.
+			lastHashes, hasLastHashes := proxy.GetLastSentResourceHashes(typeURI)
+			if osmDrivenUpdate && hashes != nil && hasLastHashes && resourceHashesEqual(lastHashes, hashes) &&
+				!(typeURI == envoy.TypeEDS && containsAnyResource(hashes, changedClusters)) {
+				log.Debug().Str("proxy", proxy.String()).Msgf("Skipping push of unchanged resources for typeURI: %s", typeURI.Short())
+				metricsstore.DefaultMetricsStore.ProxyXDSPushSkippedCount.WithLabelValues(typeURI.String()).Inc()
+				continue
+			}
+
 			// If cache disabled, craft and send a reply to the proxy on the stream
 			if err := s.SendDiscoveryResponse(proxy, finalReq, server, resources); err != nil {
 				log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error sending DiscoveryResponse for typeUrl: %s", typeURI.Short())
 				thereWereErrors = true
+				continue
 			}
+			if typeURI == envoy.TypeCDS {
+				changedClusters = changedResourceNames(lastHashes, hashes)
+			}
+			proxy.SetLastSentResourceHashes(typeURI, hashes)
 		}
 	}
 
@@ -115,6 +145,20 @@ func (s *Server) sendResponse(proxy *envoy.Proxy, server *xds_discovery.Aggregat
 	return nil
 }
 
+// resolveProxyConfig returns the configuration resolved for the given proxy, and the catalog computing the traffic
+// policies from the mesh-wide configuration of the proxy's staged rollout cohort
+func (s *Server) resolveProxyConfig(proxy *envoy.Proxy, cfg configurator.Configurator) (configurator.Configurator, catalog.MeshCataloger) {
+	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingServiceIdentity)).
+			Str("proxy", proxy.String()).Msgf("Error looking up proxy identity, using the stable configuration")
+		return cfg, s.catalog
+	}
+
+	proxyCfg := cfg.ForProxy(proxyIdentity.ToK8sServiceAccount().Namespace, proxy.UUID.String())
+	return proxyCfg, s.catalog.WithConfigurator(proxyCfg.ForNamespace(""))
+}
+
 // SendDiscoveryResponse creates a new response for <proxy> given <resourcesToSend> and <request.TypeURI> and sends it
 func (s *Server) SendDiscoveryResponse(proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, resourcesToSend []types.Resource) error {
 	// request.Node is only available on the first Discovery Request; will be nil on the following
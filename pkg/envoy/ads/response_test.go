@@ -3,6 +3,7 @@ package ads
 import (
 	"context"
 	"fmt"
+	"testing"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
@@ -11,9 +12,11 @@ import (
 
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -61,6 +64,9 @@ var _ = Describe("Test ADS response functions", func() {
 	proxySvcAccount := tests.BookstoreServiceAccount
 
 	mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
+	mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
+	mockConfigurator.EXPECT().ForProxy(gomock.Any(), gomock.Any()).Return(mockConfigurator).AnyTimes()
+	mockConfigurator.EXPECT().GetRolloutCohort().Return("").AnyTimes()
 
 	labels := map[string]string{constants.EnvoyUniqueIDLabelName: proxyUUID.String()}
 	mc := catalog.NewFakeMeshCatalog(kubeClient, configClient)
@@ -272,3 +278,42 @@ var _ = Describe("Test ADS response functions", func() {
 		})
 	})
 })
+
+func TestSendResponseResolvesProxyConfigOnce(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	meshCfg := configurator.NewMockConfigurator(mockCtrl)
+	proxyCfg := configurator.NewMockConfigurator(mockCtrl)
+	meshWideProxyCfg := configurator.NewMockConfigurator(mockCtrl)
+	meshCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	proxyCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	// The configuration is resolved for the proxy's namespace and rollout cohort once per response, and the catalog
+	// computes the traffic policies from the mesh-wide configuration of the same cohort
+	meshCfg.EXPECT().IsDebugServerEnabled().Return(false).AnyTimes()
+	meshCfg.EXPECT().ForProxy("ns", gomock.Any()).Return(proxyCfg).Times(1)
+	proxyCfg.EXPECT().ForNamespace("").Return(meshWideProxyCfg).Times(1)
+	meshCatalog.EXPECT().WithConfigurator(meshWideProxyCfg).Return(proxyCatalog).Times(1)
+
+	var handledTypeURIs []envoy.TypeURI
+	handler := func(typeURI envoy.TypeURI) func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error) {
+		return func(mc catalog.MeshCataloger, _ *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager, _ *registry.ProxyRegistry) ([]types.Resource, error) {
+			assert.Same(proxyCatalog, mc)
+			assert.Same(proxyCfg, cfg)
+			handledTypeURIs = append(handledTypeURIs, typeURI)
+			return nil, nil
+		}
+	}
+	s := &Server{
+		catalog: meshCatalog,
+		cfg:     meshCfg,
+		xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error){
+			envoy.TypeCDS: handler(envoy.TypeCDS),
+			envoy.TypeLDS: handler(envoy.TypeLDS),
+		},
+	}
+	server, _ := tests.NewFakeXDSServer(nil, nil, nil)
+
+	assert.Nil(s.sendResponse(newTestProxy(t, envoy.KindSidecar), &server, nil, meshCfg, envoy.TypeCDS, envoy.TypeLDS))
+	assert.Equal([]envoy.TypeURI{envoy.TypeCDS, envoy.TypeLDS}, handledTypeURIs)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // makeRequestForAllSecrets constructs an SDS DiscoveryRequest as if an Envoy proxy sent it.
@@ -17,6 +19,9 @@ import (
 // 2. Client's root validation certificate to validate upstream services during mTLS handshake: root-cert-for-mtls-outbound:<namespace>/<server-service-name>
 // 3. Server's service certificate when this proxy is an upstream: service-cert:<namespace>/<server-service-name>
 // 4. Server's root validation certificate to validate downstream clients during mTLS handshake: root-cert-for-mtls-inbound:<namespace>/<server-service-name>
+// 5. CA bundles and client certificates used to originate TLS for egress traffic: egress-ca-bundle:<namespace>/<secret-name> and egress-client-cert:<namespace>/<secret-name>
+// 6. Egress gateway's root validation certificate when egress traffic is routed via the egress gateway: root-cert-for-mtls-outbound:<osm-namespace>/osm-egress-gateway
+// 7. Ingress gateway's certificates used to terminate TLS for Gateway listeners: ingress-gateway-cert:<namespace>/<secret-name>
 //
 // This request will be sent to SDS which will return certificates encoded in SDS secrets corresponding to the resource names
 // encoded in the DiscoveryRequest this function creates and returns.
@@ -58,6 +63,11 @@ func makeRequestForAllSecrets(proxy *envoy.Proxy, meshCatalog catalog.MeshCatalo
 		TypeUrl: string(envoy.TypeSDS),
 	}
 
+	if proxy.Kind() == envoy.KindIngressGateway {
+		discoveryRequest.ResourceNames = append(discoveryRequest.ResourceNames, getIngressGatewaySecretNames(meshCatalog)...)
+		return discoveryRequest
+	}
+
 	// Create an SDS validation cert corresponding to each upstream service that this proxy can connect to.
 	// Each cert is used to validate the certificate presented by the corresponding upstream service.
 	upstreamServices := meshCatalog.ListOutboundServicesForIdentity(proxyIdentity)
@@ -69,5 +79,86 @@ func makeRequestForAllSecrets(proxy *envoy.Proxy, meshCatalog catalog.MeshCatalo
 		discoveryRequest.ResourceNames = append(discoveryRequest.ResourceNames, upstreamRootCertResource)
 	}
 
+	// Create an SDS cert corresponding to each CA bundle and client certificate referenced by the TLS origination
+	// settings of the Egress policies applicable to this proxy.
+	discoveryRequest.ResourceNames = append(discoveryRequest.ResourceNames, getEgressTLSSecretNames(proxy, proxyIdentity, meshCatalog)...)
+
 	return discoveryRequest
 }
+
+// getEgressTLSSecretNames returns the SDS resource names of the secrets referenced by the egress clusters of the given proxy,
+// including the root validation cert of the egress gateway for clusters routed via the egress gateway.
+func getEgressTLSSecretNames(proxy *envoy.Proxy, proxyIdentity identity.ServiceIdentity, meshCatalog catalog.MeshCataloger) []string {
+	var clusterConfigs []*trafficpolicy.EgressClusterConfig
+	if proxy.Kind() == envoy.KindEgressGateway {
+		egressGatewayPolicy, err := meshCatalog.GetEgressGatewayTrafficPolicy()
+		if err != nil || egressGatewayPolicy == nil {
+			return nil
+		}
+		for _, destination := range egressGatewayPolicy.Destinations {
+			clusterConfigs = append(clusterConfigs, destination.Cluster)
+		}
+	} else {
+		egressPolicy, err := meshCatalog.GetEgressTrafficPolicy(proxyIdentity)
+		if err != nil || egressPolicy == nil {
+			return nil
+		}
+		clusterConfigs = egressPolicy.ClustersConfigs
+	}
+
+	var names []string
+	seen := make(map[string]struct{})
+	addSecret := func(sdsCert secrets.SDSCert) {
+		name := sdsCert.String()
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	for _, clusterConfig := range clusterConfigs {
+		if clusterConfig.Gateway != nil {
+			addSecret(secrets.SDSCert{Name: clusterConfig.Gateway.Service.String(), CertType: secrets.RootCertTypeForMTLSOutbound})
+		}
+		if clusterConfig.TLS == nil {
+			continue
+		}
+		if clusterConfig.TLS.CABundleSecret != nil {
+			addSecret(secrets.SDSCert{Name: clusterConfig.TLS.CABundleSecret.String(), CertType: secrets.EgressCABundleCertType})
+		}
+		if clusterConfig.TLS.ClientCertificateSecret != nil {
+			addSecret(secrets.SDSCert{Name: clusterConfig.TLS.ClientCertificateSecret.String(), CertType: secrets.EgressClientCertType})
+		}
+	}
+
+	return names
+}
+
+// getIngressGatewaySecretNames returns the SDS resource names of the secrets referenced by the ingress gateway: the root
+// validation certs of the backends routed by the gateway, and the certificates used to terminate TLS.
+func getIngressGatewaySecretNames(meshCatalog catalog.MeshCataloger) []string {
+	ingressGatewayPolicy, err := meshCatalog.GetIngressGatewayTrafficPolicy()
+	if err != nil || ingressGatewayPolicy == nil {
+		return nil
+	}
+
+	var names []string
+	for _, clusterConfig := range ingressGatewayPolicy.ClustersConfigs {
+		names = append(names, secrets.SDSCert{Name: clusterConfig.Service.String(), CertType: secrets.RootCertTypeForMTLSOutbound}.String())
+	}
+
+	seen := make(map[string]struct{})
+	for _, listener := range ingressGatewayPolicy.Listeners {
+		for _, termination := range listener.TLSTerminations {
+			name := secrets.SDSCert{Name: termination.CertificateSecret.String(), CertType: secrets.IngressGatewayCertType}.String()
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 func TestMakeRequestForAllSecrets(t *testing.T) {
@@ -27,6 +29,7 @@ func TestMakeRequestForAllSecrets(t *testing.T) {
 		name                     string
 		proxyIdentity            identity.ServiceIdentity
 		allowedOutboundServices  []service.MeshService
+		egressPolicy             *trafficpolicy.EgressTrafficPolicy
 		expectedDiscoveryRequest *xds_discovery.DiscoveryRequest
 	}
 
@@ -119,6 +122,80 @@ func TestMakeRequestForAllSecrets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                    "scenario where proxy originates TLS for egress traffic",
+			proxyIdentity:           proxyServiceIdentity,
+			allowedOutboundServices: nil,
+			egressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+					{
+						Name: "foo.com:80",
+						Host: "foo.com",
+						Port: 443,
+						TLS: &trafficpolicy.EgressTLSConfig{
+							SNI:                     "foo.com",
+							CABundleSecret:          &types.NamespacedName{Namespace: "ns-1", Name: "ca"},
+							ClientCertificateSecret: &types.NamespacedName{Namespace: "ns-1", Name: "client"},
+						},
+					},
+					{
+						Name: "bar.com:80",
+						Host: "bar.com",
+						Port: 443,
+						TLS: &trafficpolicy.EgressTLSConfig{
+							SNI:            "bar.com",
+							CABundleSecret: &types.NamespacedName{Namespace: "ns-1", Name: "ca"},
+						},
+					},
+				},
+			},
+			expectedDiscoveryRequest: &xds_discovery.DiscoveryRequest{
+				TypeUrl: string(envoy.TypeSDS),
+				ResourceNames: []string{
+					// 1. Proxy's own cert to present to peer during mTLS/TLS handshake
+					"service-cert:ns-1/test-sa",
+
+					// 2. Inbound validation certs to validate downstreams
+					"root-cert-for-mtls-inbound:ns-1/test-sa",
+
+					// 3. Egress CA bundle and client certificate, deduplicated across clusters
+					"egress-ca-bundle:ns-1/ca",
+					"egress-client-cert:ns-1/client",
+				},
+			},
+		},
+		{
+			name:                    "scenario where proxy routes egress traffic via the egress gateway",
+			proxyIdentity:           proxyServiceIdentity,
+			allowedOutboundServices: nil,
+			egressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+					{
+						Name: "foo.com:80",
+						Host: "foo.com",
+						Port: 80,
+						Gateway: &trafficpolicy.EgressGatewayConfig{
+							Service:    service.MeshService{Name: "osm-egress-gateway", Namespace: "osm-system"},
+							Port:       15444,
+							ServerName: "egress-80.foo.com",
+						},
+					},
+				},
+			},
+			expectedDiscoveryRequest: &xds_discovery.DiscoveryRequest{
+				TypeUrl: string(envoy.TypeSDS),
+				ResourceNames: []string{
+					// 1. Proxy's own cert to present to peer during mTLS/TLS handshake
+					"service-cert:ns-1/test-sa",
+
+					// 2. Inbound validation certs to validate downstreams
+					"root-cert-for-mtls-inbound:ns-1/test-sa",
+
+					// 3. Outbound validation cert to validate the egress gateway
+					"root-cert-for-mtls-outbound:osm-system/osm-egress-gateway",
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -126,6 +203,7 @@ func TestMakeRequestForAllSecrets(t *testing.T) {
 			assert := tassert.New(t)
 
 			mockCatalog.EXPECT().ListOutboundServicesForIdentity(tc.proxyIdentity).Return(tc.allowedOutboundServices).Times(1)
+			mockCatalog.EXPECT().GetEgressTrafficPolicy(tc.proxyIdentity).Return(tc.egressPolicy, nil).Times(1)
 
 			actual := makeRequestForAllSecrets(testProxy, mockCatalog)
 
@@ -134,3 +212,72 @@ func TestMakeRequestForAllSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEgressTLSSecretNamesForEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindEgressGateway, "osm-egress-gateway", "osm-system"), "", nil)
+	assert.Nil(err)
+	proxyIdentity := identity.K8sServiceAccount{Name: "osm-egress-gateway", Namespace: "osm-system"}.ToServiceIdentity()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().GetEgressGatewayTrafficPolicy().Return(&trafficpolicy.EgressGatewayTrafficPolicy{
+		Destinations: []*trafficpolicy.EgressGatewayDestination{
+			{
+				ServerName: "egress-80.foo.com",
+				Cluster: &trafficpolicy.EgressClusterConfig{
+					Name: "foo.com:80",
+					Host: "foo.com",
+					Port: 443,
+					TLS: &trafficpolicy.EgressTLSConfig{
+						SNI:            "foo.com",
+						CABundleSecret: &types.NamespacedName{Namespace: "ns-1", Name: "ca"},
+					},
+				},
+			},
+		},
+	}, nil).Times(1)
+
+	actual := getEgressTLSSecretNames(proxy, proxyIdentity, mockCatalog)
+	assert.Equal([]string{"egress-ca-bundle:ns-1/ca"}, actual)
+}
+
+func TestMakeRequestForAllSecretsForIngressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindIngressGateway, "osm-ingress-gateway", "osm-system"), "", nil)
+	assert.Nil(err)
+
+	backend := service.MeshService{Name: "web", Namespace: "ns-1", Port: 80, TargetPort: 8080}
+	certSecret := types.NamespacedName{Namespace: "ns-1", Name: "foo-cert"}
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().GetIngressGatewayTrafficPolicy().Return(&trafficpolicy.IngressGatewayTrafficPolicy{
+		Listeners: []*trafficpolicy.IngressGatewayListener{
+			{
+				Name:     "ingress-gateway-https-443",
+				Port:     443,
+				Protocol: "https",
+				TLSTerminations: []*trafficpolicy.IngressGatewayTLSTermination{
+					{Name: "ns-1/gw/https-1", CertificateSecret: certSecret},
+					{Name: "ns-1/gw/https-2", CertificateSecret: certSecret},
+				},
+			},
+		},
+		ClustersConfigs: []*trafficpolicy.MeshClusterConfig{
+			{Name: backend.EnvoyClusterName(), Service: backend},
+		},
+	}, nil).Times(1)
+
+	actual := makeRequestForAllSecrets(proxy, mockCatalog)
+	assert.Equal([]string{
+		"service-cert:osm-system/osm-ingress-gateway",
+		"root-cert-for-mtls-inbound:osm-system/osm-ingress-gateway",
+		"root-cert-for-mtls-outbound:ns-1/web",
+		"ingress-gateway-cert:ns-1/foo-cert",
+	}, actual.ResourceNames)
+}
@@ -38,13 +38,14 @@ const (
 // NewADSServer creates a new Aggregated Discovery Service server
 func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.ProxyRegistry, enableDebug bool, osmNamespace string,
 	cfg configurator.Configurator, certManager certificate.Manager, kubecontroller k8s.Controller, msgBroker *messaging.Broker) *Server {
+	routeConfigCache := rds.NewRouteConfigCache()
 	server := Server{
 		catalog:       meshCatalog,
 		proxyRegistry: proxyRegistry,
 		xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error){
 			envoy.TypeEDS: eds.NewResponse,
 			envoy.TypeCDS: cds.NewResponse,
-			envoy.TypeRDS: rds.NewResponse,
+			envoy.TypeRDS: routeConfigCache.NewResponse,
 			envoy.TypeLDS: lds.NewResponse,
 			envoy.TypeSDS: sds.NewResponse,
 		},
@@ -54,11 +55,16 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.Pro
 		xdsMapLogMutex: sync.Mutex{},
 		xdsLog:         make(map[certificate.CommonName]map[envoy.TypeURI][]time.Time),
 		workqueues:     workerpool.NewWorkerPool(workerPoolSize),
+		admission:      newAdmissionController(cfg),
+		drainer:        newStreamDrainer(),
 		kubecontroller: kubecontroller,
 		cacheEnabled:   cfg.GetFeatureFlags().EnableSnapshotCacheMode,
 		configVerMutex: sync.Mutex{},
 		configVersion:  make(map[string]uint64),
+		lastSnapshots:  make(map[string]*proxySnapshot),
 		msgBroker:      msgBroker,
+
+		routeConfigCache: routeConfigCache,
 	}
 
 	return &server
@@ -82,14 +88,14 @@ func (s *Server) Start(ctx context.Context, cancel context.CancelFunc, port int,
 
 	if s.cacheEnabled {
 		s.ch = cachev3.NewSnapshotCache(false, cachev3.IDHash{}, &scLogger{})
-		s.srv = serverv3.NewServer(ctx, s.ch, &Callbacks{})
+		s.srv = serverv3.NewServer(ctx, s.ch, newCallbacks(s))
 
-		xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, s.srv)
+		xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, &drainableServer{Server: s.srv})
 	} else {
 		xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, s)
 	}
 
-	go utils.GrpcServe(ctx, grpcServer, lis, cancel, ServerType, nil)
+	go utils.GrpcServe(ctx, grpcServer, s.drainer.listener(lis), cancel, ServerType, nil)
 
 	if s.cacheEnabled {
 		// Start broadcast listener thread when cache is enabled and we are ready to start handling
@@ -102,6 +108,21 @@ func (s *Server) Start(ctx context.Context, cancel context.CancelFunc, port int,
 	return nil
 }
 
+// releaseProxy releases the state kept for the given proxy once its stream is closed.
+// The config version of the proxy is kept so that the versions sent to it keep increasing if it reconnects,
+// and the snapshot stored in the cache is kept so that it can be served on reconnection.
+func (s *Server) releaseProxy(proxy *envoy.Proxy) {
+	s.routeConfigCache.ReleaseProxy(proxy)
+	s.forgetSnapshot(proxy.GetCertificateCommonName().String())
+	proxy.ResetLastSentResourceHashes()
+}
+
+// Drain stops accepting new streams, and closes the existing streams in batches spread over the given window so that
+// the proxies do not all reconnect to the other control plane instances at once. It returns once all the streams are closed.
+func (s *Server) Drain(window time.Duration) {
+	s.drainer.drain(window)
+}
+
 // DeltaAggregatedResources implements discovery.AggregatedDiscoveryServiceServer
 func (s *Server) DeltaAggregatedResources(xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
 	panic("NotImplemented")
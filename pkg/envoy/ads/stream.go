@@ -1,6 +1,7 @@
 package ads
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -52,6 +54,20 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 		return err
 	}
 
+	// Track the stream to close it when the control plane shuts down. New streams are rejected while shutting down.
+	drain, err := s.drainer.register(proxy, utils.GetIPFromContext(server.Context()))
+	if err != nil {
+		metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+		return err
+	}
+	defer s.drainer.unregister(proxy)
+
+	// Throttle new streams, so that proxies reconnecting at once do not overwhelm the control plane
+	if err := s.admission.admit(server.Context(), proxy); err != nil {
+		metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+		return err
+	}
+
 	if err := s.recordPodMetadata(proxy); err == errServiceAccountMismatch {
 		// Service Account mismatch
 		log.Error().Err(err).Str("proxy", proxy.String()).Msg("Mismatched service account for proxy")
@@ -61,6 +77,7 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	s.proxyRegistry.RegisterProxy(proxy)
 
 	defer s.proxyRegistry.UnregisterProxy(proxy)
+	defer s.releaseProxy(proxy)
 
 	quit := make(chan struct{})
 	requests := make(chan xds_discovery.DiscoveryRequest)
@@ -97,6 +114,11 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
 			return nil
 
+		case <-drain:
+			log.Debug().Str("proxy", proxy.String()).Msg("Closing gRPC stream, control plane is shutting down")
+			metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+			return newRetryError(codes.Unavailable, errShuttingDown, 0)
+
 		case discoveryRequest, ok := <-requests:
 			if !ok {
 				log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGRPCStreamClosedByProxy)).Str("proxy", proxy.String()).
@@ -108,6 +130,10 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 			metricsstore.DefaultMetricsStore.ProxyXDSRequestCount.WithLabelValues(certCommonName.String(), discoveryRequest.TypeUrl).Inc()
 
+			if discoveryRequest.ErrorDetail != nil {
+				s.recordNACK(proxy)
+			}
+
 			// This function call runs xDS proto state machine given DiscoveryRequest as input.
 			// It's output is the decision to reply or not to this request.
 			if !respondToRequest(proxy, &discoveryRequest) {
@@ -117,6 +143,15 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 			typesRequest := []envoy.TypeURI{envoy.TypeURI(discoveryRequest.TypeUrl)}
 
+			// The initial request of a type requires generating its full configuration
+			if proxy.GetLastSentNonce(envoy.TypeURI(discoveryRequest.TypeUrl)) == "" {
+				if err := s.runFullConfigJob(server.Context(), newJob(typesRequest, &discoveryRequest)); err != nil {
+					metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+					return err
+				}
+				continue
+			}
+
 			<-s.workqueues.AddJob(newJob(typesRequest, &discoveryRequest))
 
 		case <-proxyUpdateChan:
@@ -132,7 +167,10 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 			// Queue a full configuration update
 			// Do not send SDS, let envoy figure out what certs does it want.
-			<-s.workqueues.AddJob(newJob([]envoy.TypeURI{envoy.TypeCDS, envoy.TypeEDS, envoy.TypeLDS, envoy.TypeRDS}, nil))
+			if err := s.runFullConfigJob(server.Context(), newJob([]envoy.TypeURI{envoy.TypeCDS, envoy.TypeEDS, envoy.TypeLDS, envoy.TypeRDS}, nil)); err != nil {
+				metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+				return err
+			}
 
 		case certRotateMsg := <-certRotateChan:
 			cert := certRotateMsg.(events.PubSubMessage).NewObj.(*certificate.Certificate)
@@ -149,6 +187,30 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	}
 }
 
+// runFullConfigJob queues the given job generating a full configuration once the number of concurrent full configuration
+// generations allows it, and waits for the job to complete. An error is returned if the stream ends while waiting.
+func (s *Server) runFullConfigJob(ctx context.Context, job *proxyResponseJob) error {
+	release, err := s.admission.acquireGeneration(ctx, job.proxy)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	<-s.workqueues.AddJob(job)
+	return nil
+}
+
+// recordNACK records a NACK from the proxy, so that a MeshConfig change rejected by canary proxies can be rolled back
+func (s *Server) recordNACK(proxy *envoy.Proxy) {
+	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingServiceIdentity)).
+			Str("proxy", proxy.String()).Msg("Error retrieving ServiceAccount for proxy")
+		return
+	}
+	s.cfg.RecordProxyNACK(proxyIdentity.ToK8sServiceAccount().Namespace, proxy.UUID.String())
+}
+
 // shouldPushUpdate handles allowing new updates to envoy from control-plane driven config changes.
 // Its use is to make sure we don't unintentintionally push new versions if at least a first request has not arrived yet.
 func shouldPushUpdate(proxy *envoy.Proxy) bool {
@@ -346,6 +408,14 @@ func (s *Server) recordPodMetadata(p *envoy.Proxy) error {
 			Msgf("Proxy is a Multicluster gateway, skipping recording pod metadata")
 		return nil
 	}
+	if p.Kind() == envoy.KindEgressGateway {
+		log.Debug().Str("proxy", p.String()).Msgf("Proxy is an egress gateway, skipping recording pod metadata")
+		return nil
+	}
+	if p.Kind() == envoy.KindIngressGateway {
+		log.Debug().Str("proxy", p.String()).Msgf("Proxy is an ingress gateway, skipping recording pod metadata")
+		return nil
+	}
 
 	pod, err := envoy.GetPodFromCertificate(p.GetCertificateCommonName(), s.kubecontroller)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -37,6 +38,8 @@ type Server struct {
 	certManager    certificate.Manager
 	ready          bool
 	workqueues     *workerpool.WorkerPool
+	admission      *admissionController
+	drainer        *streamDrainer
 	kubecontroller k8s.Controller
 
 	// ---
@@ -49,6 +52,11 @@ type Server struct {
 	// tracks at which version we are at given a proxy UUID
 	configVerMutex sync.Mutex
 	configVersion  map[string]uint64
+	// lastSnapshots tracks the last snapshot stored in the cache for a given proxy, to skip storing unchanged resources
+	lastSnapshots map[string]*proxySnapshot
 
 	msgBroker *messaging.Broker
+
+	// routeConfigCache reuses the route configurations built for the proxies sharing a service identity
+	routeConfigCache *rds.RouteConfigCache
 }
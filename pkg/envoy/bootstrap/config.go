@@ -1,16 +1,20 @@
 package bootstrap
 
 import (
+	"sort"
+
 	xds_accesslog_config "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	xds_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_metrics "github.com/envoyproxy/go-control-plane/envoy/config/metrics/v3"
 	xds_accesslog_stream "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
 	xds_transport_sockets "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	xds_upstream_http "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"github.com/golang/protobuf/ptypes/any"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -158,6 +162,15 @@ func BuildFromConfig(config Config) (*xds_bootstrap.Bootstrap, error) {
 						},
 					},
 					LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
+					// TCP keepalives detect an unreachable osm-controller replica, so that the proxy
+					// reconnects to another replica behind the service
+					UpstreamConnectionOptions: &xds_cluster.UpstreamConnectionOptions{
+						TcpKeepalive: &xds_core.TcpKeepalive{
+							KeepaliveProbes:   wrapperspb.UInt32(xdsKeepaliveProbes),
+							KeepaliveTime:     wrapperspb.UInt32(xdsKeepaliveTimeSeconds),
+							KeepaliveInterval: wrapperspb.UInt32(xdsKeepaliveIntervalSeconds),
+						},
+					},
 					LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
 						ClusterName: config.XDSClusterName,
 						Endpoints: []*xds_endpoint.LocalityLbEndpoints{
@@ -188,5 +201,29 @@ func BuildFromConfig(config Config) (*xds_bootstrap.Bootstrap, error) {
 		},
 	}
 
+	if len(config.StatsTags) > 0 {
+		bootstrap.StatsConfig = getStatsConfig(config.StatsTags)
+	}
+
 	return bootstrap, nil
 }
+
+// getStatsConfig returns the stats config adding the given tags with fixed values to the proxy's stats
+func getStatsConfig(statsTags map[string]string) *xds_metrics.StatsConfig {
+	var tagNames []string
+	for tagName := range statsTags {
+		tagNames = append(tagNames, tagName)
+	}
+	sort.Strings(tagNames)
+
+	statsConfig := &xds_metrics.StatsConfig{}
+	for _, tagName := range tagNames {
+		statsConfig.StatsTags = append(statsConfig.StatsTags, &xds_metrics.TagSpecifier{
+			TagName: tagName,
+			TagValue: &xds_metrics.TagSpecifier_FixedValue{
+				FixedValue: statsTags[tagName],
+			},
+		})
+	}
+	return statsConfig
+}
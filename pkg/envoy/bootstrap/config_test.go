@@ -103,6 +103,31 @@ static_resources:
         '@type': type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions
         explicit_http_config:
           http2_protocol_options: {}
+    upstream_connection_options:
+      tcp_keepalive:
+        keepalive_interval: 10
+        keepalive_probes: 3
+        keepalive_time: 30
+`
+	assert.Equal(expectedYAML, string(actualYAML))
+}
+
+func TestGetStatsConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	statsConfig := getStatsConfig(map[string]string{
+		"workload_tier": "gateway",
+		"team":          "payments",
+	})
+
+	actualYAML, err := utils.ProtoToYAML(statsConfig)
+	assert.Nil(err)
+
+	expectedYAML := `stats_tags:
+- fixed_value: payments
+  tag_name: team
+- fixed_value: gateway
+  tag_name: workload_tier
 `
 	assert.Equal(expectedYAML, string(actualYAML))
 }
@@ -7,6 +7,18 @@ import (
 
 var log = logger.New("envoy/bootstrap")
 
+const (
+	// xdsKeepaliveProbes is the number of unanswered TCP keepalive probes after which the connection
+	// to the XDS cluster is considered dead
+	xdsKeepaliveProbes = 3
+
+	// xdsKeepaliveTimeSeconds is the idle time after which TCP keepalive probes are sent to the XDS cluster
+	xdsKeepaliveTimeSeconds = 30
+
+	// xdsKeepaliveIntervalSeconds is the interval between TCP keepalive probes sent to the XDS cluster
+	xdsKeepaliveIntervalSeconds = 10
+)
+
 // Config is the type used to represent the information needed to build the Envoy bootstrap config
 type Config struct {
 	// Admin port is the Envoy admin port
@@ -45,4 +57,7 @@ type Config struct {
 
 	// ECDHCurves is the list of ECDH curves it supports
 	ECDHCurves []string
+
+	// StatsTags is the set of tags with fixed values added to the proxy's stats
+	StatsTags map[string]string
 }
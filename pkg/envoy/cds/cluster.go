@@ -8,7 +8,9 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	extensions_upstream_http "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -23,6 +25,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -32,6 +35,10 @@ import (
 // replacer used to configure an Envoy cluster's altStatName
 var replacer = strings.NewReplacer(".", "_", ":", "_")
 
+// systemTrustedCAFile is the CA bundle of the system trust store in the sidecar image, used to validate
+// the certificates presented by external hosts when an Egress policy does not specify a CA bundle
+const systemTrustedCAFile = "/etc/ssl/certs/ca-certificates.crt"
+
 // getUpstreamServiceCluster returns an Envoy Cluster corresponding to the given upstream service
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
 func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, config trafficpolicy.MeshClusterConfig, sidecarSpec configv1alpha2.SidecarSpec) *xds_cluster.Cluster {
@@ -212,7 +219,7 @@ func getPrometheusCluster() *xds_cluster.Cluster {
 
 // getEgressClusters returns a slice of XDS cluster objects for the given egress cluster configs.
 // If the cluster config is invalid, an error is logged and the corresponding cluster config is ignored.
-func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*xds_cluster.Cluster {
+func getEgressClusters(downstreamIdentity identity.ServiceIdentity, clusterConfigs []*trafficpolicy.EgressClusterConfig, sidecarSpec configv1alpha2.SidecarSpec) []*xds_cluster.Cluster {
 	if clusterConfigs == nil {
 		return nil
 	}
@@ -230,6 +237,17 @@ func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*x
 				egressClusters = append(egressClusters, originalDestinationEgressCluster)
 			}
 		default:
+			if config.Gateway != nil {
+				// Cluster config is routed via the egress gateway
+				if cluster, err := getEgressGatewayRoutedCluster(downstreamIdentity, config, sidecarSpec); err != nil {
+					log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingDNSEgressCluster)).
+						Msg("Error building cluster routed via the egress gateway for the given egress cluster config")
+				} else {
+					egressClusters = append(egressClusters, cluster)
+				}
+				continue
+			}
+
 			// Cluster config has a Host specified, route it based on the Host resolved using DNS.
 			// Used for HTTP based clusters
 			if cluster, err := getDNSResolvableEgressCluster(config); err != nil {
@@ -288,6 +306,20 @@ func getDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*
 		},
 	}
 
+	if config.TLS != nil {
+		marshalledUpstreamTLSContext, err := anypb.New(getEgressUpstreamTLSContext(config.TLS))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling UpstreamTLSContext for egress cluster %s", config.Name)
+			return nil, err
+		}
+		upstreamCluster.TransportSocket = &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledUpstreamTLSContext,
+			},
+		}
+	}
+
 	applyUpstreamTrafficSetting(config.UpstreamTrafficSetting, upstreamCluster, httpProtocolOptions)
 
 	typedHTTPProtocolOptions, err := getTypedHTTPProtocolOptions(httpProtocolOptions)
@@ -300,6 +332,100 @@ func getDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*
 	return upstreamCluster, nil
 }
 
+// getEgressGatewayRoutedCluster returns an XDS cluster object that routes the traffic for the given egress cluster config
+// to the egress gateway over mTLS. The egress gateway forwards the traffic to the external host based on the SNI.
+func getEgressGatewayRoutedCluster(downstreamIdentity identity.ServiceIdentity, config *trafficpolicy.EgressClusterConfig, sidecarSpec configv1alpha2.SidecarSpec) (*xds_cluster.Cluster, error) {
+	gatewayClusterConfig := *config
+	gatewayClusterConfig.Host = config.Gateway.Service.FQDN()
+	gatewayClusterConfig.Port = config.Gateway.Port
+	gatewayClusterConfig.TLS = nil
+	gatewayClusterConfig.Gateway = nil
+
+	upstreamCluster, err := getDNSResolvableEgressCluster(&gatewayClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	upstreamTLSContext := envoy.GetUpstreamTLSContext(downstreamIdentity, config.Gateway.Service, sidecarSpec)
+	upstreamTLSContext.Sni = config.Gateway.ServerName
+	marshalledUpstreamTLSContext, err := anypb.New(upstreamTLSContext)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling UpstreamTLSContext for egress cluster %s", config.Name)
+		return nil, err
+	}
+	upstreamCluster.TransportSocket = &xds_core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledUpstreamTLSContext,
+		},
+	}
+
+	return upstreamCluster, nil
+}
+
+// getEgressUpstreamTLSContext returns the UpstreamTlsContext used to originate TLS to an external host.
+// The CA bundle and client certificate referenced by the TLS config are served to the proxy using SDS.
+func getEgressUpstreamTLSContext(tlsConfig *trafficpolicy.EgressTLSConfig) *xds_auth.UpstreamTlsContext {
+	commonTLSContext := &xds_auth.CommonTlsContext{}
+
+	if tlsConfig.ClientCertificateSecret != nil {
+		commonTLSContext.TlsCertificateSdsSecretConfigs = []*xds_auth.SdsSecretConfig{{
+			// Example ==> Name: "egress-client-cert:NameSpaceHere/SecretNameHere"
+			Name: secrets.SDSCert{
+				Name:     tlsConfig.ClientCertificateSecret.String(),
+				CertType: secrets.EgressClientCertType,
+			}.String(),
+			SdsConfig: envoy.GetADSConfigSource(),
+		}}
+	}
+
+	if tlsConfig.CABundleSecret != nil {
+		// The CA bundle is served using SDS, while the certificate presented by the external host
+		// must additionally be valid for the host the TLS connection is originated to.
+		commonTLSContext.ValidationContextType = &xds_auth.CommonTlsContext_CombinedValidationContext{
+			CombinedValidationContext: &xds_auth.CommonTlsContext_CombinedCertificateValidationContext{
+				DefaultValidationContext: &xds_auth.CertificateValidationContext{
+					MatchSubjectAltNames: []*xds_matcher.StringMatcher{{
+						MatchPattern: &xds_matcher.StringMatcher_Exact{
+							Exact: tlsConfig.SNI,
+						},
+					}},
+				},
+				ValidationContextSdsSecretConfig: &xds_auth.SdsSecretConfig{
+					// Example ==> Name: "egress-ca-bundle:NameSpaceHere/SecretNameHere"
+					Name: secrets.SDSCert{
+						Name:     tlsConfig.CABundleSecret.String(),
+						CertType: secrets.EgressCABundleCertType,
+					}.String(),
+					SdsConfig: envoy.GetADSConfigSource(),
+				},
+			},
+		}
+	} else if !tlsConfig.InsecureSkipVerify {
+		// Without a CA bundle, the certificate presented by the external host is validated
+		// using the system trust store of the sidecar.
+		commonTLSContext.ValidationContextType = &xds_auth.CommonTlsContext_ValidationContext{
+			ValidationContext: &xds_auth.CertificateValidationContext{
+				TrustedCa: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_Filename{
+						Filename: systemTrustedCAFile,
+					},
+				},
+				MatchSubjectAltNames: []*xds_matcher.StringMatcher{{
+					MatchPattern: &xds_matcher.StringMatcher_Exact{
+						Exact: tlsConfig.SNI,
+					},
+				}},
+			},
+		}
+	}
+
+	return &xds_auth.UpstreamTlsContext{
+		CommonTlsContext: commonTLSContext,
+		Sni:              tlsConfig.SNI,
+	}
+}
+
 // getOriginalDestinationEgressCluster returns an Envoy cluster that routes traffic to its original destination.
 // The original destination is the original IP address and port prior to being redirected to the sidecar proxy.
 func getOriginalDestinationEgressCluster(name string, upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting) (*xds_cluster.Cluster, error) {
@@ -341,6 +467,36 @@ func upstreamClustersFromClusterConfigs(downstreamIdentity identity.ServiceIdent
 	return clusters
 }
 
+// getIngressGatewayClusters returns the clusters of the ingress gateway for the backends of the Gateway API routes.
+// Connections to HTTP backends do not advertise the in-mesh ALPN, so that they are handled by the ingress filter chain
+// of the backend authorizing the ingress gateway. TCP backends are accessed via the in-mesh TCP filter chain.
+func getIngressGatewayClusters(downstreamIdentity identity.ServiceIdentity, configs []*trafficpolicy.MeshClusterConfig, sidecarSpec configv1alpha2.SidecarSpec) []*xds_cluster.Cluster {
+	var clusters []*xds_cluster.Cluster
+
+	for _, c := range configs {
+		upstreamCluster := getUpstreamServiceCluster(downstreamIdentity, *c, sidecarSpec)
+		if upstreamCluster == nil {
+			continue
+		}
+
+		if c.Service.Protocol != constants.ProtocolTCP && c.Service.Protocol != constants.ProtocolTCPServerFirst {
+			upstreamTLSContext := envoy.GetUpstreamTLSContext(downstreamIdentity, c.Service, sidecarSpec)
+			upstreamTLSContext.CommonTlsContext.AlpnProtocols = nil
+			marshalledUpstreamTLSContext, err := anypb.New(upstreamTLSContext)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error marshalling UpstreamTLSContext for ingress gateway cluster %s", c.Name)
+				continue
+			}
+			upstreamCluster.TransportSocket.ConfigType = &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledUpstreamTLSContext,
+			}
+		}
+
+		clusters = append(clusters, upstreamCluster)
+	}
+	return clusters
+}
+
 func localClustersFromClusterConfigs(configs []*trafficpolicy.MeshClusterConfig) []*xds_cluster.Cluster {
 	var clusters []*xds_cluster.Cluster
 
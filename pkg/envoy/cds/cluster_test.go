@@ -8,12 +8,17 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
@@ -21,6 +26,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -433,7 +439,7 @@ func TestGetEgressClusters(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert := tassert.New(t)
 
-			actual := getEgressClusters(tc.clusterConfigs)
+			actual := getEgressClusters(identity.K8sServiceAccount{Name: "sa", Namespace: "ns"}.ToServiceIdentity(), tc.clusterConfigs, configv1alpha2.SidecarSpec{})
 			assert.Len(actual, tc.expectedClusterCount)
 		})
 	}
@@ -540,6 +546,132 @@ func TestGetDNSResolvableEgressCluster(t *testing.T) {
 	}
 }
 
+func TestGetDNSResolvableEgressClusterWithTLS(t *testing.T) {
+	assert := tassert.New(t)
+
+	cluster, err := getDNSResolvableEgressCluster(&trafficpolicy.EgressClusterConfig{
+		Name: "foo.com:80",
+		Host: "foo.com",
+		Port: 443,
+		TLS:  &trafficpolicy.EgressTLSConfig{SNI: "foo.com"},
+	})
+	assert.Nil(err)
+	assert.Equal(envoy.GetAddress("foo.com", 443), cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address)
+	assert.Equal(wellknown.TransportSocketTls, cluster.TransportSocket.Name)
+
+	upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+	assert.Nil(cluster.TransportSocket.GetTypedConfig().UnmarshalTo(upstreamTLSContext))
+	assert.Equal("foo.com", upstreamTLSContext.Sni)
+}
+
+func TestGetEgressGatewayRoutedCluster(t *testing.T) {
+	assert := tassert.New(t)
+
+	downstreamIdentity := identity.K8sServiceAccount{Name: "sa", Namespace: "ns"}.ToServiceIdentity()
+	gatewaySvc := service.MeshService{Name: "osm-egress-gateway", Namespace: "osm-system"}
+
+	clusters := getEgressClusters(downstreamIdentity, []*trafficpolicy.EgressClusterConfig{
+		{
+			Name: "foo.com:80",
+			Host: "foo.com",
+			Port: 80,
+			Gateway: &trafficpolicy.EgressGatewayConfig{
+				Service:    gatewaySvc,
+				Port:       15444,
+				ServerName: "egress-80.foo.com",
+			},
+		},
+	}, configv1alpha2.SidecarSpec{})
+	assert.Len(clusters, 1)
+
+	cluster := clusters[0]
+	assert.Equal("foo.com:80", cluster.Name)
+	assert.Equal(envoy.GetAddress("osm-egress-gateway.osm-system.svc.cluster.local", 15444), cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address)
+	assert.Equal(wellknown.TransportSocketTls, cluster.TransportSocket.Name)
+
+	upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+	assert.Nil(cluster.TransportSocket.GetTypedConfig().UnmarshalTo(upstreamTLSContext))
+	assert.Equal("egress-80.foo.com", upstreamTLSContext.Sni)
+	assert.Equal(envoy.ALPNInMesh, upstreamTLSContext.CommonTlsContext.AlpnProtocols)
+	assert.Equal("service-cert:ns/sa", upstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name)
+	assert.Equal("root-cert-for-mtls-outbound:osm-system/osm-egress-gateway", upstreamTLSContext.CommonTlsContext.GetValidationContextSdsSecretConfig().Name)
+}
+
+func TestGetEgressUpstreamTLSContext(t *testing.T) {
+	testCases := []struct {
+		name      string
+		tlsConfig *trafficpolicy.EgressTLSConfig
+		expected  *xds_auth.UpstreamTlsContext
+	}{
+		{
+			name:      "TLS origination without a CA bundle validates using the system trust store",
+			tlsConfig: &trafficpolicy.EgressTLSConfig{SNI: "foo.com"},
+			expected: &xds_auth.UpstreamTlsContext{
+				CommonTlsContext: &xds_auth.CommonTlsContext{
+					ValidationContextType: &xds_auth.CommonTlsContext_ValidationContext{
+						ValidationContext: &xds_auth.CertificateValidationContext{
+							TrustedCa: &xds_core.DataSource{
+								Specifier: &xds_core.DataSource_Filename{Filename: "/etc/ssl/certs/ca-certificates.crt"},
+							},
+							MatchSubjectAltNames: []*xds_matcher.StringMatcher{{
+								MatchPattern: &xds_matcher.StringMatcher_Exact{Exact: "foo.com"},
+							}},
+						},
+					},
+				},
+				Sni: "foo.com",
+			},
+		},
+		{
+			name:      "TLS origination skipping certificate validation",
+			tlsConfig: &trafficpolicy.EgressTLSConfig{SNI: "foo.com", InsecureSkipVerify: true},
+			expected: &xds_auth.UpstreamTlsContext{
+				CommonTlsContext: &xds_auth.CommonTlsContext{},
+				Sni:              "foo.com",
+			},
+		},
+		{
+			name: "TLS origination with a CA bundle and client certificate",
+			tlsConfig: &trafficpolicy.EgressTLSConfig{
+				SNI:                     "foo.com",
+				CABundleSecret:          &types.NamespacedName{Namespace: "ns", Name: "ca"},
+				ClientCertificateSecret: &types.NamespacedName{Namespace: "ns", Name: "client"},
+			},
+			expected: &xds_auth.UpstreamTlsContext{
+				CommonTlsContext: &xds_auth.CommonTlsContext{
+					TlsCertificateSdsSecretConfigs: []*xds_auth.SdsSecretConfig{{
+						Name:      "egress-client-cert:ns/client",
+						SdsConfig: envoy.GetADSConfigSource(),
+					}},
+					ValidationContextType: &xds_auth.CommonTlsContext_CombinedValidationContext{
+						CombinedValidationContext: &xds_auth.CommonTlsContext_CombinedCertificateValidationContext{
+							DefaultValidationContext: &xds_auth.CertificateValidationContext{
+								MatchSubjectAltNames: []*xds_matcher.StringMatcher{{
+									MatchPattern: &xds_matcher.StringMatcher_Exact{Exact: "foo.com"},
+								}},
+							},
+							ValidationContextSdsSecretConfig: &xds_auth.SdsSecretConfig{
+								Name:      "egress-ca-bundle:ns/ca",
+								SdsConfig: envoy.GetADSConfigSource(),
+							},
+						},
+					},
+				},
+				Sni: "foo.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := getEgressUpstreamTLSContext(tc.tlsConfig)
+			assert.True(proto.Equal(tc.expected, actual))
+		})
+	}
+}
+
 func TestFormatAltStatNameForPrometheus(t *testing.T) {
 	testCases := []struct {
 		name                string
@@ -572,3 +704,32 @@ func TestFormatAltStatNameForPrometheus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetIngressGatewayClusters(t *testing.T) {
+	assert := tassert.New(t)
+
+	downstreamIdentity := identity.K8sServiceAccount{Name: "osm-ingress-gateway", Namespace: "osm-system"}.ToServiceIdentity()
+	httpSvc := service.MeshService{Name: "web", Namespace: "ns", Port: 80, TargetPort: 8080, Protocol: constants.ProtocolHTTP}
+	tcpSvc := service.MeshService{Name: "db", Namespace: "ns", Port: 5432, TargetPort: 5432, Protocol: constants.ProtocolTCP}
+
+	clusters := getIngressGatewayClusters(downstreamIdentity, []*trafficpolicy.MeshClusterConfig{
+		{Name: httpSvc.EnvoyClusterName(), Service: httpSvc},
+		{Name: tcpSvc.EnvoyClusterName(), Service: tcpSvc},
+	}, configv1alpha2.SidecarSpec{})
+	assert.Len(clusters, 2)
+
+	// Connections to HTTP backends do not advertise the in-mesh ALPN so that they are handled by the
+	// ingress filter chain of the backend, while TCP backends are accessed via the in-mesh filter chain.
+	expectedALPN := [][]string{nil, envoy.ALPNInMesh}
+	for i, svc := range []service.MeshService{httpSvc, tcpSvc} {
+		cluster := clusters[i]
+		assert.Equal(svc.EnvoyClusterName(), cluster.Name)
+		assert.Equal(xds_cluster.Cluster_EDS, cluster.GetType())
+
+		upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+		assert.Nil(cluster.TransportSocket.GetTypedConfig().UnmarshalTo(upstreamTLSContext))
+		assert.Equal(svc.ServerName(), upstreamTLSContext.Sni)
+		assert.Equal(expectedALPN[i], upstreamTLSContext.CommonTlsContext.AlpnProtocols)
+		assert.Equal("service-cert:osm-system/osm-ingress-gateway", upstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name)
+	}
+}
@@ -0,0 +1,37 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+
+	"github.com/openservicemesh/osm/pkg/auth"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// getExtAuthzHTTPServiceCluster returns the cluster used to reach an HTTP external authorization endpoint
+func getExtAuthzHTTPServiceCluster(extAuthConfig *auth.ExtAuthConfig) *xds_cluster.Cluster {
+	clusterName := extAuthConfig.HTTPServiceClusterName()
+
+	return &xds_cluster.Cluster{
+		Name:        clusterName,
+		AltStatName: formatAltStatNameForPrometheus(clusterName),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_STRICT_DNS,
+		},
+		LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(extAuthConfig.Address, uint32(extAuthConfig.Port)),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
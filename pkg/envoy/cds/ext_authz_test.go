@@ -0,0 +1,30 @@
+package cds
+
+import (
+	"testing"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/auth"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestGetExtAuthzHTTPServiceCluster(t *testing.T) {
+	assert := tassert.New(t)
+
+	extAuthConfig := &auth.ExtAuthConfig{
+		Enable:   true,
+		Address:  "authz.test.svc.cluster.local",
+		Port:     8080,
+		Protocol: "http",
+	}
+
+	cluster := getExtAuthzHTTPServiceCluster(extAuthConfig)
+	assert.Equal("ext-authz|authz.test.svc.cluster.local:8080", cluster.Name)
+	assert.Equal(xds_cluster.Cluster_STRICT_DNS, cluster.GetType())
+	assert.Equal(cluster.Name, cluster.LoadAssignment.ClusterName)
+	assert.Len(cluster.LoadAssignment.Endpoints, 1)
+	assert.Equal(envoy.GetAddress("authz.test.svc.cluster.local", 8080),
+		cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address)
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // NewResponse creates a new Cluster Discovery Response.
@@ -39,6 +41,34 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return removeDups(clusters), nil
 	}
 
+	if proxy.Kind() == envoy.KindEgressGateway && cfg.GetFeatureFlags().EnableEgressGateway {
+		egressGatewayTrafficPolicy, err := meshCatalog.GetEgressGatewayTrafficPolicy()
+		if err != nil {
+			log.Error().Err(err).Str("proxy", proxy.String()).Msg("Error retrieving egress gateway traffic policy")
+			return nil, err
+		}
+		if egressGatewayTrafficPolicy != nil {
+			var clusterConfigs []*trafficpolicy.EgressClusterConfig
+			for _, destination := range egressGatewayTrafficPolicy.Destinations {
+				clusterConfigs = append(clusterConfigs, destination.Cluster)
+			}
+			clusters = append(clusters, getEgressClusters(proxyIdentity, clusterConfigs, cfg.GetMeshConfig().Spec.Sidecar)...)
+		}
+		return removeDups(clusters), nil
+	}
+
+	if proxy.Kind() == envoy.KindIngressGateway && cfg.GetFeatureFlags().EnableGatewayAPI {
+		ingressGatewayTrafficPolicy, err := meshCatalog.GetIngressGatewayTrafficPolicy()
+		if err != nil {
+			log.Error().Err(err).Str("proxy", proxy.String()).Msg("Error retrieving ingress gateway traffic policy")
+			return nil, err
+		}
+		if ingressGatewayTrafficPolicy != nil {
+			clusters = append(clusters, getIngressGatewayClusters(proxyIdentity, ingressGatewayTrafficPolicy.ClustersConfigs, cfg.GetMeshConfig().Spec.Sidecar)...)
+		}
+		return removeDups(clusters), nil
+	}
+
 	// Build upstream clusters based on allowed outbound traffic policies
 	outboundMeshTrafficPolicy := meshCatalog.GetOutboundMeshTrafficPolicy(proxyIdentity)
 	if outboundMeshTrafficPolicy != nil {
@@ -57,12 +87,23 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		clusters = append(clusters, localClustersFromClusterConfigs(inboundMeshTrafficPolicy.ClustersConfigs)...)
 	}
 
+	// Add the clusters used to reach the HTTP external authorization endpoints of the proxy's services
+	extAuthzClusters := mapset.NewSet()
+	for _, proxySvc := range proxyServices {
+		extAuthConfig := meshCatalog.GetInboundExternalAuthConfig(proxySvc)
+		if extAuthConfig == nil || extAuthConfig.Protocol != constants.ProtocolHTTP || extAuthzClusters.Contains(extAuthConfig.HTTPServiceClusterName()) {
+			continue
+		}
+		extAuthzClusters.Add(extAuthConfig.HTTPServiceClusterName())
+		clusters = append(clusters, getExtAuthzHTTPServiceCluster(extAuthConfig))
+	}
+
 	// Add egress clusters based on applied policies
 	if egressTrafficPolicy, err := meshCatalog.GetEgressTrafficPolicy(proxyIdentity); err != nil {
 		log.Error().Err(err).Msgf("Error retrieving egress policies for proxy with identity %s, skipping egress clusters", proxyIdentity)
 	} else {
 		if egressTrafficPolicy != nil {
-			clusters = append(clusters, getEgressClusters(egressTrafficPolicy.ClustersConfigs)...)
+			clusters = append(clusters, getEgressClusters(proxyIdentity, egressTrafficPolicy.ClustersConfigs, cfg.GetMeshConfig().Spec.Sidecar)...)
 		}
 	}
 
@@ -104,6 +104,7 @@ func TestNewResponse(t *testing.T) {
 	mockCatalog.EXPECT().GetInboundMeshTrafficPolicy(gomock.Any(), gomock.Any()).Return(expectedInboundMeshPolicy).AnyTimes()
 	mockCatalog.EXPECT().GetOutboundMeshTrafficPolicy(tests.BookbuyerServiceIdentity).Return(expectedOutboundMeshPolicy).AnyTimes()
 	mockCatalog.EXPECT().GetEgressTrafficPolicy(tests.BookbuyerServiceIdentity).Return(nil, nil).AnyTimes()
+	mockCatalog.EXPECT().GetInboundExternalAuthConfig(gomock.Any()).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true).AnyTimes()
@@ -458,6 +459,7 @@ func TestNewResponseGetEgressTrafficPolicyError(t *testing.T) {
 	meshCatalog.EXPECT().GetInboundMeshTrafficPolicy(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	meshCatalog.EXPECT().GetOutboundMeshTrafficPolicy(proxyIdentity).Return(nil).Times(1)
 	meshCatalog.EXPECT().GetEgressTrafficPolicy(proxyIdentity).Return(nil, errors.New("some error")).Times(1)
+	meshCatalog.EXPECT().GetInboundExternalAuthConfig(gomock.Any()).Return(nil).AnyTimes()
 	meshCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
 	mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{})
 	cfg.EXPECT().IsEgressEnabled().Return(false).Times(1)
@@ -486,6 +488,7 @@ func TestNewResponseGetEgressTrafficPolicyNotEmpty(t *testing.T) {
 	meshCatalog.EXPECT().GetInboundMeshTrafficPolicy(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	meshCatalog.EXPECT().GetOutboundMeshTrafficPolicy(proxyIdentity).Return(nil).Times(1)
 	meshCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
+	meshCatalog.EXPECT().GetInboundExternalAuthConfig(gomock.Any()).Return(nil).AnyTimes()
 	mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{})
 	meshCatalog.EXPECT().GetEgressTrafficPolicy(proxyIdentity).Return(&trafficpolicy.EgressTrafficPolicy{
 		ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
@@ -497,6 +500,7 @@ func TestNewResponseGetEgressTrafficPolicyNotEmpty(t *testing.T) {
 	cfg.EXPECT().IsTracingEnabled().Return(false).Times(1)
 	cfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{EnableMulticlusterMode: false}).AnyTimes()
 	cfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+	cfg.EXPECT().GetMeshConfig().AnyTimes()
 
 	resp, err := NewResponse(meshCatalog, proxy, nil, cfg, nil, proxyRegistry)
 	tassert.NoError(t, err)
@@ -530,6 +534,45 @@ func TestNewResponseForMulticlusterGateway(t *testing.T) {
 	assert.Equal(tests.BookstoreV1Service.ServerName(), resp[0].(*xds_cluster.Cluster).Name)
 }
 
+func TestNewResponseForEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+
+	proxyRegistry := registry.NewProxyRegistry(registry.ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
+		return nil, nil
+	}), nil)
+	cn := envoy.NewXDSCertCommonName(uuid.New(), envoy.KindEgressGateway, "osm-egress-gateway", "osm-system")
+	proxy, err := envoy.NewProxy(cn, "", nil)
+	assert.Nil(err)
+
+	ctrl := gomock.NewController(t)
+	meshCatalog := catalog.NewMockMeshCataloger(ctrl)
+	cfg := configurator.NewMockConfigurator(ctrl)
+
+	cfg.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{EnableEgressPolicy: true, EnableEgressGateway: true}).AnyTimes()
+	cfg.EXPECT().GetMeshConfig().AnyTimes()
+	meshCatalog.EXPECT().GetEgressGatewayTrafficPolicy().Return(&trafficpolicy.EgressGatewayTrafficPolicy{
+		Destinations: []*trafficpolicy.EgressGatewayDestination{
+			{
+				ServerName: "egress-80.foo.com",
+				Cluster: &trafficpolicy.EgressClusterConfig{
+					Name: "foo.com:80",
+					Host: "foo.com",
+					Port: 443,
+					TLS:  &trafficpolicy.EgressTLSConfig{SNI: "foo.com"},
+				},
+			},
+		},
+	}, nil).Times(1)
+
+	resp, err := NewResponse(meshCatalog, proxy, nil, cfg, nil, proxyRegistry)
+	assert.NoError(err)
+	assert.Len(resp, 1)
+
+	cluster := resp[0].(*xds_cluster.Cluster)
+	assert.Equal("foo.com:80", cluster.Name)
+	assert.Equal(envoy.GetAddress("foo.com", 443), cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address)
+}
+
 func TestRemoveDups(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -66,7 +66,12 @@ func generateEDSConfig(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy) ([
 	}
 
 	var edsResources []types.Resource
-	upstreamSvcEndpoints := getUpstreamEndpointsForProxyIdentity(meshCatalog, proxyIdentity)
+	var upstreamSvcEndpoints map[service.MeshService][]endpoint.Endpoint
+	if proxy.Kind() == envoy.KindIngressGateway {
+		upstreamSvcEndpoints = getUpstreamEndpointsForIngressGateway(meshCatalog, proxyIdentity)
+	} else {
+		upstreamSvcEndpoints = getUpstreamEndpointsForProxyIdentity(meshCatalog, proxyIdentity)
+	}
 
 	for svc, endpoints := range upstreamSvcEndpoints {
 		loadAssignment := newClusterLoadAssignment(svc, endpoints)
@@ -114,3 +119,19 @@ func getUpstreamEndpointsForProxyIdentity(meshCatalog catalog.MeshCataloger, pro
 	log.Trace().Msgf("Allowed outbound service endpoints for proxy with identity %s: %v", proxyIdentity, allowedServicesEndpoints)
 	return allowedServicesEndpoints
 }
+
+// getUpstreamEndpointsForIngressGateway returns the endpoints of the backends of the Gateway API routes programmed on the ingress gateway
+func getUpstreamEndpointsForIngressGateway(meshCatalog catalog.MeshCataloger, proxyIdentity identity.ServiceIdentity) map[service.MeshService][]endpoint.Endpoint {
+	allowedServicesEndpoints := make(map[service.MeshService][]endpoint.Endpoint)
+
+	ingressGatewayPolicy, err := meshCatalog.GetIngressGatewayTrafficPolicy()
+	if err != nil || ingressGatewayPolicy == nil {
+		return allowedServicesEndpoints
+	}
+
+	for _, clusterConfig := range ingressGatewayPolicy.ClustersConfigs {
+		allowedServicesEndpoints[clusterConfig.Service] = meshCatalog.ListAllowedUpstreamEndpointsForService(proxyIdentity, clusterConfig.Service)
+	}
+
+	return allowedServicesEndpoints
+}
@@ -3,29 +3,45 @@ package lds
 import (
 	"fmt"
 
+	envoy_config_common_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/config/common/matcher/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_matching "github.com/envoyproxy/go-control-plane/envoy/extensions/common/matching/v3"
+	xds_matcher_action "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/matcher/action/v3"
 	xds_ext_authz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/openservicemesh/osm/pkg/auth"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 )
 
-func (lb *listenerBuilder) getExtAuthConfig() *auth.ExtAuthConfig {
-	extAuthConfig := lb.cfg.GetInboundExternalAuthConfig()
-	if extAuthConfig.Enable {
-		return &extAuthConfig
-	}
-	return nil
-}
+const (
+	// extAuthzPathMatchInputName is the name of the matcher input used to match the path of requests exempt from external authorization
+	extAuthzPathMatchInputName = "request-path"
+
+	// extAuthzSkipActionName is the name of the matcher action skipping external authorization for exempt requests
+	extAuthzSkipActionName = "skip-ext-authz"
+)
 
 // getExtAuthzHTTPFilter returns an envoy HttpFilter given an ExternAuthConfig configuration
 func getExtAuthzHTTPFilter(extAuthConfig *auth.ExtAuthConfig) *xds_hcm.HttpFilter {
 	extAuth := &xds_ext_authz.ExtAuthz{
-		Services: &xds_ext_authz.ExtAuthz_GrpcService{
+		TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
+		FailureModeAllow:    extAuthConfig.FailureModeAllow,
+	}
+
+	switch extAuthConfig.Protocol {
+	case constants.ProtocolHTTP:
+		extAuth.Services = &xds_ext_authz.ExtAuthz_HttpService{
+			HttpService: getExtAuthzHTTPService(extAuthConfig),
+		}
+
+	default:
+		extAuth.Services = &xds_ext_authz.ExtAuthz_GrpcService{
 			GrpcService: &envoy_config_core_v3.GrpcService{
 				TargetSpecifier: &envoy_config_core_v3.GrpcService_GoogleGrpc_{
 					GoogleGrpc: &envoy_config_core_v3.GrpcService_GoogleGrpc{
@@ -37,13 +53,14 @@ func getExtAuthzHTTPFilter(extAuthConfig *auth.ExtAuthConfig) *xds_hcm.HttpFilte
 				},
 				Timeout: durationpb.New(extAuthConfig.AuthzTimeout),
 			},
-		},
-		TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
-		WithRequestBody: &xds_ext_authz.BufferSettings{
-			MaxRequestBytes:     8192,
-			AllowPartialMessage: true,
-		},
-		FailureModeAllow: extAuthConfig.FailureModeAllow,
+		}
+	}
+
+	if extAuthConfig.RequestBody != nil {
+		extAuth.WithRequestBody = &xds_ext_authz.BufferSettings{
+			MaxRequestBytes:     extAuthConfig.RequestBody.MaxRequestBytes,
+			AllowPartialMessage: extAuthConfig.RequestBody.AllowPartialMessage,
+		}
 	}
 
 	extAuthMarshalled, err := anypb.New(extAuth)
@@ -52,6 +69,15 @@ func getExtAuthzHTTPFilter(extAuthConfig *auth.ExtAuthConfig) *xds_hcm.HttpFilte
 			Msg("Failed to marshal External Authorization config")
 	}
 
+	// Skip the external authorization filter for requests with exempt paths
+	if len(extAuthConfig.ExemptPathPrefixes) > 0 {
+		extAuthMarshalled, err = getExtAuthzFilterWithExemptPaths(extAuthMarshalled, extAuthConfig.ExemptPathPrefixes)
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+				Msg("Failed to marshal External Authorization config with exempt paths")
+		}
+	}
+
 	return &xds_hcm.HttpFilter{
 		Name: wellknown.HTTPExternalAuthorization,
 		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
@@ -59,3 +85,88 @@ func getExtAuthzHTTPFilter(extAuthConfig *auth.ExtAuthConfig) *xds_hcm.HttpFilte
 		},
 	}
 }
+
+// getExtAuthzHTTPService returns the HttpService used to authorize requests using an HTTP external authorization endpoint
+func getExtAuthzHTTPService(extAuthConfig *auth.ExtAuthConfig) *xds_ext_authz.HttpService {
+	httpService := &xds_ext_authz.HttpService{
+		ServerUri: &envoy_config_core_v3.HttpUri{
+			Uri: fmt.Sprintf("http://%s:%d", extAuthConfig.Address, extAuthConfig.Port),
+			HttpUpstreamType: &envoy_config_core_v3.HttpUri_Cluster{
+				Cluster: extAuthConfig.HTTPServiceClusterName(),
+			},
+			Timeout: durationpb.New(extAuthConfig.AuthzTimeout),
+		},
+		PathPrefix: extAuthConfig.PathPrefix,
+	}
+
+	if len(extAuthConfig.AllowedHeaders) > 0 {
+		var patterns []*xds_matcher.StringMatcher
+		for _, header := range extAuthConfig.AllowedHeaders {
+			patterns = append(patterns, &xds_matcher.StringMatcher{
+				MatchPattern: &xds_matcher.StringMatcher_Exact{Exact: header},
+				IgnoreCase:   true,
+			})
+		}
+		httpService.AuthorizationRequest = &xds_ext_authz.AuthorizationRequest{
+			AllowedHeaders: &xds_matcher.ListStringMatcher{Patterns: patterns},
+		}
+	}
+
+	return httpService
+}
+
+// getExtAuthzFilterWithExemptPaths wraps the given external authorization filter config so that the filter
+// is skipped for requests whose path starts with one of the given prefixes
+func getExtAuthzFilterWithExemptPaths(extAuthz *anypb.Any, exemptPathPrefixes []string) (*anypb.Any, error) {
+	pathInput, err := anypb.New(&xds_matcher.HttpRequestHeaderMatchInput{HeaderName: ":path"})
+	if err != nil {
+		return nil, err
+	}
+	skipFilter, err := anypb.New(&xds_matcher_action.SkipFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var exemptPathMatchers []*envoy_config_common_matcher_v3.Matcher_MatcherList_FieldMatcher
+	for _, prefix := range exemptPathPrefixes {
+		exemptPathMatchers = append(exemptPathMatchers, &envoy_config_common_matcher_v3.Matcher_MatcherList_FieldMatcher{
+			Predicate: &envoy_config_common_matcher_v3.Matcher_MatcherList_Predicate{
+				MatchType: &envoy_config_common_matcher_v3.Matcher_MatcherList_Predicate_SinglePredicate_{
+					SinglePredicate: &envoy_config_common_matcher_v3.Matcher_MatcherList_Predicate_SinglePredicate{
+						Input: &envoy_config_core_v3.TypedExtensionConfig{
+							Name:        extAuthzPathMatchInputName,
+							TypedConfig: pathInput,
+						},
+						Matcher: &envoy_config_common_matcher_v3.Matcher_MatcherList_Predicate_SinglePredicate_ValueMatch{
+							ValueMatch: &xds_matcher.StringMatcher{
+								MatchPattern: &xds_matcher.StringMatcher_Prefix{Prefix: prefix},
+							},
+						},
+					},
+				},
+			},
+			OnMatch: &envoy_config_common_matcher_v3.Matcher_OnMatch{
+				OnMatch: &envoy_config_common_matcher_v3.Matcher_OnMatch_Action{
+					Action: &envoy_config_core_v3.TypedExtensionConfig{
+						Name:        extAuthzSkipActionName,
+						TypedConfig: skipFilter,
+					},
+				},
+			},
+		})
+	}
+
+	return anypb.New(&xds_matching.ExtensionWithMatcher{
+		Matcher: &envoy_config_common_matcher_v3.Matcher{
+			MatcherType: &envoy_config_common_matcher_v3.Matcher_MatcherList_{
+				MatcherList: &envoy_config_common_matcher_v3.Matcher_MatcherList{
+					Matchers: exemptPathMatchers,
+				},
+			},
+		},
+		ExtensionConfig: &envoy_config_core_v3.TypedExtensionConfig{
+			Name:        wellknown.HTTPExternalAuthorization,
+			TypedConfig: extAuthz,
+		},
+	})
+}
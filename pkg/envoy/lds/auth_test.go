@@ -2,65 +2,87 @@ package lds
 
 import (
 	"testing"
+	"time"
 
-	"github.com/golang/mock/gomock"
+	xds_matching "github.com/envoyproxy/go-control-plane/envoy/extensions/common/matching/v3"
+	xds_ext_authz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/openservicemesh/osm/pkg/auth"
-	"github.com/openservicemesh/osm/pkg/configurator"
 )
 
-func TestGetExtAuthConfig(t *testing.T) {
-	testCases := []struct {
-		name       string
-		authConfig *auth.ExtAuthConfig
-		expected   *auth.ExtAuthConfig
-	}{
-		{
-			name: "Ext Auth feature is disabled",
-			authConfig: &auth.ExtAuthConfig{
-				Enable:           false,
-				Address:          "test.xyz",
-				Port:             123,
-				StatPrefix:       "pref",
-				FailureModeAllow: false,
-			},
-			expected: nil,
-		},
-		{
-			name: "Ext Auth feature is enabled",
-			authConfig: &auth.ExtAuthConfig{
-				Enable:           true,
-				Address:          "test.xyz",
-				Port:             123,
-				StatPrefix:       "pref",
-				FailureModeAllow: false,
-			},
-			expected: &auth.ExtAuthConfig{
-				Enable:           true,
-				Address:          "test.xyz",
-				Port:             123,
-				StatPrefix:       "pref",
-				FailureModeAllow: false,
-			},
-		},
+func TestGetExtAuthzHTTPFilter(t *testing.T) {
+	a := assert.New(t)
+
+	// gRPC external authorization service
+	filter := getExtAuthzHTTPFilter(&auth.ExtAuthConfig{
+		Enable:           true,
+		Address:          "test.xyz",
+		Port:             123,
+		StatPrefix:       "pref",
+		AuthzTimeout:     time.Second,
+		FailureModeAllow: true,
+		Protocol:         "grpc",
+		RequestBody:      &auth.RequestBodyConfig{MaxRequestBytes: 8192, AllowPartialMessage: true},
+	})
+	a.Equal(wellknown.HTTPExternalAuthorization, filter.Name)
+
+	extAuthz := &xds_ext_authz.ExtAuthz{}
+	a.Nil(filter.GetTypedConfig().UnmarshalTo(extAuthz))
+	a.Equal("test.xyz:123", extAuthz.GetGrpcService().GetGoogleGrpc().GetTargetUri())
+	a.Equal("pref", extAuthz.GetGrpcService().GetGoogleGrpc().GetStatPrefix())
+	a.True(extAuthz.FailureModeAllow)
+	a.Equal(uint32(8192), extAuthz.GetWithRequestBody().GetMaxRequestBytes())
+	a.True(extAuthz.GetWithRequestBody().GetAllowPartialMessage())
+
+	// HTTP external authorization service
+	extAuthConfig := &auth.ExtAuthConfig{
+		Enable:         true,
+		Address:        "test.xyz",
+		Port:           8080,
+		AuthzTimeout:   time.Second,
+		Protocol:       "http",
+		PathPrefix:     "/check",
+		AllowedHeaders: []string{"authorization"},
 	}
+	filter = getExtAuthzHTTPFilter(extAuthConfig)
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			a := assert.New(t)
-			mockCtrl := gomock.NewController(t)
-			defer mockCtrl.Finish()
+	extAuthz = &xds_ext_authz.ExtAuthz{}
+	a.Nil(filter.GetTypedConfig().UnmarshalTo(extAuthz))
+	httpService := extAuthz.GetHttpService()
+	a.NotNil(httpService)
+	a.Equal("http://test.xyz:8080", httpService.GetServerUri().GetUri())
+	a.Equal(extAuthConfig.HTTPServiceClusterName(), httpService.GetServerUri().GetCluster())
+	a.Equal("/check", httpService.PathPrefix)
+	a.Len(httpService.GetAuthorizationRequest().GetAllowedHeaders().GetPatterns(), 1)
+	a.Equal("authorization", httpService.GetAuthorizationRequest().GetAllowedHeaders().GetPatterns()[0].GetExact())
+	a.Nil(extAuthz.WithRequestBody)
+}
 
-			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
-			lb := &listenerBuilder{
-				cfg: mockConfigurator,
-			}
+func TestGetExtAuthzHTTPFilterWithExemptPaths(t *testing.T) {
+	a := assert.New(t)
 
-			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(*tc.authConfig).Times(1)
+	filter := getExtAuthzHTTPFilter(&auth.ExtAuthConfig{
+		Enable:             true,
+		Address:            "test.xyz",
+		Port:               123,
+		Protocol:           "grpc",
+		ExemptPathPrefixes: []string{"/healthz", "/metrics"},
+	})
+	a.Equal(wellknown.HTTPExternalAuthorization, filter.Name)
 
-			actual := lb.getExtAuthConfig()
-			a.Equal(tc.expected, actual)
-		})
-	}
+	// The external authorization filter is wrapped by a matcher skipping it for the exempt paths
+	extensionWithMatcher := &xds_matching.ExtensionWithMatcher{}
+	a.Nil(filter.GetTypedConfig().UnmarshalTo(extensionWithMatcher))
+
+	matchers := extensionWithMatcher.GetMatcher().GetMatcherList().GetMatchers()
+	a.Len(matchers, 2)
+	a.Equal("/healthz", matchers[0].GetPredicate().GetSinglePredicate().GetValueMatch().GetPrefix())
+	a.Equal("/metrics", matchers[1].GetPredicate().GetSinglePredicate().GetValueMatch().GetPrefix())
+	a.Equal(extAuthzSkipActionName, matchers[0].GetOnMatch().GetAction().GetName())
+
+	extAuthz := &xds_ext_authz.ExtAuthz{}
+	a.Nil(extensionWithMatcher.GetExtensionConfig().GetTypedConfig().UnmarshalTo(extAuthz))
+	a.Equal("test.xyz:123", extAuthz.GetGrpcService().GetGoogleGrpc().GetTargetUri())
 }
@@ -0,0 +1,90 @@
+package lds
+
+import (
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_dns "github.com/envoyproxy/go-control-plane/envoy/data/dns/v3"
+	xds_dns_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/dns_filter/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/errcode"
+)
+
+const (
+	dnsListenerName = "dns-listener"
+	dnsFilterName   = "envoy.filters.udp.dns_filter"
+	dnsStatPrefix   = "dns-proxy"
+
+	// dnsAnswerTTL is the TTL of the answers of the DNS proxy. It is short so that the app picks up the changes
+	// of the DNS table pushed by the controller quickly.
+	dnsAnswerTTL = 30 * time.Second
+
+	// dnsResolverTimeout is the timeout of the resolution of the queries the DNS proxy does not answer
+	dnsResolverTimeout = 5 * time.Second
+
+	// dnsMaxPendingLookups is the maximum number of queries the DNS proxy resolves concurrently
+	dnsMaxPendingLookups = 256
+)
+
+// buildDNSListener returns the DNS listener, to which the DNS queries of the app are redirected when the DNS proxy is enabled.
+// The listener answers the queries for the names in the DNS table of the proxy, and resolves the other queries using the
+// nameservers of the pod.
+func (lb *listenerBuilder) buildDNSListener() (*xds_listener.Listener, error) {
+	var virtualDomains []*xds_dns.DnsTable_DnsVirtualDomain
+	for _, record := range lb.meshCatalog.GetDNSRecords(lb.serviceIdentity) {
+		virtualDomains = append(virtualDomains, &xds_dns.DnsTable_DnsVirtualDomain{
+			Name: record.Hostname,
+			Endpoint: &xds_dns.DnsTable_DnsEndpoint{
+				EndpointConfig: &xds_dns.DnsTable_DnsEndpoint_AddressList{
+					AddressList: &xds_dns.DnsTable_AddressList{
+						Address: record.IPs,
+					},
+				},
+			},
+			AnswerTtl: durationpb.New(dnsAnswerTTL),
+		})
+	}
+
+	dnsFilter := &xds_dns_filter.DnsFilterConfig{
+		StatPrefix: dnsStatPrefix,
+		ServerConfig: &xds_dns_filter.DnsFilterConfig_ServerContextConfig{
+			ConfigSource: &xds_dns_filter.DnsFilterConfig_ServerContextConfig_InlineDnsTable{
+				InlineDnsTable: &xds_dns.DnsTable{
+					VirtualDomains: virtualDomains,
+				},
+			},
+		},
+		// Without upstream resolvers, the queries not answered from the DNS table are resolved using the
+		// nameservers of the pod. The queries of Envoy are not redirected to the DNS listener.
+		ClientConfig: &xds_dns_filter.DnsFilterConfig_ClientContextConfig{
+			ResolverTimeout:   durationpb.New(dnsResolverTimeout),
+			MaxPendingLookups: dnsMaxPendingLookups,
+		},
+	}
+
+	marshalledDNSFilter, err := anypb.New(dnsFilter)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error marshalling DnsFilterConfig object")
+		return nil, err
+	}
+
+	return &xds_listener.Listener{
+		Name:             dnsListenerName,
+		TrafficDirection: xds_core.TrafficDirection_OUTBOUND,
+		Address:          envoy.GetUDPAddress(constants.LocalhostIPAddress, constants.EnvoyDNSListenerPort),
+		ListenerFilters: []*xds_listener.ListenerFilter{
+			{
+				Name: dnsFilterName,
+				ConfigType: &xds_listener.ListenerFilter_TypedConfig{
+					TypedConfig: marshalledDNSFilter,
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+package lds
+
+import (
+	"testing"
+
+	xds_dns_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/dns_filter/v3"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildDNSListener(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		records                []*trafficpolicy.DNSRecord
+		expectedVirtualDomains map[string][]string
+	}{
+		{
+			name:                   "no DNS records",
+			expectedVirtualDomains: map[string][]string{},
+		},
+		{
+			name: "DNS records for mesh services and Egress hosts",
+			records: []*trafficpolicy.DNSRecord{
+				{Hostname: "bookstore.bookstore.svc.cluster.local", IPs: []string{"10.0.0.10"}},
+				{Hostname: "db.example.com", IPs: []string{"240.240.1.2"}},
+			},
+			expectedVirtualDomains: map[string][]string{
+				"bookstore.bookstore.svc.cluster.local": {"10.0.0.10"},
+				"db.example.com":                        {"240.240.1.2"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			proxyIdentity := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity()
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCatalog.EXPECT().GetDNSRecords(proxyIdentity).Return(tc.records).Times(1)
+
+			lb := &listenerBuilder{
+				meshCatalog:     mockCatalog,
+				serviceIdentity: proxyIdentity,
+			}
+
+			listener, err := lb.buildDNSListener()
+			assert.Nil(err)
+			assert.Equal(dnsListenerName, listener.Name)
+			assert.Equal(envoy.GetUDPAddress(constants.LocalhostIPAddress, constants.EnvoyDNSListenerPort), listener.Address)
+			assert.Nil(listener.Validate())
+			assert.Len(listener.ListenerFilters, 1)
+			assert.Equal(dnsFilterName, listener.ListenerFilters[0].Name)
+
+			dnsFilter := &xds_dns_filter.DnsFilterConfig{}
+			assert.Nil(listener.ListenerFilters[0].GetTypedConfig().UnmarshalTo(dnsFilter))
+			assert.Nil(dnsFilter.Validate())
+			assert.NotNil(dnsFilter.ClientConfig)
+
+			virtualDomains := make(map[string][]string)
+			for _, virtualDomain := range dnsFilter.ServerConfig.GetInlineDnsTable().VirtualDomains {
+				virtualDomains[virtualDomain.Name] = virtualDomain.Endpoint.GetAddressList().Address
+			}
+			assert.Equal(tc.expectedVirtualDomains, virtualDomains)
+		})
+	}
+}
@@ -106,8 +106,13 @@ func (lb *listenerBuilder) getEgressTCPFilterChain(match trafficpolicy.TrafficMa
 		destinationPrefixes = append(destinationPrefixes, cidr)
 	}
 
+	filterChainName := fmt.Sprintf("%s.%d", egressTCPFilterChainPrefix, match.DestinationPort)
+	if match.Name != "" {
+		filterChainName = fmt.Sprintf("%s.%s", filterChainName, match.Name)
+	}
+
 	return &xds_listener.FilterChain{
-		Name:    fmt.Sprintf("%s.%d", egressTCPFilterChainPrefix, match.DestinationPort),
+		Name:    filterChainName,
 		Filters: []*xds_listener.Filter{tcpFilter},
 		FilterChainMatch: &xds_listener.FilterChainMatch{
 			DestinationPort: &wrapperspb.UInt32Value{
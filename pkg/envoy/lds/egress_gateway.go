@@ -0,0 +1,166 @@
+package lds
+
+import (
+	"fmt"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rbac"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	egressGatewayListenerName         = "egress-gateway-listener"
+	egressGatewayFilterChainPrefix    = "egress-gateway"
+	egressGatewayTCPProxyStatPrefix   = "egress-gateway-tcp-proxy"
+	egressGatewayRBACPolicyName       = "egress-gateway-allowed-sources"
+	egressGatewayRBACStatPrefixFormat = "egress-gateway-%s."
+)
+
+// buildEgressGatewayListener builds the listener of the egress gateway. Sidecars connect to the listener over mTLS
+// and indicate the external destination to forward the traffic to using the SNI.
+// A nil listener is returned when there are no destinations to forward traffic to.
+func (lb *listenerBuilder) buildEgressGatewayListener() (*xds_listener.Listener, error) {
+	egressGatewayTrafficPolicy, err := lb.meshCatalog.GetEgressGatewayTrafficPolicy()
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving egress gateway traffic policy")
+		return nil, err
+	}
+	if egressGatewayTrafficPolicy == nil {
+		return nil, nil
+	}
+
+	listener := &xds_listener.Listener{
+		Name:    egressGatewayListenerName,
+		Address: envoy.GetAddress(constants.WildcardIPAddr, constants.EgressGatewayPort),
+		ListenerFilters: []*xds_listener.ListenerFilter{
+			{
+				Name: wellknown.TlsInspector,
+			},
+		},
+	}
+
+	for _, destination := range egressGatewayTrafficPolicy.Destinations {
+		filterChain, err := lb.getEgressGatewayFilterChain(destination)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building egress gateway filter chain for destination %s, skipping", destination.ServerName)
+			continue
+		}
+		listener.FilterChains = append(listener.FilterChains, filterChain)
+	}
+
+	if len(listener.FilterChains) == 0 {
+		return nil, nil
+	}
+
+	return listener, nil
+}
+
+// getEgressGatewayFilterChain returns the filter chain forwarding the traffic for the given destination to its
+// external cluster. Only the source identities allowed by the Egress policies are permitted to connect.
+func (lb *listenerBuilder) getEgressGatewayFilterChain(destination *trafficpolicy.EgressGatewayDestination) (*xds_listener.FilterChain, error) {
+	rbacFilter, err := getEgressGatewayRBACFilter(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       fmt.Sprintf("%s.%s", egressGatewayTCPProxyStatPrefix, destination.Cluster.Name),
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: destination.Cluster.Name},
+		AccessLog:        envoy.GetAccessLog(),
+	}
+	marshalledTCPProxy, err := anypb.New(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error marshalling TcpProxy for egress gateway destination %s", destination.ServerName)
+		return nil, err
+	}
+
+	marshalledDownstreamTLSContext, err := anypb.New(envoy.GetDownstreamTLSContext(lb.serviceIdentity, true /* mTLS */, lb.cfg.GetMeshConfig().Spec.Sidecar))
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error marshalling DownstreamTLSContext for egress gateway destination %s", destination.ServerName)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: fmt.Sprintf("%s:%s", egressGatewayFilterChainPrefix, destination.ServerName),
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			// The ServerName is the SNI set by the sidecar in the UpstreamTlsContext of the cluster routed via the egress gateway
+			ServerNames: []string{destination.ServerName},
+
+			// Only match when transport protocol is TLS
+			TransportProtocol: envoy.TransportProtocolTLS,
+
+			// In-mesh proxies will advertise this, set in the UpstreamTlsContext by GetUpstreamTLSContext()
+			ApplicationProtocols: envoy.ALPNInMesh,
+		},
+		Filters: []*xds_listener.Filter{
+			rbacFilter,
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledDownstreamTLSContext,
+			},
+		},
+	}, nil
+}
+
+// getEgressGatewayRBACFilter returns the network RBAC filter allowing connections from the source identities
+// allowed to access the given destination.
+func getEgressGatewayRBACFilter(destination *trafficpolicy.EgressGatewayDestination) (*xds_listener.Filter, error) {
+	policy := &rbac.Policy{}
+	for _, source := range destination.AllowedSourceIdentities {
+		policy.Principals = append(policy.Principals, rbac.RulesList{
+			OrRules: []rbac.Rule{
+				{Attribute: rbac.DownstreamAuthPrincipal, Value: source.String()},
+			},
+		})
+	}
+
+	rbacPolicies := make(map[string]*xds_rbac.Policy)
+	// A destination without allowed sources denies all connections
+	if len(policy.Principals) > 0 {
+		xdsPolicy, err := policy.Generate()
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrBuildingRBACPolicy)).
+				Msgf("Error building RBAC policy for egress gateway destination %s", destination.ServerName)
+			return nil, err
+		}
+		rbacPolicies[egressGatewayRBACPolicyName] = xdsPolicy
+	}
+
+	networkRBACPolicy := &xds_network_rbac.RBAC{
+		StatPrefix: fmt.Sprintf(egressGatewayRBACStatPrefixFormat, destination.ServerName),
+		Rules: &xds_rbac.RBAC{
+			Action:   xds_rbac.RBAC_ALLOW,
+			Policies: rbacPolicies,
+		},
+	}
+
+	marshalledNetworkRBACPolicy, err := anypb.New(networkRBACPolicy)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error marshalling RBAC policy: %v", networkRBACPolicy)
+		return nil, err
+	}
+
+	return &xds_listener.Filter{
+		Name:       wellknown.RoleBasedAccessControl,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledNetworkRBACPolicy},
+	}, nil
+}
@@ -0,0 +1,145 @@
+package lds
+
+import (
+	"testing"
+
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildEgressGatewayListener(t *testing.T) {
+	source := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity()
+
+	testCases := []struct {
+		name                     string
+		egressGatewayPolicy      *trafficpolicy.EgressGatewayTrafficPolicy
+		expectNilListener        bool
+		expectedFilterChainNames []string
+	}{
+		{
+			name:              "no egress gateway policy",
+			expectNilListener: true,
+		},
+		{
+			name:                "egress gateway policy without destinations",
+			egressGatewayPolicy: &trafficpolicy.EgressGatewayTrafficPolicy{},
+			expectNilListener:   true,
+		},
+		{
+			name: "egress gateway policy with destinations",
+			egressGatewayPolicy: &trafficpolicy.EgressGatewayTrafficPolicy{
+				Destinations: []*trafficpolicy.EgressGatewayDestination{
+					{
+						ServerName:              "egress-80.foo.com",
+						Cluster:                 &trafficpolicy.EgressClusterConfig{Name: "foo.com:80", Host: "foo.com", Port: 80},
+						AllowedSourceIdentities: []identity.ServiceIdentity{source},
+					},
+					{
+						ServerName: "egress-443.bar.com",
+						Cluster:    &trafficpolicy.EgressClusterConfig{Name: "bar.com:443", Host: "bar.com", Port: 443},
+					},
+				},
+			},
+			expectedFilterChainNames: []string{"egress-gateway:egress-80.foo.com", "egress-gateway:egress-443.bar.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockCatalog.EXPECT().GetEgressGatewayTrafficPolicy().Return(tc.egressGatewayPolicy, nil).Times(1)
+			mockConfigurator.EXPECT().GetMeshConfig().AnyTimes()
+
+			lb := &listenerBuilder{
+				meshCatalog:     mockCatalog,
+				cfg:             mockConfigurator,
+				serviceIdentity: identity.K8sServiceAccount{Name: constants.OSMEgressGatewayName, Namespace: "osm-system"}.ToServiceIdentity(),
+			}
+
+			listener, err := lb.buildEgressGatewayListener()
+			assert.Nil(err)
+			if tc.expectNilListener {
+				assert.Nil(listener)
+				return
+			}
+
+			assert.Equal(egressGatewayListenerName, listener.Name)
+			assert.Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EgressGatewayPort), listener.Address)
+			assert.Len(listener.ListenerFilters, 1)
+			var filterChainNames []string
+			for _, filterChain := range listener.FilterChains {
+				filterChainNames = append(filterChainNames, filterChain.Name)
+			}
+			assert.Equal(tc.expectedFilterChainNames, filterChainNames)
+		})
+	}
+}
+
+func TestGetEgressGatewayFilterChain(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetMeshConfig().AnyTimes()
+
+	lb := &listenerBuilder{
+		cfg:             mockConfigurator,
+		serviceIdentity: identity.K8sServiceAccount{Name: constants.OSMEgressGatewayName, Namespace: "osm-system"}.ToServiceIdentity(),
+	}
+
+	source := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity()
+	filterChain, err := lb.getEgressGatewayFilterChain(&trafficpolicy.EgressGatewayDestination{
+		ServerName:              "egress-80.foo.com",
+		Cluster:                 &trafficpolicy.EgressClusterConfig{Name: "foo.com:80", Host: "foo.com", Port: 80},
+		AllowedSourceIdentities: []identity.ServiceIdentity{source},
+	})
+	assert.Nil(err)
+
+	assert.Equal([]string{"egress-80.foo.com"}, filterChain.FilterChainMatch.ServerNames)
+	assert.Equal(envoy.TransportProtocolTLS, filterChain.FilterChainMatch.TransportProtocol)
+	assert.Equal(envoy.ALPNInMesh, filterChain.FilterChainMatch.ApplicationProtocols)
+	assert.Equal(wellknown.TransportSocketTls, filterChain.TransportSocket.Name)
+	assert.Len(filterChain.Filters, 2)
+
+	// The RBAC filter only allows the source identities of the destination
+	assert.Equal(wellknown.RoleBasedAccessControl, filterChain.Filters[0].Name)
+	networkRBAC := &xds_network_rbac.RBAC{}
+	assert.Nil(filterChain.Filters[0].GetTypedConfig().UnmarshalTo(networkRBAC))
+	assert.Len(networkRBAC.Rules.Policies, 1)
+	principals := networkRBAC.Rules.Policies[egressGatewayRBACPolicyName].Principals
+	assert.Len(principals, 1)
+	assert.Contains(principals[0].String(), source.String())
+
+	// The TCP proxy filter forwards the traffic to the external cluster
+	assert.Equal(wellknown.TCPProxy, filterChain.Filters[1].Name)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(filterChain.Filters[1].GetTypedConfig().UnmarshalTo(tcpProxy))
+	assert.Equal("foo.com:80", tcpProxy.GetCluster())
+}
+
+func TestGetEgressGatewayRBACFilterWithoutSources(t *testing.T) {
+	assert := tassert.New(t)
+
+	filter, err := getEgressGatewayRBACFilter(&trafficpolicy.EgressGatewayDestination{ServerName: "egress-80.foo.com"})
+	assert.Nil(err)
+
+	networkRBAC := &xds_network_rbac.RBAC{}
+	assert.Nil(filter.GetTypedConfig().UnmarshalTo(networkRBAC))
+	assert.Empty(networkRBAC.Rules.Policies)
+}
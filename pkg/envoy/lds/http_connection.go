@@ -35,6 +35,9 @@ type httpConnManagerOptions struct {
 	direction         connectionDirection
 	rdsRoutConfigName string
 
+	// auditMode indicates the access policies applied by the RBAC filter are audited instead of enforced
+	auditMode bool
+
 	// Additional filters
 	wasmStatsHeaders         map[string]string
 	extAuthConfig            *auth.ExtAuthConfig
@@ -67,6 +70,11 @@ func (options httpConnManagerOptions) build() (*xds_hcm.HttpConnectionManager, e
 		AccessLog: envoy.GetAccessLog(),
 	}
 
+	// Log requests with the downstream identity and the result of the audited policies
+	if options.auditMode {
+		connManager.AccessLog = envoy.GetAuditAccessLog()
+	}
+
 	// For inbound connections, add the Authz filter
 	if options.direction == inbound && options.extAuthConfig != nil {
 		connManager.HttpFilters = append(connManager.HttpFilters, getExtAuthzHTTPFilter(options.extAuthConfig))
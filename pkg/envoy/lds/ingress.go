@@ -35,7 +35,7 @@ func (lb *listenerBuilder) getIngressFilterChains(svc service.MeshService) []*xd
 
 	var filterChains []*xds_listener.FilterChain
 	for _, trafficMatch := range ingressPolicy.TrafficMatches {
-		if filterChain, err := lb.getIngressFilterChainFromTrafficMatch(trafficMatch, lb.cfg.GetMeshConfig().Spec.Sidecar); err != nil {
+		if filterChain, err := lb.getIngressFilterChainFromTrafficMatch(svc, trafficMatch, lb.cfg.GetMeshConfig().Spec.Sidecar); err != nil {
 			log.Error().Err(err).Msgf("Error building ingress filter chain for proxy with identity %s service %s", lb.serviceIdentity, svc)
 		} else {
 			filterChains = append(filterChains, filterChain)
@@ -45,7 +45,7 @@ func (lb *listenerBuilder) getIngressFilterChains(svc service.MeshService) []*xd
 	return filterChains
 }
 
-func (lb *listenerBuilder) getIngressFilterChainFromTrafficMatch(trafficMatch *trafficpolicy.IngressTrafficMatch, sidecarSpec configv1alpha2.SidecarSpec) (*xds_listener.FilterChain, error) {
+func (lb *listenerBuilder) getIngressFilterChainFromTrafficMatch(svc service.MeshService, trafficMatch *trafficpolicy.IngressTrafficMatch, sidecarSpec configv1alpha2.SidecarSpec) (*xds_listener.FilterChain, error) {
 	if trafficMatch == nil {
 		return nil, errors.Errorf("Nil IngressTrafficMatch for ingress on proxy with identity %s", lb.serviceIdentity)
 	}
@@ -57,7 +57,7 @@ func (lb *listenerBuilder) getIngressFilterChainFromTrafficMatch(trafficMatch *t
 
 		// Additional filters
 		wasmStatsHeaders: nil, // no WASM Stats for ingress traffic
-		extAuthConfig:    lb.getExtAuthConfig(),
+		extAuthConfig:    lb.meshCatalog.GetInboundExternalAuthConfig(svc),
 
 		// Tracing options
 		enableTracing:      lb.cfg.IsTracingEnabled(),
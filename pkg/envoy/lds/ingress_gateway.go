@@ -0,0 +1,195 @@
+package lds
+
+import (
+	"fmt"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rds/route"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	ingressGatewayFilterChainPrefix  = "ingress-gateway"
+	ingressGatewayTCPProxyStatPrefix = "ingress-gateway-tcp-proxy"
+)
+
+// ingressGatewayALPNProtocols are the application protocols advertised by the ingress gateway when terminating TLS
+var ingressGatewayALPNProtocols = []string{"h2", "http/1.1"}
+
+// buildIngressGatewayListeners builds the listeners of the ingress gateway, one per port of the Gateway listeners
+// programmed from the Gateway API resources.
+func (lb *listenerBuilder) buildIngressGatewayListeners() ([]*xds_listener.Listener, error) {
+	ingressGatewayTrafficPolicy, err := lb.meshCatalog.GetIngressGatewayTrafficPolicy()
+	if err != nil {
+		log.Error().Err(err).Msg("Error retrieving ingress gateway traffic policy")
+		return nil, err
+	}
+	if ingressGatewayTrafficPolicy == nil {
+		return nil, nil
+	}
+
+	var listeners []*xds_listener.Listener
+	for _, gwListener := range ingressGatewayTrafficPolicy.Listeners {
+		listener, err := lb.buildIngressGatewayListener(gwListener)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building ingress gateway listener %s, skipping", gwListener.Name)
+			continue
+		}
+		if listener == nil {
+			log.Debug().Msgf("Not programming ingress gateway listener %s without filter chains", gwListener.Name)
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// buildIngressGatewayListener builds the ingress gateway listener for the given Gateway listener.
+// A nil listener is returned when the listener has no filter chains.
+func (lb *listenerBuilder) buildIngressGatewayListener(gwListener *trafficpolicy.IngressGatewayListener) (*xds_listener.Listener, error) {
+	listener := &xds_listener.Listener{
+		Name:    gwListener.Name,
+		Address: envoy.GetAddress(constants.WildcardIPAddr, gwListener.Port),
+	}
+
+	switch gwListener.Protocol {
+	case constants.ProtocolHTTP:
+		filter, err := lb.getOutboundHTTPFilter(route.GetIngressGatewayRouteConfigNameForPort(int(gwListener.Port)))
+		if err != nil {
+			return nil, err
+		}
+		listener.FilterChains = append(listener.FilterChains, &xds_listener.FilterChain{
+			Name:    fmt.Sprintf("%s:%s", ingressGatewayFilterChainPrefix, gwListener.Name),
+			Filters: []*xds_listener.Filter{filter},
+		})
+
+	case constants.ProtocolHTTPS:
+		listener.ListenerFilters = []*xds_listener.ListenerFilter{{Name: wellknown.TlsInspector}}
+		for _, termination := range gwListener.TLSTerminations {
+			filterChain, err := lb.getIngressGatewayHTTPSFilterChain(gwListener, termination)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building ingress gateway filter chain for listener %s, skipping", termination.Name)
+				continue
+			}
+			listener.FilterChains = append(listener.FilterChains, filterChain)
+		}
+
+	case constants.ProtocolTLS:
+		listener.ListenerFilters = []*xds_listener.ListenerFilter{{Name: wellknown.TlsInspector}}
+		for _, tlsRoute := range gwListener.TLSRoutes {
+			filterChain, err := getIngressGatewayTLSPassthroughFilterChain(tlsRoute)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building ingress gateway filter chain for TLSRoute %s, skipping", tlsRoute.Name)
+				continue
+			}
+			listener.FilterChains = append(listener.FilterChains, filterChain)
+		}
+	}
+
+	if len(listener.FilterChains) == 0 {
+		return nil, nil
+	}
+
+	return listener, nil
+}
+
+// getIngressGatewayHTTPSFilterChain returns the filter chain terminating TLS for the given hostnames using the certificate
+// of the Gateway listener, and routing the requests using the route configuration of the listener port.
+func (lb *listenerBuilder) getIngressGatewayHTTPSFilterChain(gwListener *trafficpolicy.IngressGatewayListener, termination *trafficpolicy.IngressGatewayTLSTermination) (*xds_listener.FilterChain, error) {
+	filter, err := lb.getOutboundHTTPFilter(route.GetIngressGatewayRouteConfigNameForPort(int(gwListener.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	downstreamTLSContext := &xds_auth.DownstreamTlsContext{
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsParams: envoy.GetTLSParams(lb.cfg.GetMeshConfig().Spec.Sidecar),
+			TlsCertificateSdsSecretConfigs: []*xds_auth.SdsSecretConfig{{
+				Name:      secrets.SDSCert{Name: termination.CertificateSecret.String(), CertType: secrets.IngressGatewayCertType}.String(),
+				SdsConfig: envoy.GetADSConfigSource(),
+			}},
+			AlpnProtocols: ingressGatewayALPNProtocols,
+		},
+		RequireClientCertificate: &wrappers.BoolValue{Value: false},
+	}
+	marshalledDownstreamTLSContext, err := anypb.New(downstreamTLSContext)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error marshalling DownstreamTLSContext for ingress gateway listener %s", termination.Name)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: fmt.Sprintf("%s:%s", ingressGatewayFilterChainPrefix, termination.Name),
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			// A filter chain without server names matches the connections not matching other filter chains
+			ServerNames:       termination.Hostnames,
+			TransportProtocol: envoy.TransportProtocolTLS,
+		},
+		Filters: []*xds_listener.Filter{filter},
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledDownstreamTLSContext,
+			},
+		},
+	}, nil
+}
+
+// getIngressGatewayTLSPassthroughFilterChain returns the filter chain passing the TLS connections matching the hostnames
+// of the given TLSRoute through to its backends.
+func getIngressGatewayTLSPassthroughFilterChain(tlsRoute *trafficpolicy.IngressGatewayTLSRoute) (*xds_listener.FilterChain, error) {
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix: fmt.Sprintf("%s.%s", ingressGatewayTCPProxyStatPrefix, tlsRoute.Name),
+		AccessLog:  envoy.GetAccessLog(),
+	}
+
+	if len(tlsRoute.WeightedClusters) == 1 {
+		tcpProxy.ClusterSpecifier = &xds_tcp_proxy.TcpProxy_Cluster{Cluster: tlsRoute.WeightedClusters[0].ClusterName.String()}
+	} else {
+		var clusterWeights []*xds_tcp_proxy.TcpProxy_WeightedCluster_ClusterWeight
+		for _, cluster := range tlsRoute.WeightedClusters {
+			clusterWeights = append(clusterWeights, &xds_tcp_proxy.TcpProxy_WeightedCluster_ClusterWeight{
+				Name:   cluster.ClusterName.String(),
+				Weight: uint32(cluster.Weight),
+			})
+		}
+		tcpProxy.ClusterSpecifier = &xds_tcp_proxy.TcpProxy_WeightedClusters{
+			WeightedClusters: &xds_tcp_proxy.TcpProxy_WeightedCluster{Clusters: clusterWeights},
+		}
+	}
+
+	marshalledTCPProxy, err := anypb.New(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+			Msgf("Error marshalling TcpProxy for TLSRoute %s", tlsRoute.Name)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: fmt.Sprintf("%s:%s", ingressGatewayFilterChainPrefix, tlsRoute.Name),
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			// A filter chain without server names matches the connections not matching other filter chains
+			ServerNames:       tlsRoute.Hostnames,
+			TransportProtocol: envoy.TransportProtocolTLS,
+		},
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,138 @@
+package lds
+
+import (
+	"testing"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildIngressGatewayListeners(t *testing.T) {
+	ingressGatewayPolicy := &trafficpolicy.IngressGatewayTrafficPolicy{
+		Listeners: []*trafficpolicy.IngressGatewayListener{
+			{
+				Name:     "ingress-gateway-http-80",
+				Port:     80,
+				Protocol: constants.ProtocolHTTP,
+			},
+			{
+				Name:     "ingress-gateway-https-443",
+				Port:     443,
+				Protocol: constants.ProtocolHTTPS,
+				TLSTerminations: []*trafficpolicy.IngressGatewayTLSTermination{
+					{
+						Name:              "ns-1/gw/https",
+						Hostnames:         []string{"foo.com"},
+						CertificateSecret: types.NamespacedName{Namespace: "ns-1", Name: "foo-cert"},
+					},
+				},
+			},
+			{
+				Name:     "ingress-gateway-tls-8443",
+				Port:     8443,
+				Protocol: constants.ProtocolTLS,
+				TLSRoutes: []*trafficpolicy.IngressGatewayTLSRoute{
+					{
+						Name:      "ns-1/db",
+						Hostnames: []string{"db.foo.com"},
+						WeightedClusters: []service.WeightedCluster{
+							{ClusterName: "ns-1/db|5432", Weight: 1},
+						},
+					},
+				},
+			},
+			// Listeners without filter chains are not programmed
+			{
+				Name:     "ingress-gateway-tls-9443",
+				Port:     9443,
+				Protocol: constants.ProtocolTLS,
+			},
+		},
+	}
+
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockCatalog.EXPECT().GetIngressGatewayTrafficPolicy().Return(ingressGatewayPolicy, nil).Times(1)
+	mockConfigurator.EXPECT().GetMeshConfig().AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetTracingEndpoint().Return("").AnyTimes()
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
+		serviceIdentity: identity.K8sServiceAccount{Name: constants.OSMIngressGatewayName, Namespace: "osm-system"}.ToServiceIdentity(),
+	}
+
+	listeners, err := lb.buildIngressGatewayListeners()
+	assert.Nil(err)
+	assert.Len(listeners, 3)
+
+	// HTTP listener routing requests using the route configuration of its port
+	httpListener := listeners[0]
+	assert.Equal("ingress-gateway-http-80", httpListener.Name)
+	assert.Equal(envoy.GetAddress(constants.WildcardIPAddr, 80), httpListener.Address)
+	assert.Empty(httpListener.ListenerFilters)
+	assert.Len(httpListener.FilterChains, 1)
+	assert.Equal(wellknown.HTTPConnectionManager, httpListener.FilterChains[0].Filters[0].Name)
+
+	// HTTPS listener terminating TLS using the certificate of the Gateway listener
+	httpsListener := listeners[1]
+	assert.Equal("ingress-gateway-https-443", httpsListener.Name)
+	assert.Equal([]*xds_listener.ListenerFilter{{Name: wellknown.TlsInspector}}, httpsListener.ListenerFilters)
+	assert.Len(httpsListener.FilterChains, 1)
+	httpsFilterChain := httpsListener.FilterChains[0]
+	assert.Equal([]string{"foo.com"}, httpsFilterChain.FilterChainMatch.ServerNames)
+	assert.Equal(wellknown.HTTPConnectionManager, httpsFilterChain.Filters[0].Name)
+	downstreamTLSContext := &xds_auth.DownstreamTlsContext{}
+	assert.Nil(httpsFilterChain.TransportSocket.GetTypedConfig().UnmarshalTo(downstreamTLSContext))
+	assert.Equal("ingress-gateway-cert:ns-1/foo-cert", downstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name)
+	assert.False(downstreamTLSContext.RequireClientCertificate.Value)
+
+	// TLS listener passing the TLS connections through to the backends
+	tlsListener := listeners[2]
+	assert.Equal("ingress-gateway-tls-8443", tlsListener.Name)
+	assert.Len(tlsListener.FilterChains, 1)
+	tlsFilterChain := tlsListener.FilterChains[0]
+	assert.Equal([]string{"db.foo.com"}, tlsFilterChain.FilterChainMatch.ServerNames)
+	assert.Nil(tlsFilterChain.TransportSocket)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(tlsFilterChain.Filters[0].GetTypedConfig().UnmarshalTo(tcpProxy))
+	assert.Equal("ns-1/db|5432", tcpProxy.GetCluster())
+}
+
+func TestGetIngressGatewayTLSPassthroughFilterChainWithWeightedClusters(t *testing.T) {
+	assert := tassert.New(t)
+
+	filterChain, err := getIngressGatewayTLSPassthroughFilterChain(&trafficpolicy.IngressGatewayTLSRoute{
+		Name: "ns-1/db",
+		WeightedClusters: []service.WeightedCluster{
+			{ClusterName: "ns-1/db-v1|5432", Weight: 90},
+			{ClusterName: "ns-1/db-v2|5432", Weight: 10},
+		},
+	})
+	assert.Nil(err)
+	assert.Nil(filterChain.FilterChainMatch.ServerNames)
+
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(filterChain.Filters[0].GetTypedConfig().UnmarshalTo(tcpProxy))
+	assert.Len(tcpProxy.GetWeightedClusters().Clusters, 2)
+	assert.Equal("ns-1/db-v1|5432", tcpProxy.GetWeightedClusters().Clusters[0].Name)
+	assert.Equal(uint32(90), tcpProxy.GetWeightedClusters().Clusters[0].Weight)
+}
@@ -12,7 +12,6 @@ import (
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 
-	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -84,9 +83,7 @@ func TestGetIngressFilterChains(t *testing.T) {
 			mockCatalog.EXPECT().GetIngressTrafficPolicy(testSvc).Return(tc.ingressPolicy, nil)
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingEndpoint().Return("test").AnyTimes()
-			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
-				Enable: false,
-			}).AnyTimes()
+			mockCatalog.EXPECT().GetInboundExternalAuthConfig(testSvc).Return(nil).AnyTimes()
 			mockConfigurator.EXPECT().GetMeshConfig().AnyTimes()
 
 			actual := lb.getIngressFilterChains(testSvc)
@@ -168,18 +165,18 @@ func TestGetIngressFilterChainFromTrafficMatch(t *testing.T) {
 
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 			lb := &listenerBuilder{
 				serviceIdentity: tests.BookstoreServiceIdentity,
 				cfg:             mockConfigurator,
+				meshCatalog:     mockCatalog,
 			}
 
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false)
 			mockConfigurator.EXPECT().GetTracingEndpoint().Return("test")
-			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
-				Enable: false,
-			})
+			mockCatalog.EXPECT().GetInboundExternalAuthConfig(tests.BookstoreV1Service).Return(nil)
 
-			actual, err := lb.getIngressFilterChainFromTrafficMatch(tc.trafficMatch, configv1alpha2.SidecarSpec{})
+			actual, err := lb.getIngressFilterChainFromTrafficMatch(tests.BookstoreV1Service, tc.trafficMatch, configv1alpha2.SidecarSpec{})
 			assert.Equal(tc.expectError, err != nil)
 
 			if err == nil {
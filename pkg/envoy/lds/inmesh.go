@@ -61,9 +61,11 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 	var filters []*xds_listener.Filter
 
 	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must be the first filter in the list of filters.
+	auditMode := false
 	if !lb.cfg.IsPermissiveTrafficPolicyMode() {
 		// Apply RBAC policies on the inbound filters based on configured policies
-		rbacFilter, err := lb.buildRBACFilter()
+		auditMode = lb.meshCatalog.IsPolicyAuditModeEnabled(proxyService)
+		rbacFilter, err := lb.buildRBACFilter(auditMode)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error applying RBAC filter for proxy service %s", proxyService)
 			return nil, err
@@ -78,8 +80,9 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 		rdsRoutConfigName: route.GetInboundMeshRouteConfigNameForPort(int(servicePort)),
 
 		// Additional filters
+		auditMode:                auditMode,
 		wasmStatsHeaders:         lb.getWASMStatsHeaders(),
-		extAuthConfig:            lb.getExtAuthConfig(),
+		extAuthConfig:            lb.meshCatalog.GetInboundExternalAuthConfig(proxyService),
 		enableActiveHealthChecks: lb.cfg.GetFeatureFlags().EnableEnvoyActiveHealthChecks,
 
 		// Tracing options
@@ -209,9 +212,11 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 	var filters []*xds_listener.Filter
 
 	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must be the first filter in the list of filters.
+	auditMode := false
 	if !lb.cfg.IsPermissiveTrafficPolicyMode() {
 		// Apply RBAC policies on the inbound filters based on configured policies
-		rbacFilter, err := lb.buildRBACFilter()
+		auditMode = lb.meshCatalog.IsPolicyAuditModeEnabled(proxyService)
+		rbacFilter, err := lb.buildRBACFilter(auditMode)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error applying RBAC filter for proxy service %s", proxyService)
 			return nil, err
@@ -225,6 +230,10 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 		StatPrefix:       fmt.Sprintf("%s.%s", inboundMeshTCPProxyStatPrefix, proxyService.EnvoyLocalClusterName()),
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: proxyService.EnvoyLocalClusterName()},
 	}
+	if auditMode {
+		// Log connections with the downstream identity and the result of the audited policies
+		tcpProxy.AccessLog = envoy.GetAuditAccessLog()
+	}
 	marshalledTCPProxy, err := anypb.New(tcpProxy)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
@@ -14,7 +14,6 @@ import (
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 
-	"github.com/openservicemesh/osm/pkg/auth"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy/rds/route"
@@ -34,9 +33,7 @@ func TestGetOutboundHTTPFilterChainForService(t *testing.T) {
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-api").AnyTimes()
-	mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
-		Enable: false,
-	}).AnyTimes()
+	mockCatalog.EXPECT().GetInboundExternalAuthConfig(gomock.Any()).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
 		EnableWASMStats: false,
 	}).AnyTimes()
@@ -211,9 +208,7 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-api").AnyTimes()
-	mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
-		Enable: false,
-	}).AnyTimes()
+	mockCatalog.EXPECT().GetInboundExternalAuthConfig(gomock.Any()).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
 		EnableWASMStats:        false,
 		EnableMulticlusterMode: true,
@@ -285,6 +280,7 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 			if !tc.permissiveMode {
 				// mock catalog calls used to build the RBAC filter
 				mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(lb.serviceIdentity).Return(trafficTargets, nil).Times(1)
+				mockCatalog.EXPECT().IsPolicyAuditModeEnabled(proxyService).Return(false).Times(1)
 			}
 
 			filterChain, err := lb.getInboundMeshHTTPFilterChain(proxyService, tc.port)
@@ -309,9 +305,7 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-api").AnyTimes()
-	mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
-		Enable: false,
-	}).AnyTimes()
+	mockCatalog.EXPECT().GetInboundExternalAuthConfig(gomock.Any()).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
 		EnableMulticlusterMode: true,
 	}).AnyTimes()
@@ -383,6 +377,7 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 			if !tc.permissiveMode {
 				// mock catalog calls used to build the RBAC filter
 				mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(lb.serviceIdentity).Return(trafficTargets, nil).Times(1)
+				mockCatalog.EXPECT().IsPolicyAuditModeEnabled(proxyService).Return(false).Times(1)
 			}
 
 			filterChain, err := lb.getInboundMeshTCPFilterChain(proxyService, tc.port)
@@ -609,9 +604,6 @@ func TestGetOutboundHTTPFilter(t *testing.T) {
 
 	mockConfigurator.EXPECT().IsTracingEnabled()
 	mockConfigurator.EXPECT().GetTracingEndpoint()
-	mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(auth.ExtAuthConfig{
-		Enable: false,
-	}).AnyTimes()
 	mockConfigurator.EXPECT().GetFeatureFlags().Return(configv1alpha2.FeatureFlags{
 		EnableWASMStats: false,
 	}).AnyTimes()
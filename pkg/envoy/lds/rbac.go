@@ -9,21 +9,32 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
+const (
+	// rbacAuditAllowAllPolicyName is the name of the RBAC policy allowing all connections when policies are audited
+	rbacAuditAllowAllPolicyName = "audit-allow-all"
+)
+
 // buildRBACFilter builds an RBAC filter based on SMI TrafficTarget policies.
 // The returned RBAC filter has policies that gives downstream principals full access to the local service.
-func (lb *listenerBuilder) buildRBACFilter() (*xds_listener.Filter, error) {
+// When auditMode is set, the policies are programmed as shadow rules and all connections are allowed.
+func (lb *listenerBuilder) buildRBACFilter(auditMode bool) (*xds_listener.Filter, error) {
 	networkRBACPolicy, err := lb.buildInboundRBACPolicies()
 	if err != nil {
 		log.Error().Err(err).Msgf("Error building inbound RBAC policies for principal %q", lb.serviceIdentity)
 		return nil, err
 	}
 
+	if auditMode {
+		auditRBACPolicy(networkRBACPolicy)
+	}
+
 	marshalledNetworkRBACPolicy, err := anypb.New(networkRBACPolicy)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
@@ -74,6 +85,20 @@ func (lb *listenerBuilder) buildInboundRBACPolicies() (*xds_network_rbac.RBAC, e
 	return networkRBACPolicy, nil
 }
 
+// auditRBACPolicy moves the rules of the given network RBAC policy to its shadow rules, and replaces them
+// with a policy that allows all connections. Connections the original rules would deny are then reported
+// with the 'rbac.audit.shadow_denied' stat instead of being rejected.
+func auditRBACPolicy(networkRBACPolicy *xds_network_rbac.RBAC) {
+	allowAllPolicy, _ := (&rbac.Policy{}).Generate() // An empty policy never fails to generate
+
+	networkRBACPolicy.ShadowRules = networkRBACPolicy.Rules
+	networkRBACPolicy.ShadowRulesStatPrefix = envoy.RBACAuditShadowRulesStatPrefix
+	networkRBACPolicy.Rules = &xds_rbac.RBAC{
+		Action:   xds_rbac.RBAC_ALLOW,
+		Policies: map[string]*xds_rbac.Policy{rbacAuditAllowAllPolicyName: allowAllPolicy},
+	}
+}
+
 // buildRBACPolicyFromTrafficTarget creates an XDS RBAC policy from the given traffic target policy
 func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes) (*xds_rbac.Policy, error) {
 	policy := &rbac.Policy{}
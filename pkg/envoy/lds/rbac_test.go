@@ -8,9 +8,11 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -304,10 +306,41 @@ func TestBuildRBACFilter(t *testing.T) {
 			// Mock catalog calls
 			mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(proxySvcAccount).Return(tc.trafficTargets, nil).Times(1)
 
-			rbacFilter, err := lb.buildRBACFilter()
+			rbacFilter, err := lb.buildRBACFilter(false)
 			assert.Equal(err != nil, tc.expectErr)
 
 			assert.Equal(rbacFilter.Name, wellknown.RoleBasedAccessControl)
 		})
 	}
 }
+
+func TestAuditRBACPolicy(t *testing.T) {
+	assert := tassert.New(t)
+
+	enforcedRules := &xds_rbac.RBAC{
+		Action: xds_rbac.RBAC_ALLOW,
+		Policies: map[string]*xds_rbac.Policy{
+			"ns-1/test-1": {
+				Principals:  []*xds_rbac.Principal{rbac.GetAuthenticatedPrincipal("sa-2.ns-2.cluster.local")},
+				Permissions: []*xds_rbac.Permission{{Rule: &xds_rbac.Permission_Any{Any: true}}},
+			},
+		},
+	}
+	networkRBACPolicy := &xds_network_rbac.RBAC{
+		StatPrefix: "network-",
+		Rules:      enforcedRules,
+	}
+
+	auditRBACPolicy(networkRBACPolicy)
+
+	// The enforced rules are moved to the shadow rules
+	assert.Equal(enforcedRules, networkRBACPolicy.ShadowRules)
+	assert.Equal(envoy.RBACAuditShadowRulesStatPrefix, networkRBACPolicy.ShadowRulesStatPrefix)
+
+	// All connections are allowed
+	assert.Equal(xds_rbac.RBAC_ALLOW, networkRBACPolicy.Rules.Action)
+	assert.Len(networkRBACPolicy.Rules.Policies, 1)
+	allowAll := networkRBACPolicy.Rules.Policies[rbacAuditAllowAllPolicyName]
+	assert.True(allowAll.Principals[0].GetAny())
+	assert.True(allowAll.Permissions[0].GetAny())
+}
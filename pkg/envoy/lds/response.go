@@ -19,6 +19,7 @@ import (
 // 1. Inbound listener to handle incoming traffic
 // 2. Outbound listener to handle outgoing traffic
 // 3. Prometheus listener for metrics
+// 4. DNS listener for DNS queries, when the DNS proxy is enabled
 func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager, proxyRegistry *registry.ProxyRegistry) ([]types.Resource, error) {
 	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
@@ -47,6 +48,32 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return ldsResources, nil
 	}
 
+	if proxy.Kind() == envoy.KindEgressGateway && cfg.GetFeatureFlags().EnableEgressGateway {
+		egressGatewayListener, err := lb.buildEgressGatewayListener()
+		if err != nil {
+			log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error building egress gateway listener")
+			return ldsResources, err
+		}
+		if egressGatewayListener == nil {
+			log.Debug().Str("proxy", proxy.String()).Msg("Not programming nil egress gateway listener")
+		} else {
+			ldsResources = append(ldsResources, egressGatewayListener)
+		}
+		return ldsResources, nil
+	}
+
+	if proxy.Kind() == envoy.KindIngressGateway && cfg.GetFeatureFlags().EnableGatewayAPI {
+		ingressGatewayListeners, err := lb.buildIngressGatewayListeners()
+		if err != nil {
+			log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error building ingress gateway listeners")
+			return ldsResources, err
+		}
+		for _, listener := range ingressGatewayListeners {
+			ldsResources = append(ldsResources, listener)
+		}
+		return ldsResources, nil
+	}
+
 	// --- OUTBOUND -------------------
 	outboundListener, err := lb.newOutboundListener()
 	if err != nil {
@@ -87,7 +114,26 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		ldsResources = append(ldsResources, inboundListener)
 	}
 
-	if pod, err := envoy.GetPodFromCertificate(proxy.GetCertificateCommonName(), meshCatalog.GetKubeController()); err != nil {
+	pod, podErr := envoy.GetPodFromCertificate(proxy.GetCertificateCommonName(), meshCatalog.GetKubeController())
+
+	// --- DNS -------------------
+	// The DNS queries of the pod are redirected to the DNS listener based on the setting at injection, the listener
+	// must be programmed for as long as the pod redirects its queries even if the DNS proxy was disabled since
+	dnsCapture := cfg.GetFeatureFlags().EnableDNSProxy
+	if podErr == nil {
+		if enabled, ok := k8s.IsDNSCaptureEnabled(pod); ok {
+			dnsCapture = enabled
+		}
+	}
+	if dnsCapture {
+		if dnsListener, err := lb.buildDNSListener(); err != nil {
+			log.Error().Err(err).Str("proxy", proxy.String()).Msg("Error building DNS listener")
+		} else {
+			ldsResources = append(ldsResources, dnsListener)
+		}
+	}
+
+	if podErr != nil {
 		log.Warn().Str("proxy", proxy.String()).Msgf("Could not find pod for connecting proxy, no metadata was recorded")
 	} else if k8s.IsMetricsEnabled(pod) {
 		// Build Prometheus listener config
@@ -155,6 +155,21 @@ func TestNewResponse(t *testing.T) {
 	assert.Equal(listener.TrafficDirection, xds_core.TrafficDirection_INBOUND)
 	assert.NotNil(listener.FilterChains)
 	assert.Len(listener.FilterChains, 1)
+
+	// The DNS listener is programmed when the DNS queries of the pod are redirected to the sidecar, even though the
+	// DNS proxy is disabled in the current configuration
+	pod, err := kubeClient.CoreV1().Pods(tests.Namespace).Get(context.TODO(), tests.BookbuyerServiceName, metav1.GetOptions{})
+	assert.Nil(err)
+	pod.Annotations[constants.DNSCaptureAnnotation] = "true"
+	_, err = kubeClient.CoreV1().Pods(tests.Namespace).Update(context.TODO(), pod, metav1.UpdateOptions{})
+	assert.Nil(err)
+
+	resources, err = NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil, proxyRegistry)
+	assert.Empty(err)
+	assert.Len(resources, 4)
+	listener, ok = resources[2].(*xds_listener.Listener)
+	assert.True(ok)
+	assert.Equal(dnsListenerName, listener.Name)
 }
 
 func TestNewResponseForMulticlusterGateway(t *testing.T) {
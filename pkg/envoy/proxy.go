@@ -38,6 +38,9 @@ type Proxy struct {
 	// Contains the last resource names sent for a given proxy and TypeURL
 	lastxDSResourcesSent map[TypeURI]mapset.Set
 
+	// Contains the hashes of the last resources sent for a given TypeURI, keyed by resource name
+	lastSentResourceHashes map[TypeURI]map[string]uint64
+
 	// Contains the last requested resource names (and therefore, subscribed) for a given TypeURI
 	subscribedResources map[TypeURI]mapset.Set
 
@@ -208,6 +211,28 @@ func (p *Proxy) SetLastResourcesSent(typeURI TypeURI, resourcesSet mapset.Set) {
 	p.lastxDSResourcesSent[typeURI] = resourcesSet
 }
 
+// GetLastSentResourceHashes returns the hashes of the resources last sent to the proxy for the given TypeURI,
+// keyed by resource name, and whether they are known
+func (p *Proxy) GetLastSentResourceHashes(typeURI TypeURI) (map[string]uint64, bool) {
+	hashes, ok := p.lastSentResourceHashes[typeURI]
+	return hashes, ok
+}
+
+// SetLastSentResourceHashes records the hashes of the resources last sent to the proxy for the given TypeURI.
+// Nil hashes record that the hashes of the resources last sent are not known.
+func (p *Proxy) SetLastSentResourceHashes(typeURI TypeURI, hashes map[string]uint64) {
+	if hashes == nil {
+		delete(p.lastSentResourceHashes, typeURI)
+		return
+	}
+	p.lastSentResourceHashes[typeURI] = hashes
+}
+
+// ResetLastSentResourceHashes forgets the hashes of the resources last sent to the proxy for all the TypeURIs
+func (p *Proxy) ResetLastSentResourceHashes() {
+	p.lastSentResourceHashes = make(map[TypeURI]map[string]uint64)
+}
+
 // GetSubscribedResources returns a set of resources subscribed for a proxy given a TypeURL
 // If none were subscribed, empty set is returned
 func (p *Proxy) GetSubscribedResources(typeURI TypeURI) mapset.Set {
@@ -251,11 +276,12 @@ func NewProxy(certCommonName certificate.CommonName, certSerialNumber certificat
 		connectedAt: time.Now(),
 		hash:        hash,
 
-		lastNonce:            make(map[TypeURI]string),
-		lastSentVersion:      make(map[TypeURI]uint64),
-		lastAppliedVersion:   make(map[TypeURI]uint64),
-		lastxDSResourcesSent: make(map[TypeURI]mapset.Set),
-		subscribedResources:  make(map[TypeURI]mapset.Set),
+		lastNonce:              make(map[TypeURI]string),
+		lastSentVersion:        make(map[TypeURI]uint64),
+		lastAppliedVersion:     make(map[TypeURI]uint64),
+		lastxDSResourcesSent:   make(map[TypeURI]mapset.Set),
+		lastSentResourceHashes: make(map[TypeURI]map[string]uint64),
+		subscribedResources:    make(map[TypeURI]mapset.Set),
 
 		kind: cnMeta.ProxyKind,
 	}, nil
@@ -2,6 +2,7 @@ package rds
 
 import (
 	mapset "github.com/deckarep/golang-set"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
@@ -13,11 +14,26 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/rds/route"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // NewResponse creates a new Route Discovery Response.
-func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryReq *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager, proxyRegistry *registry.ProxyRegistry) ([]types.Resource, error) {
+func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryReq *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, certManager certificate.Manager, proxyRegistry *registry.ProxyRegistry) ([]types.Resource, error) {
+	var routeConfigCache *RouteConfigCache // route configurations are not reused without a cache
+	return routeConfigCache.NewResponse(cataloger, proxy, discoveryReq, cfg, certManager, proxyRegistry)
+}
+
+// NewRouteConfigCache returns a new RouteConfigCache
+func NewRouteConfigCache() *RouteConfigCache {
+	return &RouteConfigCache{
+		outbound: make(map[identity.ServiceIdentity]*outboundMeshRouteConfigEntry),
+	}
+}
+
+// NewResponse creates a new Route Discovery Response, reusing the outbound mesh route configurations cached
+// for the proxy's identity
+func (c *RouteConfigCache) NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryReq *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager, proxyRegistry *registry.ProxyRegistry) ([]types.Resource, error) {
 	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingServiceIdentity)).
@@ -25,6 +41,10 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 		return nil, err
 	}
 
+	if proxy.Kind() == envoy.KindIngressGateway && cfg.GetFeatureFlags().EnableGatewayAPI {
+		return buildIngressGatewayRouteConfigs(cataloger, proxy, discoveryReq)
+	}
+
 	proxyServices, err := proxyRegistry.ListProxyServices(proxy)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrFetchingServiceList)).
@@ -53,7 +73,7 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 	outboundMeshTrafficPolicy := cataloger.GetOutboundMeshTrafficPolicy(proxyIdentity)
 
 	if outboundMeshTrafficPolicy != nil {
-		outboundMeshRouteConfig := route.BuildOutboundMeshRouteConfiguration(outboundMeshTrafficPolicy.HTTPRouteConfigsPerPort)
+		outboundMeshRouteConfig := c.getOutboundMeshRouteConfiguration(proxy, proxyIdentity, outboundMeshTrafficPolicy)
 		for _, config := range outboundMeshRouteConfig {
 			rdsResources = append(rdsResources, config)
 		}
@@ -108,6 +128,61 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 	return rdsResources, nil
 }
 
+// getOutboundMeshRouteConfiguration returns the outbound mesh route configurations for the given proxy, identity and policy.
+// The mesh catalog shares the outbound policy between the proxies with the same identity, so the route configurations
+// built from it are reused until the catalog returns a different policy for the identity.
+func (c *RouteConfigCache) getOutboundMeshRouteConfiguration(proxy *envoy.Proxy, proxyIdentity identity.ServiceIdentity, outboundMeshTrafficPolicy *trafficpolicy.OutboundMeshTrafficPolicy) []*xds_route.RouteConfiguration {
+	if c == nil {
+		return route.BuildOutboundMeshRouteConfiguration(outboundMeshTrafficPolicy.HTTPRouteConfigsPerPort)
+	}
+
+	c.mu.Lock()
+	entry, ok := c.outbound[proxyIdentity]
+	if ok && entry.policy == outboundMeshTrafficPolicy {
+		entry.proxies[proxy.UUID.String()] = struct{}{}
+		c.mu.Unlock()
+		return entry.routeConfigs
+	}
+	c.mu.Unlock()
+
+	routeConfigs := route.BuildOutboundMeshRouteConfiguration(outboundMeshTrafficPolicy.HTTPRouteConfigsPerPort)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok = c.outbound[proxyIdentity]
+	if !ok {
+		entry = &outboundMeshRouteConfigEntry{proxies: make(map[string]struct{})}
+		c.outbound[proxyIdentity] = entry
+	}
+	entry.policy = outboundMeshTrafficPolicy
+	entry.routeConfigs = routeConfigs
+	entry.proxies[proxy.UUID.String()] = struct{}{}
+	return routeConfigs
+}
+
+// ReleaseProxy releases the route configurations built for the given proxy, and the ones of its identity if it was
+// the identity's last proxy. It is called when the proxy disconnects.
+func (c *RouteConfigCache) ReleaseProxy(proxy *envoy.Proxy) {
+	if c == nil {
+		return
+	}
+	proxyIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.outbound[proxyIdentity]
+	if !ok {
+		return
+	}
+	delete(entry.proxies, proxy.UUID.String())
+	if len(entry.proxies) == 0 {
+		delete(c.outbound, proxyIdentity)
+	}
+}
+
 // ensureRDSRequestCompletion computes delta between requested resources and response resources.
 // If any resources requested were not responded to, this function will fill those in with empty RouteConfig stubs
 func ensureRDSRequestCompletion(discoveryReq *xds_discovery.DiscoveryRequest, rdsResources []types.Resource) []types.Resource {
@@ -133,3 +208,24 @@ func ensureRDSRequestCompletion(discoveryReq *xds_discovery.DiscoveryRequest, rd
 
 	return rdsResources
 }
+
+// buildIngressGatewayRouteConfigs returns the route configurations of the HTTP listeners of the ingress gateway
+func buildIngressGatewayRouteConfigs(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryReq *xds_discovery.DiscoveryRequest) ([]types.Resource, error) {
+	ingressGatewayTrafficPolicy, err := cataloger.GetIngressGatewayTrafficPolicy()
+	if err != nil {
+		log.Error().Err(err).Str("proxy", proxy.String()).Msg("Error retrieving ingress gateway traffic policy")
+		return nil, err
+	}
+
+	var rdsResources []types.Resource
+	if ingressGatewayTrafficPolicy != nil {
+		for _, config := range route.BuildIngressGatewayRouteConfiguration(ingressGatewayTrafficPolicy.Listeners) {
+			rdsResources = append(rdsResources, config)
+		}
+	}
+
+	if discoveryReq != nil {
+		rdsResources = ensureRDSRequestCompletion(discoveryReq, rdsResources)
+	}
+	return rdsResources, nil
+}
@@ -499,3 +499,37 @@ func TestResponseRequestCompletion(t *testing.T) {
 		}
 	}
 }
+
+func TestRouteConfigCache(t *testing.T) {
+	assert := tassert.New(t)
+
+	svcIdentity := identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "ns"}.ToServiceIdentity()
+	newProxy := func() *envoy.Proxy {
+		proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, "bookbuyer", "ns"), "serial", nil)
+		assert.Nil(err)
+		return proxy
+	}
+	proxy1, proxy2 := newProxy(), newProxy()
+	policy := &trafficpolicy.OutboundMeshTrafficPolicy{
+		HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.OutboundTrafficPolicy{
+			80: {trafficpolicy.NewOutboundTrafficPolicy("bookstore.ns.svc.cluster.local", []string{"bookstore"})},
+		},
+	}
+
+	c := NewRouteConfigCache()
+	routeConfigs := c.getOutboundMeshRouteConfiguration(proxy1, svcIdentity, policy)
+	assert.Len(routeConfigs, 1)
+
+	// The route configurations are reused by the proxies with the same identity and policy
+	assert.Same(routeConfigs[0], c.getOutboundMeshRouteConfiguration(proxy2, svcIdentity, policy)[0])
+
+	// The route configurations are rebuilt for a different policy
+	newPolicy := &trafficpolicy.OutboundMeshTrafficPolicy{HTTPRouteConfigsPerPort: policy.HTTPRouteConfigsPerPort}
+	assert.NotSame(routeConfigs[0], c.getOutboundMeshRouteConfiguration(proxy1, svcIdentity, newPolicy)[0])
+
+	// The route configurations of the identity are released with its last proxy
+	c.ReleaseProxy(proxy1)
+	assert.Contains(c.outbound, svcIdentity)
+	c.ReleaseProxy(proxy2)
+	assert.Empty(c.outbound)
+}
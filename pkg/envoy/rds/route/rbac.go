@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -16,16 +17,25 @@ import (
 
 const (
 	rbacPerRoutePolicyName = "rbac-for-route"
+
+	// rbacAuditAllowAllPolicyName is the name of the RBAC policy allowing all requests on a route in audit mode
+	rbacAuditAllowAllPolicyName = "audit-allow-all"
 )
 
 // buildInboundRBACFilterForRule builds an HTTP RBAC per route filter based on the given traffic policy rule.
 // The principals in the RBAC policy are derived from the allowed service accounts specified in the given rule.
 // The permissions in the RBAC policy are implicitly set to ANY (all permissions).
-func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule) (map[string]*any.Any, error) {
+// When auditMode is set, the RBAC policy derived from the rule is programmed as shadow rules while all
+// requests are allowed, and a rule with no allowed service identities shadow denies all requests.
+func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule, auditMode bool) (map[string]*any.Any, error) {
 	if rule.AllowedServiceIdentities == nil {
 		return nil, errors.Errorf("traffipolicy.Rule.AllowedServiceIdentities not set")
 	}
 
+	if auditMode && rule.AllowedServiceIdentities.Cardinality() == 0 {
+		return marshalRBACPerRoute(buildAuditHTTPRBAC(map[string]*xds_rbac.Policy{}))
+	}
+
 	policy := &rbac.Policy{}
 
 	// Create the list of principals for this policy
@@ -63,6 +73,10 @@ func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule) (map[string]*any.An
 	// A single RBAC policy per route
 	rbacPolicyMap := map[string]*xds_rbac.Policy{rbacPerRoutePolicyName: rbacPolicy}
 
+	if auditMode {
+		return marshalRBACPerRoute(buildAuditHTTPRBAC(rbacPolicyMap))
+	}
+
 	// Map generic RBAC policy to HTTP RBAC policy
 	httpRBAC := &xds_http_rbac.RBAC{
 		Rules: &xds_rbac.RBAC{
@@ -70,6 +84,29 @@ func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule) (map[string]*any.An
 			Policies: rbacPolicyMap,
 		},
 	}
+
+	return marshalRBACPerRoute(httpRBAC)
+}
+
+// buildAuditHTTPRBAC returns an HTTP RBAC policy that allows all requests, and evaluates the given
+// policies as shadow rules so that requests they do not allow are reported as shadow denied.
+func buildAuditHTTPRBAC(shadowPolicies map[string]*xds_rbac.Policy) *xds_http_rbac.RBAC {
+	allowAllPolicy, _ := (&rbac.Policy{}).Generate() // An empty policy never fails to generate
+
+	return &xds_http_rbac.RBAC{
+		Rules: &xds_rbac.RBAC{
+			Action:   xds_rbac.RBAC_ALLOW,
+			Policies: map[string]*xds_rbac.Policy{rbacAuditAllowAllPolicyName: allowAllPolicy},
+		},
+		ShadowRules: &xds_rbac.RBAC{
+			Action:   xds_rbac.RBAC_ALLOW, // Shadow allows the request if and only if there is a policy that matches the request
+			Policies: shadowPolicies,
+		},
+		ShadowRulesStatPrefix: envoy.RBACAuditShadowRulesStatPrefix,
+	}
+}
+
+func marshalRBACPerRoute(httpRBAC *xds_http_rbac.RBAC) (map[string]*any.Any, error) {
 	httpRBACPerRoute := &xds_http_rbac.RBACPerRoute{
 		Rbac: httpRBAC,
 	}
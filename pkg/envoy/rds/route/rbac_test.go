@@ -10,6 +10,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	tassert "github.com/stretchr/testify/assert"
 
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -107,7 +108,7 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 		t.Run(fmt.Sprintf("Test case %d: %s", i, tc.name), func(t *testing.T) {
 			assert := tassert.New(t)
 
-			rbacFilter, err := buildInboundRBACFilterForRule(tc.rule)
+			rbacFilter, err := buildInboundRBACFilterForRule(tc.rule, false)
 
 			assert.Equal(tc.expectError, err != nil)
 			if err != nil {
@@ -131,3 +132,68 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildInboundRBACFilterForRuleAuditMode(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		rule                     *trafficpolicy.Rule
+		expectedShadowPolicyKeys []string
+	}{
+		{
+			name: "audited rule with allowed downstream identities",
+			rule: &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+				AllowedServiceIdentities: mapset.NewSetFromSlice([]interface{}{
+					identity.K8sServiceAccount{Name: "foo", Namespace: "ns-1"}.ToServiceIdentity(),
+				}),
+			},
+			expectedShadowPolicyKeys: []string{rbacPerRoutePolicyName},
+		},
+		{
+			name: "audited rule without allowed downstream identities",
+			rule: &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   tests.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+				AllowedServiceIdentities: mapset.NewSet(),
+			},
+			expectedShadowPolicyKeys: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test case %d: %s", i, tc.name), func(t *testing.T) {
+			assert := tassert.New(t)
+
+			rbacFilter, err := buildInboundRBACFilterForRule(tc.rule, true)
+			assert.Nil(err)
+
+			marshalled := rbacFilter[wellknown.HTTPRoleBasedAccessControl]
+			httpRBACPerRoute := &xds_http_rbac.RBACPerRoute{}
+			assert.Nil(marshalled.UnmarshalTo(httpRBACPerRoute))
+
+			// All requests are allowed
+			rbacRules := httpRBACPerRoute.Rbac.Rules
+			assert.Equal(xds_rbac.RBAC_ALLOW, rbacRules.Action)
+			assert.Len(rbacRules.Policies, 1)
+			allowAll := rbacRules.Policies[rbacAuditAllowAllPolicyName]
+			assert.NotNil(allowAll)
+			assert.True(allowAll.Principals[0].GetAny())
+			assert.True(allowAll.Permissions[0].GetAny())
+
+			// The rule is audited through the shadow rules
+			shadowRules := httpRBACPerRoute.Rbac.ShadowRules
+			assert.Equal(xds_rbac.RBAC_ALLOW, shadowRules.Action)
+			var shadowPolicyKeys []string
+			for key := range shadowRules.Policies {
+				shadowPolicyKeys = append(shadowPolicyKeys, key)
+			}
+			assert.ElementsMatch(tc.expectedShadowPolicyKeys, shadowPolicyKeys)
+			assert.Equal(envoy.RBACAuditShadowRulesStatPrefix, httpRBACPerRoute.Rbac.ShadowRulesStatPrefix)
+		})
+	}
+}
@@ -37,6 +37,9 @@ const (
 	// egressRouteConfigNamePrefix is the prefix for the name of the egress RDS route configuration
 	egressRouteConfigNamePrefix = "rds-egress"
 
+	// ingressGatewayRouteConfigNamePrefix is the prefix for the name of the ingress gateway RDS route configuration
+	ingressGatewayRouteConfigNamePrefix = "rds-ingress-gateway"
+
 	// inboundVirtualHost is prefix for the virtual host's name in the inbound route configuration
 	inboundVirtualHost = "inbound_virtual-host"
 
@@ -49,6 +52,9 @@ const (
 	// ingressVirtualHost is the prefix for the virtual host's name in the ingress route configuration
 	ingressVirtualHost = "ingress_virtual-host"
 
+	// ingressGatewayVirtualHost is the prefix for the virtual host's name in the ingress gateway route configuration
+	ingressGatewayVirtualHost = "ingress-gateway_virtual-host"
+
 	// methodHeaderKey is the key of the header for HTTP methods
 	methodHeaderKey = ":method"
 
@@ -70,7 +76,7 @@ func BuildInboundMeshRouteConfiguration(portSpecificRouteConfigs map[int][]*traf
 		routeConfig := NewRouteConfigurationStub(GetInboundMeshRouteConfigNameForPort(port))
 		for _, config := range configs {
 			virtualHost := buildVirtualHostStub(inboundVirtualHost, config.Name, config.Hostnames)
-			virtualHost.Routes = buildInboundRoutes(config.Rules)
+			virtualHost.Routes = buildInboundRoutes(config.Rules, config.AuditMode)
 			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
 		}
 		if featureFlags := cfg.GetFeatureFlags(); featureFlags.EnableWASMStats {
@@ -98,7 +104,7 @@ func BuildIngressConfiguration(ingress []*trafficpolicy.InboundTrafficPolicy) *x
 	ingressRouteConfig := NewRouteConfigurationStub(IngressRouteConfigName)
 	for _, in := range ingress {
 		virtualHost := buildVirtualHostStub(ingressVirtualHost, in.Name, in.Hostnames)
-		virtualHost.Routes = buildInboundRoutes(in.Rules)
+		virtualHost.Routes = buildInboundRoutes(in.Rules, in.AuditMode)
 		ingressRouteConfig.VirtualHosts = append(ingressRouteConfig.VirtualHosts, virtualHost)
 	}
 
@@ -145,6 +151,27 @@ func BuildEgressRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpo
 	return routeConfigs
 }
 
+// BuildIngressGatewayRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) for the HTTP listeners of the ingress gateway
+func BuildIngressGatewayRouteConfiguration(listeners []*trafficpolicy.IngressGatewayListener) []*xds_route.RouteConfiguration {
+	var routeConfigs []*xds_route.RouteConfiguration
+
+	// An Envoy RouteConfiguration will exist for each HTTP listener port of the gateway
+	for _, listener := range listeners {
+		if listener.Protocol == constants.ProtocolTLS {
+			continue
+		}
+		routeConfig := NewRouteConfigurationStub(GetIngressGatewayRouteConfigNameForPort(int(listener.Port)))
+		for _, config := range listener.HTTPRouteConfigs {
+			virtualHost := buildVirtualHostStub(ingressGatewayVirtualHost, config.Name, config.Hostnames)
+			virtualHost.Routes = buildIngressGatewayRoutes(config.Routes)
+			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
+		}
+		routeConfigs = append(routeConfigs, routeConfig)
+	}
+
+	return routeConfigs
+}
+
 //NewRouteConfigurationStub creates the route configuration placeholder
 func NewRouteConfigurationStub(routeConfigName string) *xds_route.RouteConfiguration {
 	routeConfiguration := xds_route.RouteConfiguration{
@@ -168,7 +195,8 @@ func buildVirtualHostStub(namePrefix string, host string, domains []string) *xds
 }
 
 // buildInboundRoutes takes a route information from the given inbound traffic policy and returns a list of xds routes
-func buildInboundRoutes(rules []*trafficpolicy.Rule) []*xds_route.Route {
+// When auditMode is set, the RBAC policies on the routes are audited instead of enforced.
+func buildInboundRoutes(rules []*trafficpolicy.Rule, auditMode bool) []*xds_route.Route {
 	var routes []*xds_route.Route
 	for _, rule := range rules {
 		// For a given route path, sanitize the methods in case there
@@ -177,7 +205,7 @@ func buildInboundRoutes(rules []*trafficpolicy.Rule) []*xds_route.Route {
 
 		// Create an RBAC policy derived from 'trafficpolicy.Rule'
 		// Each route is associated with an RBAC policy
-		rbacPolicyForRoute, err := buildInboundRBACFilterForRule(rule)
+		rbacPolicyForRoute, err := buildInboundRBACFilterForRule(rule, auditMode)
 		if err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrBuildingRBACPolicyForRoute)).
 				Msgf("Error building RBAC policy for rule [%v], skipping route addition", rule)
@@ -225,6 +253,17 @@ func buildEgressRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule) []*x
 	return routes
 }
 
+func buildIngressGatewayRoutes(gatewayRoutes []*trafficpolicy.RouteWeightedClusters) []*xds_route.Route {
+	var routes []*xds_route.Route
+	for _, gatewayRoute := range gatewayRoutes {
+		// Each HTTP method corresponds to a separate route
+		for _, httpMethod := range sanitizeHTTPMethods(gatewayRoute.HTTPRouteMatch.Methods) {
+			routes = append(routes, buildRoute(*gatewayRoute, httpMethod))
+		}
+	}
+	return routes
+}
+
 func buildRoute(weightedClusters trafficpolicy.RouteWeightedClusters, method string) *xds_route.Route {
 	route := xds_route.Route{
 		Match: &xds_route.RouteMatch{
@@ -414,6 +453,11 @@ func GetEgressRouteConfigNameForPort(port int) string {
 	return fmt.Sprintf("%s.%d", egressRouteConfigNamePrefix, port)
 }
 
+// GetIngressGatewayRouteConfigNameForPort returns the ingress gateway route configuration object's name given the port it is targeted to
+func GetIngressGatewayRouteConfigNameForPort(port int) string {
+	return fmt.Sprintf("%s.%d", ingressGatewayRouteConfigNamePrefix, port)
+}
+
 // GetOutboundMeshRouteConfigNameForPort returns the outbound mesh route configuration object's name given the port it is targeted to
 func GetOutboundMeshRouteConfigNameForPort(port int) string {
 	return fmt.Sprintf("%s.%d", OutboundRouteConfigName, port)
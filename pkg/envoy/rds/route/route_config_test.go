@@ -390,7 +390,7 @@ func TestBuildInboundRoutes(t *testing.T) {
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
-			actual := buildInboundRoutes(tc.inputRules)
+			actual := buildInboundRoutes(tc.inputRules, false)
 			tc.expectFunc(tassert.New(t), actual)
 		})
 	}
@@ -1677,3 +1677,57 @@ func TestGetEgressRouteConfigNameForPort(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildIngressGatewayRouteConfiguration(t *testing.T) {
+	assert := tassert.New(t)
+
+	webCluster := service.WeightedCluster{ClusterName: "ns-1/web|8080", Weight: 1}
+	listeners := []*trafficpolicy.IngressGatewayListener{
+		{
+			Name:     "ingress-gateway-http-80",
+			Port:     80,
+			Protocol: constants.ProtocolHTTP,
+			HTTPRouteConfigs: []*trafficpolicy.OutboundTrafficPolicy{
+				{
+					Name:      "foo.com",
+					Hostnames: []string{"foo.com"},
+					Routes: []*trafficpolicy.RouteWeightedClusters{
+						trafficpolicy.NewRouteWeightedCluster(trafficpolicy.HTTPRouteMatch{
+							Path:          "/login",
+							PathMatchType: trafficpolicy.PathMatchExact,
+							Methods:       []string{"GET", "POST"},
+						}, []service.WeightedCluster{webCluster}),
+						trafficpolicy.NewRouteWeightedCluster(trafficpolicy.HTTPRouteMatch{
+							Path:          "/",
+							PathMatchType: trafficpolicy.PathMatchPrefix,
+							Methods:       []string{constants.WildcardHTTPMethod},
+						}, []service.WeightedCluster{webCluster}),
+					},
+				},
+			},
+		},
+		// TLS passthrough listeners do not have route configurations
+		{
+			Name:     "ingress-gateway-tls-8443",
+			Port:     8443,
+			Protocol: constants.ProtocolTLS,
+		},
+	}
+
+	routeConfigs := BuildIngressGatewayRouteConfiguration(listeners)
+	assert.Len(routeConfigs, 1)
+
+	routeConfig := routeConfigs[0]
+	assert.Equal("rds-ingress-gateway.80", routeConfig.Name)
+	assert.Len(routeConfig.VirtualHosts, 1)
+	assert.Equal("ingress-gateway_virtual-host|foo.com", routeConfig.VirtualHosts[0].Name)
+	assert.Equal([]string{"foo.com"}, routeConfig.VirtualHosts[0].Domains)
+
+	// A route is built for each method, in the order of precedence of the routes
+	routes := routeConfig.VirtualHosts[0].Routes
+	assert.Len(routes, 3)
+	assert.Equal("/login", routes[0].Match.GetPath())
+	assert.Equal("/login", routes[1].Match.GetPath())
+	assert.Equal("/", routes[2].Match.GetPrefix())
+	assert.Equal("ns-1/web|8080", routes[2].GetRoute().GetWeightedClusters().Clusters[0].Name)
+}
@@ -2,9 +2,32 @@
 package rds
 
 import (
+	"sync"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var (
 	log = logger.New("envoy/rds")
 )
+
+// RouteConfigCache reuses the outbound mesh route configurations built for a service identity between the proxies
+// with the same identity. An identity's route configurations are released once its last proxy is released.
+type RouteConfigCache struct {
+	mu sync.Mutex
+	// outbound maps a service identity to the outbound mesh route configurations last built for it
+	outbound map[identity.ServiceIdentity]*outboundMeshRouteConfigEntry
+}
+
+// outboundMeshRouteConfigEntry holds the outbound mesh route configurations built from an outbound mesh traffic policy.
+// The route configurations are shared by the proxies with the same identity and must not be mutated.
+type outboundMeshRouteConfigEntry struct {
+	policy       *trafficpolicy.OutboundMeshTrafficPolicy
+	routeConfigs []*xds_route.RouteConfiguration
+	// proxies are the UUIDs of the proxies the route configurations were built for
+	proxies map[string]struct{}
+}
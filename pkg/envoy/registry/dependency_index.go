@@ -0,0 +1,463 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// OutboundServiceLister lists the services a service identity is allowed to initiate outbound connections to
+type OutboundServiceLister interface {
+	ListOutboundServicesForIdentity(identity.ServiceIdentity) []service.MeshService
+}
+
+// EgressTrafficPolicyGetter returns the egress traffic policy of a service identity. When the OutboundServiceLister
+// of a DependencyIndex implements it, the Secrets referenced by the egress policies of the identities are indexed.
+type EgressTrafficPolicyGetter interface {
+	GetEgressTrafficPolicy(identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error)
+}
+
+// DependencyIndex is a reverse index from the identities, services, pods and namespaces referenced by the
+// configuration of the connected proxies to these proxies, so that the proxies affected by events can be
+// determined without computing the configuration of every proxy.
+// The index is updated when proxies connect and disconnect, and when events change the outbound services of
+// the indexed identities. It implements messaging.ProxyUpdateTargeter.
+type DependencyIndex struct {
+	proxyRegistry         *ProxyRegistry
+	outboundServiceLister OutboundServiceLister
+
+	mu sync.Mutex
+	// proxies maps the UUID of an indexed proxy to its identity and the dependency keys of its own resources
+	proxies map[string]*indexedProxy
+	// unindexedProxies are the UUIDs of the connected proxies whose dependencies cannot be determined, such as gateways
+	unindexedProxies map[string]struct{}
+	// proxiesByKey maps the dependency keys of the proxies' own resources to the UUIDs of the proxies
+	proxiesByKey map[string]map[string]struct{}
+	// proxiesByIdentity maps the identities of the indexed proxies to their UUIDs
+	proxiesByIdentity map[string]map[string]struct{}
+	// outboundKeys maps the identities of the indexed proxies to the dependency keys of their outbound services
+	outboundKeys map[identity.ServiceIdentity]map[string]struct{}
+	// identitiesByKey maps the dependency keys of the outbound services to the identities depending on them
+	identitiesByKey map[string]map[string]struct{}
+}
+
+// indexedProxy is a connected proxy whose dependencies are indexed
+type indexedProxy struct {
+	proxy    *envoy.Proxy
+	identity identity.ServiceIdentity
+	keys     map[string]struct{}
+}
+
+// NewDependencyIndex returns a new DependencyIndex for the proxies connected to the given registry.
+// The index is kept up to date as proxies connect to and disconnect from the registry.
+// It must not be used in snapshot cache mode, where the snapshots of every proxy are regenerated on each update.
+func NewDependencyIndex(proxyRegistry *ProxyRegistry, outboundServiceLister OutboundServiceLister) *DependencyIndex {
+	d := &DependencyIndex{
+		proxyRegistry:         proxyRegistry,
+		outboundServiceLister: outboundServiceLister,
+		proxies:               make(map[string]*indexedProxy),
+		unindexedProxies:      make(map[string]struct{}),
+		proxiesByKey:          make(map[string]map[string]struct{}),
+		proxiesByIdentity:     make(map[string]map[string]struct{}),
+		outboundKeys:          make(map[identity.ServiceIdentity]map[string]struct{}),
+		identitiesByKey:       make(map[string]map[string]struct{}),
+	}
+
+	proxyRegistry.setDependencyIndex(d)
+	for _, proxy := range proxyRegistry.ListConnectedProxies() {
+		d.addProxy(proxy)
+	}
+	return d
+}
+
+// GetConnectedProxyCount returns the number of connected proxies
+func (d *DependencyIndex) GetConnectedProxyCount() int {
+	return d.proxyRegistry.GetConnectedProxyCount()
+}
+
+// GetAffectedProxies returns the UUIDs of the connected proxies whose configuration depends on the resources
+// changed by the given events, and whether they could be determined.
+// A proxy is affected if its configuration depended on the changed resources before or after the events.
+// Proxies whose dependencies cannot be determined, such as gateways, are always affected.
+func (d *DependencyIndex) GetAffectedProxies(msgs []events.PubSubMessage) ([]string, bool) {
+	var keys []string
+	// Only endpoint updates and secret events are guaranteed not to change the outbound dependencies of the indexed identities
+	refreshOutbound := false
+	for _, msg := range msgs {
+		msgKeys, ok := getEventDependencyKeys(msg)
+		if !ok {
+			// Keep the index up to date for the events following the broadcast
+			d.refreshOutboundServices()
+			return nil, false
+		}
+		keys = append(keys, msgKeys...)
+		switch msg.Kind {
+		case announcements.EndpointUpdated, announcements.SecretAdded, announcements.SecretDeleted, announcements.SecretUpdated:
+		default:
+			refreshOutbound = true
+		}
+	}
+
+	d.mu.Lock()
+	affected := d.getProxiesDependingOn(keys)
+	d.mu.Unlock()
+
+	// Refresh the own resources of the proxies whose pods may have been added to or removed from services
+	d.refreshProxies(affected)
+
+	if refreshOutbound {
+		d.refreshOutboundServices()
+		d.mu.Lock()
+		for proxyUUID := range d.getProxiesDependingOn(keys) {
+			affected[proxyUUID] = struct{}{}
+		}
+		d.mu.Unlock()
+	}
+
+	proxyUUIDs := make([]string, 0, len(affected))
+	for proxyUUID := range affected {
+		proxyUUIDs = append(proxyUUIDs, proxyUUID)
+	}
+	sort.Strings(proxyUUIDs)
+	return proxyUUIDs, true
+}
+
+// getProxiesDependingOn returns the UUIDs of the proxies depending on any of the given keys, and of the unindexed proxies.
+// It must be called with the lock held.
+func (d *DependencyIndex) getProxiesDependingOn(keys []string) map[string]struct{} {
+	affected := make(map[string]struct{}, len(d.unindexedProxies))
+	for proxyUUID := range d.unindexedProxies {
+		affected[proxyUUID] = struct{}{}
+	}
+	for _, key := range keys {
+		for proxyUUID := range d.proxiesByKey[key] {
+			affected[proxyUUID] = struct{}{}
+		}
+		for svcIdentity := range d.identitiesByKey[key] {
+			for proxyUUID := range d.proxiesByIdentity[svcIdentity] {
+				affected[proxyUUID] = struct{}{}
+			}
+		}
+	}
+	return affected
+}
+
+// addProxy indexes the dependencies of the given proxy, replacing the ones previously indexed for it
+func (d *DependencyIndex) addProxy(proxy *envoy.Proxy) {
+	proxyUUID := proxy.UUID.String()
+	svcIdentity, keys, err := d.getProxyDependencies(proxy)
+
+	// The outbound services are shared by the proxies with the same identity
+	var outboundKeys map[string]struct{}
+	if err == nil {
+		d.mu.Lock()
+		_, indexed := d.outboundKeys[svcIdentity]
+		d.mu.Unlock()
+		if !indexed {
+			outboundKeys = d.getOutboundDependencies(svcIdentity)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if previousIdentity, ok := d.removeProxyLocked(proxyUUID); ok && err != nil {
+		d.forgetIdentityIfUnusedLocked(previousIdentity)
+	}
+	if err != nil {
+		log.Trace().Err(err).Msgf("Unknown dependencies for proxy %s, the proxy is affected by every event", proxy)
+		d.unindexedProxies[proxyUUID] = struct{}{}
+		return
+	}
+
+	d.proxies[proxyUUID] = &indexedProxy{proxy: proxy, identity: svcIdentity, keys: keys}
+	for key := range keys {
+		addToSet(d.proxiesByKey, key, proxyUUID)
+	}
+	addToSet(d.proxiesByIdentity, svcIdentity.String(), proxyUUID)
+
+	if _, ok := d.outboundKeys[svcIdentity]; !ok {
+		if outboundKeys == nil {
+			// The last proxy of the identity disconnected in the meantime
+			outboundKeys = d.getOutboundDependencies(svcIdentity)
+		}
+		d.setOutboundKeysLocked(svcIdentity, outboundKeys)
+	}
+}
+
+// removeProxy removes the dependencies of the given proxy from the index
+func (d *DependencyIndex) removeProxy(proxy *envoy.Proxy) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if svcIdentity, ok := d.removeProxyLocked(proxy.UUID.String()); ok {
+		d.forgetIdentityIfUnusedLocked(svcIdentity)
+	}
+}
+
+// forgetIdentityIfUnusedLocked stops tracking the outbound services of the given identity once its last proxy
+// disconnected. It must be called with the lock held.
+func (d *DependencyIndex) forgetIdentityIfUnusedLocked(svcIdentity identity.ServiceIdentity) {
+	if _, ok := d.proxiesByIdentity[svcIdentity.String()]; !ok {
+		d.setOutboundKeysLocked(svcIdentity, nil)
+	}
+}
+
+// removeProxyLocked removes the own dependencies of the given proxy from the index, and returns its identity
+// if it was indexed. It must be called with the lock held.
+func (d *DependencyIndex) removeProxyLocked(proxyUUID string) (identity.ServiceIdentity, bool) {
+	delete(d.unindexedProxies, proxyUUID)
+	indexed, ok := d.proxies[proxyUUID]
+	if !ok {
+		return "", false
+	}
+	delete(d.proxies, proxyUUID)
+	for key := range indexed.keys {
+		removeFromSet(d.proxiesByKey, key, proxyUUID)
+	}
+	removeFromSet(d.proxiesByIdentity, indexed.identity.String(), proxyUUID)
+	return indexed.identity, true
+}
+
+// refreshProxies re-indexes the own resources of the given proxies
+func (d *DependencyIndex) refreshProxies(proxyUUIDs map[string]struct{}) {
+	var proxies []*envoy.Proxy
+	d.mu.Lock()
+	for proxyUUID := range proxyUUIDs {
+		if indexed, ok := d.proxies[proxyUUID]; ok {
+			proxies = append(proxies, indexed.proxy)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, proxy := range proxies {
+		d.addProxy(proxy)
+	}
+}
+
+// refreshOutboundServices re-indexes the outbound services of the identities of the connected proxies
+func (d *DependencyIndex) refreshOutboundServices() {
+	d.mu.Lock()
+	identities := make([]identity.ServiceIdentity, 0, len(d.outboundKeys))
+	for svcIdentity := range d.outboundKeys {
+		identities = append(identities, svcIdentity)
+	}
+	d.mu.Unlock()
+
+	for _, svcIdentity := range identities {
+		keys := d.getOutboundDependencies(svcIdentity)
+		d.mu.Lock()
+		// The last proxy of the identity may have disconnected in the meantime
+		if _, ok := d.outboundKeys[svcIdentity]; ok {
+			d.setOutboundKeysLocked(svcIdentity, keys)
+		}
+		d.mu.Unlock()
+	}
+}
+
+// setOutboundKeysLocked replaces the indexed outbound dependency keys of the given identity, nil to remove them.
+// It must be called with the lock held.
+func (d *DependencyIndex) setOutboundKeysLocked(svcIdentity identity.ServiceIdentity, keys map[string]struct{}) {
+	for key := range d.outboundKeys[svcIdentity] {
+		removeFromSet(d.identitiesByKey, key, svcIdentity.String())
+	}
+	if keys == nil {
+		delete(d.outboundKeys, svcIdentity)
+		return
+	}
+	d.outboundKeys[svcIdentity] = keys
+	for key := range keys {
+		addToSet(d.identitiesByKey, key, svcIdentity.String())
+	}
+}
+
+// getProxyDependencies returns the identity of the given proxy, and the dependency keys of its own resources:
+// its identity, namespace and pod, and the services it belongs to.
+func (d *DependencyIndex) getProxyDependencies(proxy *envoy.Proxy) (identity.ServiceIdentity, map[string]struct{}, error) {
+	if proxy.Kind() != envoy.KindSidecar {
+		return "", nil, fmt.Errorf("proxy kind %s is not indexed", proxy.Kind())
+	}
+
+	svcIdentity, err := envoy.GetServiceIdentityFromProxyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		return "", nil, err
+	}
+	proxyServices, err := d.proxyRegistry.ListProxyServices(proxy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	svcAccount := svcIdentity.ToK8sServiceAccount()
+	keys := map[string]struct{}{
+		identityKey(svcAccount.Namespace, svcAccount.Name): {},
+		namespaceKey(svcAccount.Namespace):                 {},
+	}
+	if proxy.HasPodMetadata() {
+		keys[podKey(proxy.PodMetadata.Namespace, proxy.PodMetadata.Name)] = struct{}{}
+	}
+	for _, svc := range proxyServices {
+		keys[serviceKey(svc.Namespace, svc.Name)] = struct{}{}
+	}
+	return svcIdentity, keys, nil
+}
+
+// getOutboundDependencies returns the dependency keys of the services and namespaces the given identity can
+// initiate outbound connections to, and of the Secrets used to originate TLS to external hosts
+func (d *DependencyIndex) getOutboundDependencies(svcIdentity identity.ServiceIdentity) map[string]struct{} {
+	keys := make(map[string]struct{})
+	for _, svc := range d.outboundServiceLister.ListOutboundServicesForIdentity(svcIdentity) {
+		keys[serviceKey(svc.Namespace, svc.Name)] = struct{}{}
+		keys[namespaceKey(svc.Namespace)] = struct{}{}
+	}
+
+	egressPolicyGetter, ok := d.outboundServiceLister.(EgressTrafficPolicyGetter)
+	if !ok {
+		return keys
+	}
+	egressPolicy, err := egressPolicyGetter.GetEgressTrafficPolicy(svcIdentity)
+	if err != nil || egressPolicy == nil {
+		return keys
+	}
+	for _, clusterConfig := range egressPolicy.ClustersConfigs {
+		if clusterConfig.TLS == nil {
+			continue
+		}
+		for _, secret := range []*types.NamespacedName{clusterConfig.TLS.CABundleSecret, clusterConfig.TLS.ClientCertificateSecret} {
+			if secret != nil {
+				keys[secretKey(secret.Namespace, secret.Name)] = struct{}{}
+			}
+		}
+	}
+	return keys
+}
+
+// getEventDependencyKeys returns the dependency keys of the resources changed by the given event,
+// and false if the proxies affected by the event cannot be determined from its resources.
+func getEventDependencyKeys(msg events.PubSubMessage) ([]string, bool) {
+	var keys []string
+	for _, obj := range []interface{}{msg.OldObj, msg.NewObj} {
+		if obj == nil {
+			continue
+		}
+		objKeys, ok := getObjectDependencyKeys(obj)
+		if !ok {
+			return nil, false
+		}
+		keys = append(keys, objKeys...)
+	}
+	return keys, len(keys) > 0
+}
+
+// getObjectDependencyKeys returns the dependency keys of the given resource
+func getObjectDependencyKeys(obj interface{}) ([]string, bool) {
+	switch o := obj.(type) {
+	case *corev1.Endpoints:
+		// The pods of the endpoints are included for the proxies added to or removed from the service
+		keys := []string{serviceKey(o.Namespace, o.Name)}
+		for _, subset := range o.Subsets {
+			for _, addresses := range [][]corev1.EndpointAddress{subset.Addresses, subset.NotReadyAddresses} {
+				for _, address := range addresses {
+					if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+						keys = append(keys, podKey(address.TargetRef.Namespace, address.TargetRef.Name))
+					}
+				}
+			}
+		}
+		return keys, true
+
+	case *corev1.Secret:
+		// Secrets only affect the proxies originating TLS using them, and the gateways which are not indexed
+		return []string{secretKey(o.Namespace, o.Name)}, true
+
+	case *smiAccess.TrafficTarget:
+		// Sources can be in different namespaces than the destination
+		keys := []string{namespaceKey(o.Namespace), identityKey(o.Spec.Destination.Namespace, o.Spec.Destination.Name)}
+		for _, source := range o.Spec.Sources {
+			keys = append(keys, identityKey(source.Namespace, source.Name))
+		}
+		return keys, true
+
+	case *policyv1alpha1.Egress:
+		keys := []string{namespaceKey(o.Namespace)}
+		for _, source := range o.Spec.Sources {
+			keys = append(keys, identityKey(source.Namespace, source.Name))
+		}
+		return keys, true
+
+	case *policyv1alpha1.Retry:
+		return []string{namespaceKey(o.Namespace), identityKey(o.Spec.Source.Namespace, o.Spec.Source.Name)}, true
+
+	case *smiSplit.TrafficSplit, *smiSpecs.HTTPRouteGroup, *smiSpecs.TCPRoute,
+		*policyv1alpha1.IngressBackend, *policyv1alpha1.UpstreamTrafficSetting, *policyv1alpha1.ExternalAuthorization, *policyv1alpha1.SidecarScope,
+		*configv1alpha2.MultiClusterService, *configv1alpha2.NamespaceConfig:
+		// These resources only apply to the proxies in their namespace, or to the proxies
+		// that can initiate outbound connections to services in their namespace
+		accessor, err := meta.Accessor(o)
+		if err != nil {
+			return nil, false
+		}
+		return []string{namespaceKey(accessor.GetNamespace())}, true
+
+	default:
+		return nil, false
+	}
+}
+
+// addToSet adds the value to the set of the given key
+func addToSet(sets map[string]map[string]struct{}, key, value string) {
+	set, ok := sets[key]
+	if !ok {
+		set = make(map[string]struct{})
+		sets[key] = set
+	}
+	set[value] = struct{}{}
+}
+
+// removeFromSet removes the value from the set of the given key, and the set once empty
+func removeFromSet(sets map[string]map[string]struct{}, key, value string) {
+	set, ok := sets[key]
+	if !ok {
+		return
+	}
+	delete(set, value)
+	if len(set) == 0 {
+		delete(sets, key)
+	}
+}
+
+func identityKey(namespace, name string) string {
+	return "identity:" + namespace + "/" + name
+}
+
+func serviceKey(namespace, name string) string {
+	return "service:" + namespace + "/" + name
+}
+
+func podKey(namespace, name string) string {
+	return "pod:" + namespace + "/" + name
+}
+
+func namespaceKey(namespace string) string {
+	return "namespace:" + namespace
+}
+
+func secretKey(namespace, name string) string {
+	return "secret:" + namespace + "/" + name
+}
@@ -0,0 +1,296 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+type fakeOutboundServiceLister struct {
+	outboundServices map[identity.ServiceIdentity][]service.MeshService
+	calls            int
+}
+
+func (f *fakeOutboundServiceLister) ListOutboundServicesForIdentity(svcIdentity identity.ServiceIdentity) []service.MeshService {
+	f.calls++
+	return f.outboundServices[svcIdentity]
+}
+
+type fakeEgressOutboundServiceLister struct {
+	fakeOutboundServiceLister
+	egressPolicies map[identity.ServiceIdentity]*trafficpolicy.EgressTrafficPolicy
+}
+
+func (f *fakeEgressOutboundServiceLister) GetEgressTrafficPolicy(svcIdentity identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
+	return f.egressPolicies[svcIdentity], nil
+}
+
+func TestGetAffectedProxies(t *testing.T) {
+	bookbuyer := identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "ns1"}
+	bookstore := identity.K8sServiceAccount{Name: "bookstore", Namespace: "ns2"}
+	other := identity.K8sServiceAccount{Name: "other", Namespace: "ns3"}
+	bookstoreSvc := service.MeshService{Name: "bookstore", Namespace: "ns2"}
+
+	newProxy := func(kind envoy.ProxyKind, sa identity.K8sServiceAccount) *envoy.Proxy {
+		proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), kind, sa.Name, sa.Namespace), "serial", nil)
+		assert.Nil(t, err)
+		return proxy
+	}
+	bookbuyerProxy := newProxy(envoy.KindSidecar, bookbuyer)
+	bookstoreProxy := newProxy(envoy.KindSidecar, bookstore)
+	otherProxy := newProxy(envoy.KindSidecar, other)
+	gatewayProxy := newProxy(envoy.KindGateway, other)
+
+	proxyRegistry := NewProxyRegistry(ExplicitProxyServiceMapper(func(p *envoy.Proxy) ([]service.MeshService, error) {
+		if p == bookstoreProxy {
+			return []service.MeshService{bookstoreSvc}, nil
+		}
+		return nil, nil
+	}), nil)
+	for _, proxy := range []*envoy.Proxy{bookbuyerProxy, bookstoreProxy, otherProxy, gatewayProxy} {
+		proxyRegistry.RegisterProxy(proxy)
+	}
+	index := NewDependencyIndex(proxyRegistry, &fakeOutboundServiceLister{outboundServices: map[identity.ServiceIdentity][]service.MeshService{
+		bookbuyer.ToServiceIdentity(): {bookstoreSvc},
+	}})
+
+	testCases := []struct {
+		name            string
+		msg             events.PubSubMessage
+		expectedOK      bool
+		expectedProxies []*envoy.Proxy
+	}{
+		{
+			name: "endpoints of a service affect its proxies and its clients",
+			msg: events.PubSubMessage{
+				Kind:   announcements.EndpointUpdated,
+				NewObj: &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "ns2"}},
+			},
+			expectedOK:      true,
+			expectedProxies: []*envoy.Proxy{bookbuyerProxy, bookstoreProxy, gatewayProxy},
+		},
+		{
+			name: "endpoints of a service without clients only affect gateways",
+			msg: events.PubSubMessage{
+				Kind:   announcements.EndpointAdded,
+				NewObj: &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "unknown", Namespace: "ns3"}},
+			},
+			expectedOK:      true,
+			expectedProxies: []*envoy.Proxy{gatewayProxy},
+		},
+		{
+			name: "traffic target affects its source and destination identities",
+			msg: events.PubSubMessage{
+				Kind: announcements.TrafficTargetAdded,
+				NewObj: &smiAccess.TrafficTarget{
+					ObjectMeta: metav1.ObjectMeta{Name: "tt", Namespace: "ns2"},
+					Spec: smiAccess.TrafficTargetSpec{
+						Destination: smiAccess.IdentityBindingSubject{Kind: "ServiceAccount", Name: "bookstore", Namespace: "ns2"},
+						Sources:     []smiAccess.IdentityBindingSubject{{Kind: "ServiceAccount", Name: "bookbuyer", Namespace: "ns1"}},
+					},
+				},
+			},
+			expectedOK:      true,
+			expectedProxies: []*envoy.Proxy{bookbuyerProxy, bookstoreProxy, gatewayProxy},
+		},
+		{
+			name: "egress policy affects its sources",
+			msg: events.PubSubMessage{
+				Kind: announcements.EgressDeleted,
+				OldObj: &policyv1alpha1.Egress{
+					ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "ns4"},
+					Spec: policyv1alpha1.EgressSpec{
+						Sources: []policyv1alpha1.EgressSourceSpec{{Kind: "ServiceAccount", Name: "other", Namespace: "ns3"}},
+					},
+				},
+			},
+			expectedOK:      true,
+			expectedProxies: []*envoy.Proxy{otherProxy, gatewayProxy},
+		},
+		{
+			name: "namespace scoped resource affects the proxies in and the clients of its namespace",
+			msg: events.PubSubMessage{
+				Kind:   announcements.NamespaceConfigUpdated,
+				OldObj: &configv1alpha2.NamespaceConfig{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "ns2"}},
+				NewObj: &configv1alpha2.NamespaceConfig{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "ns2"}},
+			},
+			expectedOK:      true,
+			expectedProxies: []*envoy.Proxy{bookbuyerProxy, bookstoreProxy, gatewayProxy},
+		},
+		{
+			name: "affected proxies cannot be determined for unindexed resources",
+			msg: events.PubSubMessage{
+				Kind:   announcements.MeshConfigRolloutUpdated,
+				NewObj: "status",
+			},
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			proxyUUIDs, ok := index.GetAffectedProxies([]events.PubSubMessage{tc.msg})
+			a.Equal(tc.expectedOK, ok)
+
+			var expectedUUIDs []string
+			for _, proxy := range tc.expectedProxies {
+				expectedUUIDs = append(expectedUUIDs, proxy.UUID.String())
+			}
+			a.ElementsMatch(expectedUUIDs, proxyUUIDs)
+		})
+	}
+}
+
+func TestGetAffectedProxiesPreviousDependencies(t *testing.T) {
+	a := assert.New(t)
+
+	bookbuyer := identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "ns1"}
+	bookstoreSvc := service.MeshService{Name: "bookstore", Namespace: "ns2"}
+	proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, bookbuyer.Name, bookbuyer.Namespace), "serial", nil)
+	a.Nil(err)
+
+	proxyRegistry := NewProxyRegistry(ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
+		return nil, nil
+	}), nil)
+	proxyRegistry.RegisterProxy(proxy)
+	lister := &fakeOutboundServiceLister{outboundServices: map[identity.ServiceIdentity][]service.MeshService{
+		bookbuyer.ToServiceIdentity(): {bookstoreSvc},
+	}}
+	index := NewDependencyIndex(proxyRegistry, lister)
+	endpointsUpdated := events.PubSubMessage{
+		Kind:   announcements.EndpointUpdated,
+		NewObj: &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "ns2"}},
+	}
+	policyUpdated := events.PubSubMessage{
+		Kind:   announcements.NamespaceConfigUpdated,
+		NewObj: &configv1alpha2.NamespaceConfig{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "ns2"}},
+	}
+
+	proxyUUIDs, ok := index.GetAffectedProxies([]events.PubSubMessage{endpointsUpdated, endpointsUpdated})
+	a.True(ok)
+	a.Equal([]string{proxy.UUID.String()}, proxyUUIDs)
+	// Endpoint updates do not change the outbound services, which were only listed when the proxy connected
+	a.Equal(1, lister.calls)
+
+	// The proxy no longer depends on the namespace after the policy change, but did before it
+	delete(lister.outboundServices, bookbuyer.ToServiceIdentity())
+	proxyUUIDs, ok = index.GetAffectedProxies([]events.PubSubMessage{policyUpdated})
+	a.True(ok)
+	a.Equal([]string{proxy.UUID.String()}, proxyUUIDs)
+	a.Equal(2, lister.calls)
+
+	proxyUUIDs, ok = index.GetAffectedProxies([]events.PubSubMessage{endpointsUpdated})
+	a.True(ok)
+	a.Empty(proxyUUIDs)
+}
+
+func TestGetAffectedProxiesEgressSecrets(t *testing.T) {
+	a := assert.New(t)
+
+	bookbuyer := identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "ns1"}
+	proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, bookbuyer.Name, bookbuyer.Namespace), "serial", nil)
+	a.Nil(err)
+
+	proxyRegistry := NewProxyRegistry(ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
+		return nil, nil
+	}), nil)
+	proxyRegistry.RegisterProxy(proxy)
+	lister := &fakeEgressOutboundServiceLister{egressPolicies: map[identity.ServiceIdentity]*trafficpolicy.EgressTrafficPolicy{
+		bookbuyer.ToServiceIdentity(): {
+			ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+				{Name: "foo.com:80", Host: "foo.com", Port: 80},
+				{
+					Name: "bar.com:80", Host: "bar.com", Port: 443,
+					TLS: &trafficpolicy.EgressTLSConfig{
+						SNI:                     "bar.com",
+						CABundleSecret:          &types.NamespacedName{Namespace: "ns1", Name: "ca"},
+						ClientCertificateSecret: &types.NamespacedName{Namespace: "ns1", Name: "client"},
+					},
+				},
+			},
+		},
+	}}
+	index := NewDependencyIndex(proxyRegistry, lister)
+
+	secretUpdated := func(name string) events.PubSubMessage {
+		return events.PubSubMessage{
+			Kind:   announcements.SecretUpdated,
+			NewObj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"}},
+		}
+	}
+
+	proxyUUIDs, ok := index.GetAffectedProxies([]events.PubSubMessage{secretUpdated("ca")})
+	a.True(ok)
+	a.Equal([]string{proxy.UUID.String()}, proxyUUIDs)
+
+	proxyUUIDs, ok = index.GetAffectedProxies([]events.PubSubMessage{secretUpdated("client")})
+	a.True(ok)
+	a.Equal([]string{proxy.UUID.String()}, proxyUUIDs)
+
+	proxyUUIDs, ok = index.GetAffectedProxies([]events.PubSubMessage{secretUpdated("unreferenced")})
+	a.True(ok)
+	a.Empty(proxyUUIDs)
+
+	// Secret events do not change the outbound dependencies, which were only listed when the proxy connected
+	a.Equal(1, lister.calls)
+}
+
+func TestDependencyIndexProxyLifecycle(t *testing.T) {
+	a := assert.New(t)
+
+	bookstore := identity.K8sServiceAccount{Name: "bookstore", Namespace: "ns2"}
+	proxy, err := envoy.NewProxy(envoy.NewXDSCertCommonName(uuid.New(), envoy.KindSidecar, bookstore.Name, bookstore.Namespace), "serial", nil)
+	a.Nil(err)
+	proxy.PodMetadata = &envoy.PodMetadata{UID: "uid", Name: "bookstore-pod", Namespace: "ns2", ServiceAccount: bookstore}
+
+	proxyRegistry := NewProxyRegistry(ExplicitProxyServiceMapper(func(*envoy.Proxy) ([]service.MeshService, error) {
+		return nil, nil
+	}), nil)
+	index := NewDependencyIndex(proxyRegistry, &fakeOutboundServiceLister{})
+
+	// A service selecting the pod of the proxy is created
+	endpointsAdded := events.PubSubMessage{
+		Kind: announcements.EndpointAdded,
+		NewObj: &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore-v2", Namespace: "ns2"},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "bookstore-pod", Namespace: "ns2"}}},
+			}},
+		},
+	}
+
+	proxyUUIDs, ok := index.GetAffectedProxies([]events.PubSubMessage{endpointsAdded})
+	a.True(ok)
+	a.Empty(proxyUUIDs)
+
+	proxyRegistry.RegisterProxy(proxy)
+	proxyUUIDs, ok = index.GetAffectedProxies([]events.PubSubMessage{endpointsAdded})
+	a.True(ok)
+	a.Equal([]string{proxy.UUID.String()}, proxyUUIDs)
+
+	proxyRegistry.UnregisterProxy(proxy)
+	proxyUUIDs, ok = index.GetAffectedProxies([]events.PubSubMessage{endpointsAdded})
+	a.True(ok)
+	a.Empty(proxyUUIDs)
+	a.Empty(index.proxies)
+	a.Empty(index.proxiesByKey)
+	a.Empty(index.proxiesByIdentity)
+	a.Empty(index.outboundKeys)
+}
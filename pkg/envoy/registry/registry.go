@@ -34,12 +34,18 @@ func (pr *ProxyRegistry) RegisterProxy(proxy *envoy.Proxy) {
 		// Create a PodUID to Cert Serial Number so we can easily look-up the SerialNumber of the cert issued to a proxy for a given Pod.
 		pr.podUIDToCertificateSerialNumber.Store(podUID, proxy.GetCertificateSerialNumber())
 	}
+	if dependencyIndex := pr.getDependencyIndex(); dependencyIndex != nil {
+		dependencyIndex.addProxy(proxy)
+	}
 	log.Debug().Str("proxy", proxy.String()).Msg("Registered new proxy")
 }
 
 // UnregisterProxy unregisters the given proxy from the catalog.
 func (pr *ProxyRegistry) UnregisterProxy(p *envoy.Proxy) {
 	pr.connectedProxies.Delete(p.GetCertificateCommonName())
+	if dependencyIndex := pr.getDependencyIndex(); dependencyIndex != nil {
+		dependencyIndex.removeProxy(p)
+	}
 	log.Debug().Msgf("Unregistered proxy %s", p.String())
 }
 
@@ -47,3 +53,15 @@ func (pr *ProxyRegistry) UnregisterProxy(p *envoy.Proxy) {
 func (pr *ProxyRegistry) GetConnectedProxyCount() int {
 	return len(pr.ListConnectedProxies())
 }
+
+func (pr *ProxyRegistry) setDependencyIndex(dependencyIndex *DependencyIndex) {
+	pr.dependencyIndexMu.Lock()
+	defer pr.dependencyIndexMu.Unlock()
+	pr.dependencyIndex = dependencyIndex
+}
+
+func (pr *ProxyRegistry) getDependencyIndex() *DependencyIndex {
+	pr.dependencyIndexMu.RLock()
+	defer pr.dependencyIndexMu.RUnlock()
+	return pr.dependencyIndex
+}
@@ -25,6 +25,10 @@ type ProxyRegistry struct {
 	podUIDToCertificateSerialNumber sync.Map
 
 	msgBroker *messaging.Broker
+
+	// dependencyIndex indexes the dependencies of the connected proxies, nil if proxy updates are not targeted
+	dependencyIndex   *DependencyIndex
+	dependencyIndexMu sync.RWMutex
 }
 
 type connectedProxy struct {
@@ -0,0 +1,141 @@
+package sds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// egressCABundleKey is the key in the Secret referenced by an Egress policy holding the CA bundle
+	egressCABundleKey = "ca.crt"
+)
+
+// getEgressTLSSecret returns the SDS secret for a CA bundle or client certificate referenced by the
+// TLS origination settings of an Egress policy applicable to this proxy.
+func (s *sdsImpl) getEgressTLSSecret(sdscert secrets.SDSCert) (*xds_auth.Secret, error) {
+	secretName, err := sdscert.GetNamespacedName()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only serve Secrets referenced by the Egress policies applicable to this proxy, so that a proxy
+	// cannot request arbitrary Secrets in the cluster.
+	if !s.isEgressTLSSecretReferenced(sdscert.CertType, *secretName) {
+		log.Error().Err(errCertMismatch).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrSDSCertMismatch)).
+			Msgf("Request for SDS cert %s is not referenced by an Egress policy for proxy with identity %s", sdscert, s.serviceIdentity)
+		return nil, errCertMismatch
+	}
+
+	k8sSecret, err := s.meshCatalog.GetKubeController().GetSecret(*secretName)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingEgressTLSSecret)).
+			Msgf("Error getting Secret %s referenced by SDS cert %s", secretName, sdscert)
+		return nil, err
+	}
+
+	switch sdscert.CertType {
+	case secrets.EgressCABundleCertType:
+		caBundle, ok := k8sSecret.Data[egressCABundleKey]
+		if !ok {
+			err = errors.Errorf("Secret %s is missing key %s", secretName, egressCABundleKey)
+			break
+		}
+		return &xds_auth.Secret{
+			Name: sdscert.String(),
+			Type: &xds_auth.Secret_ValidationContext{
+				ValidationContext: &xds_auth.CertificateValidationContext{
+					TrustedCa: &xds_core.DataSource{
+						Specifier: &xds_core.DataSource_InlineBytes{
+							InlineBytes: caBundle,
+						},
+					},
+				},
+			},
+		}, nil
+
+	case secrets.EgressClientCertType:
+		certChain, hasCert := k8sSecret.Data[corev1.TLSCertKey]
+		privateKey, hasKey := k8sSecret.Data[corev1.TLSPrivateKeyKey]
+		if !hasCert || !hasKey {
+			err = errors.Errorf("Secret %s is missing keys %s and %s", secretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+			break
+		}
+		return &xds_auth.Secret{
+			Name: sdscert.String(),
+			Type: &xds_auth.Secret_TlsCertificate{
+				TlsCertificate: &xds_auth.TlsCertificate{
+					CertificateChain: &xds_core.DataSource{
+						Specifier: &xds_core.DataSource_InlineBytes{
+							InlineBytes: certChain,
+						},
+					},
+					PrivateKey: &xds_core.DataSource{
+						Specifier: &xds_core.DataSource_InlineBytes{
+							InlineBytes: privateKey,
+						},
+					},
+				},
+			},
+		}, nil
+
+	default:
+		err = errors.Errorf("Unexpected egress cert type %s", sdscert.CertType)
+	}
+
+	log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingEgressTLSSecret)).
+		Msgf("Error building SDS cert %s", sdscert)
+	return nil, err
+}
+
+// isEgressTLSSecretReferenced returns true if the given Secret is referenced as the given cert type by
+// the TLS origination settings of an Egress policy applicable to this proxy.
+func (s *sdsImpl) isEgressTLSSecretReferenced(certType secrets.SDSCertType, secretName types.NamespacedName) bool {
+	for _, clusterConfig := range s.getEgressClusterConfigs() {
+		if clusterConfig.TLS == nil {
+			continue
+		}
+
+		var referenced *types.NamespacedName
+		switch certType {
+		case secrets.EgressCABundleCertType:
+			referenced = clusterConfig.TLS.CABundleSecret
+		case secrets.EgressClientCertType:
+			referenced = clusterConfig.TLS.ClientCertificateSecret
+		}
+		if referenced != nil && *referenced == secretName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getEgressClusterConfigs returns the egress cluster configs applicable to this proxy. The egress gateway
+// originates TLS for the destinations of all the Egress policies routed via the gateway.
+func (s *sdsImpl) getEgressClusterConfigs() []*trafficpolicy.EgressClusterConfig {
+	if s.proxyKind == envoy.KindEgressGateway {
+		egressGatewayPolicy, err := s.meshCatalog.GetEgressGatewayTrafficPolicy()
+		if err != nil || egressGatewayPolicy == nil {
+			return nil
+		}
+		var clusterConfigs []*trafficpolicy.EgressClusterConfig
+		for _, destination := range egressGatewayPolicy.Destinations {
+			clusterConfigs = append(clusterConfigs, destination.Cluster)
+		}
+		return clusterConfigs
+	}
+
+	egressPolicy, err := s.meshCatalog.GetEgressTrafficPolicy(s.serviceIdentity)
+	if err != nil || egressPolicy == nil {
+		return nil
+	}
+	return egressPolicy.ClustersConfigs
+}
@@ -0,0 +1,169 @@
+package sds
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetEgressTLSSecret(t *testing.T) {
+	proxyIdentity := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity()
+	caSecretName := types.NamespacedName{Namespace: "ns-1", Name: "ca"}
+	clientSecretName := types.NamespacedName{Namespace: "ns-1", Name: "client"}
+
+	egressPolicy := &trafficpolicy.EgressTrafficPolicy{
+		ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+			{
+				Name: "foo.com:80",
+				Host: "foo.com",
+				Port: 443,
+				TLS: &trafficpolicy.EgressTLSConfig{
+					SNI:                     "foo.com",
+					CABundleSecret:          &caSecretName,
+					ClientCertificateSecret: &clientSecretName,
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		sdsCert           secrets.SDSCert
+		egressPolicy      *trafficpolicy.EgressTrafficPolicy
+		k8sSecret         *corev1.Secret
+		k8sSecretErr      error
+		expectedCABundle  []byte
+		expectedCertChain []byte
+		expectError       bool
+	}{
+		{
+			name:             "CA bundle referenced by an egress policy",
+			sdsCert:          secrets.SDSCert{Name: "ns-1/ca", CertType: secrets.EgressCABundleCertType},
+			egressPolicy:     egressPolicy,
+			k8sSecret:        &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca")}},
+			expectedCABundle: []byte("ca"),
+		},
+		{
+			name:         "client certificate referenced by an egress policy",
+			sdsCert:      secrets.SDSCert{Name: "ns-1/client", CertType: secrets.EgressClientCertType},
+			egressPolicy: egressPolicy,
+			k8sSecret: &corev1.Secret{Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			}},
+			expectedCertChain: []byte("cert"),
+		},
+		{
+			name:         "secret not referenced as the requested cert type",
+			sdsCert:      secrets.SDSCert{Name: "ns-1/ca", CertType: secrets.EgressClientCertType},
+			egressPolicy: egressPolicy,
+			expectError:  true,
+		},
+		{
+			name:         "secret not referenced by any egress policy",
+			sdsCert:      secrets.SDSCert{Name: "ns-2/ca", CertType: secrets.EgressCABundleCertType},
+			egressPolicy: egressPolicy,
+			expectError:  true,
+		},
+		{
+			name:         "secret could not be retrieved",
+			sdsCert:      secrets.SDSCert{Name: "ns-1/ca", CertType: secrets.EgressCABundleCertType},
+			egressPolicy: egressPolicy,
+			k8sSecretErr: errors.New("not found"),
+			expectError:  true,
+		},
+		{
+			name:         "secret missing the CA bundle key",
+			sdsCert:      secrets.SDSCert{Name: "ns-1/ca", CertType: secrets.EgressCABundleCertType},
+			egressPolicy: egressPolicy,
+			k8sSecret:    &corev1.Secret{Data: map[string][]byte{"foo": []byte("bar")}},
+			expectError:  true,
+		},
+		{
+			name:        "invalid secret name",
+			sdsCert:     secrets.SDSCert{Name: "ca", CertType: secrets.EgressCABundleCertType},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+
+			mockCatalog.EXPECT().GetEgressTrafficPolicy(proxyIdentity).Return(tc.egressPolicy, nil).AnyTimes()
+			mockCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
+			mockKubeController.EXPECT().GetSecret(gomock.Any()).Return(tc.k8sSecret, tc.k8sSecretErr).AnyTimes()
+
+			s := &sdsImpl{
+				serviceIdentity: proxyIdentity,
+				meshCatalog:     mockCatalog,
+			}
+
+			actual, err := s.getEgressTLSSecret(tc.sdsCert)
+			assert.Equal(tc.expectError, err != nil)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(tc.sdsCert.String(), actual.Name)
+			assert.Equal(tc.expectedCABundle, actual.GetValidationContext().GetTrustedCa().GetInlineBytes())
+			assert.Equal(tc.expectedCertChain, actual.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
+		})
+	}
+}
+
+func TestGetEgressTLSSecretForEgressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+
+	// The egress gateway is authorized to read the Secrets referenced by the destinations routed via it
+	mockCatalog.EXPECT().GetEgressGatewayTrafficPolicy().Return(&trafficpolicy.EgressGatewayTrafficPolicy{
+		Destinations: []*trafficpolicy.EgressGatewayDestination{
+			{
+				ServerName: "egress-80.foo.com",
+				Cluster: &trafficpolicy.EgressClusterConfig{
+					Name: "foo.com:80",
+					Host: "foo.com",
+					Port: 443,
+					TLS: &trafficpolicy.EgressTLSConfig{
+						SNI:            "foo.com",
+						CABundleSecret: &types.NamespacedName{Namespace: "ns-1", Name: "ca"},
+					},
+				},
+			},
+		},
+	}, nil).Times(1)
+	mockCatalog.EXPECT().GetKubeController().Return(mockKubeController).Times(1)
+	mockKubeController.EXPECT().GetSecret(types.NamespacedName{Namespace: "ns-1", Name: "ca"}).
+		Return(&corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca")}}, nil).Times(1)
+
+	s := &sdsImpl{
+		serviceIdentity: identity.K8sServiceAccount{Name: "osm-egress-gateway", Namespace: "osm-system"}.ToServiceIdentity(),
+		proxyKind:       envoy.KindEgressGateway,
+		meshCatalog:     mockCatalog,
+	}
+
+	actual, err := s.getEgressTLSSecret(secrets.SDSCert{Name: "ns-1/ca", CertType: secrets.EgressCABundleCertType})
+	assert.Nil(err)
+	assert.Equal([]byte("ca"), actual.GetValidationContext().GetTrustedCa().GetInlineBytes())
+}
@@ -0,0 +1,82 @@
+package sds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
+	"github.com/openservicemesh/osm/pkg/errcode"
+)
+
+// getIngressGatewayCertSecret returns the SDS secret for a certificate referenced by a Gateway listener
+// terminating TLS on the ingress gateway.
+func (s *sdsImpl) getIngressGatewayCertSecret(sdscert secrets.SDSCert) (*xds_auth.Secret, error) {
+	secretName, err := sdscert.GetNamespacedName()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only serve Secrets referenced by the Gateway listeners to the ingress gateway, so that a proxy
+	// cannot request arbitrary Secrets in the cluster.
+	if s.proxyKind != envoy.KindIngressGateway || !s.isIngressGatewayCertSecretReferenced(*secretName) {
+		log.Error().Err(errCertMismatch).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrSDSCertMismatch)).
+			Msgf("Request for SDS cert %s is not referenced by a Gateway listener for proxy with identity %s", sdscert, s.serviceIdentity)
+		return nil, errCertMismatch
+	}
+
+	k8sSecret, err := s.meshCatalog.GetKubeController().GetSecret(*secretName)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingIngressGatewayCertSecret)).
+			Msgf("Error getting Secret %s referenced by SDS cert %s", secretName, sdscert)
+		return nil, err
+	}
+
+	certChain, hasCert := k8sSecret.Data[corev1.TLSCertKey]
+	privateKey, hasKey := k8sSecret.Data[corev1.TLSPrivateKeyKey]
+	if !hasCert || !hasKey {
+		err = errors.Errorf("Secret %s is missing keys %s and %s", secretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGettingIngressGatewayCertSecret)).
+			Msgf("Error building SDS cert %s", sdscert)
+		return nil, err
+	}
+
+	return &xds_auth.Secret{
+		Name: sdscert.String(),
+		Type: &xds_auth.Secret_TlsCertificate{
+			TlsCertificate: &xds_auth.TlsCertificate{
+				CertificateChain: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineBytes{
+						InlineBytes: certChain,
+					},
+				},
+				PrivateKey: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineBytes{
+						InlineBytes: privateKey,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// isIngressGatewayCertSecretReferenced returns true if the given Secret is referenced by a Gateway listener terminating TLS
+func (s *sdsImpl) isIngressGatewayCertSecretReferenced(secretName types.NamespacedName) bool {
+	ingressGatewayPolicy, err := s.meshCatalog.GetIngressGatewayTrafficPolicy()
+	if err != nil || ingressGatewayPolicy == nil {
+		return false
+	}
+
+	for _, listener := range ingressGatewayPolicy.Listeners {
+		for _, termination := range listener.TLSTerminations {
+			if termination.CertificateSecret == secretName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
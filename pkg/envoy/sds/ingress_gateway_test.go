@@ -0,0 +1,101 @@
+package sds
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/secrets"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetIngressGatewayCertSecret(t *testing.T) {
+	ingressGatewayPolicy := &trafficpolicy.IngressGatewayTrafficPolicy{
+		Listeners: []*trafficpolicy.IngressGatewayListener{
+			{
+				Name:     "ingress-gateway-https-443",
+				Port:     443,
+				Protocol: "https",
+				TLSTerminations: []*trafficpolicy.IngressGatewayTLSTermination{
+					{Name: "ns-1/gw/https", CertificateSecret: types.NamespacedName{Namespace: "ns-1", Name: "foo-cert"}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		proxyKind         envoy.ProxyKind
+		sdsCert           secrets.SDSCert
+		k8sSecret         *corev1.Secret
+		expectedCertChain []byte
+		expectError       bool
+	}{
+		{
+			name:      "certificate referenced by a Gateway listener",
+			proxyKind: envoy.KindIngressGateway,
+			sdsCert:   secrets.SDSCert{Name: "ns-1/foo-cert", CertType: secrets.IngressGatewayCertType},
+			k8sSecret: &corev1.Secret{Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			}},
+			expectedCertChain: []byte("cert"),
+		},
+		{
+			name:        "certificate not referenced by a Gateway listener",
+			proxyKind:   envoy.KindIngressGateway,
+			sdsCert:     secrets.SDSCert{Name: "ns-1/bar-cert", CertType: secrets.IngressGatewayCertType},
+			expectError: true,
+		},
+		{
+			name:        "certificate requested by a proxy that is not the ingress gateway",
+			proxyKind:   envoy.KindSidecar,
+			sdsCert:     secrets.SDSCert{Name: "ns-1/foo-cert", CertType: secrets.IngressGatewayCertType},
+			expectError: true,
+		},
+		{
+			name:        "secret missing the certificate keys",
+			proxyKind:   envoy.KindIngressGateway,
+			sdsCert:     secrets.SDSCert{Name: "ns-1/foo-cert", CertType: secrets.IngressGatewayCertType},
+			k8sSecret:   &corev1.Secret{Data: map[string][]byte{"foo": []byte("bar")}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+
+			mockCatalog.EXPECT().GetIngressGatewayTrafficPolicy().Return(ingressGatewayPolicy, nil).AnyTimes()
+			mockCatalog.EXPECT().GetKubeController().Return(mockKubeController).AnyTimes()
+			mockKubeController.EXPECT().GetSecret(gomock.Any()).Return(tc.k8sSecret, nil).AnyTimes()
+
+			s := &sdsImpl{
+				serviceIdentity: identity.K8sServiceAccount{Name: "osm-ingress-gateway", Namespace: "osm-system"}.ToServiceIdentity(),
+				proxyKind:       tc.proxyKind,
+				meshCatalog:     mockCatalog,
+			}
+
+			actual, err := s.getIngressGatewayCertSecret(tc.sdsCert)
+			assert.Equal(tc.expectError, err != nil)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(tc.sdsCert.String(), actual.Name)
+			assert.Equal(tc.expectedCertChain, actual.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
+		})
+	}
+}
@@ -34,6 +34,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request
 		certManager:     certManager,
 		cfg:             cfg,
 		serviceIdentity: proxyIdentity,
+		proxyKind:       proxy.Kind(),
 	}
 
 	var sdsResources []types.Resource
@@ -64,6 +65,9 @@ func (s *sdsImpl) getSDSSecrets(cert *certificate.Certificate, requestedCerts []
 	// - "service-cert:namespace/service-account"
 	// - "root-cert-for-mtls-outbound:namespace/service"
 	// - "root-cert-for-mtls-inbound:namespace/service-service-account"
+	// - "egress-ca-bundle:namespace/secret"
+	// - "egress-client-cert:namespace/secret"
+	// - "ingress-gateway-cert:namespace/secret"
 
 	// The Envoy makes a request for a list of resources (aka certificates), which we will send as a response to the SDS request.
 	for _, requestedCertificate := range requestedCerts {
@@ -96,6 +100,24 @@ func (s *sdsImpl) getSDSSecrets(cert *certificate.Certificate, requestedCerts []
 			}
 			certs = append(certs, envoySecret)
 
+		// A CA bundle or client certificate used to originate TLS for egress traffic is requested
+		case secrets.EgressCABundleCertType, secrets.EgressClientCertType:
+			envoySecret, err := s.getEgressTLSSecret(*sdsCert)
+			if err != nil {
+				log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error getting egress cert %s for proxy", requestedCertificate)
+				continue
+			}
+			certs = append(certs, envoySecret)
+
+		// A certificate used by the ingress gateway to terminate TLS is requested
+		case secrets.IngressGatewayCertType:
+			envoySecret, err := s.getIngressGatewayCertSecret(*sdsCert)
+			if err != nil {
+				log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error getting ingress gateway cert %s for proxy", requestedCertificate)
+				continue
+			}
+			certs = append(certs, envoySecret)
+
 		default:
 			log.Error().Str("proxy", proxy.String()).Msgf("Unexpected certificate type %s requested by proxy", requestedCertificate)
 		}
@@ -5,6 +5,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
@@ -16,6 +17,7 @@ var (
 // sdsImpl is the type that implements the internal functionality of SDS
 type sdsImpl struct {
 	serviceIdentity identity.ServiceIdentity
+	proxyKind       envoy.ProxyKind
 	meshCatalog     catalog.MeshCataloger
 	cfg             configurator.Configurator
 	certManager     certificate.Manager
@@ -8,4 +8,5 @@ var (
 	errInvalidCertFormat                    = errors.New("invalid certificate string resource format")
 	errInvalidMeshServiceFormat             = errors.New("invalid mesh service string format")
 	errInvalidNamespacedServiceStringFormat = errors.New("invalid namespaced service string format")
+	errInvalidNamespacedNameFormat          = errors.New("invalid namespaced name string format")
 )
@@ -6,6 +6,7 @@ import (
 
 	tassert "github.com/stretchr/testify/assert"
 	trequire "github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -245,6 +246,45 @@ func TestUnmarshalK8sServiceAccount(t *testing.T) {
 	}
 }
 
+func TestGetNamespacedName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sdsCert  SDSCert
+		expected *types.NamespacedName
+	}{
+		{
+			name:     "successfully unmarshal namespaced name",
+			sdsCert:  SDSCert{Name: "ns/secret"},
+			expected: &types.NamespacedName{Namespace: "ns", Name: "secret"},
+		},
+		{
+			name:     "namespace missing",
+			sdsCert:  SDSCert{Name: "/secret"},
+			expected: nil,
+		},
+		{
+			name:     "name missing",
+			sdsCert:  SDSCert{Name: "ns/"},
+			expected: nil,
+		},
+		{
+			name:     "separator missing",
+			sdsCert:  SDSCert{Name: "secret"},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual, err := tc.sdsCert.GetNamespacedName()
+			assert.Equal(tc.expected == nil, err != nil)
+			assert.Equal(tc.expected, actual)
+		})
+	}
+}
+
 func TestGetSecretNameForIdentity(t *testing.T) {
 	testCases := []struct {
 		si       identity.ServiceIdentity
@@ -43,6 +43,18 @@ const (
 
 	// RootCertTypeForMTLSInbound is the prefix for the mTLS root certificate resource name for downstream connectivity. Example: "root-cert-for-mtls-inbound:ns/name"
 	RootCertTypeForMTLSInbound SDSCertType = "root-cert-for-mtls-inbound"
+
+	// EgressCABundleCertType is the prefix for the CA bundle resource name used to validate external hosts
+	// when originating TLS for egress traffic. Example: "egress-ca-bundle:ns/secret-name"
+	EgressCABundleCertType SDSCertType = "egress-ca-bundle"
+
+	// EgressClientCertType is the prefix for the client certificate resource name presented to external hosts
+	// when originating TLS for egress traffic. Example: "egress-client-cert:ns/secret-name"
+	EgressClientCertType SDSCertType = "egress-client-cert"
+
+	// IngressGatewayCertType is the prefix for the certificate resource name presented by the ingress gateway
+	// to downstream clients when terminating TLS. Example: "ingress-gateway-cert:ns/secret-name"
+	IngressGatewayCertType SDSCertType = "ingress-gateway-cert"
 )
 
 // Defines valid cert types
@@ -50,4 +62,7 @@ var validCertTypes = map[SDSCertType]struct{}{
 	ServiceCertType:             {},
 	RootCertTypeForMTLSOutbound: {},
 	RootCertTypeForMTLSInbound:  {},
+	EgressCABundleCertType:      {},
+	EgressClientCertType:        {},
+	IngressGatewayCertType:      {},
 }
@@ -96,6 +96,12 @@ const (
 	EnvoyActiveHealthCheckHeaderKey = "x-osm-envoy-healthcheck"
 )
 
+const (
+	// RBACAuditShadowRulesStatPrefix is the prefix of the stats and dynamic metadata emitted by the
+	// shadow rules of RBAC filters whose access policies are audited, ex. rbac.audit.shadow_denied
+	RBACAuditShadowRulesStatPrefix = "audit."
+)
+
 // ProxyKind is the type used to define the proxy's kind
 type ProxyKind string
 
@@ -105,4 +111,10 @@ const (
 
 	// KindGateway implies the proxy is a gateway
 	KindGateway ProxyKind = "gateway"
+
+	// KindEgressGateway implies the proxy is an egress gateway
+	KindEgressGateway ProxyKind = "egress-gateway"
+
+	// KindIngressGateway implies the proxy is the ingress gateway programmed from Gateway API resources
+	KindIngressGateway ProxyKind = "ingress-gateway"
 )
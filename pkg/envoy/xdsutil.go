@@ -1,6 +1,7 @@
 package envoy
 
 import (
+	"fmt"
 	"net"
 	"strings"
 
@@ -8,6 +9,7 @@ import (
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/google/uuid"
@@ -77,6 +79,21 @@ func GetAddress(address string, port uint32) *xds_core.Address {
 	}
 }
 
+// GetUDPAddress creates an Envoy Address struct for a UDP socket.
+func GetUDPAddress(address string, port uint32) *xds_core.Address {
+	return &xds_core.Address{
+		Address: &xds_core.Address_SocketAddress{
+			SocketAddress: &xds_core.SocketAddress{
+				Protocol: xds_core.SocketAddress_UDP,
+				Address:  address,
+				PortSpecifier: &xds_core.SocketAddress_PortValue{
+					PortValue: port,
+				},
+			},
+		},
+	}
+}
+
 // GetTLSParams creates Envoy TlsParameters struct.
 func GetTLSParams(sidecarSpec configv1alpha2.SidecarSpec) *xds_auth.TlsParameters {
 	minVersionInt := xds_auth.TlsParameters_TlsProtocol_value[sidecarSpec.TLSMinProtocolVersion]
@@ -100,7 +117,26 @@ func GetTLSParams(sidecarSpec configv1alpha2.SidecarSpec) *xds_auth.TlsParameter
 
 // GetAccessLog creates an Envoy AccessLog struct.
 func GetAccessLog() []*xds_accesslog_filter.AccessLog {
-	accessLog, err := anypb.New(getStdoutAccessLog())
+	return buildAccessLog(getStdoutAccessLog())
+}
+
+// GetAuditAccessLog creates an Envoy AccessLog struct for filter chains whose access policies are audited.
+// In addition to the fields of the default access log, it logs the identities of the downstream and local
+// peers along with the result of the shadow RBAC rules evaluated for the request or connection.
+func GetAuditAccessLog() []*xds_accesslog_filter.AccessLog {
+	accessLogger := getStdoutAccessLog()
+	fields := accessLogger.GetLogFormat().GetJsonFormat().Fields
+	fields["downstream_peer_subject"] = pbStringValue(`%DOWNSTREAM_PEER_SUBJECT%`)
+	fields["downstream_local_subject"] = pbStringValue(`%DOWNSTREAM_LOCAL_SUBJECT%`)
+	fields["rbac_shadow_policy"] = pbStringValue(fmt.Sprintf("%%DYNAMIC_METADATA(%s:%sshadow_effective_policy_id)%%", wellknown.HTTPRoleBasedAccessControl, RBACAuditShadowRulesStatPrefix))
+	fields["rbac_shadow_result"] = pbStringValue(fmt.Sprintf("%%DYNAMIC_METADATA(%s:%sshadow_engine_result)%%", wellknown.HTTPRoleBasedAccessControl, RBACAuditShadowRulesStatPrefix))
+	fields["network_rbac_shadow_policy"] = pbStringValue(fmt.Sprintf("%%DYNAMIC_METADATA(%s:%sshadow_effective_policy_id)%%", wellknown.RoleBasedAccessControl, RBACAuditShadowRulesStatPrefix))
+	fields["network_rbac_shadow_result"] = pbStringValue(fmt.Sprintf("%%DYNAMIC_METADATA(%s:%sshadow_engine_result)%%", wellknown.RoleBasedAccessControl, RBACAuditShadowRulesStatPrefix))
+	return buildAccessLog(accessLogger)
+}
+
+func buildAccessLog(accessLogger *xds_accesslog.StdoutAccessLog) []*xds_accesslog_filter.AccessLog {
+	accessLog, err := anypb.New(accessLogger)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
 			Msgf("Error marshalling AccessLog object")
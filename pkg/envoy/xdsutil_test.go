@@ -31,6 +31,25 @@ func TestGetAccessLog(t *testing.T) {
 	assert.NotNil(res)
 }
 
+func TestGetAuditAccessLog(t *testing.T) {
+	assert := tassert.New(t)
+
+	res := GetAuditAccessLog()
+	assert.Len(res, 1)
+
+	accessLogger := &xds_accesslog.StdoutAccessLog{}
+	assert.Nil(res[0].GetTypedConfig().UnmarshalTo(accessLogger))
+
+	fields := accessLogger.GetLogFormat().GetJsonFormat().Fields
+	assert.Equal(pbStringValue(`%DOWNSTREAM_PEER_SUBJECT%`), fields["downstream_peer_subject"])
+	assert.Equal(pbStringValue(`%DOWNSTREAM_LOCAL_SUBJECT%`), fields["downstream_local_subject"])
+	assert.Equal(pbStringValue(`%DYNAMIC_METADATA(envoy.filters.http.rbac:audit.shadow_engine_result)%`), fields["rbac_shadow_result"])
+	assert.Equal(pbStringValue(`%DYNAMIC_METADATA(envoy.filters.network.rbac:audit.shadow_engine_result)%`), fields["network_rbac_shadow_result"])
+
+	// The default access log fields are preserved
+	assert.Equal(pbStringValue(`%START_TIME%`), fields["start_time"])
+}
+
 func TestGetStdoutAccessLog(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -98,6 +117,26 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetUDPAddress()", func() {
+		It("should return UDP address", func() {
+			actual := GetUDPAddress("127.0.0.1", 15053)
+
+			expected := &core.Address{
+				Address: &core.Address_SocketAddress{
+					SocketAddress: &core.SocketAddress{
+						Protocol: core.SocketAddress_UDP,
+						Address:  "127.0.0.1",
+						PortSpecifier: &core.SocketAddress_PortValue{
+							PortValue: 15053,
+						},
+					},
+				},
+			}
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
 	Context("Test GetDownstreamTLSContext()", func() {
 		It("should return TLS context", func() {
 			svcAccount := identity.K8sServiceAccount{Name: "foo", Namespace: "test"}
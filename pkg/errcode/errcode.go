@@ -39,6 +39,9 @@ const (
 
 	// ErrStartingReconciler indicates the reconciler client failed to start
 	ErrStartingReconciler
+
+	// ErrStartingLeaderElection indicates the leader election among the replicas failed to start
+	ErrStartingLeaderElection
 )
 
 // Range 2000-2500 is reserved for errors related to traffic policies
@@ -80,6 +83,18 @@ const (
 
 	// ErrInvalidSourceKind	indicated an applied SMI TrafficTarget policy has an invalid source kind
 	ErrInvalidSourceKind
+
+	// ErrInvalidPolicyAuditModeAnnotation indicates the policy audit mode annotation on a namespace or service is invalid
+	ErrInvalidPolicyAuditModeAnnotation
+
+	// ErrInvalidEgressHost indicates a host specified in an egress policy is invalid for the given port protocol
+	ErrInvalidEgressHost
+
+	// ErrInvalidEgressGatewayRouting indicates an egress policy cannot be routed via the egress gateway
+	ErrInvalidEgressGatewayRouting
+
+	// ErrInvalidGatewayAPIResource indicates a Gateway API resource cannot be programmed on the ingress gateway
+	ErrInvalidGatewayAPIResource
 )
 
 // Range 3000-3500 is reserved for errors related to k8s constructs (service accounts, namespaces, etc.)
@@ -146,6 +161,12 @@ const (
 
 	// ErrRotatingCert indicates a certificate could not be rotated
 	ErrRotatingCert
+
+	// ErrLoadingPersistedCerts indicates the persisted certificates could not be loaded
+	ErrLoadingPersistedCerts
+
+	// ErrPersistingCert indicates a certificate could not be persisted or removed from the persistent store
+	ErrPersistingCert
 )
 
 // Range 4100-4150 reserved for PubSub system
@@ -167,6 +188,9 @@ const (
 
 	// ErrMeshConfigMarshaling indicates failed to marshal MeshConfig into other format like JSON
 	ErrMeshConfigMarshaling
+
+	// ErrMeshConfigStatusUpdate indicates failed to update the status of the MeshConfig
+	ErrMeshConfigStatusUpdate
 )
 
 // Range 5000-5500 reserved for errors related to Envoy XDS control plane
@@ -269,6 +293,14 @@ const (
 
 	// ErrSDSCertMismatch indicates the indentity obtained from the SDSCert request does not match the identity of the proxy
 	ErrSDSCertMismatch
+
+	// ErrGettingEgressTLSSecret indicates the Kubernetes secret referenced by an egress policy's TLS origination
+	// settings could not be retrieved
+	ErrGettingEgressTLSSecret
+
+	// ErrGettingIngressGatewayCertSecret indicates the Kubernetes secret referenced by a Gateway listener to terminate
+	// TLS could not be retrieved
+	ErrGettingIngressGatewayCertSecret
 )
 
 // Range 6000-6500 reserved for errors related to the OSM Injector
@@ -412,6 +444,12 @@ failed to start.
 	ErrStartingReconciler: `
 The Reconciler client to monitor updates and deletes to OSM's CRDs and mutating webhook
 failed to start.
+`,
+
+	ErrStartingLeaderElection: `
+The leader election among the osm-controller replicas failed to start. The singleton
+duties run by the leader, such as writing the bootstrap and gateway certificate secrets,
+will not run on this replica.
 `,
 
 	//
@@ -486,6 +524,32 @@ An applied SMI TrafficTarget policy has an invalid source kind.
 	ErrGettingInboundTrafficTargets: `
 The inbound TrafficTargets composed of their routes for a given destination
 ServiceIdentity could not be configured.
+`,
+
+	ErrInvalidPolicyAuditModeAnnotation: `
+The value of the 'openservicemesh.io/policy-audit-mode' annotation on a namespace
+or service is invalid. SMI access policies for the corresponding services are
+enforced instead of audited.
+`,
+
+	ErrInvalidEgressHost: `
+A host specified in an Egress policy is invalid for the port protocol it is
+used with. Wildcard hosts are only supported for HTTPS ports.
+The host was ignored for the corresponding port by the system.
+`,
+
+	ErrInvalidEgressGatewayRouting: `
+An Egress policy routing traffic via the egress gateway cannot be applied, either
+because the egress gateway is not enabled or because a port protocol it specifies
+is not supported by the egress gateway. Only HTTP and HTTPS ports are supported.
+The corresponding policy or port was ignored by the system.
+`,
+
+	ErrInvalidGatewayAPIResource: `
+A Gateway, HTTPRoute or TLSRoute resource managed by OSM cannot be programmed on
+the ingress gateway. The listener protocol, TLS mode, certificate reference or
+backend reference it specifies is not supported, or it conflicts with another
+resource. The corresponding listener, route or backend was ignored by the system.
 `,
 
 	//
@@ -577,6 +641,17 @@ The certificate authority privided when issuing a certificate was invalid.
 
 	ErrRotatingCert: `
 The specified certificate could not be rotated.
+`,
+
+	ErrLoadingPersistedCerts: `
+The certificates persisted by a previous instance of the certificate manager could not be
+loaded. Certificates will be issued again instead of being reused.
+`,
+
+	ErrPersistingCert: `
+The specified certificate could not be persisted or removed from the persistent store.
+The change is retried periodically. A persisted certificate may be reloaded after a restart
+until it is rotated or expires.
 `,
 
 	//
@@ -600,6 +675,11 @@ Failed to fetch MeshConfig from cache with specific key.
 `,
 	ErrMeshConfigMarshaling: `
 Failed to marshal MeshConfig into other format.
+`,
+
+	ErrMeshConfigStatusUpdate: `
+Failed to update the status of the MeshConfig. The state of the staged rollout of MeshConfig
+changes could not be persisted, and is retried on the next change or periodically.
 `,
 
 	//
@@ -757,6 +837,18 @@ The SDS secret does not correspond to a ServiceAccount.
 The identity obtained from the SDS certificate request does not match the
 identity of the proxy.
 The corresponding certificate request was ignored by the system.
+`,
+
+	ErrGettingEgressTLSSecret: `
+The Kubernetes secret referenced by the TLS origination settings of an Egress
+policy could not be retrieved or is missing the expected keys.
+The corresponding certificate request was ignored by the system.
+`,
+
+	ErrGettingIngressGatewayCertSecret: `
+The Kubernetes secret referenced by a Gateway listener to terminate TLS could
+not be retrieved or is missing the expected keys.
+The corresponding certificate request was ignored by the system.
 `,
 
 	//
@@ -0,0 +1,308 @@
+package gatewayapi
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayClientset "sigs.k8s.io/gateway-api/pkg/client/clientset/gateway/versioned"
+	gatewayInformers "sigs.k8s.io/gateway-api/pkg/client/informers/gateway/externalversions"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+// NewGatewayAPIController returns a gatewayapi.Controller interface related to functionality provided by the resources in the gateway.networking.k8s.io API group
+func NewGatewayAPIController(kubeController k8s.Controller, gatewayClient gatewayClientset.Interface, stop chan struct{}, msgBroker *messaging.Broker) (Controller, error) {
+	return newClient(kubeController, gatewayClient, stop, msgBroker)
+}
+
+func newClient(kubeController k8s.Controller, gatewayClient gatewayClientset.Interface, stop chan struct{}, msgBroker *messaging.Broker) (client, error) {
+	informerFactory := gatewayInformers.NewSharedInformerFactory(gatewayClient, k8s.DefaultKubeEventResyncInterval)
+
+	informerCollection := informerCollection{
+		gatewayClass: informerFactory.Gateway().V1alpha2().GatewayClasses().Informer(),
+		gateway:      informerFactory.Gateway().V1alpha2().Gateways().Informer(),
+		httpRoute:    informerFactory.Gateway().V1alpha2().HTTPRoutes().Informer(),
+		tlsRoute:     informerFactory.Gateway().V1alpha2().TLSRoutes().Informer(),
+	}
+
+	cacheCollection := cacheCollection{
+		gatewayClass: informerCollection.gatewayClass.GetStore(),
+		gateway:      informerCollection.gateway.GetStore(),
+		httpRoute:    informerCollection.httpRoute.GetStore(),
+		tlsRoute:     informerCollection.tlsRoute.GetStore(),
+	}
+
+	client := client{
+		informers:      &informerCollection,
+		caches:         &cacheCollection,
+		kubeController: kubeController,
+	}
+
+	// GatewayClasses are cluster scoped, so all of them are observed
+	observeAll := func(obj interface{}) bool {
+		return true
+	}
+	shouldObserve := func(obj interface{}) bool {
+		object, ok := obj.(metav1.Object)
+		if !ok {
+			return false
+		}
+		return kubeController.IsMonitoredNamespace(object.GetNamespace())
+	}
+
+	gatewayClassEventTypes := k8s.EventTypes{
+		Add:    announcements.GatewayClassAdded,
+		Update: announcements.GatewayClassUpdated,
+		Delete: announcements.GatewayClassDeleted,
+	}
+	informerCollection.gatewayClass.AddEventHandler(k8s.GetEventHandlerFuncs(observeAll, gatewayClassEventTypes, msgBroker))
+
+	gatewayEventTypes := k8s.EventTypes{
+		Add:    announcements.GatewayAdded,
+		Update: announcements.GatewayUpdated,
+		Delete: announcements.GatewayDeleted,
+	}
+	informerCollection.gateway.AddEventHandler(k8s.GetEventHandlerFuncs(shouldObserve, gatewayEventTypes, msgBroker))
+
+	httpRouteEventTypes := k8s.EventTypes{
+		Add:    announcements.HTTPRouteAdded,
+		Update: announcements.HTTPRouteUpdated,
+		Delete: announcements.HTTPRouteDeleted,
+	}
+	informerCollection.httpRoute.AddEventHandler(k8s.GetEventHandlerFuncs(shouldObserve, httpRouteEventTypes, msgBroker))
+
+	tlsRouteEventTypes := k8s.EventTypes{
+		Add:    announcements.TLSRouteAdded,
+		Update: announcements.TLSRouteUpdated,
+		Delete: announcements.TLSRouteDeleted,
+	}
+	informerCollection.tlsRoute.AddEventHandler(k8s.GetEventHandlerFuncs(shouldObserve, tlsRouteEventTypes, msgBroker))
+
+	err := client.run(stop)
+	if err != nil {
+		return client, errors.Errorf("Could not start %s informer clients: %s", gwv1alpha2.SchemeGroupVersion, err)
+	}
+
+	return client, err
+}
+
+func (c client) run(stop <-chan struct{}) error {
+	log.Info().Msgf("Starting informer clients for API group %s", gwv1alpha2.SchemeGroupVersion)
+
+	if c.informers == nil {
+		return errInitInformers
+	}
+
+	sharedInformers := map[string]cache.SharedInformer{
+		"GatewayClass": c.informers.gatewayClass,
+		"Gateway":      c.informers.gateway,
+		"HTTPRoute":    c.informers.httpRoute,
+		"TLSRoute":     c.informers.tlsRoute,
+	}
+
+	var informerNames []string
+	var hasSynced []cache.InformerSynced
+	for name, informer := range sharedInformers {
+		if informer == nil {
+			log.Error().Msgf("Informer for '%s' not initialized, ignoring it", name)
+			continue
+		}
+		informerNames = append(informerNames, name)
+		log.Info().Msgf("Starting informer: %s", name)
+		go informer.Run(stop)
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+
+	log.Info().Msgf("Waiting for informers %v caches to sync", informerNames)
+	if !cache.WaitForCacheSync(stop, hasSynced...) {
+		return errSyncingCaches
+	}
+
+	log.Info().Msgf("Cache sync finished for %v informers in API group %s", informerNames, gwv1alpha2.SchemeGroupVersion)
+	return nil
+}
+
+// ListGateways lists the Gateways in the monitored namespaces whose GatewayClass is managed by OSM
+func (c client) ListGateways() []*gwv1alpha2.Gateway {
+	managedClasses := make(map[gwv1alpha2.ObjectName]struct{})
+	for _, gatewayClassIface := range c.caches.gatewayClass.List() {
+		gatewayClass := gatewayClassIface.(*gwv1alpha2.GatewayClass)
+		if gatewayClass.Spec.ControllerName == ControllerName {
+			managedClasses[gwv1alpha2.ObjectName(gatewayClass.Name)] = struct{}{}
+		}
+	}
+
+	var gateways []*gwv1alpha2.Gateway
+	for _, gatewayIface := range c.caches.gateway.List() {
+		gateway := gatewayIface.(*gwv1alpha2.Gateway)
+
+		if !c.kubeController.IsMonitoredNamespace(gateway.Namespace) {
+			continue
+		}
+		if _, ok := managedClasses[gateway.Spec.GatewayClassName]; !ok {
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+
+	// Sort the Gateways so that conflicts between them are resolved consistently
+	sort.Slice(gateways, func(i, j int) bool {
+		if gateways[i].Namespace != gateways[j].Namespace {
+			return gateways[i].Namespace < gateways[j].Namespace
+		}
+		return gateways[i].Name < gateways[j].Name
+	})
+
+	return gateways
+}
+
+// ListHTTPRoutes lists the HTTPRoutes in the monitored namespaces attached to the given listener of the given Gateway
+func (c client) ListHTTPRoutes(gateway *gwv1alpha2.Gateway, listener gwv1alpha2.Listener) []*gwv1alpha2.HTTPRoute {
+	if !isRouteKindAllowed(listener, KindHTTPRoute) {
+		return nil
+	}
+
+	var routes []*gwv1alpha2.HTTPRoute
+	for _, routeIface := range c.caches.httpRoute.List() {
+		route := routeIface.(*gwv1alpha2.HTTPRoute)
+
+		if !c.kubeController.IsMonitoredNamespace(route.Namespace) {
+			continue
+		}
+		if !c.isRouteAttached(gateway, listener, route.Namespace, route.Spec.ParentRefs) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Namespace != routes[j].Namespace {
+			return routes[i].Namespace < routes[j].Namespace
+		}
+		return routes[i].Name < routes[j].Name
+	})
+
+	return routes
+}
+
+// ListTLSRoutes lists the TLSRoutes in the monitored namespaces attached to the given listener of the given Gateway
+func (c client) ListTLSRoutes(gateway *gwv1alpha2.Gateway, listener gwv1alpha2.Listener) []*gwv1alpha2.TLSRoute {
+	if !isRouteKindAllowed(listener, KindTLSRoute) {
+		return nil
+	}
+
+	var routes []*gwv1alpha2.TLSRoute
+	for _, routeIface := range c.caches.tlsRoute.List() {
+		route := routeIface.(*gwv1alpha2.TLSRoute)
+
+		if !c.kubeController.IsMonitoredNamespace(route.Namespace) {
+			continue
+		}
+		if !c.isRouteAttached(gateway, listener, route.Namespace, route.Spec.ParentRefs) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Namespace != routes[j].Namespace {
+			return routes[i].Namespace < routes[j].Namespace
+		}
+		return routes[i].Name < routes[j].Name
+	})
+
+	return routes
+}
+
+// isRouteAttached returns true if a route in the given namespace with the given parent references is attached
+// to the given listener of the given Gateway, and the listener allows routes from the route's namespace.
+func (c client) isRouteAttached(gateway *gwv1alpha2.Gateway, listener gwv1alpha2.Listener, routeNamespace string, parentRefs []gwv1alpha2.ParentRef) bool {
+	referenced := false
+	for _, parentRef := range parentRefs {
+		if parentRef.Group != nil && *parentRef.Group != GroupName {
+			continue
+		}
+		if parentRef.Kind != nil && *parentRef.Kind != KindGateway {
+			continue
+		}
+		parentNamespace := routeNamespace
+		if parentRef.Namespace != nil {
+			parentNamespace = string(*parentRef.Namespace)
+		}
+		if parentNamespace != gateway.Namespace || string(parentRef.Name) != gateway.Name {
+			continue
+		}
+		if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+			continue
+		}
+		referenced = true
+		break
+	}
+	if !referenced {
+		return false
+	}
+
+	// Routes are only allowed from the Gateway's namespace by default
+	from := gwv1alpha2.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case gwv1alpha2.NamespacesFromAll:
+		return true
+
+	case gwv1alpha2.NamespacesFromSelector:
+		if selector == nil {
+			return false
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid namespace selector on listener %s of Gateway %s/%s", listener.Name, gateway.Namespace, gateway.Name)
+			return false
+		}
+		namespace := c.kubeController.GetNamespace(routeNamespace)
+		if namespace == nil {
+			return false
+		}
+		return labelSelector.Matches(labels.Set(namespace.Labels))
+
+	default:
+		return routeNamespace == gateway.Namespace
+	}
+}
+
+// isRouteKindAllowed returns true if routes of the given kind can be attached to the given listener
+func isRouteKindAllowed(listener gwv1alpha2.Listener, kind string) bool {
+	if listener.AllowedRoutes != nil && len(listener.AllowedRoutes.Kinds) > 0 {
+		for _, allowedKind := range listener.AllowedRoutes.Kinds {
+			if allowedKind.Group != nil && *allowedKind.Group != GroupName {
+				continue
+			}
+			if string(allowedKind.Kind) == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	// The route kinds allowed by default are derived from the listener's protocol
+	switch listener.Protocol {
+	case gwv1alpha2.HTTPProtocolType, gwv1alpha2.HTTPSProtocolType:
+		return kind == KindHTTPRoute
+	case gwv1alpha2.TLSProtocolType:
+		return kind == KindTLSRoute
+	default:
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	fakeGatewayClient "sigs.k8s.io/gateway-api/pkg/client/clientset/gateway/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+func TestListGateways(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	c, err := newClient(mockKubeController, fakeGatewayClient.NewSimpleClientset(), nil, nil)
+	a.Nil(err)
+
+	_ = c.caches.gatewayClass.Add(&gwv1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "osm"},
+		Spec:       gwv1alpha2.GatewayClassSpec{ControllerName: ControllerName},
+	})
+	_ = c.caches.gatewayClass.Add(&gwv1alpha2.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec:       gwv1alpha2.GatewayClassSpec{ControllerName: "example.com/gateway-controller"},
+	})
+
+	managed := &gwv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw-1", Namespace: "test"},
+		Spec:       gwv1alpha2.GatewaySpec{GatewayClassName: "osm"},
+	}
+	otherClass := &gwv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw-2", Namespace: "test"},
+		Spec:       gwv1alpha2.GatewaySpec{GatewayClassName: "other"},
+	}
+	unmonitored := &gwv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw-3", Namespace: "unmonitored"},
+		Spec:       gwv1alpha2.GatewaySpec{GatewayClassName: "osm"},
+	}
+	_ = c.caches.gateway.Add(managed)
+	_ = c.caches.gateway.Add(otherClass)
+	_ = c.caches.gateway.Add(unmonitored)
+
+	a.Equal([]*gwv1alpha2.Gateway{managed}, c.ListGateways())
+}
+
+func TestListHTTPRoutes(t *testing.T) {
+	gateway := &gwv1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "test"},
+	}
+	httpListener := gwv1alpha2.Listener{Name: "http", Port: 80, Protocol: gwv1alpha2.HTTPProtocolType}
+	fromAll := gwv1alpha2.NamespacesFromAll
+	fromSelector := gwv1alpha2.NamespacesFromSelector
+	otherSection := gwv1alpha2.SectionName("other")
+	otherNamespace := gwv1alpha2.Namespace("other")
+	gatewayNamespace := gwv1alpha2.Namespace("test")
+
+	newRoute := func(namespace string, parentRef gwv1alpha2.ParentRef) *gwv1alpha2.HTTPRoute {
+		return &gwv1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: namespace},
+			Spec: gwv1alpha2.HTTPRouteSpec{
+				CommonRouteSpec: gwv1alpha2.CommonRouteSpec{ParentRefs: []gwv1alpha2.ParentRef{parentRef}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		listener       gwv1alpha2.Listener
+		route          *gwv1alpha2.HTTPRoute
+		expectAttached bool
+	}{
+		{
+			name:           "route in the Gateway's namespace referencing the Gateway",
+			listener:       httpListener,
+			route:          newRoute("test", gwv1alpha2.ParentRef{Name: "gw"}),
+			expectAttached: true,
+		},
+		{
+			name:           "route referencing another Gateway",
+			listener:       httpListener,
+			route:          newRoute("test", gwv1alpha2.ParentRef{Name: "gw-2"}),
+			expectAttached: false,
+		},
+		{
+			name:           "route referencing another listener of the Gateway",
+			listener:       httpListener,
+			route:          newRoute("test", gwv1alpha2.ParentRef{Name: "gw", SectionName: &otherSection}),
+			expectAttached: false,
+		},
+		{
+			name:           "route in another namespace not allowed by default",
+			listener:       httpListener,
+			route:          newRoute("other", gwv1alpha2.ParentRef{Name: "gw", Namespace: &gatewayNamespace}),
+			expectAttached: false,
+		},
+		{
+			name: "route in another namespace allowed from all namespaces",
+			listener: gwv1alpha2.Listener{
+				Name: "http", Port: 80, Protocol: gwv1alpha2.HTTPProtocolType,
+				AllowedRoutes: &gwv1alpha2.AllowedRoutes{Namespaces: &gwv1alpha2.RouteNamespaces{From: &fromAll}},
+			},
+			route:          newRoute("other", gwv1alpha2.ParentRef{Name: "gw", Namespace: &gatewayNamespace}),
+			expectAttached: true,
+		},
+		{
+			name: "route in another namespace allowed by the namespace selector",
+			listener: gwv1alpha2.Listener{
+				Name: "http", Port: 80, Protocol: gwv1alpha2.HTTPProtocolType,
+				AllowedRoutes: &gwv1alpha2.AllowedRoutes{Namespaces: &gwv1alpha2.RouteNamespaces{
+					From:     &fromSelector,
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"gateway": "allowed"}},
+				}},
+			},
+			route:          newRoute("other", gwv1alpha2.ParentRef{Name: "gw", Namespace: &gatewayNamespace}),
+			expectAttached: true,
+		},
+		{
+			name:           "route referencing a Gateway in another namespace",
+			listener:       httpListener,
+			route:          newRoute("test", gwv1alpha2.ParentRef{Name: "gw", Namespace: &otherNamespace}),
+			expectAttached: false,
+		},
+		{
+			name:           "HTTPRoute not allowed on a TLS listener",
+			listener:       gwv1alpha2.Listener{Name: "tls", Port: 443, Protocol: gwv1alpha2.TLSProtocolType},
+			route:          newRoute("test", gwv1alpha2.ParentRef{Name: "gw"}),
+			expectAttached: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().IsMonitoredNamespace(gomock.Any()).Return(true).AnyTimes()
+			mockKubeController.EXPECT().GetNamespace("other").Return(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"gateway": "allowed"}},
+			}).AnyTimes()
+
+			c, err := newClient(mockKubeController, fakeGatewayClient.NewSimpleClientset(), nil, nil)
+			a.Nil(err)
+			_ = c.caches.httpRoute.Add(tc.route)
+
+			routes := c.ListHTTPRoutes(gateway, tc.listener)
+			if tc.expectAttached {
+				a.Equal([]*gwv1alpha2.HTTPRoute{tc.route}, routes)
+			} else {
+				a.Empty(routes)
+			}
+		})
+	}
+}
+
+func TestListTLSRoutes(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+
+	c, err := newClient(mockKubeController, fakeGatewayClient.NewSimpleClientset(), nil, nil)
+	a.Nil(err)
+
+	route := &gwv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "test"},
+		Spec: gwv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gwv1alpha2.CommonRouteSpec{ParentRefs: []gwv1alpha2.ParentRef{{Name: "gw"}}},
+		},
+	}
+	_ = c.caches.tlsRoute.Add(route)
+
+	gateway := &gwv1alpha2.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "test"}}
+	tlsListener := gwv1alpha2.Listener{Name: "tls", Port: 443, Protocol: gwv1alpha2.TLSProtocolType}
+	httpListener := gwv1alpha2.Listener{Name: "http", Port: 80, Protocol: gwv1alpha2.HTTPProtocolType}
+
+	a.Equal([]*gwv1alpha2.TLSRoute{route}, c.ListTLSRoutes(gateway, tlsListener))
+	a.Empty(c.ListTLSRoutes(gateway, httpListener))
+}
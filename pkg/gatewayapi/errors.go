@@ -0,0 +1,8 @@
+package gatewayapi
+
+import "github.com/pkg/errors"
+
+var (
+	errSyncingCaches = errors.New("Failed initial cache sync for Gateway API informers")
+	errInitInformers = errors.New("Gateway API informers not initialized")
+)
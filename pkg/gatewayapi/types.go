@@ -0,0 +1,74 @@
+// Package gatewayapi implements the Kubernetes client for the resources in the gateway.networking.k8s.io API group
+// (Kubernetes Gateway API) implemented by OSM.
+package gatewayapi
+
+import (
+	"k8s.io/client-go/tools/cache"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("gateway-api-controller")
+)
+
+const (
+	// ControllerName is the name of the controller implementing the GatewayClasses managed by OSM.
+	// Gateways referencing a GatewayClass with this controller name are programmed on the OSM ingress gateway.
+	ControllerName gwv1alpha2.GatewayController = "openservicemesh.io/gateway-controller"
+
+	// GroupName is the name of the Gateway API group
+	GroupName = "gateway.networking.k8s.io"
+
+	// KindGateway is the Gateway kind
+	KindGateway = "Gateway"
+
+	// KindHTTPRoute is the HTTPRoute kind
+	KindHTTPRoute = "HTTPRoute"
+
+	// KindTLSRoute is the TLSRoute kind
+	KindTLSRoute = "TLSRoute"
+
+	// KindService is the Service kind referenced by route backends
+	KindService = "Service"
+
+	// KindSecret is the Secret kind referenced by Gateway listener certificates
+	KindSecret = "Secret"
+)
+
+// informerCollection is the type used to represent the collection of informers for the gateway.networking.k8s.io API group
+type informerCollection struct {
+	gatewayClass cache.SharedIndexInformer
+	gateway      cache.SharedIndexInformer
+	httpRoute    cache.SharedIndexInformer
+	tlsRoute     cache.SharedIndexInformer
+}
+
+// cacheCollection is the type used to represent the collection of caches for the gateway.networking.k8s.io API group
+type cacheCollection struct {
+	gatewayClass cache.Store
+	gateway      cache.Store
+	httpRoute    cache.Store
+	tlsRoute     cache.Store
+}
+
+// client is the type used to represent the Kubernetes client for the gateway.networking.k8s.io API group
+type client struct {
+	informers      *informerCollection
+	caches         *cacheCollection
+	kubeController k8s.Controller
+}
+
+// Controller is the interface for the functionality provided by the resources part of the gateway.networking.k8s.io API group
+type Controller interface {
+	// ListGateways lists the Gateways in the monitored namespaces whose GatewayClass is managed by OSM
+	ListGateways() []*gwv1alpha2.Gateway
+
+	// ListHTTPRoutes lists the HTTPRoutes in the monitored namespaces attached to the given listener of the given Gateway
+	ListHTTPRoutes(*gwv1alpha2.Gateway, gwv1alpha2.Listener) []*gwv1alpha2.HTTPRoute
+
+	// ListTLSRoutes lists the TLSRoutes in the monitored namespaces attached to the given listener of the given Gateway
+	ListTLSRoutes(*gwv1alpha2.Gateway, gwv1alpha2.Listener) []*gwv1alpha2.TLSRoute
+}
@@ -25,6 +25,7 @@ type ConfigV1alpha2Interface interface {
 	RESTClient() rest.Interface
 	MeshConfigsGetter
 	MultiClusterServicesGetter
+	NamespaceConfigsGetter
 }
 
 // ConfigV1alpha2Client is used to interact with features provided by the config.openservicemesh.io group.
@@ -40,6 +41,10 @@ func (c *ConfigV1alpha2Client) MultiClusterServices(namespace string) MultiClust
 	return newMultiClusterServices(c, namespace)
 }
 
+func (c *ConfigV1alpha2Client) NamespaceConfigs(namespace string) NamespaceConfigInterface {
+	return newNamespaceConfigs(c, namespace)
+}
+
 // NewForConfig creates a new ConfigV1alpha2Client for the given config.
 func NewForConfig(c *rest.Config) (*ConfigV1alpha2Client, error) {
 	config := *c
@@ -33,6 +33,10 @@ func (c *FakeConfigV1alpha2) MultiClusterServices(namespace string) v1alpha2.Mul
 	return &FakeMultiClusterServices{c, namespace}
 }
 
+func (c *FakeConfigV1alpha2) NamespaceConfigs(namespace string) v1alpha2.NamespaceConfigInterface {
+	return &FakeNamespaceConfigs{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeConfigV1alpha2) RESTClient() rest.Interface {
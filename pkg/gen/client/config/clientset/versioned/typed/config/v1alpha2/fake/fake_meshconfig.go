@@ -99,6 +99,18 @@ func (c *FakeMeshConfigs) Update(ctx context.Context, meshConfig *v1alpha2.MeshC
 	return obj.(*v1alpha2.MeshConfig), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeMeshConfigs) UpdateStatus(ctx context.Context, meshConfig *v1alpha2.MeshConfig, opts v1.UpdateOptions) (*v1alpha2.MeshConfig, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(meshconfigsResource, "status", c.ns, meshConfig), &v1alpha2.MeshConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.MeshConfig), err
+}
+
 // Delete takes name of the meshConfig and deletes it. Returns an error if one occurs.
 func (c *FakeMeshConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
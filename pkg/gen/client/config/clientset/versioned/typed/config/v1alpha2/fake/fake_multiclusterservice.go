@@ -99,6 +99,18 @@ func (c *FakeMultiClusterServices) Update(ctx context.Context, multiClusterServi
 	return obj.(*v1alpha2.MultiClusterService), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeMultiClusterServices) UpdateStatus(ctx context.Context, multiClusterService *v1alpha2.MultiClusterService, opts v1.UpdateOptions) (*v1alpha2.MultiClusterService, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(multiclusterservicesResource, "status", c.ns, multiClusterService), &v1alpha2.MultiClusterService{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.MultiClusterService), err
+}
+
 // Delete takes name of the multiClusterService and deletes it. Returns an error if one occurs.
 func (c *FakeMultiClusterServices) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
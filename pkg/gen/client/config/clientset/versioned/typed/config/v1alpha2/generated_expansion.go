@@ -18,3 +18,5 @@ package v1alpha2
 type MeshConfigExpansion interface{}
 
 type MultiClusterServiceExpansion interface{}
+
+type NamespaceConfigExpansion interface{}
@@ -37,6 +37,7 @@ type MeshConfigsGetter interface {
 type MeshConfigInterface interface {
 	Create(ctx context.Context, meshConfig *v1alpha2.MeshConfig, opts v1.CreateOptions) (*v1alpha2.MeshConfig, error)
 	Update(ctx context.Context, meshConfig *v1alpha2.MeshConfig, opts v1.UpdateOptions) (*v1alpha2.MeshConfig, error)
+	UpdateStatus(ctx context.Context, meshConfig *v1alpha2.MeshConfig, opts v1.UpdateOptions) (*v1alpha2.MeshConfig, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha2.MeshConfig, error)
@@ -132,6 +133,22 @@ func (c *meshConfigs) Update(ctx context.Context, meshConfig *v1alpha2.MeshConfi
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *meshConfigs) UpdateStatus(ctx context.Context, meshConfig *v1alpha2.MeshConfig, opts v1.UpdateOptions) (result *v1alpha2.MeshConfig, err error) {
+	result = &v1alpha2.MeshConfig{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("meshconfigs").
+		Name(meshConfig.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(meshConfig).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the meshConfig and deletes it. Returns an error if one occurs.
 func (c *meshConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
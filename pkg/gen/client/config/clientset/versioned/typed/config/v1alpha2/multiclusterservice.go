@@ -37,6 +37,7 @@ type MultiClusterServicesGetter interface {
 type MultiClusterServiceInterface interface {
 	Create(ctx context.Context, multiClusterService *v1alpha2.MultiClusterService, opts v1.CreateOptions) (*v1alpha2.MultiClusterService, error)
 	Update(ctx context.Context, multiClusterService *v1alpha2.MultiClusterService, opts v1.UpdateOptions) (*v1alpha2.MultiClusterService, error)
+	UpdateStatus(ctx context.Context, multiClusterService *v1alpha2.MultiClusterService, opts v1.UpdateOptions) (*v1alpha2.MultiClusterService, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha2.MultiClusterService, error)
@@ -132,6 +133,22 @@ func (c *multiClusterServices) Update(ctx context.Context, multiClusterService *
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *multiClusterServices) UpdateStatus(ctx context.Context, multiClusterService *v1alpha2.MultiClusterService, opts v1.UpdateOptions) (result *v1alpha2.MultiClusterService, err error) {
+	result = &v1alpha2.MultiClusterService{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("multiclusterservices").
+		Name(multiClusterService.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(multiClusterService).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the multiClusterService and deletes it. Returns an error if one occurs.
 func (c *multiClusterServices) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
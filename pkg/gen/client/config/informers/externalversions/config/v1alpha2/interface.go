@@ -25,6 +25,8 @@ type Interface interface {
 	MeshConfigs() MeshConfigInformer
 	// MultiClusterServices returns a MultiClusterServiceInformer.
 	MultiClusterServices() MultiClusterServiceInformer
+	// NamespaceConfigs returns a NamespaceConfigInformer.
+	NamespaceConfigs() NamespaceConfigInformer
 }
 
 type version struct {
@@ -47,3 +49,8 @@ func (v *version) MeshConfigs() MeshConfigInformer {
 func (v *version) MultiClusterServices() MultiClusterServiceInformer {
 	return &multiClusterServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// NamespaceConfigs returns a NamespaceConfigInformer.
+func (v *version) NamespaceConfigs() NamespaceConfigInformer {
+	return &namespaceConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -61,6 +61,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().MeshConfigs().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("multiclusterservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().MultiClusterServices().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("namespaceconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().NamespaceConfigs().Informer()}, nil
 
 	}
 
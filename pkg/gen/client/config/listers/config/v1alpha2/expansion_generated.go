@@ -30,3 +30,11 @@ type MultiClusterServiceListerExpansion interface{}
 // MultiClusterServiceNamespaceListerExpansion allows custom methods to be added to
 // MultiClusterServiceNamespaceLister.
 type MultiClusterServiceNamespaceListerExpansion interface{}
+
+// NamespaceConfigListerExpansion allows custom methods to be added to
+// NamespaceConfigLister.
+type NamespaceConfigListerExpansion interface{}
+
+// NamespaceConfigNamespaceListerExpansion allows custom methods to be added to
+// NamespaceConfigNamespaceLister.
+type NamespaceConfigNamespaceListerExpansion interface{}
@@ -99,6 +99,18 @@ func (c *FakeEgresses) Update(ctx context.Context, egress *v1alpha1.Egress, opts
 	return obj.(*v1alpha1.Egress), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeEgresses) UpdateStatus(ctx context.Context, egress *v1alpha1.Egress, opts v1.UpdateOptions) (*v1alpha1.Egress, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(egressesResource, "status", c.ns, egress), &v1alpha1.Egress{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Egress), err
+}
+
 // Delete takes name of the egress and deletes it. Returns an error if one occurs.
 func (c *FakeEgresses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -29,6 +29,10 @@ func (c *FakePolicyV1alpha1) Egresses(namespace string) v1alpha1.EgressInterface
 	return &FakeEgresses{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) ExternalAuthorizations(namespace string) v1alpha1.ExternalAuthorizationInterface {
+	return &FakeExternalAuthorizations{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) IngressBackends(namespace string) v1alpha1.IngressBackendInterface {
 	return &FakeIngressBackends{c, namespace}
 }
@@ -37,6 +41,10 @@ func (c *FakePolicyV1alpha1) Retries(namespace string) v1alpha1.RetryInterface {
 	return &FakeRetries{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) SidecarScopes(namespace string) v1alpha1.SidecarScopeInterface {
+	return &FakeSidecarScopes{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) UpstreamTrafficSettings(namespace string) v1alpha1.UpstreamTrafficSettingInterface {
 	return &FakeUpstreamTrafficSettings{c, namespace}
 }
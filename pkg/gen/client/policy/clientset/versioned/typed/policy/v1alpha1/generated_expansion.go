@@ -17,8 +17,12 @@ package v1alpha1
 
 type EgressExpansion interface{}
 
+type ExternalAuthorizationExpansion interface{}
+
 type IngressBackendExpansion interface{}
 
 type RetryExpansion interface{}
 
+type SidecarScopeExpansion interface{}
+
 type UpstreamTrafficSettingExpansion interface{}
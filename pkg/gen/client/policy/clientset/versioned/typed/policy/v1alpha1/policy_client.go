@@ -24,8 +24,10 @@ import (
 type PolicyV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	EgressesGetter
+	ExternalAuthorizationsGetter
 	IngressBackendsGetter
 	RetriesGetter
+	SidecarScopesGetter
 	UpstreamTrafficSettingsGetter
 }
 
@@ -38,6 +40,10 @@ func (c *PolicyV1alpha1Client) Egresses(namespace string) EgressInterface {
 	return newEgresses(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) ExternalAuthorizations(namespace string) ExternalAuthorizationInterface {
+	return newExternalAuthorizations(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) IngressBackends(namespace string) IngressBackendInterface {
 	return newIngressBackends(c, namespace)
 }
@@ -46,6 +52,10 @@ func (c *PolicyV1alpha1Client) Retries(namespace string) RetryInterface {
 	return newRetries(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) SidecarScopes(namespace string) SidecarScopeInterface {
+	return newSidecarScopes(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) UpstreamTrafficSettings(namespace string) UpstreamTrafficSettingInterface {
 	return newUpstreamTrafficSettings(c, namespace)
 }
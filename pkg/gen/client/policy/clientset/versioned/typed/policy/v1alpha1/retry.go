@@ -37,6 +37,7 @@ type RetriesGetter interface {
 type RetryInterface interface {
 	Create(ctx context.Context, retry *v1alpha1.Retry, opts v1.CreateOptions) (*v1alpha1.Retry, error)
 	Update(ctx context.Context, retry *v1alpha1.Retry, opts v1.UpdateOptions) (*v1alpha1.Retry, error)
+	UpdateStatus(ctx context.Context, retry *v1alpha1.Retry, opts v1.UpdateOptions) (*v1alpha1.Retry, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Retry, error)
@@ -132,6 +133,22 @@ func (c *retries) Update(ctx context.Context, retry *v1alpha1.Retry, opts v1.Upd
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *retries) UpdateStatus(ctx context.Context, retry *v1alpha1.Retry, opts v1.UpdateOptions) (result *v1alpha1.Retry, err error) {
+	result = &v1alpha1.Retry{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("retries").
+		Name(retry.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(retry).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the retry and deletes it. Returns an error if one occurs.
 func (c *retries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -52,10 +52,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=policy.openservicemesh.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("egresses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Egresses().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("externalauthorizations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().ExternalAuthorizations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ingressbackends"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().IngressBackends().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("retries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Retries().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("sidecarscopes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().SidecarScopes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("upstreamtrafficsettings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().UpstreamTrafficSettings().Informer()}, nil
 
@@ -23,10 +23,14 @@ import (
 type Interface interface {
 	// Egresses returns a EgressInformer.
 	Egresses() EgressInformer
+	// ExternalAuthorizations returns a ExternalAuthorizationInformer.
+	ExternalAuthorizations() ExternalAuthorizationInformer
 	// IngressBackends returns a IngressBackendInformer.
 	IngressBackends() IngressBackendInformer
 	// Retries returns a RetryInformer.
 	Retries() RetryInformer
+	// SidecarScopes returns a SidecarScopeInformer.
+	SidecarScopes() SidecarScopeInformer
 	// UpstreamTrafficSettings returns a UpstreamTrafficSettingInformer.
 	UpstreamTrafficSettings() UpstreamTrafficSettingInformer
 }
@@ -47,6 +51,11 @@ func (v *version) Egresses() EgressInformer {
 	return &egressInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ExternalAuthorizations returns a ExternalAuthorizationInformer.
+func (v *version) ExternalAuthorizations() ExternalAuthorizationInformer {
+	return &externalAuthorizationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // IngressBackends returns a IngressBackendInformer.
 func (v *version) IngressBackends() IngressBackendInformer {
 	return &ingressBackendInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -57,6 +66,11 @@ func (v *version) Retries() RetryInformer {
 	return &retryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SidecarScopes returns a SidecarScopeInformer.
+func (v *version) SidecarScopes() SidecarScopeInformer {
+	return &sidecarScopeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // UpstreamTrafficSettings returns a UpstreamTrafficSettingInformer.
 func (v *version) UpstreamTrafficSettings() UpstreamTrafficSettingInformer {
 	return &upstreamTrafficSettingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
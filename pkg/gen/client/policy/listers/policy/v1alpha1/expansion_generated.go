@@ -23,6 +23,14 @@ type EgressListerExpansion interface{}
 // EgressNamespaceLister.
 type EgressNamespaceListerExpansion interface{}
 
+// ExternalAuthorizationListerExpansion allows custom methods to be added to
+// ExternalAuthorizationLister.
+type ExternalAuthorizationListerExpansion interface{}
+
+// ExternalAuthorizationNamespaceListerExpansion allows custom methods to be added to
+// ExternalAuthorizationNamespaceLister.
+type ExternalAuthorizationNamespaceListerExpansion interface{}
+
 // IngressBackendListerExpansion allows custom methods to be added to
 // IngressBackendLister.
 type IngressBackendListerExpansion interface{}
@@ -39,6 +47,14 @@ type RetryListerExpansion interface{}
 // RetryNamespaceLister.
 type RetryNamespaceListerExpansion interface{}
 
+// SidecarScopeListerExpansion allows custom methods to be added to
+// SidecarScopeLister.
+type SidecarScopeListerExpansion interface{}
+
+// SidecarScopeNamespaceListerExpansion allows custom methods to be added to
+// SidecarScopeNamespaceLister.
+type SidecarScopeNamespaceListerExpansion interface{}
+
 // UpstreamTrafficSettingListerExpansion allows custom methods to be added to
 // UpstreamTrafficSettingLister.
 type UpstreamTrafficSettingListerExpansion interface{}
@@ -1,7 +1,9 @@
 package ingress
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"reflect"
 	"time"
 
@@ -18,21 +20,29 @@ import (
 )
 
 // provisionIngressGatewayCert does the following:
-// 1. If an ingress gateway certificate spec is specified in the MeshConfig resource, issues a certificate
-//    for it and stores it in the referenced secret.
+// 1. If an ingress gateway certificate spec is specified in the MeshConfig resource, reuses the certificate
+//    stored in the referenced secret when it is still valid, otherwise issues a certificate for it and stores
+//    it in the referenced secret.
 // 2. Starts a goroutine to watch for changes to the MeshConfig resource and certificate rotation, and
 //    updates/removes the certificate and secret as necessary.
+//
+// It is run every time this replica becomes the leader, so the certificate issued by a previous leader is
+// reused instead of being reissued on every leadership change.
 func (c *client) provisionIngressGatewayCert(stop <-chan struct{}) error {
+	var renewAt time.Time
 	defaultCertSpec := c.cfg.GetMeshConfig().Spec.Certificate.IngressGateway
 	if defaultCertSpec != nil {
-		// Issue a certificate for the default certificate spec
-		if err := c.createAndStoreGatewayCert(*defaultCertSpec); err != nil {
+		if storedCert := c.getStoredGatewayCert(*defaultCertSpec); storedCert != nil {
+			log.Info().Msgf("Reusing ingress gateway certificate stored in secret %s/%s", defaultCertSpec.Secret.Namespace, defaultCertSpec.Secret.Name)
+			renewAt = storedCert.GetExpiration().Add(-certificate.RenewBeforeCertExpires)
+		} else if err := c.createAndStoreGatewayCert(*defaultCertSpec); err != nil {
+			// Issue a certificate for the default certificate spec
 			return errors.Wrap(err, "Error provisioning default ingress gateway cert")
 		}
 	}
 
 	// Initialize a watcher to watch for CREATE/UPDATE/DELETE on the ingress gateway cert spec
-	go c.handleCertificateChange(defaultCertSpec, stop)
+	go c.handleCertificateChange(defaultCertSpec, renewAt, stop)
 
 	return nil
 }
@@ -74,6 +84,55 @@ func (c *client) createAndStoreGatewayCert(spec configv1alpha2.IngressGatewayCer
 	return nil
 }
 
+// getStoredGatewayCert returns the certificate stored in the secret referenced by the given certificate spec
+// if it can be reused, i.e. it was issued for the spec's SAN by the current root certificate and is not due
+// for rotation. It returns nil otherwise.
+func (c *client) getStoredGatewayCert(spec configv1alpha2.IngressGatewayCertSpec) *certificate.Certificate {
+	if len(spec.SubjectAltNames) == 0 || spec.Secret.Name == "" || spec.Secret.Namespace == "" {
+		return nil
+	}
+
+	secret, err := c.kubeClient.CoreV1().Secrets(spec.Secret.Namespace).Get(context.Background(), spec.Secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+
+	pemCert := secret.Data[corev1.TLSCertKey]
+	pemKey := secret.Data[corev1.TLSPrivateKeyKey]
+	if _, err := tls.X509KeyPair(pemCert, pemKey); err != nil {
+		log.Debug().Err(err).Msgf("Secret %s/%s does not hold a valid key pair", spec.Secret.Namespace, spec.Secret.Name)
+		return nil
+	}
+
+	x509Cert, err := certificate.DecodePEMCertificate(pemCert)
+	if err != nil {
+		return nil
+	}
+
+	rootCert, err := c.certProvider.GetRootCertificate()
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting root certificate")
+		return nil
+	}
+	issuingCA := secret.Data["ca.crt"]
+	if !bytes.Equal(issuingCA, rootCert.GetCertificateChain()) {
+		return nil
+	}
+
+	cert := &certificate.Certificate{
+		CommonName: certificate.CommonName(x509Cert.Subject.CommonName),
+		CertChain:  pemCert,
+		PrivateKey: pemKey,
+		IssuingCA:  issuingCA,
+		Expiration: x509Cert.NotAfter,
+	}
+	if cert.GetCommonName() != certificate.CommonName(spec.SubjectAltNames[0]) || cert.ShouldRotate() {
+		return nil
+	}
+
+	return cert
+}
+
 // storeCertInSecret stores the certificate in the specified k8s TLS secret
 func (c *client) storeCertInSecret(cert *certificate.Certificate, secret corev1.SecretReference) error {
 	secretData := map[string][]byte{
@@ -99,8 +158,9 @@ func (c *client) storeCertInSecret(cert *certificate.Certificate, secret corev1.
 }
 
 // handleCertificateChange updates the gateway certificate and secret when the MeshConfig resource changes or
-// when the corresponding gateway certificate is rotated.
-func (c *client) handleCertificateChange(currentCertSpec *configv1alpha2.IngressGatewayCertSpec, stop <-chan struct{}) {
+// when the corresponding gateway certificate is rotated. A non-zero renewAt is the time at which a certificate
+// reused from the secret, which is not tracked by the certificate provider, must be renewed.
+func (c *client) handleCertificateChange(currentCertSpec *configv1alpha2.IngressGatewayCertSpec, renewAt time.Time, stop <-chan struct{}) {
 	kubePubSub := c.msgBroker.GetKubeEventPubSub()
 	meshConfigUpdateChan := kubePubSub.Sub(announcements.MeshConfigUpdated.String())
 	defer c.msgBroker.Unsub(kubePubSub, meshConfigUpdateChan)
@@ -109,6 +169,13 @@ func (c *client) handleCertificateChange(currentCertSpec *configv1alpha2.Ingress
 	certRotateChan := certPubSub.Sub(announcements.CertificateRotated.String())
 	defer c.msgBroker.Unsub(certPubSub, certRotateChan)
 
+	var renewChan <-chan time.Time
+	if !renewAt.IsZero() {
+		renewTimer := time.NewTimer(time.Until(renewAt))
+		defer renewTimer.Stop()
+		renewChan = renewTimer.C
+	}
+
 	for {
 		select {
 		// MeshConfig was updated
@@ -147,6 +214,19 @@ func (c *client) handleCertificateChange(currentCertSpec *configv1alpha2.Ingress
 				}
 			}
 			currentCertSpec = newCertSpec
+			// The certificate reused from the secret, if any, was replaced or removed
+			renewChan = nil
+
+		// The certificate reused from the secret is due for renewal
+		case <-renewChan:
+			renewChan = nil
+			if currentCertSpec == nil {
+				continue
+			}
+			log.Info().Msg("Ingress gateway certificate stored in secret is due for renewal, updating corresponding secret")
+			if err := c.createAndStoreGatewayCert(*currentCertSpec); err != nil {
+				log.Error().Err(err).Msgf("Error renewing ingress gateway cert secret")
+			}
 
 		// A certificate was rotated
 		case msg, ok := <-certRotateChan:
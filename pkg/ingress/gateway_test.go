@@ -133,6 +133,106 @@ func TestProvisionIngressGatewayCert(t *testing.T) {
 	}
 }
 
+func TestProvisionIngressGatewayCertReusesStoredCert(t *testing.T) {
+	testSecret := corev1.SecretReference{
+		Name:      "gateway-cert",
+		Namespace: "gateway-ns",
+	}
+	certSpec := configv1alpha2.IngressGatewayCertSpec{
+		SubjectAltNames:  []string{"foo.bar.cluster.local"},
+		ValidityDuration: "1h",
+		Secret:           testSecret,
+	}
+
+	testCases := []struct {
+		name            string
+		storedCertSpec  configv1alpha2.IngressGatewayCertSpec
+		storedByOtherCA bool
+		expectReuse     bool
+	}{
+		{
+			name:           "stored certificate is reused",
+			storedCertSpec: certSpec,
+			expectReuse:    true,
+		},
+		{
+			name:            "stored certificate issued by another CA is not reused",
+			storedCertSpec:  certSpec,
+			storedByOtherCA: true,
+			expectReuse:     false,
+		},
+		{
+			name: "stored certificate for another SAN is not reused",
+			storedCertSpec: configv1alpha2.IngressGatewayCertSpec{
+				SubjectAltNames:  []string{"baz.bar.cluster.local"},
+				ValidityDuration: "1h",
+				Secret:           testSecret,
+			},
+			expectReuse: false,
+		},
+		{
+			name: "stored certificate due for rotation is not reused",
+			storedCertSpec: configv1alpha2.IngressGatewayCertSpec{
+				SubjectAltNames:  []string{"foo.bar.cluster.local"},
+				ValidityDuration: "10s",
+				Secret:           testSecret,
+			},
+			expectReuse: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			stop := make(chan struct{})
+			defer close(stop)
+
+			msgBroker := messaging.NewBroker(stop)
+
+			fakeClient := fake.NewSimpleClientset()
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			fakeCertProvider := tresor.NewFakeCertManager(mockConfigurator)
+
+			// Store the certificate as a previous leader would have
+			storingCertProvider := fakeCertProvider
+			if tc.storedByOtherCA {
+				storingCertProvider = tresor.NewFakeCertManager(mockConfigurator)
+			}
+			previousLeader := client{
+				kubeClient:   fakeClient,
+				certProvider: storingCertProvider,
+			}
+			a.Nil(previousLeader.createAndStoreGatewayCert(tc.storedCertSpec))
+			storedSecret, err := fakeClient.CoreV1().Secrets(testSecret.Namespace).Get(context.TODO(), testSecret.Name, metav1.GetOptions{})
+			a.Nil(err)
+
+			c := client{
+				kubeClient:   fakeClient,
+				certProvider: fakeCertProvider,
+				cfg:          mockConfigurator,
+				msgBroker:    msgBroker,
+			}
+			mockConfigurator.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{
+				Spec: configv1alpha2.MeshConfigSpec{
+					Certificate: configv1alpha2.CertificateSpec{
+						IngressGateway: &certSpec,
+					},
+				},
+			}).Times(1)
+
+			a.Nil(c.provisionIngressGatewayCert(stop))
+
+			secret, err := fakeClient.CoreV1().Secrets(testSecret.Namespace).Get(context.TODO(), testSecret.Name, metav1.GetOptions{})
+			a.Nil(err)
+			a.Equal(tc.expectReuse, reflect.DeepEqual(storedSecret.Data, secret.Data))
+			a.True(secretIsForSAN(secret, certSpec.SubjectAltNames[0]))
+		})
+	}
+}
+
 func TestCreateAndStoreGatewayCert(t *testing.T) {
 	testSecret := corev1.SecretReference{
 		Name:      "gateway-cert",
@@ -334,7 +434,7 @@ func TestHandleCertificateChange(t *testing.T) {
 				msgBroker:    msgBroker,
 			}
 
-			go c.handleCertificateChange(tc.previousCertSpec, tc.stopChan)
+			go c.handleCertificateChange(tc.previousCertSpec, time.Time{}, tc.stopChan)
 			defer close(tc.stopChan)
 			time.Sleep(maxTimeToSubscribe)
 
@@ -411,3 +511,36 @@ func secretIsForSAN(secret *corev1.Secret, san string) bool {
 
 	return cert.GetCommonName().String() == san
 }
+
+func TestHandleCertificateChangeRenewsStoredCert(t *testing.T) {
+	a := assert.New(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	testSecret := corev1.SecretReference{
+		Name:      "gateway-cert",
+		Namespace: "gateway-ns",
+	}
+	certSpec := &configv1alpha2.IngressGatewayCertSpec{
+		SubjectAltNames:  []string{"foo.bar.cluster.local"},
+		ValidityDuration: "1h",
+		Secret:           testSecret,
+	}
+
+	fakeClient := fake.NewSimpleClientset()
+	c := client{
+		kubeClient:   fakeClient,
+		certProvider: tresor.NewFakeCertManager(nil),
+		msgBroker:    messaging.NewBroker(stop),
+	}
+
+	// The certificate reused from the secret is not tracked by the certificate provider, so it is
+	// renewed when due instead of on a rotation event
+	go c.handleCertificateChange(certSpec, time.Now().Add(100*time.Millisecond), stop)
+
+	a.Eventually(func() bool {
+		secret, err := fakeClient.CoreV1().Secrets(testSecret.Namespace).Get(context.TODO(), testSecret.Name, metav1.GetOptions{})
+		return err == nil && secretIsForSAN(secret, certSpec.SubjectAltNames[0])
+	}, maxSecretPollTime, secretPollInterval, "Certificate was not renewed")
+}
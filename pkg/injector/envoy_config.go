@@ -33,6 +33,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 		TLSMaxProtocolVersion: config.TLSMaxProtocolVersion,
 		CipherSuites:          config.CipherSuites,
 		ECDHCurves:            config.ECDHCurves,
+		StatsTags:             config.StatsTags,
 	})
 	if err != nil {
 		log.Error().Err(err).Msgf("Error building Envoy boostrap config")
@@ -58,15 +59,15 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 // getProbeResources returns the listener and cluster objects that are statically configured to serve
 // startup, readiness and liveness probes.
 // These will not change during the lifetime of the Pod.
-// If the original probe defined a TCPSocket action, listener and cluster objects are not configured
-// to serve that probe.
+// If the original probe defined a TCPSocket or gRPC action, listener and cluster objects are not configured
+// to serve that probe, since it is served by osm-healthcheck.
 func getProbeResources(config envoyBootstrapConfigMeta) ([]*xds_listener.Listener, []*xds_cluster.Cluster, error) {
 	// This slice is the list of listeners for liveness, readiness, startup IF these have been configured in the Pod Spec
 	var listeners []*xds_listener.Listener
 	var clusters []*xds_cluster.Cluster
 
 	// Is there a liveness probe in the Pod Spec?
-	if config.OriginalHealthProbes.liveness != nil && !config.OriginalHealthProbes.liveness.isTCPSocket {
+	if config.OriginalHealthProbes.liveness != nil && !config.OriginalHealthProbes.liveness.isTCPSocket && !config.OriginalHealthProbes.liveness.isGRPC {
 		listener, err := getLivenessListener(config.OriginalHealthProbes.liveness)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error getting liveness listener")
@@ -77,7 +78,7 @@ func getProbeResources(config envoyBootstrapConfigMeta) ([]*xds_listener.Listene
 	}
 
 	// Is there a readiness probe in the Pod Spec?
-	if config.OriginalHealthProbes.readiness != nil && !config.OriginalHealthProbes.readiness.isTCPSocket {
+	if config.OriginalHealthProbes.readiness != nil && !config.OriginalHealthProbes.readiness.isTCPSocket && !config.OriginalHealthProbes.readiness.isGRPC {
 		listener, err := getReadinessListener(config.OriginalHealthProbes.readiness)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error getting readiness listener")
@@ -88,7 +89,7 @@ func getProbeResources(config envoyBootstrapConfigMeta) ([]*xds_listener.Listene
 	}
 
 	// Is there a startup probe in the Pod Spec?
-	if config.OriginalHealthProbes.startup != nil && !config.OriginalHealthProbes.startup.isTCPSocket {
+	if config.OriginalHealthProbes.startup != nil && !config.OriginalHealthProbes.startup.isTCPSocket && !config.OriginalHealthProbes.startup.isGRPC {
 		listener, err := getStartupListener(config.OriginalHealthProbes.startup)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error getting startup listener")
@@ -101,7 +102,10 @@ func getProbeResources(config envoyBootstrapConfigMeta) ([]*xds_listener.Listene
 	return listeners, clusters, nil
 }
 
-func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert *certificate.Certificate, originalHealthProbes healthProbes) (*corev1.Secret, error) {
+func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert *certificate.Certificate, originalHealthProbes healthProbes, statsTags map[string]string) (*corev1.Secret, error) {
+	// The TLS configuration of the proxy can be overridden for the namespace
+	sidecarSpec := wh.configurator.ForNamespace(namespace).GetMeshConfig().Spec.Sidecar
+
 	configMeta := envoyBootstrapConfigMeta{
 		EnvoyAdminPort: constants.EnvoyAdminPort,
 		XDSClusterName: constants.OSMControllerName,
@@ -118,10 +122,12 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamesp
 		// defined on the Pod Spec.
 		OriginalHealthProbes: originalHealthProbes,
 
-		TLSMinProtocolVersion: wh.configurator.GetMeshConfig().Spec.Sidecar.TLSMinProtocolVersion,
-		TLSMaxProtocolVersion: wh.configurator.GetMeshConfig().Spec.Sidecar.TLSMaxProtocolVersion,
-		CipherSuites:          wh.configurator.GetMeshConfig().Spec.Sidecar.CipherSuites,
-		ECDHCurves:            wh.configurator.GetMeshConfig().Spec.Sidecar.ECDHCurves,
+		TLSMinProtocolVersion: sidecarSpec.TLSMinProtocolVersion,
+		TLSMaxProtocolVersion: sidecarSpec.TLSMaxProtocolVersion,
+		CipherSuites:          sidecarSpec.CipherSuites,
+		ECDHCurves:            sidecarSpec.ECDHCurves,
+
+		StatsTags: statsTags,
 	}
 	yamlContent, err := getEnvoyConfigYAML(configMeta, wh.configurator)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
 	xds_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 )
 
 const (
@@ -37,25 +38,27 @@ func getLivenessCluster(originalProbe *healthProbe) *xds_cluster.Cluster {
 	if originalProbe == nil {
 		return nil
 	}
-	return getProbeCluster(livenessCluster, originalProbe.port)
+	return getProbeCluster(livenessCluster, originalProbe.port, originalProbe.isHTTPS)
 }
 
 func getReadinessCluster(originalProbe *healthProbe) *xds_cluster.Cluster {
 	if originalProbe == nil {
 		return nil
 	}
-	return getProbeCluster(readinessCluster, originalProbe.port)
+	return getProbeCluster(readinessCluster, originalProbe.port, originalProbe.isHTTPS)
 }
 
 func getStartupCluster(originalProbe *healthProbe) *xds_cluster.Cluster {
 	if originalProbe == nil {
 		return nil
 	}
-	return getProbeCluster(startupCluster, originalProbe.port)
+	return getProbeCluster(startupCluster, originalProbe.port, originalProbe.isHTTPS)
 }
 
-func getProbeCluster(clusterName string, port int32) *xds_cluster.Cluster {
-	return &xds_cluster.Cluster{
+// getProbeCluster returns the cluster for the given probe port. If originateTLS is set, Envoy originates TLS to
+// the container without verifying its certificate, as the kubelet does for HTTPS probes.
+func getProbeCluster(clusterName string, port int32, originateTLS bool) *xds_cluster.Cluster {
+	cluster := &xds_cluster.Cluster{
 		Name: clusterName,
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
 			Type: xds_cluster.Cluster_STATIC,
@@ -87,6 +90,25 @@ func getProbeCluster(clusterName string, port int32) *xds_cluster.Cluster {
 			},
 		},
 	}
+
+	if originateTLS {
+		pbUpstreamTLSContext, err := anypb.New(&xds_auth.UpstreamTlsContext{
+			CommonTlsContext: &xds_auth.CommonTlsContext{},
+		})
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
+				Msgf("Error marshaling UpstreamTlsContext struct into an anypb.Any message")
+			return nil
+		}
+		cluster.TransportSocket = &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: pbUpstreamTLSContext,
+			},
+		}
+	}
+
+	return cluster
 }
 
 func getLivenessListener(originalProbe *healthProbe) (*xds_listener.Listener, error) {
@@ -112,7 +134,7 @@ func getStartupListener(originalProbe *healthProbe) (*xds_listener.Listener, err
 
 func getProbeListener(listenerName, clusterName, newPath string, port int32, originalProbe *healthProbe) (*xds_listener.Listener, error) {
 	var filterChain *xds_listener.FilterChain
-	if originalProbe.isHTTP {
+	if originalProbe.isHTTP || originalProbe.isHTTPS {
 		httpAccessLog, err := getHTTPAccessLog()
 		if err != nil {
 			return nil, err
@@ -20,6 +20,7 @@ var _ = ginkgo.Describe("Test functions creating Envoy config and rewriting the
 	livenessNonHTTP := &healthProbe{port: 81, isHTTP: false, isTCPSocket: false, timeout: timeout}
 	readiness := &healthProbe{path: "/readiness", port: 82, isHTTP: true, isTCPSocket: false, timeout: timeout}
 	startup := &healthProbe{path: "/startup", port: 83, isHTTP: true, isTCPSocket: false, timeout: timeout}
+	livenessHTTPS := &healthProbe{path: "/liveness", port: 81, isHTTPS: true, timeout: timeout}
 
 	// Listed below are the functions we are going to test.
 	// The key in the map is the name of the function -- must match what's in the value of the map.
@@ -31,10 +32,11 @@ var _ = ginkgo.Describe("Test functions creating Envoy config and rewriting the
 		"getVirtualHostsDefault": func() protoreflect.ProtoMessage {
 			return getVirtualHost("/some/path", "-cluster-name-", "/original/probe/path", 0*time.Second)
 		},
-		"getProbeCluster":     func() protoreflect.ProtoMessage { return getProbeCluster("cluster-name", 12341234) },
-		"getLivenessCluster":  func() protoreflect.ProtoMessage { return getLivenessCluster(liveness) },
-		"getReadinessCluster": func() protoreflect.ProtoMessage { return getReadinessCluster(readiness) },
-		"getStartupCluster":   func() protoreflect.ProtoMessage { return getStartupCluster(startup) },
+		"getProbeCluster":         func() protoreflect.ProtoMessage { return getProbeCluster("cluster-name", 12341234, false) },
+		"getLivenessCluster":      func() protoreflect.ProtoMessage { return getLivenessCluster(liveness) },
+		"getLivenessClusterHTTPS": func() protoreflect.ProtoMessage { return getLivenessCluster(livenessHTTPS) },
+		"getReadinessCluster":     func() protoreflect.ProtoMessage { return getReadinessCluster(readiness) },
+		"getStartupCluster":       func() protoreflect.ProtoMessage { return getStartupCluster(startup) },
 	}
 
 	listenerFunctionsToTest := map[string]func() (protoreflect.ProtoMessage, error){
@@ -43,6 +45,7 @@ var _ = ginkgo.Describe("Test functions creating Envoy config and rewriting the
 		"getProbeListener":           func() (protoreflect.ProtoMessage, error) { return getProbeListener("a", "b", "c", 9, liveness) },
 		"getLivenessListener":        func() (protoreflect.ProtoMessage, error) { return getLivenessListener(liveness) },
 		"getLivenessListenerNonHTTP": func() (protoreflect.ProtoMessage, error) { return getLivenessListener(livenessNonHTTP) },
+		"getLivenessListenerHTTPS":   func() (protoreflect.ProtoMessage, error) { return getLivenessListener(livenessHTTPS) },
 		"getReadinessListener":       func() (protoreflect.ProtoMessage, error) { return getReadinessListener(readiness) },
 		"getStartupListener":         func() (protoreflect.ProtoMessage, error) { return getStartupListener(startup) },
 	}
@@ -78,6 +78,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 	mockCtrl := gomock.NewController(GinkgoT())
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 	mockConfigurator.EXPECT().GetMeshConfig().Return(meshConfig).AnyTimes()
+	mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
 
 	originalHealthProbes := healthProbes{
 		liveness:  &healthProbe{path: "/liveness", port: 81},
@@ -162,7 +163,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 			namespace := "a"
 			osmNamespace := "b"
 
-			secret, err := wh.createEnvoyBootstrapConfig(name, namespace, osmNamespace, cert, probes)
+			secret, err := wh.createEnvoyBootstrapConfig(name, namespace, osmNamespace, cert, probes, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			expected := corev1.Secret{
@@ -217,7 +218,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 				configurator:        mockConfigurator,
 			}
 
-			secret, err := wh.createEnvoyBootstrapConfig(name, namespace, osmNamespace, cert, probes)
+			secret, err := wh.createEnvoyBootstrapConfig(name, namespace, osmNamespace, cert, probes, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			expected := corev1.Secret{
@@ -247,6 +248,18 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 			Expect(actualClusters).To(BeNil())
 		})
 
+		It("Should not create listeners and cluster for gRPC probes", func() {
+			config.OriginalHealthProbes = healthProbes{
+				liveness:  &healthProbe{port: 81, isGRPC: true},
+				readiness: &healthProbe{port: 82, isGRPC: true},
+				startup:   &healthProbe{port: 83, isGRPC: true},
+			}
+			actualListeners, actualClusters, err := getProbeResources(config)
+			Expect(err).To(BeNil())
+			Expect(actualListeners).To(BeNil())
+			Expect(actualClusters).To(BeNil())
+		})
+
 		It("Should not create listeners and cluster for TCPSocket probes", func() {
 			config.OriginalHealthProbes = healthProbes{
 				liveness:  &healthProbe{port: 81, isTCPSocket: true},
@@ -269,19 +282,21 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 
 	Context("test unix getEnvoySidecarContainerSpec()", func() {
 		It("creates Envoy sidecar spec", func() {
-			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("debug").Times(1)
 			mockConfigurator.EXPECT().GetEnvoyImage().Return(envoyImage).Times(1)
 			mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return(envoyImage).Times(0)
-			mockConfigurator.EXPECT().GetProxyResources().Return(corev1.ResourceRequirements{
-				// Test set Limits
-				Limits: map[corev1.ResourceName]resource.Quantity{
-					"cpu":    resource.MustParse("2"),
-					"memory": resource.MustParse("512M"),
+			sidecarCfg := sidecarConfig{
+				logLevel: "debug",
+				resources: corev1.ResourceRequirements{
+					// Test set Limits
+					Limits: map[corev1.ResourceName]resource.Quantity{
+						"cpu":    resource.MustParse("2"),
+						"memory": resource.MustParse("512M"),
+					},
+					// Test unset Requests
+					Requests: nil,
 				},
-				// Test unset Requests
-				Requests: nil,
-			}).Times(1)
-			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes, constants.OSLinux)
+			}
+			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes, constants.OSLinux, sidecarCfg)
 
 			expected := corev1.Container{
 				Name:            constants.EnvoyContainerName,
@@ -393,19 +408,21 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 
 	Context("test Windows getEnvoySidecarContainerSpec()", func() {
 		It("creates Envoy sidecar spec", func() {
-			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("debug").Times(1)
 			mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return(envoyImage).Times(1)
 			mockConfigurator.EXPECT().GetEnvoyImage().Return(envoyImage).Times(0)
-			mockConfigurator.EXPECT().GetProxyResources().Return(corev1.ResourceRequirements{
-				// Test set Limits
-				Limits: map[corev1.ResourceName]resource.Quantity{
-					"cpu":    resource.MustParse("2"),
-					"memory": resource.MustParse("512M"),
+			sidecarCfg := sidecarConfig{
+				logLevel: "debug",
+				resources: corev1.ResourceRequirements{
+					// Test set Limits
+					Limits: map[corev1.ResourceName]resource.Quantity{
+						"cpu":    resource.MustParse("2"),
+						"memory": resource.MustParse("512M"),
+					},
+					// Test unset Requests
+					Requests: nil,
 				},
-				// Test unset Requests
-				Requests: nil,
-			}).Times(1)
-			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes, constants.OSWindows)
+			}
+			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes, constants.OSWindows, sidecarCfg)
 
 			expected := corev1.Container{
 				Name:            constants.EnvoyContainerName,
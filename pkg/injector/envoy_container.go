@@ -2,8 +2,12 @@ package injector
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -13,6 +17,14 @@ import (
 const (
 	envoyBootstrapConfigFile = "bootstrap.yaml"
 	envoyProxyConfigPath     = "/etc/envoy"
+
+	// sidecarDrainDurationAnnotation is the annotation used to override the mesh-wide duration during which
+	// the Envoy sidecar gracefully drains its inbound listeners when the pod terminates
+	sidecarDrainDurationAnnotation = "openservicemesh.io/sidecar-drain-duration"
+
+	// sidecarShutdownSeconds is the time granted to the Envoy sidecar, on top of its drain duration, to exit
+	// before the kubelet kills the containers of the pod
+	sidecarShutdownSeconds = 5
 )
 
 func getPlatformSpecificSpecComponents(cfg configurator.Configurator, podOS string) (podSecurityContext *corev1.SecurityContext, envoyContainer string) {
@@ -38,12 +50,12 @@ func getPlatformSpecificSpecComponents(cfg configurator.Configurator, podOS stri
 	return
 }
 
-func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator, originalHealthProbes healthProbes, podOS string) corev1.Container {
+func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator, originalHealthProbes healthProbes, podOS string, sidecarCfg sidecarConfig) corev1.Container {
 	// cluster ID will be used as an identifier to the tracing sink
 	clusterID := fmt.Sprintf("%s.%s", pod.Spec.ServiceAccountName, pod.Namespace)
 	securityContext, containerImage := getPlatformSpecificSpecComponents(cfg, podOS)
 
-	return corev1.Container{
+	sidecar := corev1.Container{
 		Name:            constants.EnvoyContainerName,
 		Image:           containerImage,
 		ImagePullPolicy: corev1.PullIfNotPresent,
@@ -55,9 +67,9 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator
 			MountPath: envoyProxyConfigPath,
 		}},
 		Command:   []string{"envoy"},
-		Resources: cfg.GetProxyResources(),
+		Resources: sidecarCfg.resources,
 		Args: []string{
-			"--log-level", cfg.GetEnvoyLogLevel(),
+			"--log-level", sidecarCfg.logLevel,
 			"--config-path", strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
 			"--service-cluster", clusterID,
 		},
@@ -104,6 +116,73 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator
 			},
 		},
 	}
+
+	if sidecarCfg.concurrency > 0 {
+		sidecar.Args = append(sidecar.Args, "--concurrency", strconv.Itoa(sidecarCfg.concurrency))
+	}
+
+	if drainDuration := sidecarCfg.drainDuration; drainDuration > 0 {
+		sidecar.Args = append(sidecar.Args, "--drain-time-s", strconv.Itoa(getDrainTimeSeconds(drainDuration)))
+
+		// The drain is triggered using a shell command, which is not available in the Envoy Windows image.
+		// Linux sidecar images must provide 'sh' and 'wget', distroless images are not supported.
+		if !strings.EqualFold(podOS, constants.OSWindows) {
+			sidecar.Lifecycle = &corev1.Lifecycle{
+				PreStop: &corev1.Handler{
+					Exec: &corev1.ExecAction{
+						Command: getEnvoyDrainCommand(drainDuration),
+					},
+				},
+			}
+		}
+	}
+
+	return sidecar
+}
+
+// getSidecarDrainDuration returns the duration during which the Envoy sidecar of the given pod gracefully drains
+// its inbound listeners when the pod terminates. The pod annotation takes precedence over the mesh-wide setting.
+// An error is returned when the pod annotation has an invalid value.
+func getSidecarDrainDuration(pod *corev1.Pod, cfg configurator.Configurator) (time.Duration, error) {
+	value, ok := pod.Annotations[sidecarDrainDurationAnnotation]
+	if !ok {
+		return cfg.GetProxyDrainDuration(), nil
+	}
+
+	log.Trace().Msgf("Pod with UID %s has annotation: '%s:%s'", pod.UID, sidecarDrainDurationAnnotation, value)
+	drainDuration, err := time.ParseDuration(value)
+	if err != nil || drainDuration < 0 {
+		return 0, errors.Errorf("Invalid annotation value for key %q: %s", sidecarDrainDurationAnnotation, value)
+	}
+	return drainDuration, nil
+}
+
+// getEnvoyDrainCommand returns the command run before the Envoy sidecar is stopped. It gracefully drains the inbound
+// listeners, so that requests in flight complete and clients are told to close their connections, and waits for the
+// drain to complete. Outbound listeners are not drained so that the application can keep reaching its dependencies
+// while it completes its in-flight work.
+func getEnvoyDrainCommand(drainDuration time.Duration) []string {
+	return []string{"sh", "-c", fmt.Sprintf("wget -q -O /dev/null --post-data '' 'http://%s:%d/drain_listeners?graceful&inboundonly'; sleep %d",
+		constants.LocalhostIPAddress, constants.EnvoyAdminPort, getDrainTimeSeconds(drainDuration))}
+}
+
+// getDrainTimeSeconds returns the given drain duration rounded up to the second
+func getDrainTimeSeconds(drainDuration time.Duration) int {
+	return int(math.Ceil(drainDuration.Seconds()))
+}
+
+// setTerminationGracePeriod ensures the termination grace period of the given pod leaves the Envoy sidecar enough time
+// to drain its listeners and exit before the containers of the pod are killed
+func setTerminationGracePeriod(pod *corev1.Pod, drainDuration time.Duration) {
+	gracePeriod := int64(corev1.DefaultTerminationGracePeriodSeconds)
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = *pod.Spec.TerminationGracePeriodSeconds
+	}
+
+	// The preStop hook sleeps for the whole drain duration, the grace period must outlast it
+	if minGracePeriod := int64(getDrainTimeSeconds(drainDuration) + sidecarShutdownSeconds); gracePeriod < minGracePeriod {
+		pod.Spec.TerminationGracePeriodSeconds = &minGracePeriod
+	}
 }
 
 func getEnvoyContainerPorts(originalHealthProbes healthProbes) []corev1.ContainerPort {
@@ -0,0 +1,178 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetSidecarDrainDuration(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		annotations           map[string]string
+		expectedDrainDuration time.Duration
+		expectedErr           bool
+	}{
+		{
+			name:                  "mesh-wide drain duration is used when the pod is not annotated",
+			annotations:           nil,
+			expectedDrainDuration: 5 * time.Second,
+		},
+		{
+			name:                  "pod annotation overrides the mesh-wide drain duration",
+			annotations:           map[string]string{sidecarDrainDurationAnnotation: "45s"},
+			expectedDrainDuration: 45 * time.Second,
+		},
+		{
+			name:                  "pod annotation disables draining",
+			annotations:           map[string]string{sidecarDrainDurationAnnotation: "0s"},
+			expectedDrainDuration: 0,
+		},
+		{
+			name:        "invalid pod annotation value",
+			annotations: map[string]string{sidecarDrainDurationAnnotation: "invalid"},
+			expectedErr: true,
+		},
+		{
+			name:        "negative pod annotation value",
+			annotations: map[string]string{sidecarDrainDurationAnnotation: "-5s"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetProxyDrainDuration().Return(5 * time.Second).AnyTimes()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+
+			drainDuration, err := getSidecarDrainDuration(pod, mockConfigurator)
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expectedDrainDuration, drainDuration)
+		})
+	}
+}
+
+func TestGetEnvoySidecarContainerSpecDrain(t *testing.T) {
+	testCases := []struct {
+		name            string
+		podOS           string
+		drainDuration   time.Duration
+		expectedArgs    []string
+		expectedPreStop []string
+	}{
+		{
+			name:          "drain enabled on unix",
+			podOS:         constants.OSLinux,
+			drainDuration: 2500 * time.Millisecond,
+			expectedArgs:  []string{"--drain-time-s", "3"},
+			expectedPreStop: []string{"sh", "-c",
+				"wget -q -O /dev/null --post-data '' 'http://127.0.0.1:15000/drain_listeners?graceful&inboundonly'; sleep 3"},
+		},
+		{
+			name:          "drain enabled on windows",
+			podOS:         constants.OSWindows,
+			drainDuration: 5 * time.Second,
+			expectedArgs:  []string{"--drain-time-s", "5"},
+		},
+		{
+			name:          "drain disabled",
+			podOS:         constants.OSLinux,
+			drainDuration: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetEnvoyImage().Return("envoy-linux-image").AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows-image").AnyTimes()
+
+			sidecar := getEnvoySidecarContainerSpec(&corev1.Pod{}, mockConfigurator, healthProbes{}, tc.podOS, sidecarConfig{
+				logLevel:      "error",
+				drainDuration: tc.drainDuration,
+			})
+
+			// The drain arguments follow the log level, config path and service cluster arguments
+			assert.Equal(tc.expectedArgs, append([]string(nil), sidecar.Args[6:]...))
+			if tc.expectedPreStop == nil {
+				assert.Nil(sidecar.Lifecycle)
+				return
+			}
+			assert.Equal(tc.expectedPreStop, sidecar.Lifecycle.PreStop.Exec.Command)
+		})
+	}
+}
+
+func TestSetTerminationGracePeriod(t *testing.T) {
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	testCases := []struct {
+		name                string
+		gracePeriod         *int64
+		drainDuration       time.Duration
+		expectedGracePeriod *int64
+	}{
+		{
+			name:                "default grace period is long enough",
+			gracePeriod:         nil,
+			drainDuration:       5 * time.Second,
+			expectedGracePeriod: nil,
+		},
+		{
+			name:                "default grace period is too short",
+			gracePeriod:         nil,
+			drainDuration:       time.Minute,
+			expectedGracePeriod: int64Ptr(65),
+		},
+		{
+			name:                "pod grace period is too short",
+			gracePeriod:         int64Ptr(2),
+			drainDuration:       5 * time.Second,
+			expectedGracePeriod: int64Ptr(10),
+		},
+		{
+			name:                "pod grace period leaves no time to exit after the drain",
+			gracePeriod:         int64Ptr(60),
+			drainDuration:       time.Minute,
+			expectedGracePeriod: int64Ptr(65),
+		},
+		{
+			name:                "pod grace period is long enough",
+			gracePeriod:         int64Ptr(120),
+			drainDuration:       time.Minute,
+			expectedGracePeriod: int64Ptr(120),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					TerminationGracePeriodSeconds: tc.gracePeriod,
+				},
+			}
+
+			setTerminationGracePeriod(pod, tc.drainDuration)
+			assert.Equal(tc.expectedGracePeriod, pod.Spec.TerminationGracePeriodSeconds)
+		})
+	}
+}
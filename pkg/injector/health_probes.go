@@ -1,6 +1,7 @@
 package injector
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -19,6 +20,10 @@ const (
 	readinessProbePath = "/osm-readiness-probe"
 	startupProbePath   = "/osm-startup-probe"
 	healthcheckPath    = "/osm-healthcheck"
+
+	originalTCPPortHeader     = "Original-Tcp-Port"
+	originalGRPCPortHeader    = "Original-Grpc-Port"
+	originalGRPCServiceHeader = "Original-Grpc-Service"
 )
 
 var errNoMatchingPort = errors.New("no matching port")
@@ -32,8 +37,15 @@ type healthProbe struct {
 	// This helps inform what kind of Envoy config to add to the pod.
 	isHTTP bool
 
+	// isHTTPS corresponds to an httpGet probe with a scheme of HTTPS.
+	// Envoy originates TLS to the container for such probes.
+	isHTTPS bool
+
 	// isTCPSocket indicates if the probe defines a TCPSocketAction.
 	isTCPSocket bool
+
+	// isGRPC indicates if the probe defines a gRPC action.
+	isGRPC bool
 }
 
 // healthProbes is to serve as an indication whether the given healthProbe has been rewritten
@@ -41,35 +53,94 @@ type healthProbes struct {
 	liveness, readiness, startup *healthProbe
 }
 
-func rewriteHealthProbes(pod *corev1.Pod) healthProbes {
+// grpcAction is the gRPC action of a probe
+type grpcAction struct {
+	Port    int32   `json:"port"`
+	Service *string `json:"service,omitempty"`
+}
+
+// grpcProbes holds the gRPC actions of the probes of a container
+type grpcProbes struct {
+	liveness, readiness, startup *grpcAction
+}
+
+// getGRPCProbes returns the gRPC actions of the probes of the containers of the given Pod object, indexed by container.
+// The gRPC action is not part of the Probe type of the Kubernetes API version the injector is built with, so it is
+// dropped when the Pod is decoded and must be read from the raw object of the admission request.
+func getGRPCProbes(rawPod []byte) ([]grpcProbes, error) {
+	type probe struct {
+		GRPC *grpcAction `json:"grpc,omitempty"`
+	}
+	var pod struct {
+		Spec struct {
+			Containers []struct {
+				LivenessProbe  *probe `json:"livenessProbe,omitempty"`
+				ReadinessProbe *probe `json:"readinessProbe,omitempty"`
+				StartupProbe   *probe `json:"startupProbe,omitempty"`
+			} `json:"containers"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(rawPod, &pod); err != nil {
+		return nil, err
+	}
+
+	getAction := func(p *probe) *grpcAction {
+		if p == nil {
+			return nil
+		}
+		return p.GRPC
+	}
+	probes := make([]grpcProbes, len(pod.Spec.Containers))
+	for idx, container := range pod.Spec.Containers {
+		probes[idx] = grpcProbes{
+			liveness:  getAction(container.LivenessProbe),
+			readiness: getAction(container.ReadinessProbe),
+			startup:   getAction(container.StartupProbe),
+		}
+	}
+	return probes, nil
+}
+
+// rewriteHealthProbes rewrites the probes of the containers of the given pod to pass through Envoy or osm-healthcheck.
+// rawPod is the raw Pod object of the admission request, from which the gRPC actions of the probes are read.
+func rewriteHealthProbes(pod *corev1.Pod, rawPod []byte) healthProbes {
+	grpc, err := getGRPCProbes(rawPod)
+	if err != nil {
+		log.Error().Err(err).Msg("Error reading gRPC probes from the Pod object, gRPC probes will not be rewritten")
+	}
+
 	probes := healthProbes{}
 	for idx := range pod.Spec.Containers {
-		if probe := rewriteLiveness(&pod.Spec.Containers[idx]); probe != nil {
+		var containerGRPC grpcProbes
+		if idx < len(grpc) {
+			containerGRPC = grpc[idx]
+		}
+		if probe := rewriteLiveness(&pod.Spec.Containers[idx], containerGRPC.liveness); probe != nil {
 			probes.liveness = probe
 		}
-		if probe := rewriteReadiness(&pod.Spec.Containers[idx]); probe != nil {
+		if probe := rewriteReadiness(&pod.Spec.Containers[idx], containerGRPC.readiness); probe != nil {
 			probes.readiness = probe
 		}
-		if probe := rewriteStartup(&pod.Spec.Containers[idx]); probe != nil {
+		if probe := rewriteStartup(&pod.Spec.Containers[idx], containerGRPC.startup); probe != nil {
 			probes.startup = probe
 		}
 	}
 	return probes
 }
 
-func rewriteLiveness(container *corev1.Container) *healthProbe {
-	return rewriteProbe(container.LivenessProbe, "liveness", livenessProbePath, livenessProbePort, &container.Ports)
+func rewriteLiveness(container *corev1.Container, grpc *grpcAction) *healthProbe {
+	return rewriteProbe(container.LivenessProbe, grpc, "liveness", livenessProbePath, livenessProbePort, &container.Ports)
 }
 
-func rewriteReadiness(container *corev1.Container) *healthProbe {
-	return rewriteProbe(container.ReadinessProbe, "readiness", readinessProbePath, readinessProbePort, &container.Ports)
+func rewriteReadiness(container *corev1.Container, grpc *grpcAction) *healthProbe {
+	return rewriteProbe(container.ReadinessProbe, grpc, "readiness", readinessProbePath, readinessProbePort, &container.Ports)
 }
 
-func rewriteStartup(container *corev1.Container) *healthProbe {
-	return rewriteProbe(container.StartupProbe, "startup", startupProbePath, startupProbePort, &container.Ports)
+func rewriteStartup(container *corev1.Container, grpc *grpcAction) *healthProbe {
+	return rewriteProbe(container.StartupProbe, grpc, "startup", startupProbePath, startupProbePort, &container.Ports)
 }
 
-func rewriteProbe(probe *corev1.Probe, probeType, path string, port int32, containerPorts *[]corev1.ContainerPort) *healthProbe {
+func rewriteProbe(probe *corev1.Probe, grpc *grpcAction, probeType, path string, port int32, containerPorts *[]corev1.ContainerPort) *healthProbe {
 	if probe == nil {
 		return nil
 	}
@@ -80,11 +151,16 @@ func rewriteProbe(probe *corev1.Probe, probeType, path string, port int32, conta
 	if probe.HTTPGet != nil {
 		definedPort = &probe.HTTPGet.Port
 		originalProbe.isHTTP = len(probe.HTTPGet.Scheme) == 0 || probe.HTTPGet.Scheme == corev1.URISchemeHTTP
+		originalProbe.isHTTPS = probe.HTTPGet.Scheme == corev1.URISchemeHTTPS
 		originalProbe.path = probe.HTTPGet.Path
-		if originalProbe.isHTTP {
+		if originalProbe.isHTTP || originalProbe.isHTTPS {
 			probe.HTTPGet.Path = path
 			newPath = probe.HTTPGet.Path
 		}
+		if originalProbe.isHTTPS {
+			// Envoy originates TLS to the container, so the probe listener is served over plain HTTP
+			probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+		}
 	} else if probe.TCPSocket != nil {
 		// Transform the TCPSocket probe into a HttpGet probe
 		originalProbe.isTCPSocket = true
@@ -97,6 +173,17 @@ func rewriteProbe(probe *corev1.Probe, probeType, path string, port int32, conta
 		definedPort = &probe.HTTPGet.Port
 		port = healthcheckPort
 		probe.TCPSocket = nil
+	} else if grpc != nil {
+		// Transform the gRPC probe into a HttpGet probe, the gRPC health service is called by osm-healthcheck
+		originalProbe.isGRPC = true
+		probe.HTTPGet = &corev1.HTTPGetAction{
+			Port:        intstr.FromInt(int(grpc.Port)),
+			Path:        healthcheckPath,
+			HTTPHeaders: []corev1.HTTPHeader{},
+		}
+		newPath = probe.HTTPGet.Path
+		definedPort = &probe.HTTPGet.Port
+		port = healthcheckPort
 	} else {
 		return nil
 	}
@@ -107,7 +194,13 @@ func rewriteProbe(probe *corev1.Probe, probeType, path string, port int32, conta
 		log.Error().Err(err).Msgf("Error finding a matching port for %+v on container %+v", *definedPort, containerPorts)
 	}
 	if originalProbe.isTCPSocket {
-		probe.HTTPGet.HTTPHeaders = append(probe.HTTPGet.HTTPHeaders, corev1.HTTPHeader{Name: "Original-Tcp-Port", Value: fmt.Sprint(originalProbe.port)})
+		probe.HTTPGet.HTTPHeaders = append(probe.HTTPGet.HTTPHeaders, corev1.HTTPHeader{Name: originalTCPPortHeader, Value: fmt.Sprint(originalProbe.port)})
+	}
+	if originalProbe.isGRPC {
+		probe.HTTPGet.HTTPHeaders = append(probe.HTTPGet.HTTPHeaders, corev1.HTTPHeader{Name: originalGRPCPortHeader, Value: fmt.Sprint(originalProbe.port)})
+		if grpc.Service != nil {
+			probe.HTTPGet.HTTPHeaders = append(probe.HTTPGet.HTTPHeaders, corev1.HTTPHeader{Name: originalGRPCServiceHeader, Value: *grpc.Service})
+		}
 	}
 	*definedPort = intstr.IntOrString{Type: intstr.Int, IntVal: port}
 	originalProbe.timeout = time.Duration(probe.TimeoutSeconds) * time.Second
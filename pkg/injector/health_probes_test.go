@@ -1,6 +1,7 @@
 package injector
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -68,6 +69,17 @@ func TestRewriteProbe(t *testing.T) {
 		}
 	}
 
+	makeGRPCProbe := func() *v1.Probe {
+		// The gRPC action is read from the raw Pod object, the decoded probe has no handler
+		return &v1.Probe{
+			InitialDelaySeconds: 1,
+			TimeoutSeconds:      probeTimeoutSeconds,
+			PeriodSeconds:       3,
+			SuccessThreshold:    4,
+			FailureThreshold:    5,
+		}
+	}
+
 	makeOriginalTCPPortHeader := func(port int32) v1.HTTPHeader {
 		return v1.HTTPHeader{
 			Name:  "Original-Tcp-Port",
@@ -93,7 +105,7 @@ func TestRewriteProbe(t *testing.T) {
 	}
 
 	t.Run("rewriteHealthProbes", func(t *testing.T) {
-		actual := rewriteHealthProbes(pod)
+		actual := rewriteHealthProbes(pod, nil)
 		expected := healthProbes{
 			liveness: &healthProbe{
 				path:    "/b",
@@ -118,7 +130,7 @@ func TestRewriteProbe(t *testing.T) {
 	})
 
 	t.Run("rewriteLiveness", func(t *testing.T) {
-		actual := rewriteLiveness(container)
+		actual := rewriteLiveness(container, nil)
 		expected := &healthProbe{
 			path:    "/k/l/m",
 			port:    7890,
@@ -129,7 +141,7 @@ func TestRewriteProbe(t *testing.T) {
 	})
 
 	t.Run("rewriteReadiness", func(t *testing.T) {
-		actual := rewriteReadiness(container)
+		actual := rewriteReadiness(container, nil)
 		expected := &healthProbe{
 			path:    "/a/b/c",
 			port:    1234,
@@ -140,7 +152,7 @@ func TestRewriteProbe(t *testing.T) {
 	})
 
 	t.Run("rewriteStartup", func(t *testing.T) {
-		actual := rewriteStartup(container)
+		actual := rewriteStartup(container, nil)
 		expected := &healthProbe{
 			path:    "/x/y/z",
 			port:    3456,
@@ -151,13 +163,16 @@ func TestRewriteProbe(t *testing.T) {
 	})
 
 	t.Run("rewriteProbe", func(t *testing.T) {
+		grpcService := "-some-service-"
 		tests := []struct {
-			name         string
-			probe        *v1.Probe
-			newPath      string
-			originalPort int32
-			newPort      int32
-			expected     *healthProbe
+			name            string
+			probe           *v1.Probe
+			grpc            *grpcAction
+			newPath         string
+			originalPort    int32
+			newPort         int32
+			expected        *healthProbe
+			expectedHeaders []v1.HTTPHeader
 		}{
 			{
 				name:     "nil",
@@ -194,12 +209,13 @@ func TestRewriteProbe(t *testing.T) {
 			{
 				name:    "https",
 				probe:   makeHTTPSProbe("/x/y/z", 3456),
-				newPath: "/x/y/z",
+				newPath: "/x",
 				newPort: 3465,
 				expected: &healthProbe{
 					path:    "/x/y/z",
 					port:    3456,
 					isHTTP:  false,
+					isHTTPS: true,
 					timeout: probeTimeoutDuration,
 				},
 			},
@@ -215,6 +231,36 @@ func TestRewriteProbe(t *testing.T) {
 					isTCPSocket: true,
 					timeout:     probeTimeoutDuration,
 				},
+				expectedHeaders: []v1.HTTPHeader{makeOriginalTCPPortHeader(3456)},
+			},
+			{
+				name:    "grpc",
+				probe:   makeGRPCProbe(),
+				grpc:    &grpcAction{Port: 3456},
+				newPath: "/osm-healthcheck",
+				newPort: 15904,
+				expected: &healthProbe{
+					port:    3456,
+					isGRPC:  true,
+					timeout: probeTimeoutDuration,
+				},
+				expectedHeaders: []v1.HTTPHeader{{Name: "Original-Grpc-Port", Value: "3456"}},
+			},
+			{
+				name:    "grpc with service",
+				probe:   makeGRPCProbe(),
+				grpc:    &grpcAction{Port: 3456, Service: &grpcService},
+				newPath: "/osm-healthcheck",
+				newPort: 15904,
+				expected: &healthProbe{
+					port:    3456,
+					isGRPC:  true,
+					timeout: probeTimeoutDuration,
+				},
+				expectedHeaders: []v1.HTTPHeader{
+					{Name: "Original-Grpc-Port", Value: "3456"},
+					{Name: "Original-Grpc-Service", Value: "-some-service-"},
+				},
 			},
 		}
 
@@ -225,7 +271,7 @@ func TestRewriteProbe(t *testing.T) {
 				// probeType left blank here because its value is only logged.
 				// containerPorts are not defined here because it's only used
 				// in getPort(), which is tested below.
-				actual := rewriteProbe(test.probe, "", test.newPath, test.newPort, nil)
+				actual := rewriteProbe(test.probe, test.grpc, "", test.newPath, test.newPort, nil)
 				assert.Equal(test.expected, actual)
 
 				// Verify the probe was modified correctly
@@ -233,12 +279,13 @@ func TestRewriteProbe(t *testing.T) {
 					if test.probe.Handler.HTTPGet != nil {
 						assert.Equal(intstr.FromInt(int(test.newPort)), test.probe.Handler.HTTPGet.Port)
 						assert.Equal(test.newPath, test.probe.Handler.HTTPGet.Path)
+						// After rewrite the probes are served over plain HTTP
+						assert.NotEqual(v1.URISchemeHTTPS, test.probe.Handler.HTTPGet.Scheme)
 					}
 					// After rewrite there should be no TCPSocket probes
 					assert.Nil(test.probe.Handler.TCPSocket)
-					if actual != nil && actual.isTCPSocket {
-						expectedHeader := makeOriginalTCPPortHeader(test.originalPort)
-						assert.Contains(test.probe.Handler.HTTPGet.HTTPHeaders, expectedHeader)
+					if test.expectedHeaders != nil {
+						assert.Equal(test.expectedHeaders, test.probe.Handler.HTTPGet.HTTPHeaders)
 					}
 				}
 			})
@@ -246,6 +293,103 @@ func TestRewriteProbe(t *testing.T) {
 	})
 }
 
+func TestRewriteHealthProbesFromRawPod(t *testing.T) {
+	assert := tassert.New(t)
+
+	rawPod := []byte(`{
+		"spec": {
+			"containers": [
+				{
+					"name": "app",
+					"livenessProbe": {"grpc": {"port": 8080}, "timeoutSeconds": 1},
+					"readinessProbe": {"httpGet": {"path": "/ready", "port": 8443, "scheme": "HTTPS"}, "timeoutSeconds": 1},
+					"startupProbe": {"grpc": {"port": 8080, "service": "startup"}, "timeoutSeconds": 1}
+				}
+			]
+		}
+	}`)
+	pod := &v1.Pod{}
+	assert.Nil(json.Unmarshal(rawPod, pod))
+
+	actual := rewriteHealthProbes(pod, rawPod)
+	expected := healthProbes{
+		liveness:  &healthProbe{port: 8080, isGRPC: true, timeout: time.Second},
+		readiness: &healthProbe{path: "/ready", port: 8443, isHTTPS: true, timeout: time.Second},
+		startup:   &healthProbe{port: 8080, isGRPC: true, timeout: time.Second},
+	}
+	assert.Equal(expected, actual)
+
+	container := pod.Spec.Containers[0]
+	assert.Equal(&v1.HTTPGetAction{
+		Path:        healthcheckPath,
+		Port:        intstr.FromInt(int(healthcheckPort)),
+		HTTPHeaders: []v1.HTTPHeader{{Name: "Original-Grpc-Port", Value: "8080"}},
+	}, container.LivenessProbe.HTTPGet)
+	assert.Equal(&v1.HTTPGetAction{
+		Path:   readinessProbePath,
+		Port:   intstr.FromInt(int(readinessProbePort)),
+		Scheme: v1.URISchemeHTTP,
+	}, container.ReadinessProbe.HTTPGet)
+	assert.Equal(&v1.HTTPGetAction{
+		Path: healthcheckPath,
+		Port: intstr.FromInt(int(healthcheckPort)),
+		HTTPHeaders: []v1.HTTPHeader{
+			{Name: "Original-Grpc-Port", Value: "8080"},
+			{Name: "Original-Grpc-Service", Value: "startup"},
+		},
+	}, container.StartupProbe.HTTPGet)
+}
+
+func TestGetGRPCProbes(t *testing.T) {
+	service := "-some-service-"
+	tests := []struct {
+		name        string
+		rawPod      string
+		expected    []grpcProbes
+		expectedErr bool
+	}{
+		{
+			name:     "no containers",
+			rawPod:   `{"spec": {}}`,
+			expected: []grpcProbes{},
+		},
+		{
+			name:     "no gRPC probes",
+			rawPod:   `{"spec": {"containers": [{"livenessProbe": {"tcpSocket": {"port": 80}}}]}}`,
+			expected: []grpcProbes{{}},
+		},
+		{
+			name: "gRPC probes",
+			rawPod: `{"spec": {"containers": [
+				{"readinessProbe": {"tcpSocket": {"port": 80}}},
+				{"livenessProbe": {"grpc": {"port": 81}}, "readinessProbe": {"grpc": {"port": 82, "service": "-some-service-"}}, "startupProbe": {"grpc": {"port": 83}}}
+			]}}`,
+			expected: []grpcProbes{
+				{},
+				{
+					liveness:  &grpcAction{Port: 81},
+					readiness: &grpcAction{Port: 82, Service: &service},
+					startup:   &grpcAction{Port: 83},
+				},
+			},
+		},
+		{
+			name:        "invalid object",
+			rawPod:      `{"spec": []}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			actual, err := getGRPCProbes([]byte(test.rawPod))
+			assert.Equal(test.expectedErr, err != nil)
+			assert.Equal(test.expected, actual)
+		})
+	}
+}
+
 func TestGetPort(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -27,7 +27,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 		It("Creates init container without ip range exclusion list", func() {
 			mockConfigurator.EXPECT().GetInitContainerImage().Return(containerImage).Times(1)
 			privileged := privilegedFalse
-			actual := getInitContainerSpec(containerName, mockConfigurator, nil, nil, nil, nil, privileged, corev1.PullAlways)
+			actual := getInitContainerSpec(containerName, mockConfigurator, nil, nil, nil, nil, false, privileged, corev1.PullAlways)
 
 			expected := corev1.Container{
 				Name:            "-container-name-",
@@ -59,8 +59,33 @@ var iptablesInboundStaticRules = []string{
 	"-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT",
 }
 
+// iptablesDNSStaticRules is the list of iptables rules related to DNS query interception and redirection
+var iptablesDNSStaticRules = []string{
+	// Don't redirect the DNS queries of Envoy back to itself, Envoy resolves the queries it does not answer using the
+	// nameservers of the pod
+	fmt.Sprintf("-A OSM_PROXY_DNS_REDIRECT -m owner --uid-owner %d -j RETURN", constants.EnvoyUID),
+
+	// Redirects DNS queries hitting the OSM_PROXY_DNS_REDIRECT chain to Envoy's DNS listener port
+	fmt.Sprintf("-A OSM_PROXY_DNS_REDIRECT -p udp -j REDIRECT --to-port %d", constants.EnvoyDNSListenerPort),
+
+	// For outbound DNS queries jump from OUTPUT chain to OSM_PROXY_DNS_REDIRECT chain
+	"-A OUTPUT -p udp --dport 53 -j OSM_PROXY_DNS_REDIRECT",
+}
+
 // generateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection
-func generateIptablesCommands(outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int) string {
+func generateIptablesCommands(outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, enableDNSCapture bool) string {
+	rules := generateIptablesRules(outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, enableDNSCapture)
+
+	cmd := fmt.Sprintf(`iptables-restore --noflush <<EOF
+%s
+EOF
+`, rules)
+
+	return cmd
+}
+
+// generateIptablesRules generates the iptables rules, in the iptables-restore format, to set up sidecar interception and redirection
+func generateIptablesRules(outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, enableDNSCapture bool) string {
 	var rules strings.Builder
 
 	fmt.Fprintln(&rules, `# OSM sidecar interception rules
@@ -69,6 +94,9 @@ func generateIptablesCommands(outboundIPRangeExclusionList []string, outboundIPR
 :OSM_PROXY_IN_REDIRECT - [0:0]
 :OSM_PROXY_OUTBOUND - [0:0]
 :OSM_PROXY_OUT_REDIRECT - [0:0]`)
+	if enableDNSCapture {
+		fmt.Fprintln(&rules, ":OSM_PROXY_DNS_REDIRECT - [0:0]")
+	}
 	var cmds []string
 
 	// 1. Create inbound rules
@@ -119,6 +147,11 @@ func generateIptablesCommands(outboundIPRangeExclusionList []string, outboundIPR
 			rule := fmt.Sprintf("-A OSM_PROXY_OUTBOUND -d %s -j OSM_PROXY_OUT_REDIRECT", cidr)
 			cmds = append(cmds, rule)
 		}
+		if enableDNSCapture {
+			// The virtual IPs of the hosts of Egress policies answered by the DNS proxy are only routable by the proxy
+			rule := fmt.Sprintf("-A OSM_PROXY_OUTBOUND -d %s -j OSM_PROXY_OUT_REDIRECT", constants.EgressVirtualIPRange)
+			cmds = append(cmds, rule)
+		}
 		// Remaining traffic not belonging to specified inclusion IP ranges are not redirected
 		cmds = append(cmds, "-A OSM_PROXY_OUTBOUND -j RETURN")
 	} else {
@@ -126,16 +159,16 @@ func generateIptablesCommands(outboundIPRangeExclusionList []string, outboundIPR
 		cmds = append(cmds, "-A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT")
 	}
 
+	// 7. Create DNS rules
+	if enableDNSCapture {
+		cmds = append(cmds, iptablesDNSStaticRules...)
+	}
+
 	for _, rule := range cmds {
 		fmt.Fprintln(&rules, rule)
 	}
 
 	fmt.Fprint(&rules, "COMMIT")
 
-	cmd := fmt.Sprintf(`iptables-restore --noflush <<EOF
-%s
-EOF
-`, rules.String())
-
-	return cmd
+	return rules.String()
 }
@@ -13,6 +13,7 @@ func TestGenerateIptablesCommands(t *testing.T) {
 		outboundIPRangeInclusions []string
 		outboundPortExclusions    []int
 		inboundPortExclusions     []int
+		enableDNSCapture          bool
 		expected                  string
 	}{
 		{
@@ -81,6 +82,43 @@ EOF
 -A OSM_PROXY_OUTBOUND -j RETURN
 COMMIT
 EOF
+`,
+		},
+		{
+			name:                      "with DNS capture",
+			outboundIPRangeInclusions: []string{"3.3.3.3/32"},
+			enableDNSCapture:          true,
+			expected: `iptables-restore --noflush <<EOF
+# OSM sidecar interception rules
+*nat
+:OSM_PROXY_INBOUND - [0:0]
+:OSM_PROXY_IN_REDIRECT - [0:0]
+:OSM_PROXY_OUTBOUND - [0:0]
+:OSM_PROXY_OUT_REDIRECT - [0:0]
+:OSM_PROXY_DNS_REDIRECT - [0:0]
+-A OSM_PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port 15003
+-A PREROUTING -p tcp -j OSM_PROXY_INBOUND
+-A OSM_PROXY_INBOUND -p tcp --dport 15010 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15901 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15902 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15903 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15904 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port 15001
+-A OSM_PROXY_OUT_REDIRECT -p tcp --dport 15000 -j ACCEPT
+-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND
+-A OSM_PROXY_OUTBOUND -o lo ! -d 127.0.0.1/32 -m owner --uid-owner 1500 -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -d 127.0.0.1/32 -j RETURN
+-A OSM_PROXY_OUTBOUND -d 3.3.3.3/32 -j OSM_PROXY_OUT_REDIRECT
+-A OSM_PROXY_OUTBOUND -d 240.240.0.0/16 -j OSM_PROXY_OUT_REDIRECT
+-A OSM_PROXY_OUTBOUND -j RETURN
+-A OSM_PROXY_DNS_REDIRECT -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_DNS_REDIRECT -p udp -j REDIRECT --to-port 15053
+-A OUTPUT -p udp --dport 53 -j OSM_PROXY_DNS_REDIRECT
+COMMIT
+EOF
 `,
 		},
 	}
@@ -88,7 +126,7 @@ EOF
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			actual := generateIptablesCommands(tc.outboundIPRangeExclusions, tc.outboundIPRangeInclusions, tc.outboundPortExclusions, tc.inboundPortExclusions)
+			actual := generateIptablesCommands(tc.outboundIPRangeExclusions, tc.outboundIPRangeInclusions, tc.outboundPortExclusions, tc.inboundPortExclusions, tc.enableDNSCapture)
 			a.Equal(tc.expected, actual)
 		})
 	}
@@ -15,6 +15,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/errcode"
@@ -24,6 +26,16 @@ import (
 func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.AdmissionRequest, proxyUUID uuid.UUID) ([]byte, error) {
 	namespace := req.Namespace
 
+	// Resolve the effective configuration for the pod's namespace
+	cfg := wh.configurator.ForNamespace(namespace)
+
+	// Validate the sidecar annotations of the pod before making any change
+	sidecarCfg, err := getSidecarConfig(pod, cfg)
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid sidecar annotations on pod: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
+		return nil, err
+	}
+
 	// Issue a certificate for the proxy sidecar - used for Envoy to connect to XDS (not Envoy-to-Envoy connections)
 	cn := envoy.NewXDSCertCommonName(proxyUUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, namespace)
 	log.Debug().Msgf("Patching POD spec: service-account=%s, namespace=%s with certificate CN=%s", pod.Spec.ServiceAccountName, namespace, cn)
@@ -38,7 +50,7 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	metricsstore.DefaultMetricsStore.CertIssuedCount.Inc()
 	metricsstore.DefaultMetricsStore.CertIssuedTime.
 		WithLabelValues().Observe(elapsed.Seconds())
-	originalHealthProbes := rewriteHealthProbes(pod)
+	originalHealthProbes := rewriteHealthProbes(pod, req.Object.Raw)
 
 	// Create the bootstrap configuration for the Envoy proxy for the given pod
 	envoyBootstrapConfigName := fmt.Sprintf("envoy-bootstrap-config-%s", proxyUUID)
@@ -49,7 +61,7 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	// Ref: https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/#side-effects
 	if req.DryRun != nil && *req.DryRun {
 		log.Debug().Msgf("Skipping envoy bootstrap config creation for dry-run request: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
-	} else if _, err = wh.createEnvoyBootstrapConfig(envoyBootstrapConfigName, namespace, wh.osmNamespace, bootstrapCertificate, originalHealthProbes); err != nil {
+	} else if _, err = wh.createEnvoyBootstrapConfig(envoyBootstrapConfigName, namespace, wh.osmNamespace, bootstrapCertificate, originalHealthProbes, sidecarCfg.statsTags); err != nil {
 		log.Error().Err(err).Msgf("Failed to create Envoy bootstrap config for pod: service-account=%s, namespace=%s, certificate CN=%s", pod.Spec.ServiceAccountName, namespace, cn)
 		return nil, err
 	}
@@ -70,9 +82,9 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 		return nil, err
 	}
 
-	if (originalHealthProbes.liveness != nil && originalHealthProbes.liveness.isTCPSocket) ||
-		(originalHealthProbes.readiness != nil && originalHealthProbes.readiness.isTCPSocket) ||
-		(originalHealthProbes.startup != nil && originalHealthProbes.startup.isTCPSocket) {
+	if (originalHealthProbes.liveness != nil && (originalHealthProbes.liveness.isTCPSocket || originalHealthProbes.liveness.isGRPC)) ||
+		(originalHealthProbes.readiness != nil && (originalHealthProbes.readiness.isTCPSocket || originalHealthProbes.readiness.isGRPC)) ||
+		(originalHealthProbes.startup != nil && (originalHealthProbes.startup.isTCPSocket || originalHealthProbes.startup.isGRPC)) {
 		healthcheckContainer := corev1.Container{
 			Name:            "osm-healthcheck",
 			Image:           os.Getenv("OSM_DEFAULT_HEALTHCHECK_CONTAINER_IMAGE"),
@@ -93,8 +105,25 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	}
 
 	// Add the Envoy sidecar
-	sidecar := getEnvoySidecarContainerSpec(pod, wh.configurator, originalHealthProbes, podOS)
-	pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	sidecar := getEnvoySidecarContainerSpec(pod, cfg, originalHealthProbes, podOS, sidecarCfg)
+	if sidecar.Lifecycle != nil && sidecar.Lifecycle.PreStop != nil {
+		setTerminationGracePeriod(pod, sidecarCfg.drainDuration)
+	}
+	holdApplication, err := isHoldApplicationUntilProxyStartsEnabled(pod, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if holdApplication && strings.EqualFold(podOS, constants.OSWindows) {
+		// The readiness of the sidecar is checked using a shell command, which is not available in the Envoy Windows image
+		log.Warn().Msgf("Holding the application until the sidecar is ready is not supported on Windows, ignoring for pod: service-account=%s, namespace=%s",
+			pod.Spec.ServiceAccountName, namespace)
+		holdApplication = false
+	}
+	if holdApplication {
+		holdApplicationUntilProxyStarts(pod, sidecar)
+	} else {
+		pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	}
 
 	enableMetrics, err := wh.isMetricsEnabled(namespace)
 	if err != nil {
@@ -145,48 +174,40 @@ func (wh *mutatingWebhook) verifyPrerequisites(podOS string) error {
 func (wh *mutatingWebhook) configurePodInit(podOS string, pod *corev1.Pod, namespace string) error {
 	if strings.EqualFold(podOS, constants.OSWindows) {
 		// No init container for Windows
+		recordDNSCapture(pod, false)
 		return nil
 	}
 
-	// Build outbound port exclusion list
-	podOutboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, outboundPortExclusionListAnnotation)
-	if err != nil {
-		return err
-	}
-	globalOutboundPortExclusionList := wh.configurator.GetMeshConfig().Spec.Traffic.OutboundPortExclusionList
-	outboundPortExclusionList := mergePortExclusionLists(podOutboundPortExclusionList, globalOutboundPortExclusionList)
-
-	// Build inbound port exclusion list
-	podInboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, inboundPortExclusionListAnnotation)
-	if err != nil {
-		return err
-	}
-	globalInboundPortExclusionList := wh.configurator.GetMeshConfig().Spec.Traffic.InboundPortExclusionList
-	inboundPortExclusionList := mergePortExclusionLists(podInboundPortExclusionList, globalInboundPortExclusionList)
-
-	// Build the outbound IP range exclusion list
-	podOutboundIPRangeExclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeExclusionListAnnotation)
+	// Build the exclusion and inclusion lists, which also validates the traffic redirection annotations of the pod
+	redirection, err := getTrafficRedirectionConfig(pod, namespace, wh.configurator.GetMeshConfig().Spec)
 	if err != nil {
 		return err
 	}
-	globalOutboundIPRangeExclusionList := wh.configurator.GetMeshConfig().Spec.Traffic.OutboundIPRangeExclusionList
-	outboundIPRangeExclusionList := mergeIPRangeLists(podOutboundIPRangeExclusionList, globalOutboundIPRangeExclusionList)
+	recordDNSCapture(pod, redirection.enableDNSCapture)
 
-	// Build the outbound IP range inclusion list
-	podOutboundIPRangeInclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeInclusionListAnnotation)
-	if err != nil {
-		return err
+	if wh.configurator.GetMeshConfig().Spec.Sidecar.TrafficRedirectionMode == configv1alpha2.TrafficRedirectionModeCNI {
+		// The traffic redirection rules are programmed by the osm-cni plugin when the network of the pod is set up
+		log.Debug().Msgf("Skipping init container for pod with CNI traffic redirection: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
+		return nil
 	}
-	globalOutboundIPRangeInclusionList := wh.configurator.GetMeshConfig().Spec.Traffic.OutboundIPRangeInclusionList
-	outboundIPRangeInclusionList := mergeIPRangeLists(podOutboundIPRangeInclusionList, globalOutboundIPRangeInclusionList)
 
 	// Add the init container to the pod spec
-	initContainer := getInitContainerSpec(constants.InitContainerName, wh.configurator, outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, wh.configurator.IsPrivilegedInitContainer(), wh.osmContainerPullPolicy)
+	initContainer := getInitContainerSpec(constants.InitContainerName, wh.configurator, redirection.outboundIPRangeExclusionList, redirection.outboundIPRangeInclusionList,
+		redirection.outboundPortExclusionList, redirection.inboundPortExclusionList, redirection.enableDNSCapture, wh.configurator.IsPrivilegedInitContainer(), wh.osmContainerPullPolicy)
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
 
 	return nil
 }
 
+// recordDNSCapture records on the given pod whether its DNS queries are redirected to its sidecar, so that the
+// DNS listener of the sidecar is programmed for as long as the pod redirects its DNS queries
+func recordDNSCapture(pod *corev1.Pod, enabled bool) {
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[constants.DNSCaptureAnnotation] = strconv.FormatBool(enabled)
+}
+
 func makePatches(req *admissionv1.AdmissionRequest, pod *corev1.Pod) []jsonpatch.JsonPatchOperation {
 	original := req.Object.Raw
 	current, err := json.Marshal(pod)
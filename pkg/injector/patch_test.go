@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/golang/mock/gomock"
@@ -59,6 +60,9 @@ func TestCreatePatch(t *testing.T) {
 				// Add Envoy Container
 				`"path":"/spec/containers"`,
 				`"command":["envoy"]`,
+				// Drain the Envoy sidecar on termination
+				`"--drain-time-s","5"`,
+				`"preStop":{"exec":{"command":["sh","-c","wget -q -O /dev/null --post-data '' 'http://127.0.0.1:15000/drain_listeners?graceful\u0026inboundonly'; sleep 5"]}}`,
 			},
 		},
 		{
@@ -94,9 +98,9 @@ func TestCreatePatch(t *testing.T) {
 				// Add Envoy UID Label
 				`"path":"/metadata/labels"`,
 				fmt.Sprintf(`"value":{"osm-proxy-uuid":"%v"`, proxyUUID),
-				// Add metrics Annotations
+				// Add DNS capture and metrics Annotations
 				`"path":"/metadata/annotations"`,
-				`"value":{"prometheus.io/path":"/stats/prometheus","prometheus.io/port":"15010","prometheus.io/scrape":"true"}`,
+				`"value":{"openservicemesh.io/dns-capture":"false","prometheus.io/path":"/stats/prometheus","prometheus.io/port":"15010","prometheus.io/scrape":"true"}`,
 				// Add Volumes
 				`"path":"/spec/volumes"`,
 				fmt.Sprintf(`"value":[{"name":"envoy-bootstrap-config-volume","secret":{"secretName":"envoy-bootstrap-config-%v"}}]}`, proxyUUID),
@@ -127,6 +131,7 @@ func TestCreatePatch(t *testing.T) {
 			client := fake.NewSimpleClientset()
 			mockCtrl := gomock.NewController(t)
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
 			mockNsController := k8s.NewMockController(mockCtrl)
 			mockNsController.EXPECT().GetNamespace(namespace).Return(tc.namespace)
 			_, err := client.CoreV1().Namespaces().Create(context.TODO(), tc.namespace, metav1.CreateOptions{})
@@ -151,6 +156,7 @@ func TestCreatePatch(t *testing.T) {
 			mockConfigurator.EXPECT().GetMeshConfig().AnyTimes()
 			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
 			mockConfigurator.EXPECT().GetProxyResources().Return(corev1.ResourceRequirements{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainDuration().Return(5 * time.Second).Times(1)
 			mockConfigurator.EXPECT().GetCertKeyBitSize().Return(2048).AnyTimes()
 
 			pod := tests.NewOsSpecificPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil, tc.os)
@@ -180,6 +186,7 @@ func TestCreatePatch(t *testing.T) {
 		client := fake.NewSimpleClientset()
 		mockCtrl := gomock.NewController(t)
 		mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
 		mockNsController := k8s.NewMockController(mockCtrl)
 
 		wh := &mutatingWebhook{
@@ -192,6 +199,9 @@ func TestCreatePatch(t *testing.T) {
 
 		mockConfigurator.EXPECT().GetEnvoyImage().Return("")
 		mockConfigurator.EXPECT().GetMeshConfig().AnyTimes()
+		mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("")
+		mockConfigurator.EXPECT().GetProxyResources().Return(corev1.ResourceRequirements{})
+		mockConfigurator.EXPECT().GetProxyDrainDuration().Return(5 * time.Second)
 
 		pod := tests.NewOsSpecificPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil, constants.OSLinux)
 
@@ -205,6 +215,37 @@ func TestCreatePatch(t *testing.T) {
 		_, err = wh.createPatch(&pod, req, proxyUUID)
 		assert.Error(err)
 	})
+
+	t.Run("invalid sidecar annotation", func(t *testing.T) {
+		assert := tassert.New(t)
+		mockCtrl := gomock.NewController(t)
+		mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().ForNamespace(gomock.Any()).Return(mockConfigurator).AnyTimes()
+
+		wh := &mutatingWebhook{
+			kubeClient:          fake.NewSimpleClientset(),
+			kubeController:      k8s.NewMockController(mockCtrl),
+			certManager:         tresor.NewFakeCertManager(mockConfigurator),
+			configurator:        mockConfigurator,
+			nonInjectNamespaces: mapset.NewSet(),
+		}
+
+		mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("")
+
+		pod := tests.NewOsSpecificPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil, constants.OSLinux)
+		pod.Annotations = map[string]string{sidecarLogLevelAnnotation: "verbose"}
+
+		raw, err := json.Marshal(pod)
+		assert.NoError(err)
+
+		req := &admissionv1.AdmissionRequest{
+			Namespace: namespace,
+			Object:    runtime.RawExtension{Raw: raw},
+		}
+		_, err = wh.createPatch(&pod, req, proxyUUID)
+		assert.Error(err)
+		assert.Contains(err.Error(), sidecarLogLevelAnnotation)
+	})
 }
 
 func TestVerifyPrerequisites(t *testing.T) {
@@ -0,0 +1,98 @@
+package injector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// holdApplicationUntilProxyStartsAnnotation is the annotation used to override the mesh-wide setting holding
+	// the start of the application containers until the Envoy sidecar is ready
+	holdApplicationUntilProxyStartsAnnotation = "openservicemesh.io/hold-application-until-proxy-starts"
+
+	// envoyReadyPath is the path on Envoy's admin interface reporting whether Envoy is ready to serve traffic
+	envoyReadyPath = "/ready"
+
+	// envoyReadyState is the state reported on Envoy's ready path once Envoy has applied its initial configuration
+	envoyReadyState = "LIVE"
+
+	// envoyReadinessProbePeriodSeconds is the period of the Envoy sidecar readiness probe
+	envoyReadinessProbePeriodSeconds = 2
+
+	// envoyReadyWaitTimeoutSeconds is the maximum duration the postStart hook waits for Envoy to be ready.
+	// The sidecar probes do not run until the hook completes, so the hook fails past this duration to have
+	// the kubelet restart the sidecar instead of holding the pod forever.
+	envoyReadyWaitTimeoutSeconds = 120
+)
+
+// isHoldApplicationUntilProxyStartsEnabled returns true if the start of the application containers of the given pod
+// must be held until the Envoy sidecar is ready. The pod annotation takes precedence over the mesh-wide setting.
+// An error is returned when the pod annotation has an invalid value.
+func isHoldApplicationUntilProxyStartsEnabled(pod *corev1.Pod, cfg configurator.Configurator) (bool, error) {
+	value, ok := pod.Annotations[holdApplicationUntilProxyStartsAnnotation]
+	if !ok {
+		return cfg.GetMeshConfig().Spec.Sidecar.HoldApplicationUntilProxyStarts, nil
+	}
+
+	log.Trace().Msgf("Pod with UID %s has annotation: '%s:%s'", pod.UID, holdApplicationUntilProxyStartsAnnotation, value)
+	switch strings.ToLower(value) {
+	case "enabled", "yes", "true":
+		return true, nil
+	case "disabled", "no", "false":
+		return false, nil
+	default:
+		return false, errors.Errorf("Invalid annotation value for key %q: %s", holdApplicationUntilProxyStartsAnnotation, value)
+	}
+}
+
+// holdApplicationUntilProxyStarts configures the given pod so that its application containers only start once
+// its Envoy sidecar is ready. The kubelet starts the containers of a pod in order, and does not start the next
+// container until the postStart hook of the previous one completes. The Envoy sidecar is thus moved first in the
+// list of containers, with a postStart hook that blocks until Envoy reports it is live, which it does once it has
+// applied the initial listener and cluster configuration received from the controller.
+// The sidecar readiness probe reports the same state so that readiness of the pod reflects readiness of the sidecar.
+func holdApplicationUntilProxyStarts(pod *corev1.Pod, sidecar corev1.Container) {
+	if sidecar.Lifecycle == nil {
+		sidecar.Lifecycle = &corev1.Lifecycle{}
+	}
+	sidecar.Lifecycle.PostStart = &corev1.Handler{
+		Exec: &corev1.ExecAction{
+			Command: getEnvoyReadyWaitCommand(),
+		},
+	}
+	sidecar.ReadinessProbe = &corev1.Probe{
+		Handler: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: getEnvoyReadyCheckCommand(),
+			},
+		},
+		PeriodSeconds: envoyReadinessProbePeriodSeconds,
+	}
+
+	pod.Spec.Containers = append([]corev1.Container{sidecar}, pod.Spec.Containers...)
+}
+
+// getEnvoyReadyCheckCommand returns the command checking whether Envoy is ready using its admin interface,
+// which is only reachable from within the pod
+func getEnvoyReadyCheckCommand() []string {
+	return []string{"sh", "-c", getEnvoyReadyCheck()}
+}
+
+// getEnvoyReadyWaitCommand returns the command blocking until Envoy is ready, which fails if Envoy is not ready
+// within envoyReadyWaitTimeoutSeconds
+func getEnvoyReadyWaitCommand() []string {
+	return []string{"sh", "-c", fmt.Sprintf(
+		"i=0; until %s; do i=$((i+1)); if [ $i -ge %d ]; then echo 'Envoy not ready after %ds' >&2; exit 1; fi; sleep 1; done",
+		getEnvoyReadyCheck(), envoyReadyWaitTimeoutSeconds, envoyReadyWaitTimeoutSeconds)}
+}
+
+func getEnvoyReadyCheck() string {
+	return fmt.Sprintf("wget -q -O - http://%s:%d%s 2>/dev/null | grep -q %s",
+		constants.LocalhostIPAddress, constants.EnvoyAdminPort, envoyReadyPath, envoyReadyState)
+}
@@ -0,0 +1,103 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+func TestIsHoldApplicationUntilProxyStartsEnabled(t *testing.T) {
+	testCases := []struct {
+		name         string
+		annotations  map[string]string
+		meshWideHold bool
+		expectedHold bool
+		expectedErr  bool
+	}{
+		{
+			name:         "mesh-wide setting is used when the pod is not annotated",
+			annotations:  nil,
+			meshWideHold: true,
+			expectedHold: true,
+		},
+		{
+			name:         "pod annotation enables holding the application",
+			annotations:  map[string]string{holdApplicationUntilProxyStartsAnnotation: "enabled"},
+			meshWideHold: false,
+			expectedHold: true,
+		},
+		{
+			name:         "pod annotation disables holding the application",
+			annotations:  map[string]string{holdApplicationUntilProxyStartsAnnotation: "false"},
+			meshWideHold: true,
+			expectedHold: false,
+		},
+		{
+			name:         "invalid pod annotation value",
+			annotations:  map[string]string{holdApplicationUntilProxyStartsAnnotation: "invalid"},
+			meshWideHold: true,
+			expectedHold: false,
+			expectedErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mockConfigurator.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{
+				Spec: configv1alpha2.MeshConfigSpec{
+					Sidecar: configv1alpha2.SidecarSpec{
+						HoldApplicationUntilProxyStarts: tc.meshWideHold,
+					},
+				},
+			}).AnyTimes()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+
+			hold, err := isHoldApplicationUntilProxyStartsEnabled(pod, mockConfigurator)
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expectedHold, hold)
+		})
+	}
+}
+
+func TestHoldApplicationUntilProxyStarts(t *testing.T) {
+	assert := tassert.New(t)
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app"},
+			},
+		},
+	}
+
+	holdApplicationUntilProxyStarts(pod, corev1.Container{Name: "envoy"})
+
+	assert.Len(pod.Spec.Containers, 2)
+	assert.Equal("app", pod.Spec.Containers[1].Name)
+
+	sidecar := pod.Spec.Containers[0]
+	assert.Equal("envoy", sidecar.Name)
+	assert.NotNil(sidecar.Lifecycle)
+	assert.Equal([]string{"sh", "-c", "i=0; until wget -q -O - http://127.0.0.1:15000/ready 2>/dev/null | grep -q LIVE; " +
+		"do i=$((i+1)); if [ $i -ge 120 ]; then echo 'Envoy not ready after 120s' >&2; exit 1; fi; sleep 1; done"},
+		sidecar.Lifecycle.PostStart.Exec.Command)
+	assert.NotNil(sidecar.ReadinessProbe)
+	assert.Equal([]string{"sh", "-c", "wget -q -O - http://127.0.0.1:15000/ready 2>/dev/null | grep -q LIVE"},
+		sidecar.ReadinessProbe.Exec.Command)
+	assert.Equal(int32(envoyReadinessProbePeriodSeconds), sidecar.ReadinessProbe.PeriodSeconds)
+}
@@ -0,0 +1,190 @@
+package injector
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+const (
+	// sidecarCPURequestAnnotation is the annotation used to override the CPU request of the Envoy sidecar
+	sidecarCPURequestAnnotation = "openservicemesh.io/sidecar-cpu-request"
+
+	// sidecarCPULimitAnnotation is the annotation used to override the CPU limit of the Envoy sidecar
+	sidecarCPULimitAnnotation = "openservicemesh.io/sidecar-cpu-limit"
+
+	// sidecarMemoryRequestAnnotation is the annotation used to override the memory request of the Envoy sidecar
+	sidecarMemoryRequestAnnotation = "openservicemesh.io/sidecar-memory-request"
+
+	// sidecarMemoryLimitAnnotation is the annotation used to override the memory limit of the Envoy sidecar
+	sidecarMemoryLimitAnnotation = "openservicemesh.io/sidecar-memory-limit"
+
+	// sidecarLogLevelAnnotation is the annotation used to override the log level of the Envoy sidecar
+	sidecarLogLevelAnnotation = "openservicemesh.io/sidecar-log-level"
+
+	// sidecarConcurrencyAnnotation is the annotation used to set the number of worker threads of the Envoy sidecar
+	sidecarConcurrencyAnnotation = "openservicemesh.io/sidecar-concurrency"
+
+	// sidecarStatsTagsAnnotation is the annotation used to add tags with fixed values to the stats of the Envoy sidecar,
+	// specified as a comma separated list of name=value pairs
+	sidecarStatsTagsAnnotation = "openservicemesh.io/sidecar-stats-tags"
+)
+
+// envoyLogLevels is the set of log levels supported by Envoy
+var envoyLogLevels = map[string]bool{
+	"trace":    true,
+	"debug":    true,
+	"info":     true,
+	"warning":  true,
+	"warn":     true,
+	"error":    true,
+	"critical": true,
+	"off":      true,
+}
+
+// statsTagNameRegex matches the valid names of the stats tags of the Envoy sidecar
+var statsTagNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// sidecarResourceAnnotations maps the sidecar resource annotations to the resource they override,
+// and whether they override the request or the limit of that resource
+var sidecarResourceAnnotations = []struct {
+	annotation   string
+	resourceName corev1.ResourceName
+	isLimit      bool
+}{
+	{sidecarCPURequestAnnotation, corev1.ResourceCPU, false},
+	{sidecarCPULimitAnnotation, corev1.ResourceCPU, true},
+	{sidecarMemoryRequestAnnotation, corev1.ResourceMemory, false},
+	{sidecarMemoryLimitAnnotation, corev1.ResourceMemory, true},
+}
+
+// sidecarConfig is the configuration of the Envoy sidecar of a pod, resulting from the mesh-wide sidecar
+// settings in the MeshConfig overridden by the sidecar annotations of the pod
+type sidecarConfig struct {
+	// logLevel is the log level of the sidecar
+	logLevel string
+
+	// resources are the compute resources of the sidecar
+	resources corev1.ResourceRequirements
+
+	// concurrency is the number of worker threads of the sidecar, 0 leaves it to Envoy's default
+	concurrency int
+
+	// statsTags are the tags with fixed values added to the stats of the sidecar
+	statsTags map[string]string
+
+	// drainDuration is the duration during which the sidecar drains its inbound listeners on termination
+	drainDuration time.Duration
+}
+
+// getSidecarConfig returns the configuration of the Envoy sidecar of the given pod. An error describing the
+// offending annotation is returned when a sidecar annotation of the pod has an invalid value.
+func getSidecarConfig(pod *corev1.Pod, cfg configurator.Configurator) (sidecarConfig, error) {
+	config := sidecarConfig{
+		logLevel: cfg.GetEnvoyLogLevel(),
+	}
+
+	if value, ok := pod.Annotations[sidecarLogLevelAnnotation]; ok {
+		if !envoyLogLevels[value] {
+			return sidecarConfig{}, errors.Errorf("Invalid annotation value for key %q: %s, must be one of trace, debug, info, warning, warn, error, critical, off",
+				sidecarLogLevelAnnotation, value)
+		}
+		config.logLevel = value
+	}
+
+	resources, err := getSidecarResources(pod, cfg.GetProxyResources())
+	if err != nil {
+		return sidecarConfig{}, err
+	}
+	config.resources = resources
+
+	if value, ok := pod.Annotations[sidecarConcurrencyAnnotation]; ok {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil || concurrency <= 0 {
+			return sidecarConfig{}, errors.Errorf("Invalid annotation value for key %q: %s, must be a positive integer",
+				sidecarConcurrencyAnnotation, value)
+		}
+		config.concurrency = concurrency
+	}
+
+	if value, ok := pod.Annotations[sidecarStatsTagsAnnotation]; ok {
+		statsTags, err := parseStatsTags(value)
+		if err != nil {
+			return sidecarConfig{}, err
+		}
+		config.statsTags = statsTags
+	}
+
+	drainDuration, err := getSidecarDrainDuration(pod, cfg)
+	if err != nil {
+		return sidecarConfig{}, err
+	}
+	config.drainDuration = drainDuration
+
+	return config, nil
+}
+
+// getSidecarResources returns the compute resources of the Envoy sidecar of the given pod, resulting from the
+// given mesh-wide resources overridden by the sidecar resource annotations of the pod
+func getSidecarResources(pod *corev1.Pod, meshResources corev1.ResourceRequirements) (corev1.ResourceRequirements, error) {
+	resources := *meshResources.DeepCopy()
+
+	for _, r := range sidecarResourceAnnotations {
+		value, ok := pod.Annotations[r.annotation]
+		if !ok {
+			continue
+		}
+
+		log.Trace().Msgf("Pod with UID %s has annotation: '%s:%s'", pod.UID, r.annotation, value)
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil || quantity.Sign() <= 0 {
+			return corev1.ResourceRequirements{}, errors.Errorf("Invalid annotation value for key %q: %s, must be a positive quantity",
+				r.annotation, value)
+		}
+
+		if r.isLimit {
+			if resources.Limits == nil {
+				resources.Limits = corev1.ResourceList{}
+			}
+			resources.Limits[r.resourceName] = quantity
+		} else {
+			if resources.Requests == nil {
+				resources.Requests = corev1.ResourceList{}
+			}
+			resources.Requests[r.resourceName] = quantity
+		}
+	}
+
+	for resourceName, request := range resources.Requests {
+		if limit, ok := resources.Limits[resourceName]; ok && request.Cmp(limit) > 0 {
+			return corev1.ResourceRequirements{}, errors.Errorf("Invalid sidecar %s resources: request %s must be less than or equal to limit %s",
+				resourceName, request.String(), limit.String())
+		}
+	}
+
+	return resources, nil
+}
+
+// parseStatsTags parses the value of the stats tags annotation, a comma separated list of name=value pairs
+func parseStatsTags(value string) (map[string]string, error) {
+	statsTags := make(map[string]string)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		nameValue := strings.SplitN(tag, "=", 2)
+		if len(nameValue) != 2 || !statsTagNameRegex.MatchString(nameValue[0]) || nameValue[1] == "" {
+			return nil, errors.Errorf("Invalid stats tag '%s' specified for annotation %q, must be of the form name=value", tag, sidecarStatsTagsAnnotation)
+		}
+		if _, ok := statsTags[nameValue[0]]; ok {
+			return nil, errors.Errorf("Duplicate stats tag '%s' specified for annotation %q", nameValue[0], sidecarStatsTagsAnnotation)
+		}
+		statsTags[nameValue[0]] = nameValue[1]
+	}
+	return statsTags, nil
+}
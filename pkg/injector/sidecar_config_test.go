@@ -0,0 +1,133 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+func TestGetSidecarConfig(t *testing.T) {
+	meshResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("512M"),
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		expectedConfig sidecarConfig
+		expectedErr    bool
+	}{
+		{
+			name:        "mesh-wide settings are used when the pod is not annotated",
+			annotations: nil,
+			expectedConfig: sidecarConfig{
+				logLevel:      "error",
+				resources:     meshResources,
+				drainDuration: 5 * time.Second,
+			},
+		},
+		{
+			name: "pod annotations override the mesh-wide settings",
+			annotations: map[string]string{
+				sidecarLogLevelAnnotation:      "debug",
+				sidecarCPURequestAnnotation:    "500m",
+				sidecarCPULimitAnnotation:      "2",
+				sidecarMemoryRequestAnnotation: "128Mi",
+				sidecarConcurrencyAnnotation:   "4",
+				sidecarStatsTagsAnnotation:     "tier=gateway, team=payments",
+			},
+			expectedConfig: sidecarConfig{
+				logLevel: "debug",
+				resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("512M"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+				concurrency:   4,
+				statsTags:     map[string]string{"tier": "gateway", "team": "payments"},
+				drainDuration: 5 * time.Second,
+			},
+		},
+		{
+			name:        "invalid log level",
+			annotations: map[string]string{sidecarLogLevelAnnotation: "verbose"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid resource quantity",
+			annotations: map[string]string{sidecarMemoryLimitAnnotation: "lots"},
+			expectedErr: true,
+		},
+		{
+			name:        "non positive resource quantity",
+			annotations: map[string]string{sidecarCPURequestAnnotation: "0"},
+			expectedErr: true,
+		},
+		{
+			name:        "request greater than the mesh-wide limit",
+			annotations: map[string]string{sidecarMemoryRequestAnnotation: "1Gi"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid concurrency",
+			annotations: map[string]string{sidecarConcurrencyAnnotation: "-1"},
+			expectedErr: true,
+		},
+		{
+			name:        "stats tag without value",
+			annotations: map[string]string{sidecarStatsTagsAnnotation: "tier"},
+			expectedErr: true,
+		},
+		{
+			name:        "stats tag with invalid name",
+			annotations: map[string]string{sidecarStatsTagsAnnotation: "tier.name=gateway"},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate stats tag",
+			annotations: map[string]string{sidecarStatsTagsAnnotation: "tier=gateway,tier=batch"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid drain duration",
+			annotations: map[string]string{sidecarDrainDurationAnnotation: "soon"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("error").AnyTimes()
+			mockConfigurator.EXPECT().GetProxyResources().Return(meshResources).AnyTimes()
+			mockConfigurator.EXPECT().GetProxyDrainDuration().Return(5 * time.Second).AnyTimes()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+
+			config, err := getSidecarConfig(pod, mockConfigurator)
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Equal(tc.expectedConfig, config)
+		})
+	}
+}
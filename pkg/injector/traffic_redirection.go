@@ -0,0 +1,78 @@
+package injector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+// trafficRedirectionConfig is the configuration of the redirection of the traffic of a pod to its sidecar,
+// resulting from the mesh-wide exclusion and inclusion lists merged with the ones annotated on the pod
+type trafficRedirectionConfig struct {
+	outboundIPRangeExclusionList []string
+	outboundIPRangeInclusionList []string
+	outboundPortExclusionList    []int
+	inboundPortExclusionList     []int
+	enableDNSCapture             bool
+}
+
+// getTrafficRedirectionConfig returns the configuration of the redirection of the traffic of the given pod to its sidecar.
+// An error is returned when an exclusion or inclusion annotation of the pod has an invalid value.
+func getTrafficRedirectionConfig(pod *corev1.Pod, namespace string, meshConfig configv1alpha2.MeshConfigSpec) (trafficRedirectionConfig, error) {
+	traffic := meshConfig.Traffic
+
+	// Build outbound port exclusion list
+	podOutboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, outboundPortExclusionListAnnotation)
+	if err != nil {
+		return trafficRedirectionConfig{}, err
+	}
+
+	// Build inbound port exclusion list
+	podInboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, inboundPortExclusionListAnnotation)
+	if err != nil {
+		return trafficRedirectionConfig{}, err
+	}
+
+	// Build the outbound IP range exclusion list
+	podOutboundIPRangeExclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeExclusionListAnnotation)
+	if err != nil {
+		return trafficRedirectionConfig{}, err
+	}
+
+	// Build the outbound IP range inclusion list
+	podOutboundIPRangeInclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeInclusionListAnnotation)
+	if err != nil {
+		return trafficRedirectionConfig{}, err
+	}
+
+	return trafficRedirectionConfig{
+		outboundIPRangeExclusionList: mergeIPRangeLists(podOutboundIPRangeExclusionList, traffic.OutboundIPRangeExclusionList),
+		outboundIPRangeInclusionList: mergeIPRangeLists(podOutboundIPRangeInclusionList, traffic.OutboundIPRangeInclusionList),
+		outboundPortExclusionList:    mergePortExclusionLists(podOutboundPortExclusionList, traffic.OutboundPortExclusionList),
+		inboundPortExclusionList:     mergePortExclusionLists(podInboundPortExclusionList, traffic.InboundPortExclusionList),
+		enableDNSCapture:             meshConfig.FeatureFlags.EnableDNSProxy,
+	}, nil
+}
+
+// GetIptablesRulesForPod returns the iptables rules, in the iptables-restore format, redirecting the traffic of the
+// given pod to its sidecar. The rules are the ones programmed by the init container injected in the pod, so that
+// the osm-cni plugin redirects the traffic of pods the same way when it is used instead of the init container.
+func GetIptablesRulesForPod(pod *corev1.Pod, meshConfig configv1alpha2.MeshConfigSpec) (string, error) {
+	redirection, err := getTrafficRedirectionConfig(pod, pod.Namespace, meshConfig)
+	if err != nil {
+		return "", err
+	}
+	// The sidecar of the pod serves DNS queries based on the setting recorded at injection, which must be honored
+	// even if the mesh-wide setting changed since
+	if enabled, ok := k8s.IsDNSCaptureEnabled(pod); ok {
+		redirection.enableDNSCapture = enabled
+	}
+
+	rules := generateIptablesRules(redirection.outboundIPRangeExclusionList, redirection.outboundIPRangeInclusionList,
+		redirection.outboundPortExclusionList, redirection.inboundPortExclusionList, redirection.enableDNSCapture)
+
+	// iptables-restore requires the rules to end with a newline
+	return rules + "\n", nil
+}
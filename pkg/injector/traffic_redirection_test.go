@@ -0,0 +1,151 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetIptablesRulesForPod(t *testing.T) {
+	assert := tassert.New(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "bookstore",
+			Annotations: map[string]string{
+				outboundPortExclusionListAnnotation:    "6379",
+				outboundIPRangeExclusionListAnnotation: "10.0.0.0/8",
+			},
+		},
+	}
+	meshConfig := configv1alpha2.MeshConfigSpec{
+		Traffic: configv1alpha2.TrafficSpec{
+			OutboundPortExclusionList: []int{3306},
+			InboundPortExclusionList:  []int{9090},
+		},
+		FeatureFlags: configv1alpha2.FeatureFlags{
+			EnableDNSProxy: true,
+		},
+	}
+
+	rules, err := GetIptablesRulesForPod(pod, meshConfig)
+	assert.Nil(err)
+	assert.Equal(generateIptablesRules([]string{"10.0.0.0/8"}, nil, []int{3306, 6379}, []int{9090}, true)+"\n", rules)
+
+	// The DNS capture recorded on the pod at injection takes precedence over the current mesh-wide setting
+	pod.Annotations[constants.DNSCaptureAnnotation] = "false"
+	rules, err = GetIptablesRulesForPod(pod, meshConfig)
+	assert.Nil(err)
+	assert.Equal(generateIptablesRules([]string{"10.0.0.0/8"}, nil, []int{3306, 6379}, []int{9090}, false)+"\n", rules)
+
+	pod.Annotations[inboundPortExclusionListAnnotation] = "invalid"
+	_, err = GetIptablesRulesForPod(pod, meshConfig)
+	assert.NotNil(err)
+}
+
+func TestConfigurePodInit(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		podOS                  string
+		redirectionMode        string
+		annotations            map[string]string
+		enableDNSProxy         bool
+		expectedInitContainers int
+		expectedDNSCapture     string
+		expectedErr            bool
+	}{
+		{
+			name:                   "init container redirection mode",
+			podOS:                  constants.OSLinux,
+			redirectionMode:        configv1alpha2.TrafficRedirectionModeInitContainer,
+			expectedInitContainers: 1,
+			expectedDNSCapture:     "false",
+		},
+		{
+			name:                   "DNS proxy enabled",
+			podOS:                  constants.OSLinux,
+			enableDNSProxy:         true,
+			expectedInitContainers: 1,
+			expectedDNSCapture:     "true",
+		},
+		{
+			name:                   "DNS capture annotation set by the user is overridden",
+			podOS:                  constants.OSLinux,
+			annotations:            map[string]string{constants.DNSCaptureAnnotation: "true"},
+			expectedInitContainers: 1,
+			expectedDNSCapture:     "false",
+		},
+		{
+			name:                   "default redirection mode",
+			podOS:                  constants.OSLinux,
+			expectedInitContainers: 1,
+			expectedDNSCapture:     "false",
+		},
+		{
+			name:                   "CNI redirection mode",
+			podOS:                  constants.OSLinux,
+			redirectionMode:        configv1alpha2.TrafficRedirectionModeCNI,
+			enableDNSProxy:         true,
+			expectedInitContainers: 0,
+			expectedDNSCapture:     "true",
+		},
+		{
+			name:            "CNI redirection mode with invalid exclusion annotation",
+			podOS:           constants.OSLinux,
+			redirectionMode: configv1alpha2.TrafficRedirectionModeCNI,
+			annotations:     map[string]string{outboundPortExclusionListAnnotation: "invalid"},
+			expectedErr:     true,
+		},
+		{
+			name:                   "windows pod",
+			podOS:                  constants.OSWindows,
+			enableDNSProxy:         true,
+			expectedInitContainers: 0,
+			expectedDNSCapture:     "false",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{
+				Spec: configv1alpha2.MeshConfigSpec{
+					Sidecar: configv1alpha2.SidecarSpec{
+						TrafficRedirectionMode: tc.redirectionMode,
+					},
+					FeatureFlags: configv1alpha2.FeatureFlags{
+						EnableDNSProxy: tc.enableDNSProxy,
+					},
+				},
+			}).AnyTimes()
+			mockConfigurator.EXPECT().GetInitContainerImage().Return("init-container-image").AnyTimes()
+			mockConfigurator.EXPECT().IsPrivilegedInitContainer().Return(false).AnyTimes()
+
+			wh := &mutatingWebhook{
+				configurator: mockConfigurator,
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+
+			err := wh.configurePodInit(tc.podOS, pod, "bookstore")
+			assert.Equal(tc.expectedErr, err != nil)
+			assert.Len(pod.Spec.InitContainers, tc.expectedInitContainers)
+			if !tc.expectedErr {
+				assert.Equal(tc.expectedDNSCapture, pod.Annotations[constants.DNSCaptureAnnotation])
+			}
+		})
+	}
+}
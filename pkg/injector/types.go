@@ -62,4 +62,7 @@ type envoyBootstrapConfigMeta struct {
 	TLSMaxProtocolVersion string
 	CipherSuites          []string
 	ECDHCurves            []string
+
+	// Tags with fixed values added to the sidecar stats
+	StatsTags map[string]string
 }
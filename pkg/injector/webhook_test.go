@@ -844,6 +844,7 @@ func TestWebhookMutate(t *testing.T) {
 		kubeController.EXPECT().IsMonitoredNamespace(namespace).Return(true)
 
 		cfg := configurator.NewMockConfigurator(mockCtrl)
+		cfg.EXPECT().ForNamespace(gomock.Any()).Return(cfg).AnyTimes()
 		cfg.EXPECT().GetMeshConfig().AnyTimes()
 		cfg.EXPECT().IsPrivilegedInitContainer()
 		cfg.EXPECT().GetInitContainerImage().Return("init-container-image").AnyTimes()
@@ -851,6 +852,7 @@ func TestWebhookMutate(t *testing.T) {
 		cfg.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows-image").AnyTimes()
 		cfg.EXPECT().GetProxyResources()
 		cfg.EXPECT().GetEnvoyLogLevel()
+		cfg.EXPECT().GetProxyDrainDuration()
 
 		wh := &mutatingWebhook{
 			nonInjectNamespaces: mapset.NewSet(),
@@ -890,6 +892,7 @@ func TestWebhookMutate(t *testing.T) {
 		kubeController.EXPECT().IsMonitoredNamespace(namespace).Return(true)
 
 		cfg := configurator.NewMockConfigurator(mockCtrl)
+		cfg.EXPECT().ForNamespace(gomock.Any()).Return(cfg).AnyTimes()
 		cfg.EXPECT().GetMeshConfig().AnyTimes()
 		cfg.EXPECT().IsPrivilegedInitContainer()
 		cfg.EXPECT().GetInitContainerImage().Return("init-container-image").AnyTimes()
@@ -897,6 +900,7 @@ func TestWebhookMutate(t *testing.T) {
 		cfg.EXPECT().GetEnvoyWindowsImage().Return("envoy-windows-image").AnyTimes()
 		cfg.EXPECT().GetProxyResources()
 		cfg.EXPECT().GetEnvoyLogLevel()
+		cfg.EXPECT().GetProxyDrainDuration()
 
 		wh := &mutatingWebhook{
 			nonInjectNamespaces: mapset.NewSet(),
@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
@@ -57,6 +58,11 @@ func WatchAndUpdateProxyBootstrapSecret(kubeClient kubernetes.Interface, msgBrok
 				continue
 			}
 
+			if hasOwnerReference(secret, podUID) {
+				log.Debug().Msgf("Secret %s/%s already references Pod %s/%s, skipping update", namespace, secretName, namespace, podName)
+				continue
+			}
+
 			secret.ObjectMeta.OwnerReferences = append(secret.ObjectMeta.OwnerReferences, metav1.OwnerReference{
 				APIVersion: "v1",
 				Kind:       "Pod",
@@ -77,6 +83,16 @@ func WatchAndUpdateProxyBootstrapSecret(kubeClient kubernetes.Interface, msgBrok
 	}
 }
 
+// hasOwnerReference returns whether the given secret is owned by the object with the given UID
+func hasOwnerReference(secret *corev1.Secret, ownerUID types.UID) bool {
+	for _, ref := range secret.OwnerReferences {
+		if ref.UID == ownerUID {
+			return true
+		}
+	}
+	return false
+}
+
 // WatchAndUpdateLogLevel watches for log level changes and updates the global log level
 func WatchAndUpdateLogLevel(msgBroker *messaging.Broker, stop <-chan struct{}) {
 	kubePubSub := msgBroker.GetKubeEventPubSub()
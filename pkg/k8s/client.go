@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	mapset "github.com/deckarep/golang-set"
@@ -10,12 +11,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	policyv1alpha1Client "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/messaging"
 
@@ -26,17 +30,18 @@ import (
 )
 
 // NewKubernetesController returns a new kubernetes.Controller which means to provide access to locally-cached k8s resources
-func NewKubernetesController(kubeClient kubernetes.Interface, policyClient policyv1alpha1Client.Interface, meshName string,
+func NewKubernetesController(kubeClient kubernetes.Interface, policyClient policyv1alpha1Client.Interface, configClient configClientset.Interface, meshName string,
 	stop <-chan struct{}, msgBroker *messaging.Broker, selectInformers ...InformerKey) (Controller, error) {
-	return newClient(kubeClient, policyClient, meshName, stop, msgBroker, selectInformers...)
+	return newClient(kubeClient, policyClient, configClient, meshName, stop, msgBroker, selectInformers...)
 }
 
-func newClient(kubeClient kubernetes.Interface, policyClient policyv1alpha1Client.Interface, meshName string,
+func newClient(kubeClient kubernetes.Interface, policyClient policyv1alpha1Client.Interface, configClient configClientset.Interface, meshName string,
 	stop <-chan struct{}, msgBroker *messaging.Broker, selectInformers ...InformerKey) (*client, error) {
 	// Initialize client object
 	c := &client{
 		kubeClient:   kubeClient,
 		policyClient: policyClient,
+		configClient: configClient,
 		meshName:     meshName,
 		informers:    informerCollection{},
 		msgBroker:    msgBroker,
@@ -49,11 +54,12 @@ func newClient(kubeClient kubernetes.Interface, policyClient policyv1alpha1Clien
 		ServiceAccounts: c.initServiceAccountsMonitor,
 		Pods:            c.initPodMonitor,
 		Endpoints:       c.initEndpointMonitor,
+		Secrets:         c.initSecretsMonitor,
 	}
 
 	// If specific informers are not selected to be initialized, initialize all informers
 	if len(selectInformers) == 0 {
-		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, Endpoints}
+		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, Endpoints, Secrets}
 	}
 
 	for _, informer := range selectInformers {
@@ -150,6 +156,25 @@ func (c *client) initEndpointMonitor() {
 	c.informers[Endpoints].AddEventHandler(GetEventHandlerFuncs(c.shouldObserve, eptEventTypes, c.msgBroker))
 }
 
+// Initializes Secret monitoring. The Secrets managed by OSM and service account tokens cannot be referenced
+// by the mesh resources, so they are not cached to limit the size of the cache.
+func (c *client) initSecretsMonitor() {
+	option := informers.WithTweakListOptions(func(opt *metav1.ListOptions) {
+		opt.LabelSelector = fmt.Sprintf("%s!=%s", constants.OSMAppNameLabelKey, constants.OSMAppNameLabelValue)
+		opt.FieldSelector = fields.OneTermNotEqualSelector("type", string(corev1.SecretTypeServiceAccountToken)).String()
+	})
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(c.kubeClient, DefaultKubeEventResyncInterval, option)
+	c.informers[Secrets] = informerFactory.Core().V1().Secrets().Informer()
+
+	secretEventTypes := EventTypes{
+		Add:    announcements.SecretAdded,
+		Update: announcements.SecretUpdated,
+		Delete: announcements.SecretDeleted,
+	}
+	// Secrets referenced by Gateway listeners can be in namespaces that are not monitored
+	c.informers[Secrets].AddEventHandler(GetEventHandlerFuncs(nil, secretEventTypes, c.msgBroker))
+}
+
 func (c *client) run(stop <-chan struct{}) error {
 	log.Info().Msg("Namespace controller client started")
 	var hasSynced []cache.InformerSynced
@@ -325,6 +350,37 @@ func IsMetricsEnabled(pod *corev1.Pod) bool {
 	return isScrapingEnabled
 }
 
+// IsDNSCaptureEnabled returns whether the DNS queries of the given pod are redirected to its sidecar, as recorded
+// on the pod by the sidecar injector. The second return value is false if the pod does not record it, such as pods
+// injected by an earlier version of the sidecar injector.
+func IsDNSCaptureEnabled(pod *corev1.Pod) (bool, bool) {
+	value, ok := pod.Annotations[constants.DNSCaptureAnnotation]
+	if !ok {
+		return false, false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false
+	}
+	return enabled, true
+}
+
+// GetSecret returns the Secret with the given namespaced name from the cache
+func (c client) GetSecret(name types.NamespacedName) (*corev1.Secret, error) {
+	if c.informers[Secrets] == nil {
+		return nil, errInitInformers
+	}
+	secretInterface, exists, err := c.informers[Secrets].GetStore().GetByKey(name.String())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errSecretNotFound
+	}
+	return secretInterface.(*corev1.Secret), nil
+}
+
 // UpdateStatus updates the status subresource for the given resource and GroupVersionKind
 // The resource within the 'interface{}' must be a pointer to the underlying resource
 func (c client) UpdateStatus(resource interface{}) (metav1.Object, error) {
@@ -333,6 +389,29 @@ func (c client) UpdateStatus(resource interface{}) (metav1.Object, error) {
 		obj := resource.(*policyv1alpha1.IngressBackend)
 		return c.policyClient.PolicyV1alpha1().IngressBackends(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
 
+	case *policyv1alpha1.Egress:
+		obj := resource.(*policyv1alpha1.Egress)
+		return c.policyClient.PolicyV1alpha1().Egresses(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.Retry:
+		obj := resource.(*policyv1alpha1.Retry)
+		return c.policyClient.PolicyV1alpha1().Retries(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.UpstreamTrafficSetting:
+		obj := resource.(*policyv1alpha1.UpstreamTrafficSetting)
+		return c.policyClient.PolicyV1alpha1().UpstreamTrafficSettings(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *policyv1alpha1.SidecarScope:
+		obj := resource.(*policyv1alpha1.SidecarScope)
+		return c.policyClient.PolicyV1alpha1().SidecarScopes(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
+	case *configv1alpha2.MultiClusterService:
+		obj := resource.(*configv1alpha2.MultiClusterService)
+		if c.configClient == nil {
+			return nil, errors.Errorf("Cannot update status of %T without a config client", t)
+		}
+		return c.configClient.ConfigV1alpha2().MultiClusterServices(obj.Namespace).UpdateStatus(context.Background(), obj, metav1.UpdateOptions{})
+
 	default:
 		return nil, errors.Errorf("Unsupported type: %T", t)
 	}
@@ -9,9 +9,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	testclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	fakePolicyClient "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
 
@@ -56,7 +58,7 @@ func TestIsMonitoredNamespace(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Namespaces].GetStore().Add(tc.namespace)
 
@@ -98,7 +100,7 @@ func TestGetNamespace(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Namespaces].GetStore().Add(tc.namespace)
 
@@ -144,7 +146,7 @@ func TestListMonitoredNamespaces(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			for _, ns := range tc.namespaces {
 				_ = c.informers[Namespaces].GetStore().Add(ns)
@@ -191,7 +193,7 @@ func TestGetService(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Services].GetStore().Add(tc.service)
 
@@ -247,7 +249,7 @@ func TestListServices(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Namespaces].GetStore().Add(tc.namespace)
 
@@ -303,7 +305,7 @@ func TestListServiceAccounts(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Namespaces].GetStore().Add(tc.namespace)
 
@@ -359,7 +361,7 @@ func TestListPods(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Namespaces].GetStore().Add(tc.namespace)
 
@@ -412,7 +414,7 @@ func TestGetEndpoints(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Endpoints].GetStore().Add(tc.endpoints)
 
@@ -510,7 +512,7 @@ func TestListServiceIdentitiesForService(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			c, err := newClient(testclient.NewSimpleClientset(), nil, testMeshName, nil, nil)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
 			a.Nil(err)
 			_ = c.informers[Namespaces].GetStore().Add(tc.namespace)
 			for _, p := range tc.pods {
@@ -583,6 +585,100 @@ func TestIsMetricsEnabled(t *testing.T) {
 	}
 }
 
+func TestIsDNSCaptureEnabled(t *testing.T) {
+	testCases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedEnabled  bool
+		expectedRecorded bool
+	}{
+		{
+			name:             "pod without DNS capture annotation",
+			expectedEnabled:  false,
+			expectedRecorded: false,
+		},
+		{
+			name:             "pod with DNS capture enabled",
+			annotations:      map[string]string{constants.DNSCaptureAnnotation: "true"},
+			expectedEnabled:  true,
+			expectedRecorded: true,
+		},
+		{
+			name:             "pod with DNS capture disabled",
+			annotations:      map[string]string{constants.DNSCaptureAnnotation: "false"},
+			expectedEnabled:  false,
+			expectedRecorded: true,
+		},
+		{
+			name:             "pod with invalid DNS capture annotation",
+			annotations:      map[string]string{constants.DNSCaptureAnnotation: "invalid"},
+			expectedEnabled:  false,
+			expectedRecorded: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			enabled, recorded := IsDNSCaptureEnabled(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+			})
+			assert.Equal(tc.expectedEnabled, enabled)
+			assert.Equal(tc.expectedRecorded, recorded)
+		})
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	testCases := []struct {
+		name        string
+		secret      *corev1.Secret
+		secretName  types.NamespacedName
+		expectedErr error
+	}{
+		{
+			name: "gets the secret from the cache given its key",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns1",
+				},
+			},
+			secretName:  types.NamespacedName{Name: "foo", Namespace: "ns1"},
+			expectedErr: nil,
+		},
+		{
+			name: "returns an error if the secret is not found in the cache",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns1",
+				},
+			},
+			secretName:  types.NamespacedName{Name: "foo", Namespace: "ns2"},
+			expectedErr: errSecretNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			c, err := newClient(testclient.NewSimpleClientset(), nil, nil, testMeshName, nil, nil)
+			a.Nil(err)
+			_ = c.informers[Secrets].GetStore().Add(tc.secret)
+
+			actual, err := c.GetSecret(tc.secretName)
+			a.Equal(tc.expectedErr, err)
+			if tc.expectedErr == nil {
+				a.Equal(tc.secret, actual)
+			} else {
+				a.Nil(actual)
+			}
+		})
+	}
+}
+
 func TestUpdateStatus(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -644,10 +740,61 @@ func TestUpdateStatus(t *testing.T) {
 					Reason:        "valid",
 				},
 			},
+		}, {
+			name: "valid Egress resource",
+			existingResource: &policyv1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+			},
+			updatedResource: &policyv1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+				Status: policyv1alpha1.EgressStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   policyv1alpha1.PolicyConditionAccepted,
+							Status: metav1.ConditionTrue,
+							Reason: policyv1alpha1.PolicyReasonAccepted,
+						},
+					},
+				},
+			},
+		}, {
+			name: "valid SidecarScope resource",
+			existingResource: &policyv1alpha1.SidecarScope{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "scope-1",
+					Namespace: "test",
+				},
+			},
+			updatedResource: &policyv1alpha1.SidecarScope{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "scope-1",
+					Namespace: "test",
+				},
+				Status: policyv1alpha1.SidecarScopeStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   policyv1alpha1.PolicyConditionAccepted,
+							Status: metav1.ConditionTrue,
+							Reason: policyv1alpha1.PolicyReasonAccepted,
+						},
+					},
+				},
+			},
 		}, {
 			name:             "unsupported resource",
+			existingResource: &policyv1alpha1.ExternalAuthorization{},
+			updatedResource:  &policyv1alpha1.ExternalAuthorization{},
+			expectErr:        true,
+		}, {
+			name:             "MultiClusterService without a config client",
 			existingResource: &policyv1alpha1.Egress{},
-			updatedResource:  &policyv1alpha1.Egress{},
+			updatedResource:  &configv1alpha2.MultiClusterService{},
 			expectErr:        true,
 		},
 	}
@@ -658,7 +805,7 @@ func TestUpdateStatus(t *testing.T) {
 			kubeClient := testclient.NewSimpleClientset()
 			policyClient := fakePolicyClient.NewSimpleClientset(tc.existingResource.(runtime.Object))
 
-			c, err := NewKubernetesController(kubeClient, policyClient, testMeshName, make(chan struct{}), nil)
+			c, err := NewKubernetesController(kubeClient, policyClient, nil, testMeshName, make(chan struct{}), nil)
 			a.Nil(err)
 
 			_, err = c.UpdateStatus(tc.updatedResource)
@@ -798,7 +945,7 @@ func TestK8sServicesToMeshServices(t *testing.T) {
 
 			fakeClient := testclient.NewSimpleClientset(tc.svcEndpoints...)
 			stop := make(chan struct{})
-			kubeController, err := NewKubernetesController(fakeClient, nil, testMeshName, stop, nil)
+			kubeController, err := NewKubernetesController(fakeClient, nil, nil, testMeshName, stop, nil)
 			assert.Nil(err)
 			assert.NotNil(kubeController)
 
@@ -7,4 +7,5 @@ var (
 	errInitInformers     = errors.New("Informer not initialized")
 	errListingNamespaces = errors.New("Failed to list monitored namespaces")
 	errServiceNotFound   = errors.New("Service not found")
+	errSecretNotFound    = errors.New("Secret not found")
 )
@@ -7,9 +7,11 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	policyv1alpha1Client "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/messaging"
 
@@ -61,6 +63,8 @@ const (
 	Endpoints InformerKey = "Endpoints"
 	// ServiceAccounts lookup identifier
 	ServiceAccounts InformerKey = "ServiceAccounts"
+	// Secrets lookup identifier
+	Secrets InformerKey = "Secrets"
 )
 
 // informerCollection is the type holding the collection of informers we keep
@@ -71,6 +75,7 @@ type client struct {
 	meshName     string
 	kubeClient   kubernetes.Interface
 	policyClient policyv1alpha1Client.Interface
+	configClient configClientset.Interface
 	informers    informerCollection
 	msgBroker    *messaging.Broker
 }
@@ -105,6 +110,10 @@ type Controller interface {
 	// GetEndpoints returns the endpoints for a given service, if found
 	GetEndpoints(service.MeshService) (*corev1.Endpoints, error)
 
+	// GetSecret returns the Secret with the given namespaced name from the cache.
+	// Secrets managed by OSM and service account tokens are not cached.
+	GetSecret(types.NamespacedName) (*corev1.Secret, error)
+
 	// UpdateStatus updates the status subresource for the given resource and GroupVersionKind
 	// The object within the 'interface{}' must be a pointer to the underlying resource
 	UpdateStatus(interface{}) (metav1.Object, error)
@@ -0,0 +1,115 @@
+package leader
+
+import (
+	"context"
+	"sync/atomic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/openservicemesh/osm/pkg/errcode"
+)
+
+// NewElector returns a new Elector for the replica with the given identity, electing the leader
+// using the Lease with the given name and namespace
+func NewElector(kubeClient kubernetes.Interface, namespace, leaseName, identity string) *Elector {
+	return &Elector{
+		kubeClient:    kubeClient,
+		namespace:     namespace,
+		leaseName:     leaseName,
+		identity:      identity,
+		leaseDuration: defaultLeaseDuration,
+		renewDeadline: defaultRenewDeadline,
+		retryPeriod:   defaultRetryPeriod,
+		duties:        make(map[string]Duty),
+	}
+}
+
+// AddDuty adds a singleton duty to run when this replica becomes the leader.
+// Duties must be added before the elector is run.
+func (e *Elector) AddDuty(name string, duty Duty) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.duties[name] = duty
+}
+
+// IsLeader returns whether this replica is the leader
+func (e *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&e.isLeader) == 1
+}
+
+// Run campaigns for leadership until the stop channel is closed. When this replica becomes the leader, each duty
+// is run in its own goroutine until leadership is lost, after which the replica campaigns for leadership again.
+// The lease is released when stopped, so that another replica can take over without waiting for it to expire.
+func (e *Elector) Run(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta: metav1.ObjectMeta{
+					Name:      e.leaseName,
+					Namespace: e.namespace,
+				},
+				Client: e.kubeClient.CoordinationV1(),
+				LockConfig: resourcelock.ResourceLockConfig{
+					Identity: e.identity,
+				},
+			},
+			LeaseDuration:   e.leaseDuration,
+			RenewDeadline:   e.renewDeadline,
+			RetryPeriod:     e.retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            e.leaseName,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: e.lead,
+				OnStoppedLeading: func() {
+					atomic.StoreInt32(&e.isLeader, 0)
+				},
+				OnNewLeader: func(identity string) {
+					log.Info().Msgf("Replica %s is the leader of lease %s/%s", identity, e.namespace, e.leaseName)
+				},
+			},
+		})
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrStartingLeaderElection)).
+				Msgf("Error configuring leader election for lease %s/%s", e.namespace, e.leaseName)
+			return
+		}
+
+		// Run returns when leadership is lost or the context is canceled
+		elector.Run(ctx)
+
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn().Msgf("Replica %s lost leadership of lease %s/%s, campaigning again", e.identity, e.namespace, e.leaseName)
+	}
+}
+
+// lead runs the duties until the given context is canceled on loss of leadership
+func (e *Elector) lead(ctx context.Context) {
+	// Leadership may have been lost before the duties are started
+	if ctx.Err() != nil {
+		return
+	}
+	atomic.StoreInt32(&e.isLeader, 1)
+	log.Info().Msgf("Replica %s acquired leadership of lease %s/%s", e.identity, e.namespace, e.leaseName)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for name, duty := range e.duties {
+		log.Debug().Msgf("Starting leader duty %s", name)
+		go duty(ctx.Done())
+	}
+}
@@ -0,0 +1,323 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+const (
+	testNamespace = "osm-system"
+	testLeaseName = "osm-controller-leader"
+)
+
+// newFakeClientset returns a fake clientset rejecting lease updates based on a stale resource version,
+// as the API server does, so that replicas cannot concurrently acquire the same lease.
+func newFakeClientset() *fake.Clientset {
+	kubeClient := fake.NewSimpleClientset()
+	var resourceVersion int64
+
+	kubeClient.PrependReactor("create", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(metav1.Object)
+		obj.SetResourceVersion(strconv.FormatInt(atomic.AddInt64(&resourceVersion, 1), 10))
+		return false, nil, nil
+	})
+	kubeClient.PrependReactor("update", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(metav1.Object)
+		current, err := kubeClient.Tracker().Get(action.GetResource(), obj.GetNamespace(), obj.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		if current.(metav1.Object).GetResourceVersion() != obj.GetResourceVersion() {
+			return true, nil, apierrors.NewConflict(action.GetResource().GroupResource(), obj.GetName(), fmt.Errorf("stale resource version"))
+		}
+		obj.SetResourceVersion(strconv.FormatInt(atomic.AddInt64(&resourceVersion, 1), 10))
+		return false, nil, nil
+	})
+
+	return kubeClient
+}
+
+func newTestElector(kubeClient *fake.Clientset, identity string) *Elector {
+	e := NewElector(kubeClient, testNamespace, testLeaseName, identity)
+	e.leaseDuration = 1 * time.Second
+	e.renewDeadline = 500 * time.Millisecond
+	e.retryPeriod = 100 * time.Millisecond
+	return e
+}
+
+func countLeaders(electors []*Elector) int {
+	leaders := 0
+	for _, e := range electors {
+		if e.IsLeader() {
+			leaders++
+		}
+	}
+	return leaders
+}
+
+func TestElectorRunsDutiesOnLeader(t *testing.T) {
+	assert := tassert.New(t)
+	kubeClient := newFakeClientset()
+
+	var runningDuties int32
+	var electors []*Elector
+	var stops []chan struct{}
+	for i := 0; i < 3; i++ {
+		e := newTestElector(kubeClient, fmt.Sprintf("replica-%d", i))
+		e.AddDuty("test", func(stop <-chan struct{}) {
+			atomic.AddInt32(&runningDuties, 1)
+			<-stop
+			atomic.AddInt32(&runningDuties, -1)
+		})
+		stop := make(chan struct{})
+		go e.Run(stop)
+		electors = append(electors, e)
+		stops = append(stops, stop)
+	}
+	defer func() {
+		for _, stop := range stops {
+			select {
+			case <-stop:
+			default:
+				close(stop)
+			}
+		}
+	}()
+
+	// A single replica is elected and runs the duties
+	assert.Eventually(func() bool {
+		return countLeaders(electors) == 1 && atomic.LoadInt32(&runningDuties) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// Another replica takes over when the leader stops
+	var leaderIdx int
+	for i, e := range electors {
+		if e.IsLeader() {
+			leaderIdx = i
+		}
+	}
+	close(stops[leaderIdx])
+
+	assert.Eventually(func() bool {
+		if electors[leaderIdx].IsLeader() {
+			return false
+		}
+		return countLeaders(electors) == 1 && atomic.LoadInt32(&runningDuties) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestMultipleReplicasDoNotChurnSecrets(t *testing.T) {
+	assert := tassert.New(t)
+	kubeClient := newFakeClientset()
+
+	podUUID := "6c4a6ea7-d7a3-4f52-a2e5-9e1bde6a1d5a"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "app",
+			UID:       "pod-uid",
+			Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: podUUID},
+		},
+	}
+	bootstrapSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "envoy-bootstrap-config-" + podUUID,
+			Namespace: "app",
+		},
+	}
+	_, err := kubeClient.CoreV1().Secrets("app").Create(context.Background(), bootstrapSecret, metav1.CreateOptions{})
+	assert.Nil(err)
+
+	var secretWrites int32
+	kubeClient.PrependReactor("*", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch action.GetVerb() {
+		case "update", "patch", "delete":
+			atomic.AddInt32(&secretWrites, 1)
+		}
+		return false, nil, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	var electors []*Elector
+	var brokers []*messaging.Broker
+	var rootCerts []*certificate.Certificate
+	for i := 0; i < 3; i++ {
+		// Each replica generates its own CA, but all the replicas must use the one stored first
+		ca, err := tresor.NewCA("osm-ca", time.Hour, "US", "Seattle", "Open Service Mesh")
+		assert.Nil(err)
+		rootCert, err := providers.GetCertificateFromSecret(testNamespace, "osm-ca-bundle", ca, kubeClient)
+		assert.Nil(err)
+		rootCerts = append(rootCerts, rootCert)
+
+		msgBroker := messaging.NewBroker(stop)
+		e := newTestElector(kubeClient, fmt.Sprintf("replica-%d", i))
+		e.AddDuty("proxy-bootstrap-secret", func(leaderStop <-chan struct{}) {
+			k8s.WatchAndUpdateProxyBootstrapSecret(kubeClient, msgBroker, leaderStop)
+		})
+		go e.Run(stop)
+
+		electors = append(electors, e)
+		brokers = append(brokers, msgBroker)
+	}
+
+	for _, rootCert := range rootCerts[1:] {
+		assert.Equal(rootCerts[0].GetCertificateChain(), rootCert.GetCertificateChain())
+		assert.Equal(rootCerts[0].GetSerialNumber(), rootCert.GetSerialNumber())
+	}
+
+	// Every replica observes the pod, as each runs its own informers
+	publishPodAdded := func() {
+		for _, msgBroker := range brokers {
+			msgBroker.GetQueue().Add(events.PubSubMessage{Kind: announcements.PodAdded, NewObj: pod})
+		}
+	}
+	assert.Eventually(func() bool {
+		publishPodAdded()
+		secret, err := kubeClient.CoreV1().Secrets("app").Get(context.Background(), bootstrapSecret.Name, metav1.GetOptions{})
+		return err == nil && len(secret.OwnerReferences) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Subsequent events for the same pod do not update the secret again
+	for i := 0; i < 3; i++ {
+		publishPodAdded()
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets("app").Get(context.Background(), bootstrapSecret.Name, metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Len(secret.OwnerReferences, 1)
+	assert.Equal(int32(1), atomic.LoadInt32(&secretWrites))
+	assert.Equal(1, countLeaders(electors))
+}
+
+func TestLeaderFailoverDoesNotChurnIngressGatewayCert(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	kubeClient := newFakeClientset()
+
+	gatewaySecret := corev1.SecretReference{
+		Name:      "gateway-cert",
+		Namespace: "gateway-ns",
+	}
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{
+		Spec: configv1alpha2.MeshConfigSpec{
+			Certificate: configv1alpha2.CertificateSpec{
+				IngressGateway: &configv1alpha2.IngressGatewayCertSpec{
+					SubjectAltNames:  []string{"foo.bar.cluster.local"},
+					ValidityDuration: "1h",
+					Secret:           gatewaySecret,
+				},
+			},
+		},
+	}).AnyTimes()
+
+	var secretWrites int32
+	kubeClient.PrependReactor("*", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if ns := action.GetNamespace(); ns != gatewaySecret.Namespace {
+			return false, nil, nil
+		}
+		switch action.GetVerb() {
+		case "create", "update", "patch", "delete":
+			atomic.AddInt32(&secretWrites, 1)
+		}
+		return false, nil, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	var electors []*Elector
+	var replicaStops []chan struct{}
+	for i := 0; i < 3; i++ {
+		// Every replica issues certificates using the CA stored first
+		ca, err := tresor.NewCA("osm-ca", time.Hour, "US", "Seattle", "Open Service Mesh")
+		assert.Nil(err)
+		rootCert, err := providers.GetCertificateFromSecret(testNamespace, "osm-ca-bundle", ca, kubeClient)
+		assert.Nil(err)
+
+		msgBroker := messaging.NewBroker(stop)
+		certManager, err := tresor.NewCertManager(rootCert, "Open Service Mesh", mockConfigurator, time.Hour, 2048, msgBroker)
+		assert.Nil(err)
+
+		e := newTestElector(kubeClient, fmt.Sprintf("replica-%d", i))
+		e.AddDuty("ingress-gateway-certificate", func(leaderStop <-chan struct{}) {
+			assert.Nil(ingress.Initialize(kubeClient, nil, leaderStop, mockConfigurator, certManager, msgBroker))
+		})
+		replicaStop := make(chan struct{})
+		go e.Run(replicaStop)
+
+		electors = append(electors, e)
+		replicaStops = append(replicaStops, replicaStop)
+	}
+	defer func() {
+		for i, e := range electors {
+			if e != nil {
+				close(replicaStops[i])
+			}
+		}
+	}()
+
+	getSecretData := func() map[string][]byte {
+		secret, err := kubeClient.CoreV1().Secrets(gatewaySecret.Namespace).Get(context.Background(), gatewaySecret.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		return secret.Data
+	}
+	assert.Eventually(func() bool {
+		return countLeaders(electors) == 1 && getSecretData() != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	issuedData := getSecretData()
+
+	// Stop the leader, so that another replica takes over the leader duties
+	for i, e := range electors {
+		if e != nil && e.IsLeader() {
+			close(replicaStops[i])
+			electors[i] = nil
+			break
+		}
+	}
+	var remaining []*Elector
+	for _, e := range electors {
+		if e != nil {
+			remaining = append(remaining, e)
+		}
+	}
+	assert.Eventually(func() bool {
+		return countLeaders(remaining) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	time.Sleep(500 * time.Millisecond)
+
+	// The new leader reuses the certificate issued by the previous leader
+	assert.Equal(issuedData, getSecretData())
+	assert.Equal(int32(1), atomic.LoadInt32(&secretWrites))
+}
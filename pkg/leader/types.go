@@ -0,0 +1,47 @@
+// Package leader implements the election of a leader among the replicas of a control plane component, so that
+// the duties that must not run concurrently, such as writing shared secrets, are only run by a single replica.
+package leader
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("leader-election")
+
+const (
+	// defaultLeaseDuration is the duration non-leader replicas wait before attempting to acquire a lease
+	// that has not been renewed
+	defaultLeaseDuration = 15 * time.Second
+
+	// defaultRenewDeadline is the duration the leader retries renewing its lease before giving up leadership
+	defaultRenewDeadline = 10 * time.Second
+
+	// defaultRetryPeriod is the duration replicas wait between attempts to acquire or renew the lease
+	defaultRetryPeriod = 2 * time.Second
+)
+
+// Duty is a singleton duty run by the leader. The given stop channel is closed when leadership is lost.
+type Duty func(stop <-chan struct{})
+
+// Elector elects a leader among the replicas sharing a Lease, and runs the singleton duties on the leader
+type Elector struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	leaseName  string
+	identity   string
+
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+
+	mu     sync.Mutex
+	duties map[string]Duty
+
+	// isLeader is 1 while this replica is the leader
+	isLeader int32
+}
@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"fmt"
+	"reflect"
 	"sync/atomic"
 	"time"
 
@@ -122,6 +123,11 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 	batchCount := 0 // number of proxy update events batched per dispatch
 
 	var event proxyUpdateEvent
+
+	// pendingMsgs are the messages of the batched proxy update events, used to determine
+	// the proxies affected by the batch once it is dispatched.
+	var pendingMsgs []events.PubSubMessage
+
 	for {
 		select {
 		case e, ok := <-b.proxyUpdateCh:
@@ -130,6 +136,7 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 				return
 			}
 			event = e
+			pendingMsgs = append(pendingMsgs, e.msg)
 
 			if !dispatchPending {
 				// No proxy update events are pending send on the pub-sub.
@@ -164,12 +171,11 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 				<-maxTimer.C
 			}
 			maxTimer.Reset(noTimeout)
-			b.proxyUpdatePubSub.Pub(event.msg, event.topic)
-			atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
-			metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
+			b.dispatchProxyUpdate(event, pendingMsgs)
 			log.Trace().Msgf("Sliding window expired, msg kind %s, batch size %d", event.msg.Kind, batchCount)
 			dispatchPending = false
 			batchCount = 0
+			pendingMsgs = nil
 
 		case <-maxTimer.C:
 			maxTimer.Reset(noTimeout) // 'maxTimer' drained in this case statement
@@ -179,12 +185,11 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 				<-slidingTimer.C
 			}
 			slidingTimer.Reset(noTimeout)
-			b.proxyUpdatePubSub.Pub(event.msg, event.topic)
-			atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
-			metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
+			b.dispatchProxyUpdate(event, pendingMsgs)
 			log.Trace().Msgf("Max window expired, msg kind %s, batch size %d", event.msg.Kind, batchCount)
 			dispatchPending = false
 			batchCount = 0
+			pendingMsgs = nil
 
 		case <-stopCh:
 			log.Info().Msg("Proxy update dispatcher received stop signal, exiting")
@@ -193,13 +198,85 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 	}
 }
 
+// dispatchProxyUpdate publishes a batched proxy update event to the proxies affected by the given batched
+// messages when a targeter is set and can determine them, otherwise to every proxy.
+func (b *Broker) dispatchProxyUpdate(event proxyUpdateEvent, msgs []events.PubSubMessage) {
+	targeter := b.getProxyUpdateTargeter()
+	if targeter == nil {
+		b.broadcastProxyUpdate(event, -1) // the number of connected proxies is unknown without a targeter
+		return
+	}
+
+	connectedProxies := targeter.GetConnectedProxyCount()
+	proxyUUIDs, ok := targeter.GetAffectedProxies(msgs)
+	if !ok {
+		b.broadcastProxyUpdate(event, connectedProxies)
+		return
+	}
+	if len(proxyUUIDs) == 0 {
+		log.Trace().Msgf("Batch of %d msgs does not affect any connected proxy", len(msgs))
+		metricsstore.DefaultMetricsStore.ProxyUpdateSkippedCount.Add(float64(connectedProxies))
+		return
+	}
+
+	atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
+	for _, proxyUUID := range proxyUUIDs {
+		b.proxyUpdatePubSub.Pub(event.msg, GetPubSubTopicForProxyUUID(proxyUUID))
+	}
+	metricsstore.DefaultMetricsStore.ProxyTargetedEventCount.Inc()
+	metricsstore.DefaultMetricsStore.ProxyUpdateFanout.WithLabelValues("targeted").Observe(float64(len(proxyUUIDs)))
+	if skipped := connectedProxies - len(proxyUUIDs); skipped > 0 {
+		metricsstore.DefaultMetricsStore.ProxyUpdateSkippedCount.Add(float64(skipped))
+	}
+	log.Trace().Msgf("Dispatched msg kind %s to %d of %d proxies", event.msg.Kind, len(proxyUUIDs), connectedProxies)
+}
+
+// broadcastProxyUpdate publishes a batched proxy update event to every proxy
+func (b *Broker) broadcastProxyUpdate(event proxyUpdateEvent, connectedProxies int) {
+	atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
+	b.proxyUpdatePubSub.Pub(event.msg, event.topic)
+	metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
+	if connectedProxies >= 0 {
+		metricsstore.DefaultMetricsStore.ProxyUpdateFanout.WithLabelValues("broadcast").Observe(float64(connectedProxies))
+	}
+}
+
+// SetProxyUpdateTargeter sets the targeter used to determine the proxies affected by the batched
+// events that would otherwise update every proxy. Proxy update events are only published to the
+// affected proxies, unless the targeter cannot determine them.
+func (b *Broker) SetProxyUpdateTargeter(targeter ProxyUpdateTargeter) {
+	b.proxyUpdateTargeterMu.Lock()
+	defer b.proxyUpdateTargeterMu.Unlock()
+	b.proxyUpdateTargeter = targeter
+}
+
+func (b *Broker) getProxyUpdateTargeter() ProxyUpdateTargeter {
+	b.proxyUpdateTargeterMu.RLock()
+	defer b.proxyUpdateTargeterMu.RUnlock()
+	return b.proxyUpdateTargeter
+}
+
+// AddSyncEventHandler adds a handler called for every event before the event results in proxy updates
+func (b *Broker) AddSyncEventHandler(handler SyncEventHandler) {
+	b.syncEventHandlersMu.Lock()
+	defer b.syncEventHandlersMu.Unlock()
+	b.syncEventHandlers = append(b.syncEventHandlers, handler)
+}
+
 // processEvent processes an event dispatched from the workqueue.
 // It does the following:
-// 1. If the event must update a proxy, it publishes a proxy update message
-// 2. Processes other internal control plane events
-// 3. Updates metrics associated with the event
+// 1. Calls the synchronous event handlers, so that proxy updates are not computed from outdated state
+// 2. If the event must update a proxy, it publishes a proxy update message
+// 3. Processes other internal control plane events
+// 4. Updates metrics associated with the event
 func (b *Broker) processEvent(msg events.PubSubMessage) {
 	log.Trace().Msgf("Processing msg kind: %s", msg.Kind)
+	b.syncEventHandlersMu.RLock()
+	for _, handler := range b.syncEventHandlers {
+		handler(msg)
+	}
+	b.syncEventHandlersMu.RUnlock()
+
 	// Update proxies if applicable
 	if event := getProxyUpdateEvent(msg); event != nil {
 		log.Trace().Msgf("Msg kind %s will update proxies", msg.Kind)
@@ -211,7 +288,8 @@ func (b *Broker) processEvent(msg events.PubSubMessage) {
 			atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
 		} else {
 			// Pass the broadcast event to the dispatcher routine, that coalesces
-			// multiple broadcasts received in close proximity.
+			// multiple broadcasts received in close proximity, and determines the
+			// proxies affected by the coalesced events when a targeter is set.
 			b.proxyUpdateCh <- *event
 		}
 	}
@@ -257,6 +335,8 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 		announcements.EndpointAdded, announcements.EndpointDeleted, announcements.EndpointUpdated,
 		// k8s Ingress event
 		announcements.IngressAdded, announcements.IngressDeleted, announcements.IngressUpdated,
+		// Secret event
+		announcements.SecretAdded, announcements.SecretDeleted, announcements.SecretUpdated,
 		//
 		// OSM resource events
 		//
@@ -268,8 +348,16 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 		announcements.RetryPolicyAdded, announcements.RetryPolicyDeleted, announcements.RetryPolicyUpdated,
 		// UpstreamTrafficSetting event
 		announcements.UpstreamTrafficSettingAdded, announcements.UpstreamTrafficSettingDeleted, announcements.UpstreamTrafficSettingUpdated,
+		// ExternalAuthorization event
+		announcements.ExternalAuthorizationAdded, announcements.ExternalAuthorizationDeleted, announcements.ExternalAuthorizationUpdated,
+		// SidecarScope event
+		announcements.SidecarScopeAdded, announcements.SidecarScopeDeleted, announcements.SidecarScopeUpdated,
 		// MulticlusterService event
 		announcements.MultiClusterServiceAdded, announcements.MultiClusterServiceDeleted, announcements.MultiClusterServiceUpdated,
+		// NamespaceConfig event
+		announcements.NamespaceConfigAdded, announcements.NamespaceConfigDeleted, announcements.NamespaceConfigUpdated,
+		// MeshConfig rollout event
+		announcements.MeshConfigRolloutUpdated,
 		//
 		// SMI resource events
 		//
@@ -282,6 +370,17 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 		// SMI TrafficTarget event
 		announcements.TrafficTargetAdded, announcements.TrafficTargetDeleted, announcements.TrafficTargetUpdated,
 		//
+		// Gateway API resource events
+		//
+		// GatewayClass event
+		announcements.GatewayClassAdded, announcements.GatewayClassDeleted, announcements.GatewayClassUpdated,
+		// Gateway event
+		announcements.GatewayAdded, announcements.GatewayDeleted, announcements.GatewayUpdated,
+		// HTTPRoute event
+		announcements.HTTPRouteAdded, announcements.HTTPRouteDeleted, announcements.HTTPRouteUpdated,
+		// TLSRoute event
+		announcements.TLSRouteAdded, announcements.TLSRouteDeleted, announcements.TLSRouteUpdated,
+		//
 		// Proxy events
 		//
 		announcements.ProxyUpdate:
@@ -298,17 +397,9 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 			return nil
 		}
 
-		prevSpec := prevMeshConfig.Spec
-		newSpec := newMeshConfig.Spec
-		// A proxy config update must only be triggered when a MeshConfig field that maps to a proxy config
-		// changes.
-		if prevSpec.Traffic.EnableEgress != newSpec.Traffic.EnableEgress ||
-			prevSpec.Traffic.EnablePermissiveTrafficPolicyMode != newSpec.Traffic.EnablePermissiveTrafficPolicyMode ||
-			prevSpec.Observability.Tracing != newSpec.Observability.Tracing ||
-			prevSpec.Traffic.InboundExternalAuthorization.Enable != newSpec.Traffic.InboundExternalAuthorization.Enable ||
-			// Only trigger an update on InboundExternalAuthorization field changes if the new spec has the 'Enable' flag set to true.
-			(newSpec.Traffic.InboundExternalAuthorization.Enable && (prevSpec.Traffic.InboundExternalAuthorization != newSpec.Traffic.InboundExternalAuthorization)) ||
-			prevSpec.FeatureFlags != newSpec.FeatureFlags {
+		// A proxy config update is triggered for every change considered a proxy config change by staged rollouts,
+		// so that the canary proxies of a rollout are updated whatever field changed
+		if IsProxyConfigChanged(prevMeshConfig, newMeshConfig) {
 			return &proxyUpdateEvent{
 				msg:   msg,
 				topic: announcements.ProxyUpdate.String(),
@@ -340,6 +431,14 @@ func getProxyUpdateEvent(msg events.PubSubMessage) *proxyUpdateEvent {
 	}
 }
 
+// IsProxyConfigChanged returns whether the spec of the given MeshConfigs differs, ignoring the rollout spec which only
+// controls how the changes are rolled out to the proxies
+func IsProxyConfigChanged(prev, cur *configv1alpha2.MeshConfig) bool {
+	prevSpec, curSpec := prev.Spec, cur.Spec
+	prevSpec.Rollout, curSpec.Rollout = configv1alpha2.RolloutSpec{}, configv1alpha2.RolloutSpec{}
+	return !reflect.DeepEqual(prevSpec, curSpec)
+}
+
 // GetPubSubTopicForProxyUUID returns the topic on which PubSubMessages specific to a proxy UUID are published
 func GetPubSubTopicForProxyUUID(uuid string) string {
 	return fmt.Sprintf("proxy:%s", uuid)
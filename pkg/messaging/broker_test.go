@@ -189,6 +189,22 @@ func TestGetProxyUpdateEvent(t *testing.T) {
 			expectEvent:   true,
 			expectedTopic: announcements.ProxyUpdate.String(),
 		},
+		{
+			name: "MeshConfig rollout event",
+			msg: events.PubSubMessage{
+				Kind: announcements.MeshConfigRolloutUpdated,
+			},
+			expectEvent:   true,
+			expectedTopic: announcements.ProxyUpdate.String(),
+		},
+		{
+			name: "NamespaceConfig event",
+			msg: events.PubSubMessage{
+				Kind: announcements.NamespaceConfigUpdated,
+			},
+			expectEvent:   true,
+			expectedTopic: announcements.ProxyUpdate.String(),
+		},
 		{
 			name: "MeshConfig updated to enable permissive mode",
 			msg: events.PubSubMessage{
@@ -220,26 +236,49 @@ func TestGetProxyUpdateEvent(t *testing.T) {
 			expectEvent: false,
 		},
 		{
-			name: "MeshConfig updated with field that does not result in proxy update",
+			name: "MeshConfig updated with rollout spec that does not result in proxy update",
 			msg: events.PubSubMessage{
 				Kind: announcements.MeshConfigUpdated,
 				OldObj: &configv1alpha2.MeshConfig{
 					Spec: configv1alpha2.MeshConfigSpec{
-						Observability: configv1alpha2.ObservabilitySpec{
-							OSMLogLevel: "trace",
+						Rollout: configv1alpha2.RolloutSpec{
+							Enable: false,
 						},
 					},
 				},
 				NewObj: &configv1alpha2.MeshConfig{
 					Spec: configv1alpha2.MeshConfigSpec{
-						Observability: configv1alpha2.ObservabilitySpec{
-							OSMLogLevel: "info",
+						Rollout: configv1alpha2.RolloutSpec{
+							Enable:           true,
+							CanaryPercentage: 10,
 						},
 					},
 				},
 			},
 			expectEvent: false,
 		},
+		{
+			name: "MeshConfig update with sidecar TLS version results in proxy update",
+			msg: events.PubSubMessage{
+				Kind: announcements.MeshConfigUpdated,
+				OldObj: &configv1alpha2.MeshConfig{
+					Spec: configv1alpha2.MeshConfigSpec{
+						Sidecar: configv1alpha2.SidecarSpec{
+							TLSMinProtocolVersion: "TLSv1_2",
+						},
+					},
+				},
+				NewObj: &configv1alpha2.MeshConfig{
+					Spec: configv1alpha2.MeshConfigSpec{
+						Sidecar: configv1alpha2.SidecarSpec{
+							TLSMinProtocolVersion: "TLSv1_3",
+						},
+					},
+				},
+			},
+			expectEvent:   true,
+			expectedTopic: announcements.ProxyUpdate.String(),
+		},
 		{
 			name: "MeshConfig update with feature flags results in proxy update",
 			msg: events.PubSubMessage{
@@ -394,6 +433,135 @@ func TestRunProxyUpdateDispatcher(t *testing.T) {
 	a.EqualValues(b.GetTotalDispatchedProxyEventCount(), 2) // 1 carried over from sliding window test
 }
 
+type fakeProxyUpdateTargeter struct {
+	proxyUUIDs []string
+	ok         bool
+}
+
+func (f fakeProxyUpdateTargeter) GetAffectedProxies(_ []events.PubSubMessage) ([]string, bool) {
+	return f.proxyUUIDs, f.ok
+}
+
+func (f fakeProxyUpdateTargeter) GetConnectedProxyCount() int {
+	return 3
+}
+
+func TestTargetedProxyUpdates(t *testing.T) {
+	testCases := []struct {
+		name               string
+		targeter           ProxyUpdateTargeter
+		expectBroadcast    bool
+		expectedProxies    []string
+		expectedDispatches uint64
+	}{
+		{
+			name:               "no targeter broadcasts to every proxy",
+			targeter:           nil,
+			expectBroadcast:    true,
+			expectedDispatches: 1,
+		},
+		{
+			name:               "affected proxies cannot be determined",
+			targeter:           fakeProxyUpdateTargeter{ok: false},
+			expectBroadcast:    true,
+			expectedDispatches: 1,
+		},
+		{
+			name:               "affected proxies are notified",
+			targeter:           fakeProxyUpdateTargeter{proxyUUIDs: []string{"proxy-1", "proxy-2"}, ok: true},
+			expectedProxies:    []string{"proxy-1", "proxy-2"},
+			expectedDispatches: 1,
+		},
+		{
+			name:               "no proxy affected",
+			targeter:           fakeProxyUpdateTargeter{proxyUUIDs: []string{}, ok: true},
+			expectedDispatches: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			b := NewBroker(stopCh)
+			if tc.targeter != nil {
+				b.SetProxyUpdateTargeter(tc.targeter)
+			}
+
+			broadcastChan := b.GetProxyUpdatePubSub().Sub(announcements.ProxyUpdate.String())
+			defer b.Unsub(b.proxyUpdatePubSub, broadcastChan)
+			proxy1Chan := b.GetProxyUpdatePubSub().Sub(GetPubSubTopicForProxyUUID("proxy-1"))
+			defer b.Unsub(b.proxyUpdatePubSub, proxy1Chan)
+			proxy2Chan := b.GetProxyUpdatePubSub().Sub(GetPubSubTopicForProxyUUID("proxy-2"))
+			defer b.Unsub(b.proxyUpdatePubSub, proxy2Chan)
+			proxy3Chan := b.GetProxyUpdatePubSub().Sub(GetPubSubTopicForProxyUUID("proxy-3"))
+			defer b.Unsub(b.proxyUpdatePubSub, proxy3Chan)
+
+			b.processEvent(events.PubSubMessage{Kind: announcements.EndpointUpdated})
+
+			broadcast := false
+			var notified []string
+			timeout := time.After(proxyUpdateSlidingWindow + 500*time.Millisecond)
+		receive:
+			for {
+				select {
+				case <-broadcastChan:
+					broadcast = true
+				case <-proxy1Chan:
+					notified = append(notified, "proxy-1")
+				case <-proxy2Chan:
+					notified = append(notified, "proxy-2")
+				case <-proxy3Chan:
+					notified = append(notified, "proxy-3")
+				case <-timeout:
+					break receive
+				}
+			}
+
+			a.Equal(tc.expectBroadcast, broadcast)
+			a.ElementsMatch(tc.expectedProxies, notified)
+			a.EqualValues(tc.expectedDispatches, b.GetTotalDispatchedProxyEventCount())
+		})
+	}
+}
+
+type recordingProxyUpdateTargeter struct {
+	batches chan []events.PubSubMessage
+}
+
+func (r recordingProxyUpdateTargeter) GetAffectedProxies(msgs []events.PubSubMessage) ([]string, bool) {
+	r.batches <- msgs
+	return []string{"proxy-1"}, true
+}
+
+func (r recordingProxyUpdateTargeter) GetConnectedProxyCount() int {
+	return 1
+}
+
+func TestProxyUpdatesTargetedAfterCoalescing(t *testing.T) {
+	a := assert.New(t)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	b := NewBroker(stopCh)
+	targeter := recordingProxyUpdateTargeter{batches: make(chan []events.PubSubMessage, 2)}
+	b.SetProxyUpdateTargeter(targeter)
+
+	b.processEvent(events.PubSubMessage{Kind: announcements.EndpointUpdated})
+	b.processEvent(events.PubSubMessage{Kind: announcements.TrafficTargetUpdated})
+
+	// The affected proxies are determined once for the batched events
+	select {
+	case batch := <-targeter.batches:
+		a.Len(batch, 2)
+	case <-time.After(proxyUpdateSlidingWindow + 500*time.Millisecond):
+		a.Fail("expected the affected proxies to be determined for the batched events")
+	}
+	a.Len(targeter.batches, 0)
+}
+
 func TestGetPubSubTopicForProxyUUID(t *testing.T) {
 	a := assert.New(t)
 
@@ -3,6 +3,8 @@
 package messaging
 
 import (
+	"sync"
+
 	"github.com/cskr/pubsub"
 	"k8s.io/client-go/util/workqueue"
 
@@ -24,6 +26,29 @@ type Broker struct {
 	totalQEventCount               uint64
 	totalQProxyEventCount          uint64
 	totalDispatchedProxyEventCount uint64
+
+	// proxyUpdateTargeter determines the proxies affected by an event, nil to broadcast proxy updates to every proxy
+	proxyUpdateTargeter   ProxyUpdateTargeter
+	proxyUpdateTargeterMu sync.RWMutex
+
+	// syncEventHandlers are called for every event before it results in proxy updates
+	syncEventHandlers   []SyncEventHandler
+	syncEventHandlersMu sync.RWMutex
+}
+
+// SyncEventHandler handles an event synchronously on the broker's event processing routine, before the event
+// results in proxy updates or is published to the subscribers of the PubSub instances. It is used to invalidate
+// the state proxy configurations are computed from, and must not block.
+type SyncEventHandler func(msg events.PubSubMessage)
+
+// ProxyUpdateTargeter determines the proxies whose configuration is affected by an event
+type ProxyUpdateTargeter interface {
+	// GetAffectedProxies returns the UUIDs of the proxies whose configuration depends on the resources changed by the given events,
+	// and whether the affected proxies could be determined. When they cannot be determined, every proxy must be updated.
+	GetAffectedProxies(msgs []events.PubSubMessage) ([]string, bool)
+
+	// GetConnectedProxyCount returns the number of connected proxies
+	GetConnectedProxyCount() int
 }
 
 // proxyUpdateEvent specifies the PubSubMessage and topic for an event that
@@ -45,6 +45,15 @@ type MetricsStore struct {
 	// ProxyBroadcastEventCounter is the metric for the total number of ProxyBroadcast events published
 	ProxyBroadcastEventCount prometheus.Counter
 
+	// ProxyTargetedEventCount is the metric for the total number of proxy update events published to the affected proxies only
+	ProxyTargetedEventCount prometheus.Counter
+
+	// ProxyUpdateFanout is the histogram to track the number of proxies notified per proxy update event
+	ProxyUpdateFanout *prometheus.HistogramVec
+
+	// ProxyUpdateSkippedCount is the metric for the total number of proxy updates avoided by notifying the affected proxies only
+	ProxyUpdateSkippedCount prometheus.Counter
+
 	// ProxyResponseSendSuccessCount is the metric for the total number of successful responses sent to the proxies
 	ProxyResponseSendSuccessCount *prometheus.CounterVec
 
@@ -54,10 +63,27 @@ type MetricsStore struct {
 	// ProxyXDSRequestCount counts XDS requests made by proxies
 	ProxyXDSRequestCount *prometheus.CounterVec
 
+	// ProxyXDSPushSkippedCount counts the XDS pushes skipped because the resources are unchanged since last sent to the proxies
+	ProxyXDSPushSkippedCount *prometheus.CounterVec
+
 	// ProxyMaxConnectionsRejected counts the number of proxy connections
 	// rejected due to the max connections limit being reached
 	ProxyMaxConnectionsRejected prometheus.Counter
 
+	// ProxyConnectionsQueued is the metric for the number of new proxy connections waiting to be admitted
+	ProxyConnectionsQueued prometheus.Gauge
+
+	// ProxyConnectionsDelayed counts the number of proxy connections admitted after waiting in the admission queue
+	ProxyConnectionsDelayed prometheus.Counter
+
+	// ProxyConnectionsThrottled counts the number of proxy connections rejected with a backoff hint
+	// due to the admission rate limit being reached
+	ProxyConnectionsThrottled prometheus.Counter
+
+	// ProxyConfigGenerationsQueued is the metric for the number of full proxy configuration generations
+	// waiting due to the max concurrent configuration generations limit being reached
+	ProxyConfigGenerationsQueued prometheus.Gauge
+
 	/*
 	 * Injector metrics
 	 */
@@ -180,6 +206,32 @@ func init() {
 		Help:      "Represents the number of ProxyBroadcast events published by the OSM controller",
 	})
 
+	defaultMetricsStore.ProxyTargetedEventCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "targeted_event_count",
+		Help:      "Represents the number of proxy update events published to the affected proxies only by the OSM controller",
+	})
+
+	defaultMetricsStore.ProxyUpdateFanout = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "update_fanout",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+			Help:      "Histogram to track the number of proxies notified per proxy update event",
+		},
+		[]string{
+			"type", // broadcast or targeted
+		})
+
+	defaultMetricsStore.ProxyUpdateSkippedCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "update_skipped_count",
+		Help:      "Represents the number of proxy updates avoided by notifying only the proxies affected by an event",
+	})
+
 	defaultMetricsStore.ProxyXDSRequestCount = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: metricsRootNamespace,
 		Subsystem: "proxy",
@@ -187,6 +239,13 @@ func init() {
 		Help:      "Represents the number of XDS requests made by proxies",
 	}, []string{"common_name", "type"})
 
+	defaultMetricsStore.ProxyXDSPushSkippedCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "xds_push_skipped_count",
+		Help:      "Represents the number of XDS pushes skipped because the resources are unchanged since last sent to the proxies",
+	}, []string{"type"})
+
 	defaultMetricsStore.ProxyMaxConnectionsRejected = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: metricsRootNamespace,
 		Subsystem: "proxy",
@@ -194,6 +253,34 @@ func init() {
 		Help:      "Represents the number of proxy connections rejected due to the configured max connections limit",
 	})
 
+	defaultMetricsStore.ProxyConnectionsQueued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "connections_queued",
+		Help:      "Represents the number of new proxy connections waiting to be admitted",
+	})
+
+	defaultMetricsStore.ProxyConnectionsDelayed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "connections_delayed",
+		Help:      "Represents the number of proxy connections admitted after waiting due to the configured admission rate limit",
+	})
+
+	defaultMetricsStore.ProxyConnectionsThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "connections_throttled",
+		Help:      "Represents the number of proxy connections rejected with a backoff hint due to the configured admission rate limit",
+	})
+
+	defaultMetricsStore.ProxyConfigGenerationsQueued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "config_generations_queued",
+		Help:      "Represents the number of full proxy configuration generations waiting due to the configured concurrency limit",
+	})
+
 	/*
 	 * Injector metrics
 	 */
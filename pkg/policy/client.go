@@ -34,6 +34,8 @@ func newClient(kubeController k8s.Controller, policyClient policyClientset.Inter
 		ingressBackend:         informerFactory.Policy().V1alpha1().IngressBackends().Informer(),
 		retry:                  informerFactory.Policy().V1alpha1().Retries().Informer(),
 		upstreamTrafficSetting: informerFactory.Policy().V1alpha1().UpstreamTrafficSettings().Informer(),
+		externalAuthorization:  informerFactory.Policy().V1alpha1().ExternalAuthorizations().Informer(),
+		sidecarScope:           informerFactory.Policy().V1alpha1().SidecarScopes().Informer(),
 	}
 
 	cacheCollection := cacheCollection{
@@ -41,6 +43,8 @@ func newClient(kubeController k8s.Controller, policyClient policyClientset.Inter
 		ingressBackend:         informerCollection.ingressBackend.GetStore(),
 		retry:                  informerCollection.retry.GetStore(),
 		upstreamTrafficSetting: informerCollection.upstreamTrafficSetting.GetStore(),
+		externalAuthorization:  informerCollection.externalAuthorization.GetStore(),
+		sidecarScope:           informerCollection.sidecarScope.GetStore(),
 	}
 
 	client := client{
@@ -84,6 +88,20 @@ func newClient(kubeController k8s.Controller, policyClient policyClientset.Inter
 	}
 	informerCollection.upstreamTrafficSetting.AddEventHandler(k8s.GetEventHandlerFuncs(shouldObserve, upstreamTrafficSettingEventTypes, msgBroker))
 
+	externalAuthorizationEventTypes := k8s.EventTypes{
+		Add:    announcements.ExternalAuthorizationAdded,
+		Update: announcements.ExternalAuthorizationUpdated,
+		Delete: announcements.ExternalAuthorizationDeleted,
+	}
+	informerCollection.externalAuthorization.AddEventHandler(k8s.GetEventHandlerFuncs(shouldObserve, externalAuthorizationEventTypes, msgBroker))
+
+	sidecarScopeEventTypes := k8s.EventTypes{
+		Add:    announcements.SidecarScopeAdded,
+		Update: announcements.SidecarScopeUpdated,
+		Delete: announcements.SidecarScopeDeleted,
+	}
+	informerCollection.sidecarScope.AddEventHandler(k8s.GetEventHandlerFuncs(shouldObserve, sidecarScopeEventTypes, msgBroker))
+
 	err := client.run(stop)
 	if err != nil {
 		return client, errors.Errorf("Could not start %s informer clients: %s", policyV1alpha1.SchemeGroupVersion, err)
@@ -104,6 +122,8 @@ func (c client) run(stop <-chan struct{}) error {
 		"IngressBackend":         c.informers.ingressBackend,
 		"Retry":                  c.informers.retry,
 		"UpstreamTrafficSetting": c.informers.upstreamTrafficSetting,
+		"ExternalAuthorization":  c.informers.externalAuthorization,
+		"SidecarScope":           c.informers.sidecarScope,
 	}
 
 	var informerNames []string
@@ -149,6 +169,23 @@ func (c client) ListEgressPoliciesForSourceIdentity(source identity.K8sServiceAc
 	return policies
 }
 
+// ListEgressPolicies lists the Egress policies in the monitored namespaces
+func (c client) ListEgressPolicies() []*policyV1alpha1.Egress {
+	var policies []*policyV1alpha1.Egress
+
+	for _, egressIface := range c.caches.egress.List() {
+		egressPolicy := egressIface.(*policyV1alpha1.Egress)
+
+		if !c.kubeController.IsMonitoredNamespace(egressPolicy.Namespace) {
+			continue
+		}
+
+		policies = append(policies, egressPolicy)
+	}
+
+	return policies
+}
+
 // GetIngressBackendPolicy returns the IngressBackend policy for the given backend MeshService
 func (c client) GetIngressBackendPolicy(svc service.MeshService) *policyV1alpha1.IngressBackend {
 	for _, ingressBackendIface := range c.caches.ingressBackend.List() {
@@ -171,6 +208,23 @@ func (c client) GetIngressBackendPolicy(svc service.MeshService) *policyV1alpha1
 	return nil
 }
 
+// ListIngressBackendPolicies lists the IngressBackend policies in the monitored namespaces
+func (c client) ListIngressBackendPolicies() []*policyV1alpha1.IngressBackend {
+	var policies []*policyV1alpha1.IngressBackend
+
+	for _, ingressBackendIface := range c.caches.ingressBackend.List() {
+		ingressBackend := ingressBackendIface.(*policyV1alpha1.IngressBackend)
+
+		if !c.kubeController.IsMonitoredNamespace(ingressBackend.Namespace) {
+			continue
+		}
+
+		policies = append(policies, ingressBackend)
+	}
+
+	return policies
+}
+
 // ListRetryPolicies returns the retry policies for the given source identity based on service accounts.
 func (c client) ListRetryPolicies(source identity.K8sServiceAccount) []*policyV1alpha1.Retry {
 	var retries []*policyV1alpha1.Retry
@@ -188,6 +242,23 @@ func (c client) ListRetryPolicies(source identity.K8sServiceAccount) []*policyV1
 	return retries
 }
 
+// ListAllRetryPolicies lists the Retry policies in the monitored namespaces
+func (c client) ListAllRetryPolicies() []*policyV1alpha1.Retry {
+	var retries []*policyV1alpha1.Retry
+
+	for _, retryInterface := range c.caches.retry.List() {
+		retry := retryInterface.(*policyV1alpha1.Retry)
+
+		if !c.kubeController.IsMonitoredNamespace(retry.Namespace) {
+			continue
+		}
+
+		retries = append(retries, retry)
+	}
+
+	return retries
+}
+
 // GetUpstreamTrafficSetting returns the UpstreamTrafficSetting resource that matches the given options
 func (c client) GetUpstreamTrafficSetting(options UpstreamTrafficSettingGetOpt) *policyV1alpha1.UpstreamTrafficSetting {
 	if options.MeshService == nil && options.NamespacedName == nil {
@@ -216,3 +287,111 @@ func (c client) GetUpstreamTrafficSetting(options UpstreamTrafficSettingGetOpt)
 
 	return nil
 }
+
+// ListUpstreamTrafficSettings lists the UpstreamTrafficSetting resources in the monitored namespaces
+func (c client) ListUpstreamTrafficSettings() []*policyV1alpha1.UpstreamTrafficSetting {
+	var upstreamTrafficSettings []*policyV1alpha1.UpstreamTrafficSetting
+
+	for _, resource := range c.caches.upstreamTrafficSetting.List() {
+		upstreamTrafficSetting := resource.(*policyV1alpha1.UpstreamTrafficSetting)
+
+		if !c.kubeController.IsMonitoredNamespace(upstreamTrafficSetting.Namespace) {
+			continue
+		}
+
+		upstreamTrafficSettings = append(upstreamTrafficSettings, upstreamTrafficSetting)
+	}
+
+	return upstreamTrafficSettings
+}
+
+// GetExternalAuthorizationPolicy returns the ExternalAuthorization policy applicable to the given MeshService.
+// A policy targeting the service by name takes precedence over a policy applicable to all the services in the
+// namespace. When multiple policies of the same precedence match, the one with the lexicographically smallest
+// name is returned so that the result is deterministic.
+func (c client) GetExternalAuthorizationPolicy(svc service.MeshService) *policyV1alpha1.ExternalAuthorization {
+	var servicePolicy, namespacePolicy *policyV1alpha1.ExternalAuthorization
+
+	for _, extAuthzIface := range c.caches.externalAuthorization.List() {
+		extAuthz := extAuthzIface.(*policyV1alpha1.ExternalAuthorization)
+
+		if extAuthz.Namespace != svc.Namespace {
+			continue
+		}
+
+		if len(extAuthz.Spec.Services) == 0 {
+			if namespacePolicy == nil || extAuthz.Name < namespacePolicy.Name {
+				namespacePolicy = extAuthz
+			}
+			continue
+		}
+
+		for _, svcName := range extAuthz.Spec.Services {
+			if svcName == svc.Name {
+				if servicePolicy == nil || extAuthz.Name < servicePolicy.Name {
+					servicePolicy = extAuthz
+				}
+				break
+			}
+		}
+	}
+
+	if servicePolicy != nil {
+		return servicePolicy
+	}
+	return namespacePolicy
+}
+
+// GetSidecarScope returns the SidecarScope policy applicable to the workloads with the given service account.
+// A policy selecting the service account by name takes precedence over a policy applicable to all the workloads
+// in the namespace. When multiple policies of the same precedence match, the one with the lexicographically
+// smallest name is returned so that the result is deterministic.
+func (c client) GetSidecarScope(svcAccount identity.K8sServiceAccount) *policyV1alpha1.SidecarScope {
+	var serviceAccountScope, namespaceScope *policyV1alpha1.SidecarScope
+
+	for _, scopeIface := range c.caches.sidecarScope.List() {
+		scope := scopeIface.(*policyV1alpha1.SidecarScope)
+
+		if scope.Namespace != svcAccount.Namespace {
+			continue
+		}
+
+		if len(scope.Spec.ServiceAccounts) == 0 {
+			if namespaceScope == nil || scope.Name < namespaceScope.Name {
+				namespaceScope = scope
+			}
+			continue
+		}
+
+		for _, name := range scope.Spec.ServiceAccounts {
+			if name == svcAccount.Name {
+				if serviceAccountScope == nil || scope.Name < serviceAccountScope.Name {
+					serviceAccountScope = scope
+				}
+				break
+			}
+		}
+	}
+
+	if serviceAccountScope != nil {
+		return serviceAccountScope
+	}
+	return namespaceScope
+}
+
+// ListSidecarScopes lists the SidecarScope policies in the monitored namespaces
+func (c client) ListSidecarScopes() []*policyV1alpha1.SidecarScope {
+	var scopes []*policyV1alpha1.SidecarScope
+
+	for _, scopeIface := range c.caches.sidecarScope.List() {
+		scope := scopeIface.(*policyV1alpha1.SidecarScope)
+
+		if !c.kubeController.IsMonitoredNamespace(scope.Namespace) {
+			continue
+		}
+
+		scopes = append(scopes, scope)
+	}
+
+	return scopes
+}
@@ -147,6 +147,72 @@ func TestListEgressPoliciesForSourceIdentity(t *testing.T) {
 	}
 }
 
+func TestListEgressPolicies(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	monitored := &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "egress-1",
+			Namespace: "test",
+		},
+	}
+	unmonitored := &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "egress-2",
+			Namespace: "unmonitored",
+		},
+	}
+
+	c, err := newClient(mockKubeController, fakePolicyClient.NewSimpleClientset(), nil, nil)
+	a.Nil(err)
+	_ = c.caches.egress.Add(monitored)
+	_ = c.caches.egress.Add(unmonitored)
+
+	a.ElementsMatch([]*policyV1alpha1.Egress{monitored}, c.ListEgressPolicies())
+}
+
+func TestListPoliciesInMonitoredNamespaces(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	monitoredMeta := metav1.ObjectMeta{Name: "policy-1", Namespace: "test"}
+	unmonitoredMeta := metav1.ObjectMeta{Name: "policy-2", Namespace: "unmonitored"}
+
+	c, err := newClient(mockKubeController, fakePolicyClient.NewSimpleClientset(), nil, nil)
+	a.Nil(err)
+
+	monitoredIngressBackend := &policyV1alpha1.IngressBackend{ObjectMeta: monitoredMeta}
+	_ = c.caches.ingressBackend.Add(monitoredIngressBackend)
+	_ = c.caches.ingressBackend.Add(&policyV1alpha1.IngressBackend{ObjectMeta: unmonitoredMeta})
+	a.ElementsMatch([]*policyV1alpha1.IngressBackend{monitoredIngressBackend}, c.ListIngressBackendPolicies())
+
+	monitoredRetry := &policyV1alpha1.Retry{ObjectMeta: monitoredMeta}
+	_ = c.caches.retry.Add(monitoredRetry)
+	_ = c.caches.retry.Add(&policyV1alpha1.Retry{ObjectMeta: unmonitoredMeta})
+	a.ElementsMatch([]*policyV1alpha1.Retry{monitoredRetry}, c.ListAllRetryPolicies())
+
+	monitoredUpstreamTrafficSetting := &policyV1alpha1.UpstreamTrafficSetting{ObjectMeta: monitoredMeta}
+	_ = c.caches.upstreamTrafficSetting.Add(monitoredUpstreamTrafficSetting)
+	_ = c.caches.upstreamTrafficSetting.Add(&policyV1alpha1.UpstreamTrafficSetting{ObjectMeta: unmonitoredMeta})
+	a.ElementsMatch([]*policyV1alpha1.UpstreamTrafficSetting{monitoredUpstreamTrafficSetting}, c.ListUpstreamTrafficSettings())
+
+	monitoredSidecarScope := &policyV1alpha1.SidecarScope{ObjectMeta: monitoredMeta}
+	_ = c.caches.sidecarScope.Add(monitoredSidecarScope)
+	_ = c.caches.sidecarScope.Add(&policyV1alpha1.SidecarScope{ObjectMeta: unmonitoredMeta})
+	a.ElementsMatch([]*policyV1alpha1.SidecarScope{monitoredSidecarScope}, c.ListSidecarScopes())
+}
+
 func TestGetIngressBackendPolicy(t *testing.T) {
 	testCases := []struct {
 		name                   string
@@ -619,3 +685,179 @@ func TestGetUpstreamTrafficSetting(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExternalAuthorizationPolicy(t *testing.T) {
+	servicePolicy := &policyV1alpha1.ExternalAuthorization{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc-authz", Namespace: "test"},
+		Spec: policyV1alpha1.ExternalAuthorizationSpec{
+			Services: []string{"s1", "s2"},
+			Address:  "authz.test.svc.cluster.local",
+			Port:     9000,
+		},
+	}
+	namespacePolicy := &policyV1alpha1.ExternalAuthorization{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-authz", Namespace: "test"},
+		Spec: policyV1alpha1.ExternalAuthorizationSpec{
+			Address: "ns-authz.test.svc.cluster.local",
+			Port:    9000,
+		},
+	}
+	otherNamespacePolicy := &policyV1alpha1.ExternalAuthorization{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-authz", Namespace: "other"},
+		Spec: policyV1alpha1.ExternalAuthorizationSpec{
+			Disable: true,
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		allResources   []*policyV1alpha1.ExternalAuthorization
+		svc            service.MeshService
+		expectedPolicy *policyV1alpha1.ExternalAuthorization
+	}{
+		{
+			name:           "no ExternalAuthorization policies",
+			svc:            service.MeshService{Name: "s1", Namespace: "test"},
+			expectedPolicy: nil,
+		},
+		{
+			name:           "service specific policy takes precedence over the namespace wide policy",
+			allResources:   []*policyV1alpha1.ExternalAuthorization{servicePolicy, namespacePolicy, otherNamespacePolicy},
+			svc:            service.MeshService{Name: "s2", Namespace: "test"},
+			expectedPolicy: servicePolicy,
+		},
+		{
+			name:           "namespace wide policy applies to services not targeted by a service specific policy",
+			allResources:   []*policyV1alpha1.ExternalAuthorization{servicePolicy, namespacePolicy, otherNamespacePolicy},
+			svc:            service.MeshService{Name: "s3", Namespace: "test"},
+			expectedPolicy: namespacePolicy,
+		},
+		{
+			name:           "policies in other namespaces do not apply",
+			allResources:   []*policyV1alpha1.ExternalAuthorization{servicePolicy, otherNamespacePolicy},
+			svc:            service.MeshService{Name: "s3", Namespace: "test"},
+			expectedPolicy: nil,
+		},
+		{
+			name: "lexicographically smallest policy name is returned for conflicting policies",
+			allResources: []*policyV1alpha1.ExternalAuthorization{
+				namespacePolicy,
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "a-ns-authz", Namespace: "test"},
+					Spec:       policyV1alpha1.ExternalAuthorizationSpec{Disable: true},
+				},
+			},
+			svc: service.MeshService{Name: "s1", Namespace: "test"},
+			expectedPolicy: &policyV1alpha1.ExternalAuthorization{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-ns-authz", Namespace: "test"},
+				Spec:       policyV1alpha1.ExternalAuthorizationSpec{Disable: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			c, err := newClient(nil, fakePolicyClient.NewSimpleClientset(), nil, nil)
+			a.Nil(err)
+			a.NotNil(c)
+
+			for _, extAuthz := range tc.allResources {
+				_ = c.caches.externalAuthorization.Add(extAuthz)
+			}
+
+			actual := c.GetExternalAuthorizationPolicy(tc.svc)
+			a.Equal(tc.expectedPolicy, actual)
+		})
+	}
+}
+
+func TestGetSidecarScope(t *testing.T) {
+	serviceAccountScope := &policyV1alpha1.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "sa-scope", Namespace: "test"},
+		Spec: policyV1alpha1.SidecarScopeSpec{
+			ServiceAccounts: []string{"sa1", "sa2"},
+			Upstreams:       []policyV1alpha1.SidecarScopeUpstreamSpec{{Namespace: "test"}},
+		},
+	}
+	namespaceScope := &policyV1alpha1.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-scope", Namespace: "test"},
+		Spec: policyV1alpha1.SidecarScopeSpec{
+			Upstreams: []policyV1alpha1.SidecarScopeUpstreamSpec{{Namespace: "test"}, {Namespace: "shared"}},
+		},
+	}
+	otherNamespaceScope := &policyV1alpha1.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-scope", Namespace: "other"},
+		Spec: policyV1alpha1.SidecarScopeSpec{
+			Upstreams: []policyV1alpha1.SidecarScopeUpstreamSpec{{Namespace: "other"}},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		allResources  []*policyV1alpha1.SidecarScope
+		svcAccount    identity.K8sServiceAccount
+		expectedScope *policyV1alpha1.SidecarScope
+	}{
+		{
+			name:          "no SidecarScope policies",
+			svcAccount:    identity.K8sServiceAccount{Name: "sa1", Namespace: "test"},
+			expectedScope: nil,
+		},
+		{
+			name:          "service account specific scope takes precedence over the namespace wide scope",
+			allResources:  []*policyV1alpha1.SidecarScope{serviceAccountScope, namespaceScope, otherNamespaceScope},
+			svcAccount:    identity.K8sServiceAccount{Name: "sa2", Namespace: "test"},
+			expectedScope: serviceAccountScope,
+		},
+		{
+			name:          "namespace wide scope applies to service accounts not selected by a service account specific scope",
+			allResources:  []*policyV1alpha1.SidecarScope{serviceAccountScope, namespaceScope, otherNamespaceScope},
+			svcAccount:    identity.K8sServiceAccount{Name: "sa3", Namespace: "test"},
+			expectedScope: namespaceScope,
+		},
+		{
+			name:          "scopes in other namespaces do not apply",
+			allResources:  []*policyV1alpha1.SidecarScope{serviceAccountScope, otherNamespaceScope},
+			svcAccount:    identity.K8sServiceAccount{Name: "sa3", Namespace: "test"},
+			expectedScope: nil,
+		},
+		{
+			name: "lexicographically smallest scope name is returned for conflicting scopes",
+			allResources: []*policyV1alpha1.SidecarScope{
+				namespaceScope,
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "a-ns-scope", Namespace: "test"},
+					Spec: policyV1alpha1.SidecarScopeSpec{
+						Upstreams: []policyV1alpha1.SidecarScopeUpstreamSpec{{Namespace: "shared"}},
+					},
+				},
+			},
+			svcAccount: identity.K8sServiceAccount{Name: "sa1", Namespace: "test"},
+			expectedScope: &policyV1alpha1.SidecarScope{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-ns-scope", Namespace: "test"},
+				Spec: policyV1alpha1.SidecarScopeSpec{
+					Upstreams: []policyV1alpha1.SidecarScopeUpstreamSpec{{Namespace: "shared"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			c, err := newClient(nil, fakePolicyClient.NewSimpleClientset(), nil, nil)
+			a.Nil(err)
+			a.NotNil(c)
+
+			for _, scope := range tc.allResources {
+				_ = c.caches.sidecarScope.Add(scope)
+			}
+
+			actual := c.GetSidecarScope(tc.svcAccount)
+			a.Equal(tc.expectedScope, actual)
+		})
+	}
+}
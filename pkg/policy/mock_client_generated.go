@@ -36,6 +36,20 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
+// GetExternalAuthorizationPolicy mocks base method.
+func (m *MockController) GetExternalAuthorizationPolicy(arg0 service.MeshService) *v1alpha1.ExternalAuthorization {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalAuthorizationPolicy", arg0)
+	ret0, _ := ret[0].(*v1alpha1.ExternalAuthorization)
+	return ret0
+}
+
+// GetExternalAuthorizationPolicy indicates an expected call of GetExternalAuthorizationPolicy.
+func (mr *MockControllerMockRecorder) GetExternalAuthorizationPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalAuthorizationPolicy", reflect.TypeOf((*MockController)(nil).GetExternalAuthorizationPolicy), arg0)
+}
+
 // GetIngressBackendPolicy mocks base method.
 func (m *MockController) GetIngressBackendPolicy(arg0 service.MeshService) *v1alpha1.IngressBackend {
 	m.ctrl.T.Helper()
@@ -50,6 +64,20 @@ func (mr *MockControllerMockRecorder) GetIngressBackendPolicy(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressBackendPolicy", reflect.TypeOf((*MockController)(nil).GetIngressBackendPolicy), arg0)
 }
 
+// GetSidecarScope mocks base method.
+func (m *MockController) GetSidecarScope(arg0 identity.K8sServiceAccount) *v1alpha1.SidecarScope {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSidecarScope", arg0)
+	ret0, _ := ret[0].(*v1alpha1.SidecarScope)
+	return ret0
+}
+
+// GetSidecarScope indicates an expected call of GetSidecarScope.
+func (mr *MockControllerMockRecorder) GetSidecarScope(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSidecarScope", reflect.TypeOf((*MockController)(nil).GetSidecarScope), arg0)
+}
+
 // GetUpstreamTrafficSetting mocks base method.
 func (m *MockController) GetUpstreamTrafficSetting(arg0 UpstreamTrafficSettingGetOpt) *v1alpha1.UpstreamTrafficSetting {
 	m.ctrl.T.Helper()
@@ -64,6 +92,34 @@ func (mr *MockControllerMockRecorder) GetUpstreamTrafficSetting(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamTrafficSetting", reflect.TypeOf((*MockController)(nil).GetUpstreamTrafficSetting), arg0)
 }
 
+// ListAllRetryPolicies mocks base method.
+func (m *MockController) ListAllRetryPolicies() []*v1alpha1.Retry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllRetryPolicies")
+	ret0, _ := ret[0].([]*v1alpha1.Retry)
+	return ret0
+}
+
+// ListAllRetryPolicies indicates an expected call of ListAllRetryPolicies.
+func (mr *MockControllerMockRecorder) ListAllRetryPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRetryPolicies", reflect.TypeOf((*MockController)(nil).ListAllRetryPolicies))
+}
+
+// ListEgressPolicies mocks base method.
+func (m *MockController) ListEgressPolicies() []*v1alpha1.Egress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEgressPolicies")
+	ret0, _ := ret[0].([]*v1alpha1.Egress)
+	return ret0
+}
+
+// ListEgressPolicies indicates an expected call of ListEgressPolicies.
+func (mr *MockControllerMockRecorder) ListEgressPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEgressPolicies", reflect.TypeOf((*MockController)(nil).ListEgressPolicies))
+}
+
 // ListEgressPoliciesForSourceIdentity mocks base method.
 func (m *MockController) ListEgressPoliciesForSourceIdentity(arg0 identity.K8sServiceAccount) []*v1alpha1.Egress {
 	m.ctrl.T.Helper()
@@ -78,6 +134,20 @@ func (mr *MockControllerMockRecorder) ListEgressPoliciesForSourceIdentity(arg0 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEgressPoliciesForSourceIdentity", reflect.TypeOf((*MockController)(nil).ListEgressPoliciesForSourceIdentity), arg0)
 }
 
+// ListIngressBackendPolicies mocks base method.
+func (m *MockController) ListIngressBackendPolicies() []*v1alpha1.IngressBackend {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngressBackendPolicies")
+	ret0, _ := ret[0].([]*v1alpha1.IngressBackend)
+	return ret0
+}
+
+// ListIngressBackendPolicies indicates an expected call of ListIngressBackendPolicies.
+func (mr *MockControllerMockRecorder) ListIngressBackendPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngressBackendPolicies", reflect.TypeOf((*MockController)(nil).ListIngressBackendPolicies))
+}
+
 // ListRetryPolicies mocks base method.
 func (m *MockController) ListRetryPolicies(arg0 identity.K8sServiceAccount) []*v1alpha1.Retry {
 	m.ctrl.T.Helper()
@@ -91,3 +161,31 @@ func (mr *MockControllerMockRecorder) ListRetryPolicies(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetryPolicies", reflect.TypeOf((*MockController)(nil).ListRetryPolicies), arg0)
 }
+
+// ListSidecarScopes mocks base method.
+func (m *MockController) ListSidecarScopes() []*v1alpha1.SidecarScope {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSidecarScopes")
+	ret0, _ := ret[0].([]*v1alpha1.SidecarScope)
+	return ret0
+}
+
+// ListSidecarScopes indicates an expected call of ListSidecarScopes.
+func (mr *MockControllerMockRecorder) ListSidecarScopes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSidecarScopes", reflect.TypeOf((*MockController)(nil).ListSidecarScopes))
+}
+
+// ListUpstreamTrafficSettings mocks base method.
+func (m *MockController) ListUpstreamTrafficSettings() []*v1alpha1.UpstreamTrafficSetting {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUpstreamTrafficSettings")
+	ret0, _ := ret[0].([]*v1alpha1.UpstreamTrafficSetting)
+	return ret0
+}
+
+// ListUpstreamTrafficSettings indicates an expected call of ListUpstreamTrafficSettings.
+func (mr *MockControllerMockRecorder) ListUpstreamTrafficSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpstreamTrafficSettings", reflect.TypeOf((*MockController)(nil).ListUpstreamTrafficSettings))
+}
@@ -0,0 +1,77 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+// conditions returns the status conditions corresponding to the policy result
+func (r policyResult) conditions(generation int64) []metav1.Condition {
+	accepted := metav1.Condition{
+		Type:               policyv1alpha1.PolicyConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             policyv1alpha1.PolicyReasonAccepted,
+		Message:            "Policy is valid and does not conflict with other policies",
+		ObservedGeneration: generation,
+	}
+	if r.notAcceptedReason != "" {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = r.notAcceptedReason
+		accepted.Message = r.notAcceptedMessage
+	}
+
+	resolvedRefs := metav1.Condition{
+		Type:               policyv1alpha1.PolicyConditionResolvedRefs,
+		Status:             metav1.ConditionTrue,
+		Reason:             policyv1alpha1.PolicyReasonResolvedRefs,
+		Message:            "All the resources referenced by the policy exist",
+		ObservedGeneration: generation,
+	}
+	if len(r.missingRefs) > 0 {
+		resolvedRefs.Status = metav1.ConditionFalse
+		resolvedRefs.Reason = policyv1alpha1.PolicyReasonRefNotFound
+		resolvedRefs.Message = fmt.Sprintf("Referenced resources not found: %s", strings.Join(r.missingRefs, ", "))
+	}
+
+	programmed := metav1.Condition{
+		Type:               policyv1alpha1.PolicyConditionProgrammed,
+		Status:             metav1.ConditionTrue,
+		Reason:             policyv1alpha1.PolicyReasonProgrammed,
+		Message:            fmt.Sprintf("Policy is configured on the proxies of %d ready pods", r.proxyCount),
+		ObservedGeneration: generation,
+	}
+	switch {
+	case r.notAcceptedReason != "":
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = policyv1alpha1.PolicyReasonNotAccepted
+		programmed.Message = "Policy is not accepted"
+
+	case r.proxyCount == 0:
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = policyv1alpha1.PolicyReasonNoProxies
+		programmed.Message = "No ready pod has a proxy configured with the policy"
+	}
+
+	return []metav1.Condition{accepted, resolvedRefs, programmed}
+}
+
+// setConditions sets the given conditions on the existing conditions and returns whether the existing conditions changed.
+// The last transition time of a condition is only updated when its status changes.
+func setConditions(existing *[]metav1.Condition, conditions []metav1.Condition) bool {
+	changed := false
+	for _, condition := range conditions {
+		current := meta.FindStatusCondition(*existing, condition.Type)
+		if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+			current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+			continue
+		}
+		meta.SetStatusCondition(existing, condition)
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,450 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/config"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/validator"
+)
+
+const (
+	kindServiceAccount         = "ServiceAccount"
+	kindService                = "Service"
+	kindNamespace              = "Namespace"
+	kindHTTPRouteGroup         = "HTTPRouteGroup"
+	kindUpstreamTrafficSetting = "UpstreamTrafficSetting"
+)
+
+// NewReporter returns a new Reporter. A nil configController is passed in if multicluster mode is not enabled.
+func NewReporter(kubeController k8s.Controller, policyController policy.Controller, configController config.Controller,
+	meshSpec smi.MeshSpec, meshCatalog catalog.MeshCataloger) *Reporter {
+	return &Reporter{
+		kubeController:   kubeController,
+		policyController: policyController,
+		configController: configController,
+		meshSpec:         meshSpec,
+		meshCatalog:      meshCatalog,
+	}
+}
+
+// Start periodically reports the status of the policy resources until the stop channel is closed
+func (r *Reporter) Start(stop <-chan struct{}) {
+	ticker := time.NewTicker(reportInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			r.reportStatus()
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// reportStatus evaluates the policy resources and updates the status of the ones whose conditions changed
+func (r *Reporter) reportStatus() {
+	proxies := r.listProxies()
+
+	for _, egress := range r.policyController.ListEgressPolicies() {
+		updated := egress.DeepCopy()
+		if setConditions(&updated.Status.Conditions, r.evaluateEgress(egress, proxies).conditions(egress.Generation)) {
+			r.updateStatus(updated)
+		}
+	}
+
+	for _, retry := range r.policyController.ListAllRetryPolicies() {
+		updated := retry.DeepCopy()
+		if setConditions(&updated.Status.Conditions, r.evaluateRetry(retry, proxies).conditions(retry.Generation)) {
+			r.updateStatus(updated)
+		}
+	}
+
+	ingressBackends := r.policyController.ListIngressBackendPolicies()
+	for _, ingressBackend := range ingressBackends {
+		updated := ingressBackend.DeepCopy()
+		if setConditions(&updated.Status.Conditions, r.evaluateIngressBackend(ingressBackend, ingressBackends, proxies).conditions(ingressBackend.Generation)) {
+			r.updateStatus(updated)
+		}
+	}
+
+	upstreamTrafficSettings := r.policyController.ListUpstreamTrafficSettings()
+	outboundServices := make(map[identity.K8sServiceAccount][]service.MeshService)
+	for _, upstreamTrafficSetting := range upstreamTrafficSettings {
+		updated := upstreamTrafficSetting.DeepCopy()
+		result := r.evaluateUpstreamTrafficSetting(upstreamTrafficSetting, upstreamTrafficSettings, proxies, outboundServices)
+		if setConditions(&updated.Status.Conditions, result.conditions(upstreamTrafficSetting.Generation)) {
+			r.updateStatus(updated)
+		}
+	}
+
+	sidecarScopes := r.policyController.ListSidecarScopes()
+	for _, scope := range sidecarScopes {
+		updated := scope.DeepCopy()
+		if setConditions(&updated.Status.Conditions, r.evaluateSidecarScope(scope, sidecarScopes, proxies).conditions(scope.Generation)) {
+			r.updateStatus(updated)
+		}
+	}
+
+	if r.configController == nil {
+		return
+	}
+	multiClusterServices := r.configController.ListMultiClusterServices()
+	for i := range multiClusterServices {
+		mcs := &multiClusterServices[i]
+		updated := mcs.DeepCopy()
+		if setConditions(&updated.Status.Conditions, r.evaluateMultiClusterService(mcs, proxies, outboundServices).conditions(mcs.Generation)) {
+			r.updateStatus(updated)
+		}
+	}
+}
+
+func (r *Reporter) updateStatus(resource interface{}) {
+	obj, err := r.kubeController.UpdateStatus(resource)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error updating status for %T", resource)
+		return
+	}
+	log.Debug().Msgf("Updated status for %T %s/%s", resource, obj.GetNamespace(), obj.GetName())
+}
+
+// listProxies returns the identity and services of the proxies of the ready meshed pods.
+// The proxies are derived from the pods rather than from the connected proxies, because the proxies connect
+// to any replica of the controller while the status is only reported by the leader. A sidecar is only ready
+// once it has received its configuration, so a ready pod is programmed with the policies that apply to it.
+func (r *Reporter) listProxies() []proxyInfo {
+	services := r.kubeController.ListServices()
+
+	var proxies []proxyInfo
+	for _, pod := range r.kubeController.ListPods() {
+		if _, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]; !ok || !isPodReady(pod) {
+			continue
+		}
+		proxy := proxyInfo{identity: identity.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: pod.Namespace}}
+		for _, svc := range services {
+			if svc.Namespace != pod.Namespace || len(svc.Spec.Selector) == 0 {
+				continue
+			}
+			if labels.Set(svc.Spec.Selector).AsSelector().Matches(labels.Set(pod.Labels)) {
+				// The policies are only matched against the name and namespace of the services
+				proxy.services = append(proxy.services, service.MeshService{Name: svc.Name, Namespace: svc.Namespace})
+			}
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies
+}
+
+// isPodReady returns whether the given pod is running and ready
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *Reporter) serviceAccountExists(sa identity.K8sServiceAccount) bool {
+	for _, svcAccount := range r.kubeController.ListServiceAccounts() {
+		if svcAccount.Name == sa.Name && svcAccount.Namespace == sa.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reporter) evaluateEgress(egress *policyv1alpha1.Egress, proxies []proxyInfo) policyResult {
+	var result policyResult
+	if err := validator.ValidateEgress(egress); err != nil {
+		result.notAcceptedReason = policyv1alpha1.PolicyReasonInvalid
+		result.notAcceptedMessage = err.Error()
+	}
+
+	// A TypedLocalObjectReference (Spec.Matches) is a reference to another object in the same namespace
+	for _, match := range egress.Spec.Matches {
+		switch match.Kind {
+		case kindHTTPRouteGroup:
+			if r.meshSpec.GetHTTPRouteGroup(fmt.Sprintf("%s/%s", egress.Namespace, match.Name)) == nil {
+				result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s/%s", match.Kind, egress.Namespace, match.Name))
+			}
+		case kindUpstreamTrafficSetting:
+			namespacedName := types.NamespacedName{Namespace: egress.Namespace, Name: match.Name}
+			if r.policyController.GetUpstreamTrafficSetting(policy.UpstreamTrafficSettingGetOpt{NamespacedName: &namespacedName}) == nil {
+				result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", match.Kind, namespacedName))
+			}
+		}
+	}
+
+	sources := mapset.NewSet()
+	for _, source := range egress.Spec.Sources {
+		if source.Kind != kindServiceAccount {
+			continue
+		}
+		sa := identity.K8sServiceAccount{Name: source.Name, Namespace: source.Namespace}
+		sources.Add(sa)
+		if !r.serviceAccountExists(sa) {
+			result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", source.Kind, sa))
+		}
+	}
+
+	for _, proxy := range proxies {
+		if sources.Contains(proxy.identity) {
+			result.proxyCount++
+		}
+	}
+
+	return result
+}
+
+func (r *Reporter) evaluateRetry(retry *policyv1alpha1.Retry, proxies []proxyInfo) policyResult {
+	var result policyResult
+
+	source := identity.K8sServiceAccount{Name: retry.Spec.Source.Name, Namespace: retry.Spec.Source.Namespace}
+	if retry.Spec.Source.Kind != kindServiceAccount {
+		result.notAcceptedReason = policyv1alpha1.PolicyReasonInvalid
+		result.notAcceptedMessage = fmt.Sprintf("Expected 'source.kind' to be '%s', got: %s", kindServiceAccount, retry.Spec.Source.Kind)
+	} else if !r.serviceAccountExists(source) {
+		result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindServiceAccount, source))
+	}
+
+	for _, dest := range retry.Spec.Destinations {
+		if dest.Kind != kindService {
+			continue
+		}
+		meshSvc := service.MeshService{Name: dest.Name, Namespace: dest.Namespace}
+		if r.kubeController.GetService(meshSvc) == nil {
+			result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindService, meshSvc))
+		}
+	}
+
+	for _, proxy := range proxies {
+		if proxy.identity == source {
+			result.proxyCount++
+		}
+	}
+
+	return result
+}
+
+func (r *Reporter) evaluateIngressBackend(ingressBackend *policyv1alpha1.IngressBackend, all []*policyv1alpha1.IngressBackend, proxies []proxyInfo) policyResult {
+	var result policyResult
+	if err := validator.ValidateIngressBackend(ingressBackend); err != nil {
+		result.notAcceptedReason = policyv1alpha1.PolicyReasonInvalid
+		result.notAcceptedMessage = err.Error()
+	} else {
+		// The oldest policy wins when multiple policies in a namespace specify the same backend
+		var conflicts []string
+		for _, other := range all {
+			if other.Namespace != ingressBackend.Namespace || !isOlder(other.ObjectMeta, ingressBackend.ObjectMeta) {
+				continue
+			}
+			for _, err := range policy.DetectIngressBackendConflicts(*ingressBackend, *other) {
+				conflicts = append(conflicts, err.Error())
+			}
+		}
+		if len(conflicts) > 0 {
+			result.notAcceptedReason = policyv1alpha1.PolicyReasonConflicted
+			result.notAcceptedMessage = strings.Join(conflicts, "; ")
+		}
+	}
+
+	backends := mapset.NewSet()
+	for _, backend := range ingressBackend.Spec.Backends {
+		meshSvc := service.MeshService{Name: backend.Name, Namespace: ingressBackend.Namespace}
+		backends.Add(meshSvc)
+		if r.kubeController.GetService(meshSvc) == nil {
+			result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindService, meshSvc))
+		}
+	}
+
+	for _, source := range ingressBackend.Spec.Sources {
+		if source.Kind != policyv1alpha1.KindService {
+			continue
+		}
+		meshSvc := service.MeshService{Name: source.Name, Namespace: source.Namespace}
+		if r.kubeController.GetService(meshSvc) == nil {
+			result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindService, meshSvc))
+		}
+	}
+
+	for _, proxy := range proxies {
+		for _, svc := range proxy.services {
+			if backends.Contains(service.MeshService{Name: svc.Name, Namespace: svc.Namespace}) {
+				result.proxyCount++
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func (r *Reporter) evaluateUpstreamTrafficSetting(upstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting, all []*policyv1alpha1.UpstreamTrafficSetting,
+	proxies []proxyInfo, outboundServices map[identity.K8sServiceAccount][]service.MeshService) policyResult {
+	var result policyResult
+
+	// The oldest resource wins when multiple resources in a namespace specify the same host
+	for _, other := range all {
+		if other.Namespace == upstreamTrafficSetting.Namespace && other.Spec.Host == upstreamTrafficSetting.Spec.Host &&
+			isOlder(other.ObjectMeta, upstreamTrafficSetting.ObjectMeta) {
+			result.notAcceptedReason = policyv1alpha1.PolicyReasonConflicted
+			result.notAcceptedMessage = fmt.Sprintf("Host %s is already specified in UpstreamTrafficSetting %s/%s",
+				upstreamTrafficSetting.Spec.Host, other.Namespace, other.Name)
+			break
+		}
+	}
+
+	// The host must be the FQDN of a service in the same namespace as the resource
+	host := service.MeshService{Name: k8s.GetServiceFromHostname(upstreamTrafficSetting.Spec.Host), Namespace: upstreamTrafficSetting.Namespace}
+	if host.FQDN() != upstreamTrafficSetting.Spec.Host || r.kubeController.GetService(host) == nil {
+		result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindService, upstreamTrafficSetting.Spec.Host))
+	}
+
+	for _, proxy := range proxies {
+		if serviceInList(host, proxy.services) || serviceInList(host, r.listOutboundServices(proxy.identity, outboundServices)) {
+			result.proxyCount++
+		}
+	}
+
+	return result
+}
+
+func (r *Reporter) evaluateMultiClusterService(mcs *configv1alpha2.MultiClusterService, proxies []proxyInfo,
+	outboundServices map[identity.K8sServiceAccount][]service.MeshService) policyResult {
+	var result policyResult
+	if err := validator.ValidateMultiClusterService(mcs); err != nil {
+		result.notAcceptedReason = policyv1alpha1.PolicyReasonInvalid
+		result.notAcceptedMessage = err.Error()
+	}
+
+	sa := identity.K8sServiceAccount{Name: mcs.Spec.ServiceAccount, Namespace: mcs.Namespace}
+	if !r.serviceAccountExists(sa) {
+		result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindServiceAccount, sa))
+	}
+
+	meshSvc := service.MeshService{Name: mcs.Name, Namespace: mcs.Namespace}
+	for _, proxy := range proxies {
+		if serviceInList(meshSvc, r.listOutboundServices(proxy.identity, outboundServices)) {
+			result.proxyCount++
+		}
+	}
+
+	return result
+}
+
+func (r *Reporter) evaluateSidecarScope(scope *policyv1alpha1.SidecarScope, all []*policyv1alpha1.SidecarScope, proxies []proxyInfo) policyResult {
+	var result policyResult
+	if err := validator.ValidateSidecarScope(scope); err != nil {
+		result.notAcceptedReason = policyv1alpha1.PolicyReasonInvalid
+		result.notAcceptedMessage = err.Error()
+	} else {
+		// The policy with the smallest name wins when multiple policies of the same precedence select a workload
+		for _, other := range all {
+			if other.Namespace != scope.Namespace || other.Name >= scope.Name {
+				continue
+			}
+			if overlap := sidecarScopeOverlap(scope, other); overlap != "" {
+				result.notAcceptedReason = policyv1alpha1.PolicyReasonConflicted
+				result.notAcceptedMessage = fmt.Sprintf("%s is already selected by SidecarScope %s/%s", overlap, other.Namespace, other.Name)
+				break
+			}
+		}
+	}
+
+	for _, name := range scope.Spec.ServiceAccounts {
+		sa := identity.K8sServiceAccount{Name: name, Namespace: scope.Namespace}
+		if !r.serviceAccountExists(sa) {
+			result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindServiceAccount, sa))
+		}
+	}
+
+	for _, upstream := range scope.Spec.Upstreams {
+		if len(upstream.Services) == 0 {
+			if r.kubeController.GetNamespace(upstream.Namespace) == nil {
+				result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindNamespace, upstream.Namespace))
+			}
+			continue
+		}
+		for _, name := range upstream.Services {
+			meshSvc := service.MeshService{Name: name, Namespace: upstream.Namespace}
+			if r.kubeController.GetService(meshSvc) == nil {
+				result.missingRefs = append(result.missingRefs, fmt.Sprintf("%s %s", kindService, meshSvc))
+			}
+		}
+	}
+
+	for _, proxy := range proxies {
+		if applied := r.policyController.GetSidecarScope(proxy.identity); applied != nil &&
+			applied.Namespace == scope.Namespace && applied.Name == scope.Name {
+			result.proxyCount++
+		}
+	}
+
+	return result
+}
+
+// sidecarScopeOverlap describes the workloads selected by both the given SidecarScope policies with the same
+// precedence, or returns an empty string if they do not select the same workloads
+func sidecarScopeOverlap(x, y *policyv1alpha1.SidecarScope) string {
+	if len(x.Spec.ServiceAccounts) == 0 && len(y.Spec.ServiceAccounts) == 0 {
+		return fmt.Sprintf("%s %s", kindNamespace, x.Namespace)
+	}
+	for _, name := range x.Spec.ServiceAccounts {
+		for _, otherName := range y.Spec.ServiceAccounts {
+			if name == otherName {
+				return fmt.Sprintf("%s %s/%s", kindServiceAccount, x.Namespace, name)
+			}
+		}
+	}
+	return ""
+}
+
+// listOutboundServices returns the outbound services for the given identity, caching them for the current evaluation
+func (r *Reporter) listOutboundServices(sa identity.K8sServiceAccount, cache map[identity.K8sServiceAccount][]service.MeshService) []service.MeshService {
+	if services, ok := cache[sa]; ok {
+		return services
+	}
+	services := r.meshCatalog.ListOutboundServicesForIdentity(sa.ToServiceIdentity())
+	cache[sa] = services
+	return services
+}
+
+// serviceInList returns whether a service with the same name and namespace as the given service is in the list
+func serviceInList(svc service.MeshService, services []service.MeshService) bool {
+	for _, s := range services {
+		if s.Name == svc.Name && s.Namespace == svc.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// isOlder returns whether the object x was created before the object y, using the name to break ties
+func isOlder(x, y metav1.ObjectMeta) bool {
+	if !x.CreationTimestamp.Equal(&y.CreationTimestamp) {
+		return x.CreationTimestamp.Before(&y.CreationTimestamp)
+	}
+	return x.Name < y.Name
+}
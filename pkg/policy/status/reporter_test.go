@@ -0,0 +1,397 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+func TestPolicyResultConditions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   policyResult
+		expected map[string]string // condition type -> reason
+	}{
+		{
+			name:   "policy programmed",
+			result: policyResult{proxyCount: 2},
+			expected: map[string]string{
+				policyv1alpha1.PolicyConditionAccepted:     policyv1alpha1.PolicyReasonAccepted,
+				policyv1alpha1.PolicyConditionResolvedRefs: policyv1alpha1.PolicyReasonResolvedRefs,
+				policyv1alpha1.PolicyConditionProgrammed:   policyv1alpha1.PolicyReasonProgrammed,
+			},
+		},
+		{
+			name:   "policy with missing references and no proxies",
+			result: policyResult{missingRefs: []string{"Service ns/svc"}},
+			expected: map[string]string{
+				policyv1alpha1.PolicyConditionAccepted:     policyv1alpha1.PolicyReasonAccepted,
+				policyv1alpha1.PolicyConditionResolvedRefs: policyv1alpha1.PolicyReasonRefNotFound,
+				policyv1alpha1.PolicyConditionProgrammed:   policyv1alpha1.PolicyReasonNoProxies,
+			},
+		},
+		{
+			name:   "policy not accepted",
+			result: policyResult{notAcceptedReason: policyv1alpha1.PolicyReasonConflicted, proxyCount: 1},
+			expected: map[string]string{
+				policyv1alpha1.PolicyConditionAccepted:     policyv1alpha1.PolicyReasonConflicted,
+				policyv1alpha1.PolicyConditionResolvedRefs: policyv1alpha1.PolicyReasonResolvedRefs,
+				policyv1alpha1.PolicyConditionProgrammed:   policyv1alpha1.PolicyReasonNotAccepted,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			conditions := tc.result.conditions(3)
+			a.Len(conditions, len(tc.expected))
+			for _, condition := range conditions {
+				a.Equal(tc.expected[condition.Type], condition.Reason)
+				a.Equal(condition.Reason == policyv1alpha1.PolicyReasonAccepted || condition.Reason == policyv1alpha1.PolicyReasonResolvedRefs ||
+					condition.Reason == policyv1alpha1.PolicyReasonProgrammed, condition.Status == metav1.ConditionTrue)
+				a.EqualValues(3, condition.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestSetConditions(t *testing.T) {
+	a := assert.New(t)
+
+	var conditions []metav1.Condition
+	a.True(setConditions(&conditions, policyResult{}.conditions(1)))
+	a.Len(conditions, 3)
+	transitionTime := meta.FindStatusCondition(conditions, policyv1alpha1.PolicyConditionProgrammed).LastTransitionTime
+
+	// Same conditions
+	a.False(setConditions(&conditions, policyResult{}.conditions(1)))
+
+	// Message changes without a status change
+	time.Sleep(time.Second)
+	a.True(setConditions(&conditions, policyResult{missingRefs: []string{"Service ns/svc"}}.conditions(1)))
+	a.Equal(transitionTime, meta.FindStatusCondition(conditions, policyv1alpha1.PolicyConditionProgrammed).LastTransitionTime)
+
+	// Status change
+	a.True(setConditions(&conditions, policyResult{proxyCount: 1}.conditions(2)))
+	programmed := meta.FindStatusCondition(conditions, policyv1alpha1.PolicyConditionProgrammed)
+	a.Equal(metav1.ConditionTrue, programmed.Status)
+	a.NotEqual(transitionTime, programmed.LastTransitionTime)
+	a.EqualValues(2, programmed.ObservedGeneration)
+}
+
+func TestEvaluateIngressBackend(t *testing.T) {
+	older := &policyv1alpha1.IngressBackend{
+		ObjectMeta: metav1.ObjectMeta{Name: "ib-1", Namespace: "ns", CreationTimestamp: metav1.NewTime(time.Unix(100, 0))},
+		Spec: policyv1alpha1.IngressBackendSpec{
+			Backends: []policyv1alpha1.BackendSpec{{Name: "backend", Port: policyv1alpha1.PortSpec{Number: 80, Protocol: "http"}}},
+			Sources:  []policyv1alpha1.IngressSourceSpec{{Kind: policyv1alpha1.KindService, Name: "ingress", Namespace: "ingress-ns"}},
+		},
+	}
+	newer := older.DeepCopy()
+	newer.Name = "ib-2"
+	newer.CreationTimestamp = metav1.NewTime(time.Unix(200, 0))
+	invalid := older.DeepCopy()
+	invalid.Name = "ib-3"
+	invalid.Spec.Backends[0].Port.Protocol = "tcp"
+
+	testCases := []struct {
+		name               string
+		ingressBackend     *policyv1alpha1.IngressBackend
+		existingServices   []service.MeshService
+		expectedNotAccept  string
+		expectedMissingRef []string
+		expectedProxyCount int
+	}{
+		{
+			name:               "oldest policy is accepted",
+			ingressBackend:     older,
+			existingServices:   []service.MeshService{{Name: "backend", Namespace: "ns"}, {Name: "ingress", Namespace: "ingress-ns"}},
+			expectedProxyCount: 1,
+		},
+		{
+			name:               "newer policy conflicts with the oldest policy",
+			ingressBackend:     newer,
+			existingServices:   []service.MeshService{{Name: "backend", Namespace: "ns"}},
+			expectedNotAccept:  policyv1alpha1.PolicyReasonConflicted,
+			expectedMissingRef: []string{"Service ingress-ns/ingress"},
+			expectedProxyCount: 1,
+		},
+		{
+			name:               "invalid policy",
+			ingressBackend:     invalid,
+			expectedNotAccept:  policyv1alpha1.PolicyReasonInvalid,
+			expectedMissingRef: []string{"Service ns/backend", "Service ingress-ns/ingress"},
+			expectedProxyCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+
+			mockKubeController.EXPECT().GetService(gomock.Any()).DoAndReturn(func(svc service.MeshService) *corev1.Service {
+				for _, s := range tc.existingServices {
+					if s == svc {
+						return &corev1.Service{}
+					}
+				}
+				return nil
+			}).AnyTimes()
+
+			r := &Reporter{kubeController: mockKubeController}
+			proxies := []proxyInfo{
+				{identity: identity.K8sServiceAccount{Name: "backend", Namespace: "ns"}, services: []service.MeshService{{Name: "backend", Namespace: "ns", Port: 80}}},
+				{identity: identity.K8sServiceAccount{Name: "other", Namespace: "ns"}, services: []service.MeshService{{Name: "other", Namespace: "ns"}}},
+			}
+
+			result := r.evaluateIngressBackend(tc.ingressBackend, []*policyv1alpha1.IngressBackend{newer, older, invalid}, proxies)
+			a.Equal(tc.expectedNotAccept, result.notAcceptedReason)
+			a.Equal(tc.expectedMissingRef, result.missingRefs)
+			a.Equal(tc.expectedProxyCount, result.proxyCount)
+		})
+	}
+}
+
+func TestEvaluateUpstreamTrafficSetting(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	hostSvc := service.MeshService{Name: "backend", Namespace: "ns"}
+	older := &policyv1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"},
+		Spec:       policyv1alpha1.UpstreamTrafficSettingSpec{Host: hostSvc.FQDN()},
+	}
+	newer := older.DeepCopy()
+	newer.Name = "b"
+	unknownHost := older.DeepCopy()
+	unknownHost.Name = "c"
+	unknownHost.Spec.Host = "backend.other"
+	all := []*policyv1alpha1.UpstreamTrafficSetting{older, newer, unknownHost}
+
+	mockKubeController.EXPECT().GetService(hostSvc).Return(&corev1.Service{}).AnyTimes()
+	client := identity.K8sServiceAccount{Name: "client", Namespace: "ns"}
+	unrelated := identity.K8sServiceAccount{Name: "unrelated", Namespace: "ns"}
+	mockCatalog.EXPECT().ListOutboundServicesForIdentity(client.ToServiceIdentity()).Return([]service.MeshService{{Name: "backend", Namespace: "ns", Port: 80}}).Times(1)
+	mockCatalog.EXPECT().ListOutboundServicesForIdentity(unrelated.ToServiceIdentity()).Return(nil).Times(1)
+
+	r := &Reporter{kubeController: mockKubeController, meshCatalog: mockCatalog}
+	proxies := []proxyInfo{
+		{identity: identity.K8sServiceAccount{Name: "backend", Namespace: "ns"}, services: []service.MeshService{hostSvc}},
+		{identity: client},
+		{identity: unrelated},
+	}
+	outboundServices := make(map[identity.K8sServiceAccount][]service.MeshService)
+
+	result := r.evaluateUpstreamTrafficSetting(older, all, proxies, outboundServices)
+	a.Equal(policyResult{proxyCount: 2}, result)
+
+	result = r.evaluateUpstreamTrafficSetting(newer, all, proxies, outboundServices)
+	a.Equal(policyv1alpha1.PolicyReasonConflicted, result.notAcceptedReason)
+	a.Equal(2, result.proxyCount)
+
+	result = r.evaluateUpstreamTrafficSetting(unknownHost, all, proxies, outboundServices)
+	a.Empty(result.notAcceptedReason)
+	a.Equal([]string{"Service backend.other"}, result.missingRefs)
+}
+
+func TestEvaluateSidecarScope(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+
+	scoped := identity.K8sServiceAccount{Name: "scoped", Namespace: "ns"}
+	other := identity.K8sServiceAccount{Name: "other", Namespace: "ns"}
+	scope := &policyv1alpha1.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"},
+		Spec: policyv1alpha1.SidecarScopeSpec{
+			ServiceAccounts: []string{scoped.Name},
+			Upstreams: []policyv1alpha1.SidecarScopeUpstreamSpec{
+				{Namespace: "ns", Services: []string{"backend", "unknown"}},
+				{Namespace: "unknown-ns"},
+			},
+		},
+	}
+	conflicting := scope.DeepCopy()
+	conflicting.Name = "b"
+	namespaceScope := &policyv1alpha1.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns"},
+		Spec: policyv1alpha1.SidecarScopeSpec{
+			Upstreams: []policyv1alpha1.SidecarScopeUpstreamSpec{{Namespace: "ns"}},
+		},
+	}
+	invalid := &policyv1alpha1.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "ns"},
+		Spec:       policyv1alpha1.SidecarScopeSpec{ServiceAccounts: []string{other.Name}},
+	}
+	all := []*policyv1alpha1.SidecarScope{scope, conflicting, namespaceScope, invalid}
+
+	mockKubeController.EXPECT().ListServiceAccounts().Return([]*corev1.ServiceAccount{
+		{ObjectMeta: metav1.ObjectMeta{Name: scoped.Name, Namespace: scoped.Namespace}},
+		{ObjectMeta: metav1.ObjectMeta{Name: other.Name, Namespace: other.Namespace}},
+	}).AnyTimes()
+	mockKubeController.EXPECT().GetService(service.MeshService{Name: "backend", Namespace: "ns"}).Return(&corev1.Service{}).AnyTimes()
+	mockKubeController.EXPECT().GetService(service.MeshService{Name: "unknown", Namespace: "ns"}).Return(nil).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace("ns").Return(&corev1.Namespace{}).AnyTimes()
+	mockKubeController.EXPECT().GetNamespace("unknown-ns").Return(nil).AnyTimes()
+	mockPolicyController.EXPECT().GetSidecarScope(scoped).Return(scope).AnyTimes()
+	mockPolicyController.EXPECT().GetSidecarScope(other).Return(namespaceScope).AnyTimes()
+
+	r := &Reporter{kubeController: mockKubeController, policyController: mockPolicyController}
+	proxies := []proxyInfo{{identity: scoped}, {identity: scoped}, {identity: other}}
+
+	result := r.evaluateSidecarScope(scope, all, proxies)
+	a.Empty(result.notAcceptedReason)
+	a.Equal([]string{"Service ns/unknown", "Namespace unknown-ns"}, result.missingRefs)
+	a.Equal(2, result.proxyCount)
+
+	result = r.evaluateSidecarScope(conflicting, all, proxies)
+	a.Equal(policyv1alpha1.PolicyReasonConflicted, result.notAcceptedReason)
+	a.Equal("ServiceAccount ns/scoped is already selected by SidecarScope ns/a", result.notAcceptedMessage)
+	a.Equal(0, result.proxyCount)
+
+	result = r.evaluateSidecarScope(namespaceScope, all, proxies)
+	a.Equal(policyResult{proxyCount: 1}, result)
+
+	result = r.evaluateSidecarScope(invalid, all, proxies)
+	a.Equal(policyv1alpha1.PolicyReasonInvalid, result.notAcceptedReason)
+}
+
+func TestReportStatus(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+
+	source := identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns"}
+	egress := &policyv1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress-1", Namespace: "ns", Generation: 1},
+		Spec: policyv1alpha1.EgressSpec{
+			Sources: []policyv1alpha1.EgressSourceSpec{{Kind: kindServiceAccount, Name: source.Name, Namespace: source.Namespace}},
+			Hosts:   []string{"foo.com"},
+			Ports:   []policyv1alpha1.PortSpec{{Number: 80, Protocol: "http"}},
+		},
+	}
+
+	mockPolicyController.EXPECT().ListEgressPolicies().Return([]*policyv1alpha1.Egress{egress}).Times(1)
+	mockPolicyController.EXPECT().ListAllRetryPolicies().Return(nil).Times(1)
+	mockPolicyController.EXPECT().ListIngressBackendPolicies().Return(nil).Times(1)
+	mockPolicyController.EXPECT().ListUpstreamTrafficSettings().Return(nil).Times(1)
+	mockPolicyController.EXPECT().ListSidecarScopes().Return(nil).Times(1)
+	mockKubeController.EXPECT().ListServiceAccounts().Return([]*corev1.ServiceAccount{
+		{ObjectMeta: metav1.ObjectMeta{Name: source.Name, Namespace: source.Namespace}},
+	}).Times(1)
+
+	// Only the ready pods with a sidecar are counted
+	newPod := func(name string, meshed bool, ready corev1.ConditionStatus) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: source.Namespace, Labels: map[string]string{}},
+			Spec:       corev1.PodSpec{ServiceAccountName: source.Name},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+		if meshed {
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = uuid.New().String()
+		}
+		return pod
+	}
+	mockKubeController.EXPECT().ListServices().Return(nil).Times(2)
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{
+		newPod("ready", true, corev1.ConditionTrue),
+		newPod("not-ready", true, corev1.ConditionFalse),
+		newPod("not-meshed", false, corev1.ConditionTrue),
+	}).Times(2)
+
+	var updated *policyv1alpha1.Egress
+	mockKubeController.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(resource interface{}) (metav1.Object, error) {
+		updated = resource.(*policyv1alpha1.Egress)
+		return updated, nil
+	}).Times(1)
+
+	r := NewReporter(mockKubeController, mockPolicyController, nil, mockMeshSpec, nil)
+	r.reportStatus()
+
+	a.NotNil(updated)
+	a.Empty(egress.Status.Conditions) // the cached resource must not be modified
+	for _, conditionType := range []string{policyv1alpha1.PolicyConditionAccepted, policyv1alpha1.PolicyConditionResolvedRefs, policyv1alpha1.PolicyConditionProgrammed} {
+		a.True(meta.IsStatusConditionTrue(updated.Status.Conditions, conditionType), conditionType)
+	}
+	a.Equal("Policy is configured on the proxies of 1 ready pods", meta.FindStatusCondition(updated.Status.Conditions, policyv1alpha1.PolicyConditionProgrammed).Message)
+
+	// The status is not updated when the conditions do not change
+	mockPolicyController.EXPECT().ListEgressPolicies().Return([]*policyv1alpha1.Egress{updated}).Times(1)
+	mockPolicyController.EXPECT().ListAllRetryPolicies().Return(nil).Times(1)
+	mockPolicyController.EXPECT().ListIngressBackendPolicies().Return(nil).Times(1)
+	mockPolicyController.EXPECT().ListUpstreamTrafficSettings().Return(nil).Times(1)
+	mockPolicyController.EXPECT().ListSidecarScopes().Return(nil).Times(1)
+	mockKubeController.EXPECT().ListServiceAccounts().Return([]*corev1.ServiceAccount{
+		{ObjectMeta: metav1.ObjectMeta{Name: source.Name, Namespace: source.Namespace}},
+	}).Times(1)
+	r.reportStatus()
+}
+
+func TestListProxies(t *testing.T) {
+	a := assert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-1",
+			Namespace: "ns",
+			Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String(), "app": "foo"},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: "sa-1"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	pendingPod := pod.DeepCopy()
+	pendingPod.Name = "pod-2"
+	pendingPod.Status.Phase = corev1.PodPending
+
+	newService := func(name, namespace string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}
+	}
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{pod, pendingPod}).Times(1)
+	mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{
+		newService("foo", "ns", map[string]string{"app": "foo"}),
+		newService("bar", "ns", map[string]string{"app": "bar"}),
+		newService("foo", "other", map[string]string{"app": "foo"}),
+		newService("no-selector", "ns", nil),
+	}).Times(1)
+
+	r := NewReporter(mockKubeController, nil, nil, nil, nil)
+	a.Equal([]proxyInfo{{
+		identity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns"},
+		services: []service.MeshService{{Name: "foo", Namespace: "ns"}},
+	}}, r.listProxies())
+}
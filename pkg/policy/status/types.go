@@ -0,0 +1,53 @@
+// Package status implements the reporting of status conditions on the policy resources, so that users
+// can determine whether a policy has taken effect using 'kubectl get'.
+package status
+
+import (
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/config"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+var log = logger.New("policy-status")
+
+const (
+	// reportInterval is the interval at which the status of the policy resources is evaluated
+	reportInterval = 10 * time.Second
+)
+
+// Reporter evaluates the policy resources and reports their status conditions
+type Reporter struct {
+	kubeController   k8s.Controller
+	policyController policy.Controller
+	configController config.Controller
+	meshSpec         smi.MeshSpec
+	meshCatalog      catalog.MeshCataloger
+}
+
+// proxyInfo is the information about the proxy of a ready meshed pod required to determine the policies it is programmed with
+type proxyInfo struct {
+	identity identity.K8sServiceAccount
+	services []service.MeshService
+}
+
+// policyResult is the outcome of the evaluation of a policy, used to compute its status conditions
+type policyResult struct {
+	// notAcceptedReason is the reason the policy is not accepted, empty if the policy is accepted
+	notAcceptedReason string
+
+	// notAcceptedMessage describes why the policy is not accepted
+	notAcceptedMessage string
+
+	// missingRefs lists the resources referenced by the policy that do not exist
+	missingRefs []string
+
+	// proxyCount is the number of proxies of ready meshed pods programmed with the policy
+	proxyCount int
+}
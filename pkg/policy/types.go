@@ -24,6 +24,8 @@ type informerCollection struct {
 	ingressBackend         cache.SharedIndexInformer
 	retry                  cache.SharedIndexInformer
 	upstreamTrafficSetting cache.SharedIndexInformer
+	externalAuthorization  cache.SharedIndexInformer
+	sidecarScope           cache.SharedIndexInformer
 }
 
 // cacheCollection is the type used to represent the collection of caches for the policy.openservicemesh.io API group
@@ -32,6 +34,8 @@ type cacheCollection struct {
 	ingressBackend         cache.Store
 	retry                  cache.Store
 	upstreamTrafficSetting cache.Store
+	externalAuthorization  cache.Store
+	sidecarScope           cache.Store
 }
 
 // client is the type used to represent the Kubernetes client for the policy.openservicemesh.io API group
@@ -46,14 +50,35 @@ type Controller interface {
 	// ListEgressPoliciesForSourceIdentity lists the Egress policies for the given source identity
 	ListEgressPoliciesForSourceIdentity(identity.K8sServiceAccount) []*policyV1alpha1.Egress
 
+	// ListEgressPolicies lists the Egress policies in the monitored namespaces
+	ListEgressPolicies() []*policyV1alpha1.Egress
+
 	// GetIngressBackendPolicy returns the IngressBackend policy for the given backend MeshService
 	GetIngressBackendPolicy(service.MeshService) *policyV1alpha1.IngressBackend
 
+	// ListIngressBackendPolicies lists the IngressBackend policies in the monitored namespaces
+	ListIngressBackendPolicies() []*policyV1alpha1.IngressBackend
+
 	// ListRetryPolicies returns the Retry policies for the given source identity
 	ListRetryPolicies(identity.K8sServiceAccount) []*policyV1alpha1.Retry
 
+	// ListAllRetryPolicies lists the Retry policies in the monitored namespaces
+	ListAllRetryPolicies() []*policyV1alpha1.Retry
+
 	// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting resource that matches the given options
 	GetUpstreamTrafficSetting(UpstreamTrafficSettingGetOpt) *policyv1alpha1.UpstreamTrafficSetting
+
+	// ListUpstreamTrafficSettings lists the UpstreamTrafficSetting resources in the monitored namespaces
+	ListUpstreamTrafficSettings() []*policyv1alpha1.UpstreamTrafficSetting
+
+	// GetExternalAuthorizationPolicy returns the ExternalAuthorization policy applicable to the given MeshService
+	GetExternalAuthorizationPolicy(service.MeshService) *policyV1alpha1.ExternalAuthorization
+
+	// GetSidecarScope returns the SidecarScope policy applicable to the workloads with the given service account
+	GetSidecarScope(identity.K8sServiceAccount) *policyV1alpha1.SidecarScope
+
+	// ListSidecarScopes lists the SidecarScope policies in the monitored namespaces
+	ListSidecarScopes() []*policyV1alpha1.SidecarScope
 }
 
 // UpstreamTrafficSettingGetOpt specifies the options used to filter UpstreamTrafficSetting objects as a part of its getter
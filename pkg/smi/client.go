@@ -47,6 +47,15 @@ func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interfa
 	smiTrafficSpecClientSet := smiTrafficSpecClient.NewForConfigOrDie(smiKubeConfig)
 	smiTrafficTargetClientSet := smiAccessClient.NewForConfigOrDie(smiKubeConfig)
 
+	return NewMeshSpecClientFromClientsets(kubeClient, smiTrafficSplitClientSet, smiTrafficSpecClientSet, smiTrafficTargetClientSet,
+		osmNamespace, kubeController, stop, msgBroker)
+}
+
+// NewMeshSpecClientFromClientsets implements mesh.MeshSpec using the given SMI clientsets, such as fake clientsets
+// when running the control plane without a Kubernetes cluster.
+func NewMeshSpecClientFromClientsets(kubeClient kubernetes.Interface, smiTrafficSplitClientSet smiTrafficSplitClient.Interface,
+	smiTrafficSpecClientSet smiTrafficSpecClient.Interface, smiTrafficTargetClientSet smiAccessClient.Interface, osmNamespace string,
+	kubeController k8s.Controller, stop chan struct{}, msgBroker *messaging.Broker) (MeshSpec, error) {
 	client, err := newSMIClient(
 		kubeClient,
 		smiTrafficSplitClientSet,
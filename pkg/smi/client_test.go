@@ -48,7 +48,7 @@ func bootstrapClient(stop chan struct{}) (*client, *fakeKubeClientSet, error) {
 	smiTrafficSpecClientSet := testTrafficSpecClient.NewSimpleClientset()
 	smiTrafficTargetClientSet := testTrafficTargetClient.NewSimpleClientset()
 	msgBroker := messaging.NewBroker(stop)
-	kubernetesClient, err := k8s.NewKubernetesController(kubeClient, nil, meshName, stop, msgBroker)
+	kubernetesClient, err := k8s.NewKubernetesController(kubeClient, nil, nil, meshName, stop, msgBroker)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,10 @@
+package trafficpolicy
+
+// DNSRecord is the type used to represent a hostname answered by the DNS proxy of a sidecar
+type DNSRecord struct {
+	// Hostname defines the fully qualified hostname of the record
+	Hostname string
+
+	// IPs defines the IP addresses the hostname resolves to
+	IPs []string
+}
@@ -1,7 +1,11 @@
 package trafficpolicy
 
 import (
+	"k8s.io/apimachinery/pkg/types"
+
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // EgressTrafficPolicy is the type used to represent the different egress traffic policy configurations
@@ -44,6 +48,49 @@ type EgressClusterConfig struct {
 
 	// UpstreamTrafficSetting is the traffic setting for the upstream cluster
 	UpstreamTrafficSetting *policyv1alpha1.UpstreamTrafficSetting
+
+	// TLS defines the TLS origination settings for the external cluster.
+	// If unspecified, traffic is forwarded to the external cluster as is.
+	// +optional
+	TLS *EgressTLSConfig
+
+	// Gateway defines the egress gateway traffic to the external cluster is routed through.
+	// If specified, the cluster's endpoint is the egress gateway instead of the external host,
+	// and TLS origination to the external host is performed by the egress gateway.
+	// +optional
+	Gateway *EgressGatewayConfig
+}
+
+// EgressGatewayConfig is the type used to represent the egress gateway an external cluster is routed through
+type EgressGatewayConfig struct {
+	// Service defines the MeshService of the egress gateway
+	Service service.MeshService
+
+	// Port defines the port number the egress gateway accepts mTLS connections on
+	Port int
+
+	// ServerName defines the SNI used by the egress gateway to match the connection to the external cluster
+	ServerName string
+}
+
+// EgressTLSConfig is the type used to represent the TLS origination settings for an external cluster
+type EgressTLSConfig struct {
+	// SNI defines the server name indicated to the external cluster in the TLS handshake
+	SNI string
+
+	// CABundleSecret defines the Secret holding the CA bundle used to validate the certificate
+	// presented by the external cluster. If unspecified, the certificate is validated using the
+	// system trust store, unless InsecureSkipVerify is set.
+	// +optional
+	CABundleSecret *types.NamespacedName
+
+	// InsecureSkipVerify defines whether the certificate presented by the external cluster is not validated
+	InsecureSkipVerify bool
+
+	// ClientCertificateSecret defines the Secret holding the client certificate presented to
+	// the external cluster.
+	// +optional
+	ClientCertificateSecret *types.NamespacedName
 }
 
 // EgressHTTPRouteConfig is the type used to represent an HTTP route configuration along with associated routing rules
@@ -69,3 +116,22 @@ type EgressHTTPRoutingRule struct {
 	// AllowedDestinationIPRanges defines the destination IP ranges allowed for the `Route` defined in the routing rule.
 	AllowedDestinationIPRanges []string
 }
+
+// EgressGatewayTrafficPolicy is the type used to represent the traffic policy configurations of the egress gateway
+type EgressGatewayTrafficPolicy struct {
+	// Destinations defines the list of external destinations the egress gateway forwards traffic to
+	Destinations []*EgressGatewayDestination
+}
+
+// EgressGatewayDestination is the type used to represent an external destination of the egress gateway
+type EgressGatewayDestination struct {
+	// ServerName defines the SNI sidecars indicate in the mTLS handshake with the egress gateway
+	// to route traffic to this destination
+	ServerName string
+
+	// Cluster defines the external cluster traffic to this destination is forwarded to
+	Cluster *EgressClusterConfig
+
+	// AllowedSourceIdentities defines the list of service identities allowed to access this destination
+	AllowedSourceIdentities []identity.ServiceIdentity
+}
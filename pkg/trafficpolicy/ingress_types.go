@@ -1,5 +1,11 @@
 package trafficpolicy
 
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
 // IngressTrafficPolicy defines the ingress traffic match and routes for a given backend
 type IngressTrafficPolicy struct {
 	TrafficMatches    []*IngressTrafficMatch
@@ -15,3 +21,58 @@ type IngressTrafficMatch struct {
 	ServerNames              []string
 	SkipClientCertValidation bool
 }
+
+// IngressGatewayTrafficPolicy defines the listeners and upstream clusters of the OSM ingress gateway
+// programmed from Gateway API resources
+type IngressGatewayTrafficPolicy struct {
+	// Listeners is the list of listeners of the ingress gateway, one per port
+	Listeners []*IngressGatewayListener
+
+	// ClustersConfigs is the list of upstream mesh clusters the ingress gateway routes traffic to
+	ClustersConfigs []*MeshClusterConfig
+}
+
+// IngressGatewayListener defines a port of the ingress gateway and how the traffic it accepts is routed
+type IngressGatewayListener struct {
+	// Name is the name of the listener
+	Name string
+
+	// Port is the port the listener accepts traffic on
+	Port uint32
+
+	// Protocol is the protocol of the listener, one of http, https or tls
+	Protocol string
+
+	// TLSTerminations is the list of certificates presented by an https listener per set of SNI hostnames
+	TLSTerminations []*IngressGatewayTLSTermination
+
+	// HTTPRouteConfigs is the list of virtual hosts of an http or https listener
+	HTTPRouteConfigs []*OutboundTrafficPolicy
+
+	// TLSRoutes is the list of routes of a tls listener passing the TLS traffic through to the upstream clusters
+	TLSRoutes []*IngressGatewayTLSRoute
+}
+
+// IngressGatewayTLSTermination defines the certificate presented by the ingress gateway for a set of SNI hostnames
+type IngressGatewayTLSTermination struct {
+	// Name is the name of the TLS termination, used to name the filter chain terminating TLS
+	Name string
+
+	// Hostnames is the list of SNI hostnames the certificate is presented for, any hostname when empty
+	Hostnames []string
+
+	// CertificateSecret is the Secret holding the certificate and private key presented to clients
+	CertificateSecret types.NamespacedName
+}
+
+// IngressGatewayTLSRoute defines the upstream clusters TLS traffic for a set of SNI hostnames is passed through to
+type IngressGatewayTLSRoute struct {
+	// Name is the name of the route, used to name the filter chain passing the TLS traffic through
+	Name string
+
+	// Hostnames is the list of SNI hostnames matched by the route, any hostname when empty
+	Hostnames []string
+
+	// WeightedClusters is the list of upstream clusters the traffic is passed through to
+	WeightedClusters []service.WeightedCluster
+}
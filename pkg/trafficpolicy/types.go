@@ -57,6 +57,10 @@ type InboundTrafficPolicy struct {
 	Name      string   `json:"name:omitempty"`
 	Hostnames []string `json:"hostnames"`
 	Rules     []*Rule  `json:"rules:omitempty"`
+
+	// AuditMode indicates the Rules must be audited instead of enforced: all requests are allowed, and
+	// requests the Rules would deny are reported by the proxy.
+	AuditMode bool `json:"audit_mode,omitempty"`
 }
 
 // Rule is a struct that represents which service identities (authenticated principals) can access a Route
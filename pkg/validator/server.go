@@ -56,9 +56,11 @@ func NewValidatingWebhook(webhookConfigName, osmNamespace, osmVersion, meshName
 
 	v := &validatingWebhookServer{
 		validators: map[string]validateFunc{
-			policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend").String(): ingressBackendValidator,
-			policyv1alpha1.SchemeGroupVersion.WithKind("Egress").String():         egressValidator,
-			smiAccess.SchemeGroupVersion.WithKind("TrafficTarget").String():       trafficTargetValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend").String():        ingressBackendValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("Egress").String():                egressValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("ExternalAuthorization").String(): externalAuthorizationValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("SidecarScope").String():          sidecarScopeValidator,
+			smiAccess.SchemeGroupVersion.WithKind("TrafficTarget").String():              trafficTargetValidator,
 		},
 	}
 
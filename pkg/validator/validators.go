@@ -82,6 +82,11 @@ func ingressBackendValidator(req *admissionv1.AdmissionRequest) (*admissionv1.Ad
 		return nil, err
 	}
 
+	return nil, ValidateIngressBackend(ingressBackend)
+}
+
+// ValidateIngressBackend validates the given IngressBackend policy
+func ValidateIngressBackend(ingressBackend *policyv1alpha1.IngressBackend) error {
 	for _, backend := range ingressBackend.Spec.Backends {
 		// Validate port
 		switch strings.ToLower(backend.Port.Protocol) {
@@ -100,11 +105,11 @@ func ingressBackendValidator(req *admissionv1.AdmissionRequest) (*admissionv1.Ad
 			}
 
 			if backend.TLS.SkipClientCertValidation && !authenticatedSourceFound {
-				return nil, errors.Errorf("HTTPS ingress with client certificate validation enabled must specify at least one 'AuthenticatedPrincipal` source")
+				return errors.Errorf("HTTPS ingress with client certificate validation enabled must specify at least one 'AuthenticatedPrincipal` source")
 			}
 
 		default:
-			return nil, errors.Errorf("Expected 'port.protocol' to be 'http' or 'https', got: %s", backend.Port.Protocol)
+			return errors.Errorf("Expected 'port.protocol' to be 'http' or 'https', got: %s", backend.Port.Protocol)
 		}
 	}
 
@@ -114,29 +119,29 @@ func ingressBackendValidator(req *admissionv1.AdmissionRequest) (*admissionv1.Ad
 		// Add validation for source kinds here
 		case policyv1alpha1.KindService:
 			if source.Name == "" {
-				return nil, errors.Errorf("'source.name' not specified for source kind %s", policyv1alpha1.KindService)
+				return errors.Errorf("'source.name' not specified for source kind %s", policyv1alpha1.KindService)
 			}
 			if source.Namespace == "" {
-				return nil, errors.Errorf("'source.namespace' not specified for source kind %s", policyv1alpha1.KindService)
+				return errors.Errorf("'source.namespace' not specified for source kind %s", policyv1alpha1.KindService)
 			}
 
 		case policyv1alpha1.KindAuthenticatedPrincipal:
 			if source.Name == "" {
-				return nil, errors.Errorf("'source.name' not specified for source kind %s", policyv1alpha1.KindAuthenticatedPrincipal)
+				return errors.Errorf("'source.name' not specified for source kind %s", policyv1alpha1.KindAuthenticatedPrincipal)
 			}
 
 		case policyv1alpha1.KindIPRange:
 			if _, _, err := net.ParseCIDR(source.Name); err != nil {
-				return nil, errors.Errorf("Invalid 'source.name' value specified for IPRange. Expected CIDR notation 'a.b.c.d/x', got '%s'", source.Name)
+				return errors.Errorf("Invalid 'source.name' value specified for IPRange. Expected CIDR notation 'a.b.c.d/x', got '%s'", source.Name)
 			}
 
 		default:
-			return nil, errors.Errorf("Invalid 'source.kind' value specified. Must be one of: %s, %s, %s",
+			return errors.Errorf("Invalid 'source.kind' value specified. Must be one of: %s, %s, %s",
 				policyv1alpha1.KindService, policyv1alpha1.KindAuthenticatedPrincipal, policyv1alpha1.KindIPRange)
 		}
 	}
 
-	return nil, nil
+	return nil
 }
 
 // egressValidator validates the Egress custom resource
@@ -146,6 +151,11 @@ func egressValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionR
 		return nil, err
 	}
 
+	return nil, ValidateEgress(egress)
+}
+
+// ValidateEgress validates the given Egress policy
+func ValidateEgress(egress *policyv1alpha1.Egress) error {
 	// Validate match references
 	allowedAPIGroups := []string{smiSpecs.SchemeGroupVersion.String(), policyv1alpha1.SchemeGroupVersion.String()}
 	upstreamTrafficSettingMatchCount := 0
@@ -157,7 +167,7 @@ func egressValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionR
 				// no additional validation
 
 			default:
-				return nil, errors.Errorf("Expected 'matches.kind' for match '%s' to be 'HTTPRouteGroup', got: %s", m.Name, m.Kind)
+				return errors.Errorf("Expected 'matches.kind' for match '%s' to be 'HTTPRouteGroup', got: %s", m.Name, m.Kind)
 			}
 
 		case policyv1alpha1.SchemeGroupVersion.String():
@@ -166,22 +176,139 @@ func egressValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionR
 				upstreamTrafficSettingMatchCount++
 
 			default:
-				return nil, errors.Errorf("Expected 'matches.kind' for match '%s' to be 'UpstreamTrafficSetting', got: %s", m.Name, m.Kind)
+				return errors.Errorf("Expected 'matches.kind' for match '%s' to be 'UpstreamTrafficSetting', got: %s", m.Name, m.Kind)
 			}
 
 		default:
-			return nil, errors.Errorf("Expected 'matches.apiGroup' to be one of %v, got: %s", allowedAPIGroups, *m.APIGroup)
+			return errors.Errorf("Expected 'matches.apiGroup' to be one of %v, got: %s", allowedAPIGroups, *m.APIGroup)
 		}
 	}
 
 	// Can't have more than 1 UpstreamTrafficSetting match for an Egress policy
 	if upstreamTrafficSettingMatchCount > 1 {
-		return nil, errors.New("Cannot have more than 1 UpstreamTrafficSetting match")
+		return errors.New("Cannot have more than 1 UpstreamTrafficSetting match")
+	}
+
+	// Wildcard hosts must be of the form '*.example.com'
+	for _, host := range egress.Spec.Hosts {
+		if strings.Contains(host, "*") && (!strings.HasPrefix(host, "*.") || strings.Count(host, "*") > 1 || len(host) == len("*.")) {
+			return errors.Errorf("Invalid wildcard host %s, wildcard hosts must be of the form '*.example.com'", host)
+		}
+	}
+
+	// TLS origination is only applicable to HTTP ports
+	if egress.Spec.TLS != nil {
+		hasHTTPPort := false
+		for _, port := range egress.Spec.Ports {
+			if strings.ToLower(port.Protocol) == constants.ProtocolHTTP {
+				hasHTTPPort = true
+				break
+			}
+		}
+		if !hasHTTPPort {
+			return errors.Errorf("Expected at least one port with protocol '%s' when 'tls' is specified", constants.ProtocolHTTP)
+		}
+		if egress.Spec.TLS.InsecureSkipVerify && egress.Spec.TLS.CABundleSecret != "" {
+			return errors.New("Cannot specify both 'tls.caBundleSecret' and 'tls.insecureSkipVerify'")
+		}
+	}
+
+	// The egress gateway routes traffic based on the host, so only host based HTTP and HTTPS traffic can be routed via it
+	if egress.Spec.RouteViaGateway {
+		if len(egress.Spec.Hosts) == 0 {
+			return errors.New("Expected 'hosts' to be specified when 'routeViaGateway' is set")
+		}
+		for _, port := range egress.Spec.Ports {
+			if protocol := strings.ToLower(port.Protocol); protocol != constants.ProtocolHTTP && protocol != constants.ProtocolHTTPS {
+				return errors.Errorf("Expected port protocol to be '%s' or '%s' when 'routeViaGateway' is set, got: %s",
+					constants.ProtocolHTTP, constants.ProtocolHTTPS, port.Protocol)
+			}
+		}
+	}
+
+	return nil
+}
+
+// externalAuthorizationValidator validates the ExternalAuthorization custom resource
+func externalAuthorizationValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	extAuthz := &policyv1alpha1.ExternalAuthorization{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(extAuthz); err != nil {
+		return nil, err
+	}
+
+	// A disabled policy only opts the targeted services out of external authorization
+	if extAuthz.Spec.Disable {
+		return nil, nil
+	}
+
+	if extAuthz.Spec.Address == "" {
+		return nil, errors.New("Expected 'address' to be specified when 'disable' is not set")
+	}
+	if extAuthz.Spec.Port == 0 {
+		return nil, errors.New("Expected 'port' to be specified when 'disable' is not set")
+	}
+
+	switch protocol := strings.ToLower(extAuthz.Spec.Protocol); protocol {
+	case "", constants.ProtocolGRPC:
+		// The headers forwarded to a gRPC external authorization service cannot be filtered
+		if len(extAuthz.Spec.ForwardedHeaders) > 0 {
+			return nil, errors.Errorf("Expected 'protocol' to be '%s' when 'forwardedHeaders' is specified", constants.ProtocolHTTP)
+		}
+		if extAuthz.Spec.PathPrefix != "" {
+			return nil, errors.Errorf("Expected 'protocol' to be '%s' when 'pathPrefix' is specified", constants.ProtocolHTTP)
+		}
+
+	case constants.ProtocolHTTP:
+		// Valid
+
+	default:
+		return nil, errors.Errorf("Expected 'protocol' to be '%s' or '%s', got: %s", constants.ProtocolGRPC, constants.ProtocolHTTP, extAuthz.Spec.Protocol)
+	}
+
+	if extAuthz.Spec.WithRequestBody != nil && extAuthz.Spec.WithRequestBody.MaxRequestBytes == 0 {
+		return nil, errors.New("Expected 'withRequestBody.maxRequestBytes' to be greater than 0")
+	}
+
+	for _, prefix := range extAuthz.Spec.ExemptPathPrefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			return nil, errors.Errorf("Invalid exempt path prefix %s, path prefixes must start with '/'", prefix)
+		}
 	}
 
 	return nil, nil
 }
 
+// sidecarScopeValidator validates the SidecarScope custom resource
+func sidecarScopeValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	scope := &policyv1alpha1.SidecarScope{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(scope); err != nil {
+		return nil, err
+	}
+
+	return nil, ValidateSidecarScope(scope)
+}
+
+// ValidateSidecarScope validates the given SidecarScope policy
+func ValidateSidecarScope(scope *policyv1alpha1.SidecarScope) error {
+	if len(scope.Spec.Upstreams) == 0 {
+		return errors.New("Expected 'upstreams' to be specified")
+	}
+
+	upstreamNamespaces := make(map[string]struct{})
+	for _, upstream := range scope.Spec.Upstreams {
+		if upstream.Namespace == "" {
+			return errors.New("Expected 'upstreams.namespace' to be specified")
+		}
+		// Services in the same namespace must be specified in a single upstream
+		if _, ok := upstreamNamespaces[upstream.Namespace]; ok {
+			return errors.Errorf("Duplicate upstream namespace %s, each namespace must be specified once", upstream.Namespace)
+		}
+		upstreamNamespaces[upstream.Namespace] = struct{}{}
+	}
+
+	return nil
+}
+
 // MultiClusterServiceValidator validates the MultiClusterService CRD.
 func MultiClusterServiceValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
 	config := &configv1alpha2.MultiClusterService{}
@@ -189,28 +316,33 @@ func MultiClusterServiceValidator(req *admissionv1.AdmissionRequest) (*admission
 		return nil, err
 	}
 
+	return nil, ValidateMultiClusterService(config)
+}
+
+// ValidateMultiClusterService validates the given MultiClusterService
+func ValidateMultiClusterService(config *configv1alpha2.MultiClusterService) error {
 	clusterNames := make(map[string]bool)
 
 	for _, cluster := range config.Spec.Clusters {
 		if len(strings.TrimSpace(cluster.Name)) == 0 {
-			return nil, errors.New("Cluster name is not valid")
+			return errors.New("Cluster name is not valid")
 		}
 		if _, ok := clusterNames[cluster.Name]; ok {
-			return nil, errors.Errorf("Cluster named %s already exists", cluster.Name)
+			return errors.Errorf("Cluster named %s already exists", cluster.Name)
 		}
 		if len(strings.TrimSpace(cluster.Address)) == 0 {
-			return nil, errors.Errorf("Cluster address %s is not valid", cluster.Address)
+			return errors.Errorf("Cluster address %s is not valid", cluster.Address)
 		}
 		clusterAddress := strings.Split(cluster.Address, ":")
 		if net.ParseIP(clusterAddress[0]) == nil {
-			return nil, errors.Errorf("Error parsing IP address %s", cluster.Address)
+			return errors.Errorf("Error parsing IP address %s", cluster.Address)
 		}
 		_, err := strconv.ParseUint(clusterAddress[1], 10, 32)
 		if err != nil {
-			return nil, errors.Errorf("Error parsing port value %s", cluster.Address)
+			return errors.Errorf("Error parsing port value %s", cluster.Address)
 		}
 		clusterNames[cluster.Name] = true
 	}
 
-	return nil, nil
+	return nil
 }
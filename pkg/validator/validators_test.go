@@ -588,6 +588,227 @@ func TestEgressValidator(t *testing.T) {
 			expResp:   nil,
 			expErrStr: "Cannot have more than 1 UpstreamTrafficSetting match",
 		},
+		{
+			name: "Egress with a valid wildcard host and TLS origination passes",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["*.foo.com", "bar.com"],
+							"ports": [
+								{
+								"number": 80,
+								"protocol": "http"
+								}
+							],
+							"tls": {
+								"port": 443,
+								"caBundleSecret": "ca"
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "Egress with an invalid wildcard host is invalid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["foo.*.com"],
+							"ports": [
+								{
+								"number": 443,
+								"protocol": "https"
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid wildcard host foo.*.com, wildcard hosts must be of the form '*.example.com'",
+		},
+		{
+			name: "Egress with TLS origination and no HTTP port is invalid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["foo.com"],
+							"ports": [
+								{
+								"number": 443,
+								"protocol": "https"
+								}
+							],
+							"tls": {
+								"caBundleSecret": "ca"
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected at least one port with protocol 'http' when 'tls' is specified",
+		},
+		{
+			name: "Egress with TLS origination specifying both a CA bundle and insecureSkipVerify is invalid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["foo.com"],
+							"ports": [
+								{
+								"number": 80,
+								"protocol": "http"
+								}
+							],
+							"tls": {
+								"caBundleSecret": "ca",
+								"insecureSkipVerify": true
+							}
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Cannot specify both 'tls.caBundleSecret' and 'tls.insecureSkipVerify'",
+		},
+		{
+			name: "Egress routed via the egress gateway is valid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["foo.com"],
+							"ports": [
+								{
+								"number": 80,
+								"protocol": "http"
+								},
+								{
+								"number": 443,
+								"protocol": "https"
+								}
+							],
+							"routeViaGateway": true
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "Egress routed via the egress gateway without hosts is invalid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"ipAddresses": ["10.0.0.0/8"],
+							"ports": [
+								{
+								"number": 443,
+								"protocol": "https"
+								}
+							],
+							"routeViaGateway": true
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'hosts' to be specified when 'routeViaGateway' is set",
+		},
+		{
+			name: "Egress routed via the egress gateway with a TCP port is invalid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "Egress",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "Egress",
+						"spec": {
+							"hosts": ["foo.com"],
+							"ports": [
+								{
+								"number": 5432,
+								"protocol": "tcp"
+								}
+							],
+							"routeViaGateway": true
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected port protocol to be 'http' or 'https' when 'routeViaGateway' is set, got: tcp",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -598,6 +819,350 @@ func TestEgressValidator(t *testing.T) {
 			assert.Equal(tc.expResp, resp)
 			if err != nil {
 				assert.Equal(tc.expErrStr, err.Error())
+			} else {
+				assert.Empty(tc.expErrStr)
+			}
+		})
+	}
+}
+
+func TestExternalAuthorizationValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     *admissionv1.AdmissionRequest
+		expResp   *admissionv1.AdmissionResponse
+		expErrStr string
+	}{
+		{
+			name: "disabled policy passes",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"services": ["s1"], "disable": true}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "gRPC policy passes",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"address": "authz.test.svc.cluster.local", "port": 9000, "withRequestBody": {"maxRequestBytes": 8192}, "exemptPathPrefixes": ["/healthz"]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "HTTP policy with forwarded headers passes",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"protocol": "http", "address": "authz.test.svc.cluster.local", "port": 8080, "pathPrefix": "/authz", "forwardedHeaders": ["authorization"]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "address is not specified",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"port": 9000}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'address' to be specified when 'disable' is not set",
+		},
+		{
+			name: "port is not specified",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"address": "authz.test.svc.cluster.local"}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'port' to be specified when 'disable' is not set",
+		},
+		{
+			name: "protocol is invalid",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"protocol": "tcp", "address": "authz.test.svc.cluster.local", "port": 9000}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'protocol' to be 'grpc' or 'http', got: tcp",
+		},
+		{
+			name: "forwarded headers with the gRPC protocol",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"protocol": "grpc", "address": "authz.test.svc.cluster.local", "port": 9000, "forwardedHeaders": ["authorization"]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'protocol' to be 'http' when 'forwardedHeaders' is specified",
+		},
+		{
+			name: "path prefix with the gRPC protocol",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"address": "authz.test.svc.cluster.local", "port": 9000, "pathPrefix": "/authz"}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'protocol' to be 'http' when 'pathPrefix' is specified",
+		},
+		{
+			name: "request body without a maximum size",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"address": "authz.test.svc.cluster.local", "port": 9000, "withRequestBody": {"allowPartialMessage": true}}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'withRequestBody.maxRequestBytes' to be greater than 0",
+		},
+		{
+			name: "exempt path prefix without a leading slash",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "ExternalAuthorization",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "ExternalAuthorization",
+						"spec": {"address": "authz.test.svc.cluster.local", "port": 9000, "exemptPathPrefixes": ["healthz"]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid exempt path prefix healthz, path prefixes must start with '/'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			resp, err := externalAuthorizationValidator(tc.input)
+			assert.Equal(tc.expResp, resp)
+			if err != nil {
+				assert.Equal(tc.expErrStr, err.Error())
+			} else {
+				assert.Empty(tc.expErrStr)
+			}
+		})
+	}
+}
+
+func TestSidecarScopeValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     *admissionv1.AdmissionRequest
+		expResp   *admissionv1.AdmissionResponse
+		expErrStr string
+	}{
+		{
+			name: "valid policy passes",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "SidecarScope",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "SidecarScope",
+						"spec": {"serviceAccounts": ["sa1"], "upstreams": [{"namespace": "ns1"}, {"namespace": "ns2", "services": ["s1"]}]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "",
+		},
+		{
+			name: "policy without upstreams fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "SidecarScope",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "SidecarScope",
+						"spec": {"serviceAccounts": ["sa1"]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'upstreams' to be specified",
+		},
+		{
+			name: "upstream without namespace fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "SidecarScope",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "SidecarScope",
+						"spec": {"upstreams": [{"services": ["s1"]}]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Expected 'upstreams.namespace' to be specified",
+		},
+		{
+			name: "duplicate upstream namespace fails",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "SidecarScope",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha1",
+						"kind": "SidecarScope",
+						"spec": {"upstreams": [{"namespace": "ns1", "services": ["s1"]}, {"namespace": "ns1", "services": ["s2"]}]}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Duplicate upstream namespace ns1, each namespace must be specified once",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			resp, err := sidecarScopeValidator(tc.input)
+			assert.Equal(tc.expResp, resp)
+			if err != nil {
+				assert.Equal(tc.expErrStr, err.Error())
+			} else {
+				assert.Empty(tc.expErrStr)
 			}
 		})
 	}
@@ -0,0 +1,407 @@
+package scenarios
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smiAccessClientFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smiTrafficSpecClientFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	smiTrafficSplitClientFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	k8sYAML "k8s.io/apimachinery/pkg/util/yaml"
+	k8sClientFake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/cds"
+	"github.com/openservicemesh/osm/pkg/envoy/eds"
+	"github.com/openservicemesh/osm/pkg/envoy/lds"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	configClientFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	policyClientFake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/providers/kube"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+const (
+	// scenarioInputFile is the name of the file with the Kubernetes, SMI and OSM resources of a scenario
+	scenarioInputFile = "input.yaml"
+
+	// scenarioExpectedDir is the name of the directory with the golden files of a scenario
+	scenarioExpectedDir = "expected"
+
+	scenarioMeshName       = "osm"
+	scenarioOSMNamespace   = "osm-system"
+	scenarioMeshConfigName = "osm-mesh-config"
+	scenarioCertValidity   = 24 * time.Hour
+)
+
+// scenarioGenerators are the xDS generators whose output is compared to the golden files, keyed by the golden file name
+var scenarioGenerators = map[string]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error){
+	"cds.yaml": cds.NewResponse,
+	"eds.yaml": eds.NewResponse,
+	"lds.yaml": lds.NewResponse,
+	"rds.yaml": rds.NewResponse,
+}
+
+// scenario is a set of Kubernetes, SMI and OSM resources loaded from a scenario directory
+type scenario struct {
+	kubeObjects   []runtime.Object
+	accessObjects []runtime.Object
+	specObjects   []runtime.Object
+	splitObjects  []runtime.Object
+	configObjects []runtime.Object
+	policyObjects []runtime.Object
+}
+
+// scenarioProxy is the sidecar of a pod of a scenario
+type scenarioProxy struct {
+	pod   *corev1.Pod
+	proxy *envoy.Proxy
+}
+
+// goldenName returns the path of the proxy's golden files relative to the scenario's expected directory
+func (p scenarioProxy) goldenName() string {
+	return filepath.Join(p.pod.Namespace, p.pod.Name)
+}
+
+// loadScenario loads the resources of the scenario in the given directory
+func loadScenario(dir string) (*scenario, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(filepath.Join(dir, scenarioInputFile)))
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		smiAccess.AddToScheme,
+		smiSpecs.AddToScheme,
+		smiSplit.AddToScheme,
+		configv1alpha2.AddToScheme,
+		policyv1alpha1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	s := &scenario{}
+	namespaces := make(map[string]bool)
+	hasMeshConfig := false
+	reader := k8sYAML.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding resource in %s", scenarioInputFile)
+		}
+
+		switch gvk.Group {
+		case "":
+			s.kubeObjects = append(s.kubeObjects, obj)
+		case smiAccess.SchemeGroupVersion.Group:
+			s.accessObjects = append(s.accessObjects, obj)
+		case smiSpecs.SchemeGroupVersion.Group:
+			s.specObjects = append(s.specObjects, obj)
+		case smiSplit.SchemeGroupVersion.Group:
+			s.splitObjects = append(s.splitObjects, obj)
+		case configv1alpha2.SchemeGroupVersion.Group:
+			s.configObjects = append(s.configObjects, obj)
+			hasMeshConfig = hasMeshConfig || gvk.Kind == "MeshConfig"
+		case policyv1alpha1.SchemeGroupVersion.Group:
+			s.policyObjects = append(s.policyObjects, obj)
+		default:
+			return nil, errors.Errorf("unsupported resource %s in %s", gvk, scenarioInputFile)
+		}
+
+		if ns, ok := obj.(*corev1.Namespace); ok {
+			namespaces[ns.Name] = true
+		} else if objMeta, ok := obj.(metav1.Object); ok && objMeta.GetNamespace() != "" {
+			if _, ok := namespaces[objMeta.GetNamespace()]; !ok {
+				namespaces[objMeta.GetNamespace()] = false
+			}
+		}
+	}
+
+	// Namespaces that are not defined are created as monitored namespaces, to keep the scenarios concise
+	for namespace, defined := range namespaces {
+		if defined || namespace == scenarioOSMNamespace {
+			continue
+		}
+		s.kubeObjects = append(s.kubeObjects, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: scenarioMeshName},
+			},
+		})
+	}
+
+	if !hasMeshConfig {
+		s.configObjects = append(s.configObjects, &configv1alpha2.MeshConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      scenarioMeshConfigName,
+				Namespace: scenarioOSMNamespace,
+			},
+			Spec: configv1alpha2.MeshConfigSpec{
+				Certificate: configv1alpha2.CertificateSpec{
+					ServiceCertValidityDuration: scenarioCertValidity.String(),
+				},
+			},
+		})
+	}
+
+	return s, nil
+}
+
+// run runs the control plane components against the scenario's resources, and returns the output of the xDS
+// generators for each proxy of the scenario, keyed by the path of the golden file relative to the expected directory
+func (s *scenario) run(ca *certificate.Certificate) (map[string]string, error) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	kubeClient := k8sClientFake.NewSimpleClientset(s.kubeObjects...)
+	policyClient := policyClientFake.NewSimpleClientset(s.policyObjects...)
+	configClient := configClientFake.NewSimpleClientset(s.configObjects...)
+	smiAccessClient := smiAccessClientFake.NewSimpleClientset(s.accessObjects...)
+	smiSpecClient := smiTrafficSpecClientFake.NewSimpleClientset(s.specObjects...)
+	smiSplitClient := smiTrafficSplitClientFake.NewSimpleClientset(s.splitObjects...)
+
+	// Wire the control plane components like osm-controller does
+	msgBroker := messaging.NewBroker(stop)
+	cfg := configurator.NewConfigurator(configClient, stop, scenarioOSMNamespace, scenarioMeshConfigName, msgBroker)
+	k8sClient, err := k8s.NewKubernetesController(kubeClient, policyClient, configClient, scenarioMeshName, stop, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+	meshSpec, err := smi.NewMeshSpecClientFromClientsets(kubeClient, smiSplitClient, smiSpecClient, smiAccessClient, scenarioOSMNamespace, k8sClient, stop, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+	certManager, err := tresor.NewCertManager(ca, "Open Service Mesh Scenarios", cfg, scenarioCertValidity, 0, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+	policyController, err := policy.NewPolicyController(k8sClient, policyClient, stop, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+	kubeProvider := kube.NewClient(k8sClient, nil, cfg)
+	meshCatalog := catalog.NewMeshCatalog(k8sClient, meshSpec, certManager, policyController, nil, stop, cfg,
+		[]service.Provider{kubeProvider}, []endpoint.Provider{kubeProvider}, msgBroker)
+	proxyRegistry := registry.NewProxyRegistry(&registry.KubeProxyServiceMapper{KubeController: k8sClient}, msgBroker)
+
+	proxies, err := s.proxies()
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(map[string]string)
+	for _, p := range proxies {
+		for fileName, generator := range scenarioGenerators {
+			resources, err := generator(meshCatalog, p.proxy, nil, cfg, certManager, proxyRegistry)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error generating %s for proxy %s", fileName, p.goldenName())
+			}
+			marshalled, err := marshalResources(resources)
+			if err != nil {
+				return nil, err
+			}
+			output[filepath.Join(p.goldenName(), fileName)] = marshalled
+		}
+	}
+	return output, nil
+}
+
+// proxies returns the sidecars of the pods of the scenario, i.e. the pods with a proxy UUID label
+func (s *scenario) proxies() ([]scenarioProxy, error) {
+	var proxies []scenarioProxy
+	for _, obj := range s.kubeObjects {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		proxyUUID, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]
+		if !ok {
+			continue
+		}
+		parsedUUID, err := uuid.Parse(proxyUUID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing the proxy UUID of pod %s/%s", pod.Namespace, pod.Name)
+		}
+
+		cn := envoy.NewXDSCertCommonName(parsedUUID, envoy.KindSidecar, pod.Spec.ServiceAccountName, pod.Namespace)
+		proxy, err := envoy.NewProxy(cn, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		proxy.PodMetadata = &envoy.PodMetadata{
+			UID:       string(pod.UID),
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			ServiceAccount: identity.K8sServiceAccount{
+				Namespace: pod.Namespace,
+				Name:      pod.Spec.ServiceAccountName,
+			},
+		}
+		proxies = append(proxies, scenarioProxy{pod: pod, proxy: proxy})
+	}
+	return proxies, nil
+}
+
+// marshalResources marshals the given xDS resources into YAML, sorted by name so that the output is stable
+func marshalResources(resources []types.Resource) (string, error) {
+	sort.Slice(resources, func(i, j int) bool {
+		return cache.GetResourceName(resources[i]) < cache.GetResourceName(resources[j])
+	})
+	for _, res := range resources {
+		normalizeResource(res)
+	}
+
+	marshalOptions := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}
+	objs := make([]interface{}, 0, len(resources))
+	for _, res := range resources {
+		configJSON, err := marshalOptions.Marshal(res.(proto.Message))
+		if err != nil {
+			return "", err
+		}
+		// yaml.Unmarshal is used instead of json.Unmarshal to preserve the number types, refer to
+		// MarshalXdsStructAndSaveToFile in pkg/injector/test.
+		var obj interface{}
+		if err := yaml.Unmarshal(configJSON, &obj); err != nil {
+			return "", err
+		}
+		objs = append(objs, obj)
+	}
+	configYAML, err := yaml.Marshal(objs)
+	if err != nil {
+		return "", err
+	}
+	return string(configYAML), nil
+}
+
+// normalizeResource sorts the lists of the given xDS resource whose order is not deterministic and has no effect on
+// the proxy's behavior: filter chains are selected by their match, virtual hosts by their domains, and the clusters
+// of a weighted route and the endpoints of a cluster have no precedence.
+func normalizeResource(res types.Resource) {
+	switch r := res.(type) {
+	case *xds_listener.Listener:
+		sort.SliceStable(r.FilterChains, func(i, j int) bool {
+			return r.FilterChains[i].Name < r.FilterChains[j].Name
+		})
+
+	case *xds_route.RouteConfiguration:
+		sort.SliceStable(r.VirtualHosts, func(i, j int) bool {
+			return r.VirtualHosts[i].Name < r.VirtualHosts[j].Name
+		})
+		for _, virtualHost := range r.VirtualHosts {
+			for _, route := range virtualHost.Routes {
+				if weightedClusters := route.GetRoute().GetWeightedClusters(); weightedClusters != nil {
+					sort.SliceStable(weightedClusters.Clusters, func(i, j int) bool {
+						return weightedClusters.Clusters[i].Name < weightedClusters.Clusters[j].Name
+					})
+				}
+			}
+		}
+
+	case *xds_endpoint.ClusterLoadAssignment:
+		for _, localityEndpoints := range r.Endpoints {
+			sort.SliceStable(localityEndpoints.LbEndpoints, func(i, j int) bool {
+				return localityEndpoints.LbEndpoints[i].GetEndpoint().GetAddress().GetSocketAddress().GetAddress() <
+					localityEndpoints.LbEndpoints[j].GetEndpoint().GetAddress().GetSocketAddress().GetAddress()
+			})
+		}
+	}
+}
+
+// loadGoldenFiles returns the content of the golden files in the given expected directory, keyed by their path
+// relative to it
+func loadGoldenFiles(expectedDir string) (map[string]string, error) {
+	golden := make(map[string]string)
+	err := filepath.Walk(expectedDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(expectedDir, path)
+		if err != nil {
+			return err
+		}
+		golden[relPath] = string(content)
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return golden, nil
+	}
+	return golden, err
+}
+
+// writeGoldenFiles replaces the golden files in the given expected directory with the given output
+func writeGoldenFiles(expectedDir string, output map[string]string) error {
+	if err := os.RemoveAll(expectedDir); err != nil {
+		return err
+	}
+	for relPath, content := range output {
+		path := filepath.Join(expectedDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			return errors.Wrapf(err, "error writing golden file %s", path)
+		}
+	}
+	return nil
+}
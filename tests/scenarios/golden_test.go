@@ -0,0 +1,71 @@
+package scenarios
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+)
+
+const scenariosDir = "testdata"
+
+var update = flag.Bool("update", false, "Regenerate the golden files of the scenarios in "+scenariosDir)
+
+// TestGoldenScenarios runs the xDS generators against the resources of each scenario in the testdata directory, and
+// compares their output for each proxy to the scenario's golden files.
+// Run with -update to regenerate the golden files after an intended change to the generated configuration.
+func TestGoldenScenarios(t *testing.T) {
+	assert := tassert.New(t)
+
+	ca, err := tresor.NewCA("osm-ca.openservicemesh.io", time.Hour, "US", "CA", "Open Service Mesh Scenarios")
+	assert.Nil(err)
+
+	dirs, err := ioutil.ReadDir(scenariosDir)
+	assert.Nil(err)
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		scenarioDir := filepath.Join(scenariosDir, dir.Name())
+		t.Run(dir.Name(), func(t *testing.T) {
+			assert := tassert.New(t)
+
+			s, err := loadScenario(scenarioDir)
+			assert.Nil(err)
+			if err != nil {
+				return
+			}
+			output, err := s.run(ca)
+			assert.Nil(err)
+			if err != nil {
+				return
+			}
+
+			expectedDir := filepath.Join(scenarioDir, scenarioExpectedDir)
+			if *update {
+				assert.Nil(writeGoldenFiles(expectedDir, output))
+				return
+			}
+
+			golden, err := loadGoldenFiles(expectedDir)
+			assert.Nil(err)
+			for relPath, actual := range output {
+				expected, ok := golden[relPath]
+				if !assert.True(ok, "Missing golden file %s, run the test with -update to generate it", filepath.Join(expectedDir, relPath)) {
+					continue
+				}
+				assert.Equal(expected, actual, "Generated configuration does not match golden file %s, run the test with -update to regenerate it if the change is intended", filepath.Join(expectedDir, relPath))
+			}
+			for relPath := range golden {
+				_, ok := output[relPath]
+				assert.True(ok, "Golden file %s has no matching proxy, run the test with -update to remove it", filepath.Join(expectedDir, relPath))
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package xdsload
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"math/rand"
+	"sort"
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// simulatedEnvoy is an xDS client behaving like an Envoy proxy connected to the ADS server: it subscribes to the
+// resources referenced by the clusters and listeners it receives, and ACKs or NACKs every response.
+type simulatedEnvoy struct {
+	cn        certificate.CommonName
+	cert      *certificate.Certificate
+	nackRatio float64
+	rand      *rand.Rand
+	churn     *churnTracker
+	stats     *clientStats
+
+	// The following track the state of the subscriptions, by TypeURI
+	lastVersion    map[envoy.TypeURI]string
+	lastNonce      map[envoy.TypeURI]string
+	subscribed     map[envoy.TypeURI][]string
+	received       map[envoy.TypeURI]bool
+	secretsFromCDS []string
+	secretsFromLDS []string
+
+	connectedAt    time.Time
+	configured     bool
+	lastChurnSeen  uint64
+	configuredChan chan struct{}
+}
+
+func newSimulatedEnvoy(cn certificate.CommonName, cert *certificate.Certificate, nackRatio float64, seed int64, churn *churnTracker, stats *clientStats) *simulatedEnvoy {
+	return &simulatedEnvoy{
+		cn:             cn,
+		cert:           cert,
+		nackRatio:      nackRatio,
+		rand:           rand.New(rand.NewSource(seed)), // #nosec G404
+		churn:          churn,
+		stats:          stats,
+		lastVersion:    make(map[envoy.TypeURI]string),
+		lastNonce:      make(map[envoy.TypeURI]string),
+		subscribed:     make(map[envoy.TypeURI][]string),
+		received:       make(map[envoy.TypeURI]bool),
+		configuredChan: make(chan struct{}),
+	}
+}
+
+// run connects to the ADS server at the given address and processes the responses until the context is canceled
+func (e *simulatedEnvoy) run(ctx context.Context, addr string) error {
+	certPool := x509.NewCertPool()
+	if ok := certPool.AppendCertsFromPEM(e.cert.GetIssuingCA()); !ok {
+		return errors.New("error appending the issuing CA to the cert pool")
+	}
+	keyPair, err := tls.X509KeyPair(e.cert.GetCertificateChain(), e.cert.GetPrivateKey())
+	if err != nil {
+		return err
+	}
+	tlsConfig := &tls.Config{
+		ServerName:   adsServerCommonName,
+		Certificates: []tls.Certificate{keyPair},
+		RootCAs:      certPool,
+		MinVersion:   tls.VersionTLS13,
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), grpc.WithBlock())
+	if err != nil {
+		return err
+	}
+	defer conn.Close() //nolint: errcheck
+
+	stream, err := xds_discovery.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(ctx)
+	if err != nil {
+		return err
+	}
+	e.connectedAt = time.Now()
+	e.stats.connected()
+
+	// Like Envoy, start with the wildcard subscriptions to clusters and listeners
+	for _, typeURI := range []envoy.TypeURI{envoy.TypeCDS, envoy.TypeLDS} {
+		if err := e.send(stream, typeURI, nil); err != nil {
+			return err
+		}
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := e.handleResponse(stream, resp); err != nil {
+			return err
+		}
+	}
+}
+
+// handleResponse ACKs or NACKs the given response, and updates the subscriptions to the resources it references
+func (e *simulatedEnvoy) handleResponse(stream xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesClient, resp *xds_discovery.DiscoveryResponse) error {
+	typeURI := envoy.TypeURI(resp.TypeUrl)
+	e.stats.response(proto.Size(resp))
+	e.recordPushLatency()
+
+	// Only pushes are NACKed: the ADS server does not resend a NACKed response until the resources change again, so
+	// NACKing the initial configuration would leave the proxy unconfigured.
+	if e.configured && e.rand.Float64() < e.nackRatio {
+		e.stats.nack()
+		e.lastNonce[typeURI] = resp.Nonce
+		return e.send(stream, typeURI, &status.Status{Message: "simulated NACK"})
+	}
+
+	e.lastVersion[typeURI] = resp.VersionInfo
+	e.lastNonce[typeURI] = resp.Nonce
+	e.received[typeURI] = true
+	e.stats.ack()
+	if err := e.send(stream, typeURI, nil); err != nil {
+		return err
+	}
+
+	resources := make([]proto.Message, 0, len(resp.Resources))
+	for _, res := range resp.Resources {
+		msg, err := res.UnmarshalNew()
+		if err != nil {
+			return err
+		}
+		resources = append(resources, msg)
+	}
+
+	// Subscribe to the resources referenced by the clusters and listeners
+	switch typeURI {
+	case envoy.TypeCDS:
+		var clusterNames []string
+		for _, res := range resources {
+			cluster, ok := res.(*xds_cluster.Cluster)
+			if !ok || cluster.GetType() != xds_cluster.Cluster_EDS {
+				continue
+			}
+			if serviceName := cluster.GetEdsClusterConfig().GetServiceName(); serviceName != "" {
+				clusterNames = append(clusterNames, serviceName)
+			} else {
+				clusterNames = append(clusterNames, cluster.Name)
+			}
+		}
+		e.secretsFromCDS = secretNames(resources)
+		if err := e.subscribe(stream, envoy.TypeEDS, clusterNames); err != nil {
+			return err
+		}
+		if err := e.subscribe(stream, envoy.TypeSDS, append(e.secretsFromCDS, e.secretsFromLDS...)); err != nil {
+			return err
+		}
+
+	case envoy.TypeLDS:
+		var routeConfigNames []string
+		for _, res := range resources {
+			walkMessages(res.ProtoReflect(), func(msg proto.Message) {
+				if rds, ok := msg.(*xds_hcm.Rds); ok {
+					routeConfigNames = append(routeConfigNames, rds.RouteConfigName)
+				}
+			})
+		}
+		e.secretsFromLDS = secretNames(resources)
+		if err := e.subscribe(stream, envoy.TypeRDS, routeConfigNames); err != nil {
+			return err
+		}
+		if err := e.subscribe(stream, envoy.TypeSDS, append(e.secretsFromCDS, e.secretsFromLDS...)); err != nil {
+			return err
+		}
+	}
+
+	e.recordConfigured()
+	return nil
+}
+
+// subscribe updates the subscription to the resources of the given type, if the resource names changed
+func (e *simulatedEnvoy) subscribe(stream xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesClient, typeURI envoy.TypeURI, names []string) error {
+	names = dedup(names)
+	if stringsEqual(e.subscribed[typeURI], names) {
+		return nil
+	}
+	e.subscribed[typeURI] = names
+	return e.send(stream, typeURI, nil)
+}
+
+// send sends a request for the subscribed resources of the given type, NACKing the last response if an error is given
+func (e *simulatedEnvoy) send(stream xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesClient, typeURI envoy.TypeURI, nackErr *status.Status) error {
+	return stream.Send(&xds_discovery.DiscoveryRequest{
+		Node:          &xds_core.Node{Id: e.cn.String()},
+		TypeUrl:       typeURI.String(),
+		VersionInfo:   e.lastVersion[typeURI],
+		ResponseNonce: e.lastNonce[typeURI],
+		ResourceNames: e.subscribed[typeURI],
+		ErrorDetail:   nackErr,
+	})
+}
+
+// recordConfigured records the initial configuration latency once all the subscribed resources were received
+func (e *simulatedEnvoy) recordConfigured() {
+	if e.configured {
+		return
+	}
+	for _, typeURI := range envoy.XDSResponseOrder {
+		subscribed := typeURI == envoy.TypeCDS || typeURI == envoy.TypeLDS || len(e.subscribed[typeURI]) > 0
+		if subscribed && !e.received[typeURI] {
+			return
+		}
+	}
+	e.configured = true
+	e.lastChurnSeen, _ = e.churn.last()
+	e.stats.configured(time.Since(e.connectedAt))
+	close(e.configuredChan)
+}
+
+// recordPushLatency records the latency between the last churn event and the first response received after it
+func (e *simulatedEnvoy) recordPushLatency() {
+	if !e.configured {
+		return
+	}
+	seq, at := e.churn.last()
+	if seq <= e.lastChurnSeen {
+		return
+	}
+	e.lastChurnSeen = seq
+	e.stats.push(time.Since(at))
+}
+
+// secretNames returns the names of the SDS secrets referenced by the given resources
+func secretNames(resources []proto.Message) []string {
+	var names []string
+	for _, res := range resources {
+		walkMessages(res.ProtoReflect(), func(msg proto.Message) {
+			if sdsConfig, ok := msg.(*xds_tls.SdsSecretConfig); ok {
+				names = append(names, sdsConfig.Name)
+			}
+		})
+	}
+	return names
+}
+
+// walkMessages calls fn for the given message and all the messages nested in it, including the messages packed in Any fields
+func walkMessages(m protoreflect.Message, fn func(proto.Message)) {
+	fn(m.Interface())
+	if packed, ok := m.Interface().(*anypb.Any); ok {
+		if unpacked, err := packed.UnmarshalNew(); err == nil {
+			walkMessages(unpacked.ProtoReflect(), fn)
+		}
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i := 0; i < v.List().Len(); i++ {
+				walkMessages(v.List().Get(i).Message(), fn)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				walkMessages(mv.Message(), fn)
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			walkMessages(v.Message(), fn)
+		}
+		return true
+	})
+}
+
+// dedup returns the sorted unique strings of the given slice
+func dedup(s []string) []string {
+	set := make(map[string]struct{}, len(s))
+	var unique []string
+	for _, str := range s {
+		if _, ok := set[str]; ok {
+			continue
+		}
+		set[str] = struct{}{}
+		unique = append(unique, str)
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,285 @@
+// Package xdsload implements an in-process load harness for the ADS server. It runs the real control plane
+// components against fake Kubernetes and SMI clientsets populated with a synthetic mesh, connects simulated
+// Envoy proxies to the ADS server, churns the mesh's endpoints and policies, and reports the push latency
+// and resource usage of the control plane.
+package xdsload
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	smiAccessClientFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smiTrafficSpecClientFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	smiTrafficSplitClientFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClientFake "k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy/ads"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	configClientFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	policyClientFake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/providers/kube"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+const (
+	meshName            = "osm"
+	osmNamespace        = "osm-system"
+	meshConfigName      = "osm-mesh-config"
+	adsServerCommonName = "ads"
+
+	caCommonName       = "osm-ca.openservicemesh.io"
+	caValidityPeriod   = 24 * time.Hour
+	certOrganization   = "Open Service Mesh xDS Load"
+	certValidityPeriod = 24 * time.Hour
+
+	usageSampleInterval = 100 * time.Millisecond
+)
+
+var log = logger.New("xds-load")
+
+// Options configure a load run
+type Options struct {
+	// Namespaces is the number of namespaces of the synthetic mesh
+	Namespaces int
+	// ServicesPerNamespace is the number of services in each namespace
+	ServicesPerNamespace int
+	// PodsPerService is the number of pods backing each service, each pod being a simulated proxy
+	PodsPerService int
+
+	// ConnectTimeout is the maximum duration to wait for all the proxies to receive their initial configuration
+	ConnectTimeout time.Duration
+	// Duration is the duration of the churn phase, once all the proxies received their initial configuration
+	Duration time.Duration
+	// ChurnInterval is the interval between two changes to the mesh during the churn phase.
+	// Changes alternate between moving a pod to a new IP address and deleting or recreating a TrafficTarget.
+	ChurnInterval time.Duration
+	// Cooldown is the duration the proxies stay connected after the churn phase, so that the pushes of the last
+	// changes are measured. Proxy updates are batched by the message broker, so it should exceed the batching window.
+	Cooldown time.Duration
+	// NACKRatio is the ratio of the responses NACKed by the simulated proxies once they received their initial configuration
+	NACKRatio float64
+
+	// PermissiveTrafficPolicyMode configures the mesh in permissive traffic policy mode
+	PermissiveTrafficPolicyMode bool
+	// SnapshotCacheMode configures the ADS server to serve the resources from the snapshot cache
+	SnapshotCacheMode bool
+	// CertKeyBitSize is the key bit size of the certificates issued by the control plane
+	CertKeyBitSize int
+	// Seed seeds the random choices made by the harness
+	Seed int64
+}
+
+// Run runs the control plane and the simulated proxies with the given options, and returns the report of the run
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	stop := make(chan struct{})
+	defer close(stop)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	kubeClient := k8sClientFake.NewSimpleClientset()
+	policyClient := policyClientFake.NewSimpleClientset()
+	configClient := configClientFake.NewSimpleClientset()
+	smiSplitClient := smiTrafficSplitClientFake.NewSimpleClientset()
+	smiSpecClient := smiTrafficSpecClientFake.NewSimpleClientset()
+	smiAccessClient := smiAccessClientFake.NewSimpleClientset()
+
+	if _, err := configClient.ConfigV1alpha2().MeshConfigs(osmNamespace).Create(ctx, newMeshConfig(opts), metav1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+
+	mesh := newSyntheticMesh(kubeClient, smiAccessClient, smiSpecClient, opts.Seed)
+	proxies, err := mesh.create(ctx, meshName, opts.Namespaces, opts.ServicesPerNamespace, opts.PodsPerService)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the synthetic mesh")
+	}
+
+	// Wire the control plane components like osm-controller does
+	msgBroker := messaging.NewBroker(stop)
+	cfg := configurator.NewConfigurator(configClient, stop, osmNamespace, meshConfigName, msgBroker)
+	k8sClient, err := k8s.NewKubernetesController(kubeClient, policyClient, configClient, meshName, stop, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+	meshSpec, err := smi.NewMeshSpecClientFromClientsets(kubeClient, smiSplitClient, smiSpecClient, smiAccessClient, osmNamespace, k8sClient, stop, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := tresor.NewCA(caCommonName, caValidityPeriod, "US", "CA", certOrganization)
+	if err != nil {
+		return nil, err
+	}
+	certManager, err := tresor.NewCertManager(ca, certOrganization, cfg, certValidityPeriod, opts.CertKeyBitSize, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeProvider := kube.NewClient(k8sClient, nil, cfg)
+	policyController, err := policy.NewPolicyController(k8sClient, policyClient, stop, msgBroker)
+	if err != nil {
+		return nil, err
+	}
+	meshCatalog := catalog.NewMeshCatalog(k8sClient, meshSpec, certManager, policyController, nil, stop, cfg,
+		[]service.Provider{kubeProvider}, []endpoint.Provider{kubeProvider}, msgBroker)
+
+	proxyRegistry := registry.NewProxyRegistry(&registry.KubeProxyServiceMapper{KubeController: k8sClient}, msgBroker)
+	go proxyRegistry.ReleaseCertificateHandler(certManager, stop)
+	if !cfg.GetFeatureFlags().EnableSnapshotCacheMode {
+		msgBroker.SetProxyUpdateTargeter(registry.NewDependencyIndex(proxyRegistry, meshCatalog))
+	}
+
+	adsCert, err := certManager.IssueCertificate(adsServerCommonName, constants.XDSCertificateValidityPeriod)
+	if err != nil {
+		return nil, err
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	xdsServer := ads.NewADSServer(meshCatalog, proxyRegistry, false, osmNamespace, cfg, certManager, k8sClient, msgBroker)
+	if err := xdsServer.Start(ctx, cancel, port, adsCert); err != nil {
+		return nil, err
+	}
+
+	// Issue the certificates of the proxies before measuring the usage of the control plane
+	envoys := make([]*simulatedEnvoy, 0, len(proxies))
+	stats := &clientStats{}
+	churn := &churnTracker{}
+	for i, proxy := range proxies {
+		cert, err := certManager.IssueCertificate(proxy.certCommonName(), constants.XDSCertificateValidityPeriod)
+		if err != nil {
+			return nil, err
+		}
+		envoys = append(envoys, newSimulatedEnvoy(proxy.certCommonName(), cert, opts.NACKRatio, opts.Seed+int64(i), churn, stats))
+	}
+
+	usage := newUsageSampler()
+	usageStop := make(chan struct{})
+	go usage.run(usageSampleInterval, usageStop)
+
+	clientCtx, clientCancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	for _, e := range envoys {
+		wg.Add(1)
+		go func(e *simulatedEnvoy) {
+			defer wg.Done()
+			if err := e.run(clientCtx, addr); err != nil {
+				log.Error().Err(err).Msgf("Error running the simulated proxy %s", e.cn)
+				stats.streamError()
+			}
+		}(e)
+	}
+
+	// Wait for the proxies to receive their initial configuration
+	connectTimeout := time.After(opts.ConnectTimeout)
+	for _, e := range envoys {
+		select {
+		case <-e.configuredChan:
+		case <-connectTimeout:
+		case <-ctx.Done():
+		}
+	}
+
+	// Churn the mesh
+	churnErr := runChurn(ctx, mesh, churn, opts.Duration, opts.ChurnInterval)
+	select {
+	case <-time.After(opts.Cooldown):
+	case <-ctx.Done():
+	}
+
+	clientCancel()
+	wg.Wait()
+	close(usageStop)
+
+	report := &Report{
+		Proxies:     len(envoys),
+		ChurnEvents: churnEvents(churn),
+	}
+	stats.fill(report)
+	usage.fill(report)
+	return report, churnErr
+}
+
+// runChurn changes the mesh at the given interval for the given duration
+func runChurn(ctx context.Context, mesh *syntheticMesh, churn *churnTracker, duration, interval time.Duration) error {
+	if duration <= 0 || interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	deadline := time.After(duration)
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-deadline:
+			return nil
+		case <-ticker.C:
+		}
+
+		churn.record()
+		var err error
+		if i%2 == 0 {
+			err = mesh.churnEndpoints(ctx)
+		} else {
+			err = mesh.churnPolicy(ctx)
+		}
+		if err != nil {
+			return errors.Wrap(err, "error churning the synthetic mesh")
+		}
+	}
+}
+
+func churnEvents(churn *churnTracker) uint64 {
+	seq, _ := churn.last()
+	return seq
+}
+
+// newMeshConfig returns the MeshConfig of the control plane for the given options
+func newMeshConfig(opts Options) *configv1alpha2.MeshConfig {
+	return &configv1alpha2.MeshConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      meshConfigName,
+			Namespace: osmNamespace,
+		},
+		Spec: configv1alpha2.MeshConfigSpec{
+			Traffic: configv1alpha2.TrafficSpec{
+				EnablePermissiveTrafficPolicyMode: opts.PermissiveTrafficPolicyMode,
+			},
+			Certificate: configv1alpha2.CertificateSpec{
+				ServiceCertValidityDuration: certValidityPeriod.String(),
+				CertKeyBitSize:              opts.CertKeyBitSize,
+			},
+			FeatureFlags: configv1alpha2.FeatureFlags{
+				EnableSnapshotCacheMode: opts.SnapshotCacheMode,
+			},
+		},
+	}
+}
+
+// freePort returns a TCP port available on the loopback interface
+func freePort() (int, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer lis.Close() //nolint: errcheck
+	return lis.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,269 @@
+package xdsload
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/google/uuid"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiAccessClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smiTrafficSpecClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+const (
+	servicePort     = 8080
+	routeGroupName  = "all"
+	routeMatchName  = "all"
+	appLabelKey     = "app"
+	httpAppProtocol = "http"
+	namespacePrefix = "xdsload"
+	servicePrefix   = "svc"
+)
+
+// syntheticMesh is a mesh of synthetic services and pods created in fake Kubernetes and SMI clientsets.
+// Each service allows traffic from the previous service in its namespace with an SMI TrafficTarget.
+type syntheticMesh struct {
+	kubeClient      kubernetes.Interface
+	smiAccessClient smiAccessClient.Interface
+	smiSpecClient   smiTrafficSpecClient.Interface
+
+	services []*syntheticService
+	nextIP   uint32
+	rand     *rand.Rand
+}
+
+// syntheticService is a service of the synthetic mesh
+type syntheticService struct {
+	namespace string
+	name      string
+	source    string
+	pods      []*corev1.Pod
+	// hasTrafficTarget is whether the TrafficTarget allowing traffic to the service currently exists
+	hasTrafficTarget bool
+}
+
+// syntheticProxy is the sidecar of a pod of the synthetic mesh
+type syntheticProxy struct {
+	uuid      uuid.UUID
+	namespace string
+	service   string
+}
+
+func newSyntheticMesh(kubeClient kubernetes.Interface, accessClient smiAccessClient.Interface, specClient smiTrafficSpecClient.Interface, seed int64) *syntheticMesh {
+	return &syntheticMesh{
+		kubeClient:      kubeClient,
+		smiAccessClient: accessClient,
+		smiSpecClient:   specClient,
+		nextIP:          10 << 24,                       // 10.0.0.0
+		rand:            rand.New(rand.NewSource(seed)), // #nosec G404
+	}
+}
+
+// create creates the namespaces, services, pods and SMI policies of the synthetic mesh, and returns its proxies
+func (m *syntheticMesh) create(ctx context.Context, meshName string, namespaces, servicesPerNamespace, podsPerService int) ([]syntheticProxy, error) {
+	var proxies []syntheticProxy
+	for i := 0; i < namespaces; i++ {
+		namespace := fmt.Sprintf("%s-%d", namespacePrefix, i)
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: meshName},
+			},
+		}
+		if _, err := m.kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+
+		routeGroup := &smiSpecs.HTTPRouteGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: routeGroupName, Namespace: namespace},
+			Spec: smiSpecs.HTTPRouteGroupSpec{
+				Matches: []smiSpecs.HTTPMatch{{
+					Name:      routeMatchName,
+					PathRegex: ".*",
+					Methods:   []string{"*"},
+				}},
+			},
+		}
+		if _, err := m.smiSpecClient.SpecsV1alpha4().HTTPRouteGroups(namespace).Create(ctx, routeGroup, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+
+		for j := 0; j < servicesPerNamespace; j++ {
+			svc := &syntheticService{
+				namespace: namespace,
+				name:      fmt.Sprintf("%s-%d", servicePrefix, j),
+				source:    fmt.Sprintf("%s-%d", servicePrefix, (j+servicesPerNamespace-1)%servicesPerNamespace),
+			}
+			if err := m.createService(ctx, svc, podsPerService); err != nil {
+				return nil, err
+			}
+			for _, pod := range svc.pods {
+				proxies = append(proxies, syntheticProxy{
+					uuid:      uuid.MustParse(pod.Labels[constants.EnvoyUniqueIDLabelName]),
+					namespace: namespace,
+					service:   svc.name,
+				})
+			}
+			m.services = append(m.services, svc)
+		}
+	}
+	return proxies, nil
+}
+
+// createService creates the service account, service, pods, endpoints and TrafficTarget of the given service
+func (m *syntheticMesh) createService(ctx context.Context, svc *syntheticService, podsPerService int) error {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: svc.namespace}}
+	if _, err := m.kubeClient.CoreV1().ServiceAccounts(svc.namespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	appProtocol := httpAppProtocol
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: svc.namespace},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{appLabelKey: svc.name},
+			Ports: []corev1.ServicePort{{
+				Name:        httpAppProtocol,
+				Port:        servicePort,
+				TargetPort:  intstr.FromInt(servicePort),
+				AppProtocol: &appProtocol,
+			}},
+		},
+	}
+	if _, err := m.kubeClient.CoreV1().Services(svc.namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	for k := 0; k < podsPerService; k++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", svc.name, k),
+				Namespace: svc.namespace,
+				Labels: map[string]string{
+					appLabelKey:                      svc.name,
+					constants.EnvoyUniqueIDLabelName: uuid.New().String(),
+				},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: svc.name,
+				Containers: []corev1.Container{{
+					Name:  svc.name,
+					Ports: []corev1.ContainerPort{{ContainerPort: servicePort}},
+				}},
+			},
+			Status: corev1.PodStatus{PodIP: m.allocateIP()},
+		}
+		created, err := m.kubeClient.CoreV1().Pods(svc.namespace).Create(ctx, pod, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		svc.pods = append(svc.pods, created)
+	}
+
+	if _, err := m.kubeClient.CoreV1().Endpoints(svc.namespace).Create(ctx, m.endpoints(svc), metav1.CreateOptions{}); err != nil {
+		return err
+	}
+
+	return m.createTrafficTarget(ctx, svc)
+}
+
+// endpoints returns the endpoints of the given service
+func (m *syntheticMesh) endpoints(svc *syntheticService) *corev1.Endpoints {
+	appProtocol := httpAppProtocol
+	subset := corev1.EndpointSubset{
+		Ports: []corev1.EndpointPort{{
+			Name:        httpAppProtocol,
+			Port:        servicePort,
+			AppProtocol: &appProtocol,
+		}},
+	}
+	for _, pod := range svc.pods {
+		subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{IP: pod.Status.PodIP})
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: svc.namespace},
+		Subsets:    []corev1.EndpointSubset{subset},
+	}
+}
+
+// createTrafficTarget creates the TrafficTarget allowing traffic from the source service to the given service
+func (m *syntheticMesh) createTrafficTarget(ctx context.Context, svc *syntheticService) error {
+	trafficTarget := &smiAccess.TrafficTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: svc.namespace},
+		Spec: smiAccess.TrafficTargetSpec{
+			Destination: smiAccess.IdentityBindingSubject{
+				Kind:      smi.ServiceAccountKind,
+				Name:      svc.name,
+				Namespace: svc.namespace,
+			},
+			Sources: []smiAccess.IdentityBindingSubject{{
+				Kind:      smi.ServiceAccountKind,
+				Name:      svc.source,
+				Namespace: svc.namespace,
+			}},
+			Rules: []smiAccess.TrafficTargetRule{{
+				Kind:    smi.HTTPRouteGroupKind,
+				Name:    routeGroupName,
+				Matches: []string{routeMatchName},
+			}},
+		},
+	}
+	if _, err := m.smiAccessClient.AccessV1alpha3().TrafficTargets(svc.namespace).Create(ctx, trafficTarget, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	svc.hasTrafficTarget = true
+	return nil
+}
+
+// churnEndpoints moves a random pod of a random service to a new IP address, and updates the service's endpoints
+func (m *syntheticMesh) churnEndpoints(ctx context.Context) error {
+	svc := m.services[m.rand.Intn(len(m.services))]
+	if len(svc.pods) == 0 {
+		return nil
+	}
+	pod := svc.pods[m.rand.Intn(len(svc.pods))]
+	pod.Status.PodIP = m.allocateIP()
+	if _, err := m.kubeClient.CoreV1().Pods(svc.namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	_, err := m.kubeClient.CoreV1().Endpoints(svc.namespace).Update(ctx, m.endpoints(svc), metav1.UpdateOptions{})
+	return err
+}
+
+// churnPolicy deletes the TrafficTarget of a random service if it exists, or recreates it otherwise
+func (m *syntheticMesh) churnPolicy(ctx context.Context) error {
+	svc := m.services[m.rand.Intn(len(m.services))]
+	if !svc.hasTrafficTarget {
+		return m.createTrafficTarget(ctx, svc)
+	}
+	err := m.smiAccessClient.AccessV1alpha3().TrafficTargets(svc.namespace).Delete(ctx, svc.name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return err
+	}
+	svc.hasTrafficTarget = false
+	return nil
+}
+
+// allocateIP returns a new unique IPv4 address
+func (m *syntheticMesh) allocateIP() string {
+	m.nextIP++
+	ip := m.nextIP
+	return fmt.Sprintf("%d.%d.%d.%d", byte(ip>>24), byte(ip>>16), byte(ip>>8), byte(ip))
+}
+
+// certCommonName returns the common name of the certificate of the given proxy
+func (p syntheticProxy) certCommonName() certificate.CommonName {
+	return envoy.NewXDSCertCommonName(p.uuid, envoy.KindSidecar, p.service, p.namespace)
+}
@@ -0,0 +1,253 @@
+package xdsload
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Report is the result of a load run
+type Report struct {
+	// Proxies is the number of simulated proxies
+	Proxies int
+	// Connected is the number of proxies that established an ADS stream
+	Connected int
+	// Configured is the number of proxies that received all of their subscribed resources
+	Configured int
+	// StreamErrors is the number of ADS streams that ended with an error
+	StreamErrors int
+
+	// InitialConfigLatency is the latency between a proxy opening its ADS stream and receiving all of its subscribed resources
+	InitialConfigLatency LatencyStats
+	// PushLatency is the latency between a change to the mesh and a proxy receiving its next response
+	PushLatency LatencyStats
+	// ChurnEvents is the number of changes made to the mesh
+	ChurnEvents uint64
+
+	// Responses is the number of xDS responses sent by the ADS server
+	Responses uint64
+	// BytesSent is the size of the xDS responses sent by the ADS server
+	BytesSent uint64
+	// ACKs and NACKs are the number of responses ACKed and NACKed by the proxies
+	ACKs  uint64
+	NACKs uint64
+
+	// Duration is the wall-clock duration of the run
+	Duration time.Duration
+	// CPUTime is the user and system CPU time consumed by the process, including the simulated proxies
+	CPUTime time.Duration
+	// PeakHeapInuse is the peak number of bytes in in-use heap spans
+	PeakHeapInuse uint64
+	// PeakGoroutines is the peak number of goroutines
+	PeakGoroutines int
+}
+
+// LatencyStats summarizes a set of latencies
+type LatencyStats struct {
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+func newLatencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	return LatencyStats{
+		Count: len(sorted),
+		P50:   percentile(0.5),
+		P90:   percentile(0.9),
+		P99:   percentile(0.99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+func (l LatencyStats) String() string {
+	return fmt.Sprintf("count=%d p50=%s p90=%s p99=%s max=%s", l.Count, l.P50, l.P90, l.P99, l.Max)
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "proxies:                %d (connected=%d configured=%d stream errors=%d)\n", r.Proxies, r.Connected, r.Configured, r.StreamErrors)
+	fmt.Fprintf(&sb, "initial config latency: %s\n", r.InitialConfigLatency)
+	fmt.Fprintf(&sb, "push latency:           %s (churn events=%d)\n", r.PushLatency, r.ChurnEvents)
+	fmt.Fprintf(&sb, "responses:              %d (acks=%d nacks=%d)\n", r.Responses, r.ACKs, r.NACKs)
+	fmt.Fprintf(&sb, "bytes sent:             %d\n", r.BytesSent)
+	fmt.Fprintf(&sb, "duration:               %s\n", r.Duration)
+	fmt.Fprintf(&sb, "cpu time:               %s (%.2f cores)\n", r.CPUTime, r.CPUTime.Seconds()/r.Duration.Seconds())
+	fmt.Fprintf(&sb, "peak heap in use:       %d MiB\n", r.PeakHeapInuse/(1<<20))
+	fmt.Fprintf(&sb, "peak goroutines:        %d\n", r.PeakGoroutines)
+	return sb.String()
+}
+
+// clientStats aggregates the stats recorded by the simulated proxies
+type clientStats struct {
+	mu                   sync.Mutex
+	connectedCount       int
+	configuredCount      int
+	streamErrors         int
+	initialConfigLatency []time.Duration
+	pushLatency          []time.Duration
+	responses, bytes     uint64
+	acks, nacks          uint64
+}
+
+func (s *clientStats) connected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectedCount++
+}
+
+func (s *clientStats) configured(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configuredCount++
+	s.initialConfigLatency = append(s.initialConfigLatency, latency)
+}
+
+func (s *clientStats) streamError() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streamErrors++
+}
+
+func (s *clientStats) push(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pushLatency = append(s.pushLatency, latency)
+}
+
+func (s *clientStats) response(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses++
+	s.bytes += uint64(size)
+}
+
+func (s *clientStats) ack() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acks++
+}
+
+func (s *clientStats) nack() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nacks++
+}
+
+// fill fills the given report with the recorded stats
+func (s *clientStats) fill(r *Report) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r.Connected = s.connectedCount
+	r.Configured = s.configuredCount
+	r.StreamErrors = s.streamErrors
+	r.InitialConfigLatency = newLatencyStats(s.initialConfigLatency)
+	r.PushLatency = newLatencyStats(s.pushLatency)
+	r.Responses = s.responses
+	r.BytesSent = s.bytes
+	r.ACKs = s.acks
+	r.NACKs = s.nacks
+}
+
+// churnTracker records the last change made to the mesh, to measure the push latency
+type churnTracker struct {
+	mu  sync.Mutex
+	seq uint64
+	at  time.Time
+}
+
+// record records a change made to the mesh
+func (c *churnTracker) record() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	c.at = time.Now()
+}
+
+// last returns the sequence number and time of the last change made to the mesh
+func (c *churnTracker) last() (uint64, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.seq, c.at
+}
+
+// usageSampler samples the resource usage of the process
+type usageSampler struct {
+	start    time.Time
+	startCPU time.Duration
+
+	mu             sync.Mutex
+	peakHeapInuse  uint64
+	peakGoroutines int
+}
+
+func newUsageSampler() *usageSampler {
+	return &usageSampler{
+		start:    time.Now(),
+		startCPU: cpuTime(),
+	}
+}
+
+// run samples the memory usage at the given interval until stop is closed
+func (u *usageSampler) run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		u.sample()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *usageSampler) sample() {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	goroutines := runtime.NumGoroutine()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if memStats.HeapInuse > u.peakHeapInuse {
+		u.peakHeapInuse = memStats.HeapInuse
+	}
+	if goroutines > u.peakGoroutines {
+		u.peakGoroutines = goroutines
+	}
+}
+
+// fill fills the given report with the sampled usage
+func (u *usageSampler) fill(r *Report) {
+	u.sample()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	r.Duration = time.Since(u.start)
+	r.CPUTime = cpuTime() - u.startCPU
+	r.PeakHeapInuse = u.peakHeapInuse
+	r.PeakGoroutines = u.peakGoroutines
+}
+
+// cpuTime returns the user and system CPU time consumed by the process
+func cpuTime() time.Duration {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
@@ -0,0 +1,53 @@
+package xdsload
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	namespaces           = flag.Int("xdsload.namespaces", 1, "Number of namespaces of the synthetic mesh")
+	servicesPerNamespace = flag.Int("xdsload.services-per-namespace", 2, "Number of services in each namespace")
+	podsPerService       = flag.Int("xdsload.pods-per-service", 2, "Number of pods backing each service")
+	connectTimeout       = flag.Duration("xdsload.connect-timeout", 2*time.Minute, "Maximum duration to wait for all the proxies to be configured")
+	duration             = flag.Duration("xdsload.duration", 2*time.Second, "Duration of the churn phase")
+	cooldown             = flag.Duration("xdsload.cooldown", 3*time.Second, "Duration the proxies stay connected after the churn phase")
+	churnInterval        = flag.Duration("xdsload.churn-interval", 500*time.Millisecond, "Interval between two changes to the mesh")
+	nackRatio            = flag.Float64("xdsload.nack-ratio", 0.05, "Ratio of the pushes NACKed by the proxies")
+	permissive           = flag.Bool("xdsload.permissive", false, "Run the mesh in permissive traffic policy mode")
+	snapshotCache        = flag.Bool("xdsload.snapshot-cache", false, "Serve the xDS resources from the snapshot cache")
+	keyBitSize           = flag.Int("xdsload.key-bit-size", 2048, "Key bit size of the certificates")
+	logLevel             = flag.String("xdsload.log-level", "error", "Log level of the control plane")
+)
+
+func TestXDSLoad(t *testing.T) {
+	assert := tassert.New(t)
+	assert.Nil(logger.SetLogLevel(*logLevel))
+
+	report, err := Run(context.Background(), Options{
+		Namespaces:                  *namespaces,
+		ServicesPerNamespace:        *servicesPerNamespace,
+		PodsPerService:              *podsPerService,
+		ConnectTimeout:              *connectTimeout,
+		Duration:                    *duration,
+		ChurnInterval:               *churnInterval,
+		Cooldown:                    *cooldown,
+		NACKRatio:                   *nackRatio,
+		PermissiveTrafficPolicyMode: *permissive,
+		SnapshotCacheMode:           *snapshotCache,
+		CertKeyBitSize:              *keyBitSize,
+		Seed:                        time.Now().UnixNano(),
+	})
+	assert.Nil(err)
+	t.Logf("xDS load report:\n%s", report)
+
+	assert.Equal(report.Proxies, report.Connected)
+	assert.Equal(report.Proxies, report.Configured)
+	assert.Zero(report.StreamErrors)
+}